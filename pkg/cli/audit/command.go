@@ -0,0 +1,128 @@
+// Package audit implements the aqua audit command for finding known vulnerabilities of packages.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/cliargs"
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/urfave/cli/v3"
+)
+
+const description = `Find known vulnerabilities of packages in aqua.yaml from an OSV database.
+
+This command doesn't access vulnerability databases on the internet.
+Please download an OSV database in advance and set the path to the directory or the zip file.
+
+	$ curl -sSLO https://osv-vulnerabilities.storage.googleapis.com/Go/all.zip
+	$ aqua audit -db all.zip
+	MEDIUM	cli/cli@v2.40.0	GHSA-xxxx-xxxx-xxxx (CVE-2024-xxxxx)	fixed in 2.41.0	...
+
+Packages are mapped to OSV packages by their Package URLs (purl),
+Go modules for go_install packages and packages of GitHub repositories, and crates for cargo packages.
+
+You can filter findings by the -min-severity option (low, medium, high, or critical).
+Findings whose severities are unknown are always output.
+
+	$ aqua audit -db all.zip -min-severity high
+
+By default, this command succeeds even if vulnerabilities are found.
+If the -fail option is set, this command fails if vulnerabilities are found, which is useful in CI.
+
+	$ aqua audit -db all.zip -fail
+`
+
+// Args holds command-line arguments for the audit command.
+type Args struct {
+	*cliargs.GlobalArgs
+
+	All         bool
+	DB          string
+	MinSeverity string
+	Format      string
+	Fail        bool
+}
+
+type command struct {
+	r *util.Param
+}
+
+// New creates and returns a new CLI command for finding vulnerabilities.
+func New(r *util.Param, globalArgs *cliargs.GlobalArgs) *cli.Command {
+	args := &Args{
+		GlobalArgs: globalArgs,
+	}
+	i := &command{
+		r: r,
+	}
+	return &cli.Command{
+		Name:        "audit",
+		Usage:       "Find known vulnerabilities of packages from an OSV database",
+		Description: description,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "db",
+				Usage:       "the path to an OSV database directory or zip file",
+				Sources:     cli.EnvVars("AQUA_OSV_DB"),
+				Destination: &args.DB,
+			},
+			&cli.StringFlag{
+				Name:        "min-severity",
+				Usage:       "the minimum severity of output vulnerabilities (low, medium, high, or critical)",
+				Destination: &args.MinSeverity,
+			},
+			&cli.BoolFlag{
+				Name:        "fail",
+				Usage:       "fail if vulnerabilities are found",
+				Destination: &args.Fail,
+			},
+			&cli.BoolFlag{
+				Name:        "all",
+				Aliases:     []string{"a"},
+				Usage:       "audit packages in global configuration files too",
+				Destination: &args.All,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "output format (text or json)",
+				Value:       "text",
+				Destination: &args.Format,
+			},
+		},
+		Action: func(ctx context.Context, _ *cli.Command) error {
+			return i.action(ctx, args)
+		},
+	}
+}
+
+// action implements the main logic for the audit command.
+func (i *command) action(ctx context.Context, args *Args) error {
+	profiler, err := profile.Start(args.Trace, args.CPUProfile)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(args.GlobalArgs, i.r.Logger, param, i.r.Version); err != nil {
+		return fmt.Errorf("set param: %w", err)
+	}
+	param.All = args.All
+	param.OSVDB = args.DB
+	param.MinSeverity = args.MinSeverity
+	param.FailOnVulnerability = args.Fail
+	param.OutputFormat = args.Format
+	ctrl, err := controller.InitializeAuditCommandController(ctx, i.r.Logger.Logger, param, http.DefaultClient, i.r.Runtime)
+	if err != nil {
+		return fmt.Errorf("initialize an AuditController: %w", err)
+	}
+	if err := ctrl.Audit(ctx, i.r.Logger.Logger, param); err != nil {
+		return err //nolint:wrapcheck
+	}
+	return nil
+}
@@ -15,6 +15,8 @@ type GlobalArgs struct {
 	DisableGitHubArtifactAttestation bool
 	Trace                            string
 	CPUProfile                       string
+	MaxConnsPerHost                  int
+	DownloadBandwidthLimit           string
 }
 
 // GlobalFlags returns the global CLI flags with destinations bound to the provided GlobalArgs.
@@ -56,6 +58,18 @@ func GlobalFlags(args *GlobalArgs) []cli.Flag {
 			Usage:  "This flag was deprecated and had no meaning from aqua v2.60.1. This flag will be removed in aqua v3.0.0.",
 			Hidden: true,
 		},
+		&cli.IntFlag{
+			Name:        "max-conns-per-host",
+			Usage:       "The maximum number of concurrent HTTP requests per host. 0 means unlimited",
+			Sources:     cli.EnvVars("AQUA_MAX_CONNS_PER_HOST"),
+			Destination: &args.MaxConnsPerHost,
+		},
+		&cli.StringFlag{
+			Name:        "download-bandwidth-limit",
+			Usage:       "The aggregate bandwidth of downloads per second such as 10MB. By default, it's unlimited",
+			Sources:     cli.EnvVars("AQUA_DOWNLOAD_BANDWIDTH_LIMIT"),
+			Destination: &args.DownloadBandwidthLimit,
+		},
 		&cli.StringFlag{
 			Name:        "trace",
 			Usage:       "trace output file path",
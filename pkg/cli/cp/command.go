@@ -126,6 +126,7 @@ func (i *command) action(ctx context.Context, args *Args) error {
 	if err := util.SetParam(args.GlobalArgs, i.r.Logger, param, i.r.Version); err != nil {
 		return fmt.Errorf("set param: %w", err)
 	}
+	param.SubCommand = "cp"
 	param.SkipLink = true
 	param.Dest = args.Output
 	param.All = args.All
@@ -0,0 +1,99 @@
+// Package doctor implements the aqua doctor command.
+// The doctor command diagnoses common problems of the environment,
+// such as PATH ordering and broken symbolic links, and outputs how to fix them.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/cliargs"
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/urfave/cli/v3"
+)
+
+const description = `Diagnose the environment and output how to fix problems.
+
+e.g.
+
+$ aqua doctor
+
+The following things are checked.
+
+- The root directory is writable
+- $(aqua root-dir)/bin is in PATH, and commands in it aren't shadowed by preceding directories
+- Files in $(aqua root-dir)/bin are symbolic links to aqua-proxy
+- aqua-proxy which this version of aqua requires is installed
+- A GitHub access token is available
+- Registries of configuration files can be installed
+- Multiple packages don't provide the same command
+- Registry cache files aren't broken
+
+If any error is found, this command fails.
+Warnings don't make this command fail.
+
+You can output the result as JSON.
+
+$ aqua doctor -format json`
+
+// Args holds command-line arguments for the doctor command.
+type Args struct {
+	*cliargs.GlobalArgs
+
+	Format string
+}
+
+// command holds the parameters and configuration for the doctor command.
+type command struct {
+	r *util.Param
+}
+
+// New creates and returns a new CLI command for diagnosing the environment.
+func New(r *util.Param, globalArgs *cliargs.GlobalArgs) *cli.Command {
+	args := &Args{
+		GlobalArgs: globalArgs,
+	}
+	i := &command{
+		r: r,
+	}
+	return &cli.Command{
+		Name:        "doctor",
+		Usage:       "Diagnose the environment",
+		Description: description,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "output format (text or json)",
+				Value:       "text",
+				Destination: &args.Format,
+			},
+		},
+		Action: func(ctx context.Context, _ *cli.Command) error {
+			return i.action(ctx, args)
+		},
+	}
+}
+
+// action implements the main logic for the doctor command.
+func (i *command) action(ctx context.Context, args *Args) error {
+	profiler, err := profile.Start(args.Trace, args.CPUProfile)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(args.GlobalArgs, i.r.Logger, param, i.r.Version); err != nil {
+		return fmt.Errorf("set param: %w", err)
+	}
+	param.OutputFormat = args.Format
+	ctrl, err := controller.InitializeDoctorCommandController(ctx, i.r.Logger.Logger, param, http.DefaultClient, i.r.Runtime)
+	if err != nil {
+		return fmt.Errorf("initialize a DoctorController: %w", err)
+	}
+	return ctrl.Doctor(ctx, i.r.Logger.Logger, param) //nolint:wrapcheck
+}
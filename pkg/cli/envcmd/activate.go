@@ -0,0 +1,83 @@
+package envcmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/cliargs"
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/controller/env"
+	"github.com/urfave/cli/v3"
+)
+
+// ActivateArgs holds command-line arguments for the activate command.
+type ActivateArgs struct {
+	*cliargs.GlobalArgs
+
+	Shell string
+}
+
+type activateCommand struct {
+	r *util.Param
+}
+
+// NewActivate creates and returns a new CLI command for outputting a shell hook
+// which runs "aqua env" automatically.
+func NewActivate(r *util.Param, globalArgs *cliargs.GlobalArgs) *cli.Command {
+	args := &ActivateArgs{
+		GlobalArgs: globalArgs,
+	}
+	i := &activateCommand{
+		r: r,
+	}
+	return &cli.Command{
+		Name:      "activate",
+		Usage:     "Output a shell hook to run aqua env automatically",
+		ArgsUsage: `<bash|zsh|fish|powershell>`,
+		Description: `Output a shell hook which runs "aqua env --hook" before every prompt.
+
+Packages are installed and added to PATH when you change the current directory or configuration files, like direnv.
+
+e.g.
+
+.bashrc
+
+eval "$(aqua activate bash)"
+
+.zshrc
+
+eval "$(aqua activate zsh)"
+
+config.fish
+
+aqua activate fish | source
+
+PowerShell profile
+
+aqua activate powershell | Out-String | Invoke-Expression
+`,
+		Action: func(_ context.Context, _ *cli.Command) error {
+			return i.action(args)
+		},
+		Arguments: []cli.Argument{
+			&cli.StringArg{
+				Name:        "shell",
+				Destination: &args.Shell,
+			},
+		},
+	}
+}
+
+func (i *activateCommand) action(args *ActivateArgs) error {
+	profiler, err := profile.Start(args.Trace, args.CPUProfile)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	if args.Shell == "" {
+		return errShellIsRequired
+	}
+	return env.WriteHook(i.r.Stdout, args.Shell) //nolint:wrapcheck
+}
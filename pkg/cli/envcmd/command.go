@@ -0,0 +1,127 @@
+// Package envcmd implements the aqua env and aqua activate commands.
+// The env command installs packages and outputs a shell snippet which
+// prepends directories of their executables to PATH, so that tools are
+// executed directly rather than through aqua-proxy.
+package envcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/cliargs"
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/urfave/cli/v3"
+)
+
+// Args holds command-line arguments for the env command.
+type Args struct {
+	*cliargs.GlobalArgs
+
+	Shell       string
+	Hook        bool
+	All         bool
+	Tags        string
+	ExcludeTags string
+}
+
+// command holds the parameters and configuration for the env command.
+type command struct {
+	r *util.Param
+}
+
+// New creates and returns a new CLI command for activating packages by PATH.
+func New(r *util.Param, globalArgs *cliargs.GlobalArgs) *cli.Command {
+	args := &Args{
+		GlobalArgs: globalArgs,
+	}
+	i := &command{
+		r: r,
+	}
+	return &cli.Command{
+		Name:      "env",
+		Usage:     "Install packages and output a shell snippet to add them to PATH",
+		ArgsUsage: `<bash|zsh|fish|powershell>`,
+		Description: `Install packages in configuration files and output a shell snippet to prepend directories of their executables to PATH.
+
+Some tools don't work well via aqua-proxy, because they refer to their own path or argv[0].
+By this command, tools are executed directly without aqua-proxy.
+
+e.g.
+
+$ eval "$(aqua env bash)"
+
+Directories added by the previous execution are removed from PATH,
+so you can run the command again after changing the current directory or configuration files.
+
+To run the command automatically, please use "aqua activate".
+
+With --hook, nothing is output if configuration files aren't changed since the last execution.
+`,
+		Action: func(ctx context.Context, _ *cli.Command) error {
+			return i.action(ctx, args)
+		},
+		Arguments: []cli.Argument{
+			&cli.StringArg{
+				Name:        "shell",
+				Destination: &args.Shell,
+			},
+		},
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "hook",
+				Usage:       "output nothing if configuration files aren't changed since the last execution",
+				Destination: &args.Hook,
+			},
+			&cli.BoolFlag{
+				Name:        "all",
+				Aliases:     []string{"a"},
+				Usage:       "add packages in global configuration files too",
+				Destination: &args.All,
+			},
+			&cli.StringFlag{
+				Name:        "tags",
+				Aliases:     []string{"t"},
+				Usage:       "filter packages with tags",
+				Destination: &args.Tags,
+			},
+			&cli.StringFlag{
+				Name:        "exclude-tags",
+				Usage:       "exclude packages with tags",
+				Destination: &args.ExcludeTags,
+			},
+		},
+	}
+}
+
+func (i *command) action(ctx context.Context, args *Args) error {
+	profiler, err := profile.Start(args.Trace, args.CPUProfile)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	if args.Shell == "" {
+		return errShellIsRequired
+	}
+	param := &config.Param{}
+	if err := util.SetParam(args.GlobalArgs, i.r.Logger, param, i.r.Version); err != nil {
+		return fmt.Errorf("set param: %w", err)
+	}
+	param.SubCommand = "env"
+	param.All = args.All
+	param.Tags = util.ParseTags(strings.Split(args.Tags, ","))
+	param.ExcludedTags = util.ParseTags(strings.Split(args.ExcludeTags, ","))
+	ctrl, err := controller.InitializeEnvCommandController(ctx, i.r.Logger.Logger, param, http.DefaultClient, i.r.Runtime)
+	if err != nil {
+		return fmt.Errorf("initialize an EnvController: %w", err)
+	}
+	return ctrl.Env(ctx, i.r.Logger.Logger, param, args.Shell, args.Hook) //nolint:wrapcheck
+}
+
+var errShellIsRequired = errors.New("shell is required")
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"path/filepath"
 	"strings"
 
 	"github.com/aquaproj/aqua/v2/pkg/cli/cliargs"
@@ -26,6 +27,9 @@ type Args struct {
 	All         bool
 	Tags        string
 	ExcludeTags string
+	DryRun      bool
+	FromDir     string
+	Format      string
 }
 
 // command holds the parameters and configuration for the install command.
@@ -67,6 +71,25 @@ You can filter installed packages with package tags.
 e.g.
 $ aqua i -t foo # Install only packages having a tag "foo"
 $ aqua i --exclude-tags foo # Install only packages not having a tag "foo"
+
+If you want to see what aqua would install without downloading packages,
+please set "--dry-run" option.
+aqua outputs packages' download URLs, expected checksums, verifications, and links.
+Registries are downloaded to resolve packages, but packages aren't downloaded,
+links aren't created, and aqua-checksums.json isn't updated.
+
+$ aqua i --dry-run
+$ aqua i -a --dry-run --format json
+
+If packages are delivered out-of-band, you can install them from local files
+by setting "--from-dir" option.
+aqua looks for files whose names are equal to packages' asset names in the directory.
+If assets of multiple packages have the same file name, aqua can't tell which package the file belongs to,
+so installing those packages fails.
+Local files must be verified with checksums in aqua-checksums.json.
+Packages whose assets aren't found in the directory are downloaded as usual.
+
+$ aqua i --from-dir ./artifacts
 `,
 		Action: func(ctx context.Context, _ *cli.Command) error {
 			return i.action(ctx, args)
@@ -100,6 +123,22 @@ $ aqua i --exclude-tags foo # Install only packages not having a tag "foo"
 				Usage:       "exclude installed packages with tags",
 				Destination: &args.ExcludeTags,
 			},
+			&cli.StringFlag{
+				Name:        "from-dir",
+				Usage:       "install packages from local files in the directory",
+				Destination: &args.FromDir,
+			},
+			&cli.BoolFlag{
+				Name:        "dry-run",
+				Usage:       "output what would be installed without downloading packages",
+				Destination: &args.DryRun,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "output format of --dry-run (text or json)",
+				Value:       "text",
+				Destination: &args.Format,
+			},
 		},
 	}
 }
@@ -118,10 +157,19 @@ func (i *command) action(ctx context.Context, args *Args) error {
 	if err := util.SetParam(args.GlobalArgs, i.r.Logger, param, i.r.Version); err != nil {
 		return fmt.Errorf("parse the command line arguments: %w", err)
 	}
+	param.SubCommand = "install"
 	param.OnlyLink = args.OnlyLink
 	param.All = args.All
 	param.Tags = util.ParseTags(strings.Split(args.Tags, ","))
 	param.ExcludedTags = util.ParseTags(strings.Split(args.ExcludeTags, ","))
+	param.DryRun = args.DryRun
+	param.OutputFormat = args.Format
+	if args.FromDir != "" {
+		param.ArtifactDir = args.FromDir
+		if !filepath.IsAbs(param.ArtifactDir) {
+			param.ArtifactDir = filepath.Join(param.CWD, param.ArtifactDir)
+		}
+	}
 
 	ctrl, err := controller.InitializeInstallCommandController(ctx, i.r.Logger.Logger, param, http.DefaultClient, i.r.Runtime)
 	if err != nil {
@@ -22,6 +22,7 @@ func New(r *util.Param, globalArgs *cliargs.GlobalArgs) *cli.Command {
 			newPolicyAllow(r, globalArgs),
 			newPolicyDeny(r, globalArgs),
 			newPolicyInit(r, globalArgs),
+			newPolicyTest(r, globalArgs),
 		},
 	}
 }
@@ -0,0 +1,111 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/cliargs"
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/urfave/cli/v3"
+)
+
+const policyTestDescription = `Test if policies allow packages in configuration files without installing them.
+
+This command resolves registries, but doesn't download assets of packages.
+It outputs whether each package is allowed or denied, and fails if some packages are denied.
+
+e.g.
+$ aqua policy test
+allow	cli/cli@v2.40.0	standard	/home/foo/workspace/aqua.yaml	default policy packages[0]
+deny	suzuki-shunsuke/tfcmt@v4.0.0	local	/home/foo/workspace/aqua.yaml	this registry isn't allowed
+
+You can give configuration files as arguments.
+
+$ aqua policy test foo/aqua.yaml bar/aqua.yaml
+
+By default, policies are read in the same way as aqua install.
+You can test new policy files by the -policy option.
+Then only given policy files are used, and they don't have to be allowed by "aqua policy allow".
+
+$ aqua policy test -policy aqua-policy.yaml
+
+You can output the result as JSON by "-format json".
+`
+
+// policyTestArgs holds command-line arguments for the policy test command.
+type policyTestArgs struct {
+	*cliargs.GlobalArgs
+
+	ConfigFilePaths []string
+	PolicyFilePaths []string
+	Format          string
+}
+
+// policyTestCommand holds the parameters and configuration for the policy test command.
+type policyTestCommand struct {
+	r *util.Param
+}
+
+// newPolicyTest creates and returns a new CLI command for testing policies.
+func newPolicyTest(r *util.Param, globalArgs *cliargs.GlobalArgs) *cli.Command {
+	args := &policyTestArgs{
+		GlobalArgs: globalArgs,
+	}
+	i := &policyTestCommand{
+		r: r,
+	}
+	return &cli.Command{
+		Action: func(ctx context.Context, _ *cli.Command) error {
+			return i.action(ctx, args)
+		},
+		Name:        "test",
+		Usage:       "Test if policies allow packages without installing them",
+		Description: policyTestDescription,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "policy",
+				Aliases:     []string{"p"},
+				Usage:       "policy file paths. This option can be set multiple times",
+				Destination: &args.PolicyFilePaths,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "output format (text or json)",
+				Value:       "text",
+				Destination: &args.Format,
+			},
+		},
+		Arguments: []cli.Argument{
+			&cli.StringArgs{
+				Name:        "config_file_path",
+				Max:         -1,
+				Destination: &args.ConfigFilePaths,
+			},
+		},
+	}
+}
+
+// action implements the main logic for the policy test command.
+func (pt *policyTestCommand) action(ctx context.Context, args *policyTestArgs) error {
+	profiler, err := profile.Start(args.Trace, args.CPUProfile)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(args.GlobalArgs, pt.r.Logger, param, pt.r.Version); err != nil {
+		return fmt.Errorf("set param: %w", err)
+	}
+	param.Args = args.ConfigFilePaths
+	param.OutputFormat = args.Format
+	ctrl, err := controller.InitializeTestPolicyCommandController(ctx, pt.r.Logger.Logger, param, http.DefaultClient, pt.r.Runtime)
+	if err != nil {
+		return fmt.Errorf("initialize a TestPolicyController: %w", err)
+	}
+	return ctrl.Test(ctx, pt.r.Logger.Logger, param, args.PolicyFilePaths) //nolint:wrapcheck
+}
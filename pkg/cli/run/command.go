@@ -0,0 +1,126 @@
+// Package run implements the aqua run command.
+// The run command installs a package from a registry and executes it
+// without adding the package to configuration files, like npx and uvx.
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/cliargs"
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/aquaproj/aqua/v2/pkg/controller/run"
+	"github.com/urfave/cli/v3"
+)
+
+// Args holds command-line arguments for the run command.
+type Args struct {
+	*cliargs.GlobalArgs
+
+	Package  string
+	Registry string
+	Command  string
+	ExecArgs []string
+}
+
+// command holds the parameters and configuration for the run command.
+type command struct {
+	r *util.Param
+}
+
+// New creates and returns a new CLI command for running a package without configuration files.
+func New(r *util.Param, globalArgs *cliargs.GlobalArgs) *cli.Command {
+	args := &Args{
+		GlobalArgs: globalArgs,
+	}
+	i := &command{
+		r: r,
+	}
+	return &cli.Command{
+		Name:      "run",
+		Usage:     "Install a package and execute it without configuration files",
+		ArgsUsage: `<package name>[@<version>] [-- <arg> ...]`,
+		Description: `Install a package and execute its command without adding the package to configuration files.
+
+e.g.
+
+$ aqua run cli/cli@v2.40.0 -- version
+$ aqua run cli/cli -- version # the latest version
+
+If the version is omitted, the version in configuration files is used if the package is there.
+Otherwise, the latest version is used.
+
+The package is searched from the registry of the nearest configuration file which has the registry.
+Policies and checksum settings of configuration files are applied as "aqua exec".
+If no configuration file has the standard registry, the standard registry is used.
+
+$ aqua run -r local foo/bar -- --help # Use the registry "local"
+
+If the package has multiple commands, you have to specify the command by --cmd.
+
+$ aqua run --cmd kubectl-krew kubernetes-sigs/krew -- version
+`,
+		Action: func(ctx context.Context, _ *cli.Command) error {
+			return i.action(ctx, args)
+		},
+		Arguments: []cli.Argument{
+			&cli.StringArg{
+				Name:        "package",
+				Destination: &args.Package,
+			},
+			&cli.StringArgs{
+				Name:        "exec_args",
+				Min:         0,
+				Max:         -1,
+				Destination: &args.ExecArgs,
+			},
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "registry",
+				Aliases:     []string{"r"},
+				Usage:       "Registry name",
+				Value:       "standard",
+				Destination: &args.Registry,
+			},
+			&cli.StringFlag{
+				Name:        "cmd",
+				Usage:       "Executed command. This is required if the package has multiple commands",
+				Destination: &args.Command,
+			},
+		},
+	}
+}
+
+// action implements the main logic for the run command.
+func (i *command) action(ctx context.Context, args *Args) error {
+	profiler, err := profile.Start(args.Trace, args.CPUProfile)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(args.GlobalArgs, i.r.Logger, param, i.r.Version); err != nil {
+		return fmt.Errorf("set param: %w", err)
+	}
+	param.SubCommand = "run"
+	if args.Package == "" {
+		return errors.New("package name is required")
+	}
+	ctrl, err := controller.InitializeRunCommandController(ctx, i.r.Logger.Logger, param, http.DefaultClient, i.r.Runtime)
+	if err != nil {
+		return fmt.Errorf("initialize a RunController: %w", err)
+	}
+	return ctrl.Run(ctx, i.r.Logger.Logger, param, &run.Param{ //nolint:wrapcheck
+		Package:  args.Package,
+		Registry: args.Registry,
+		Command:  args.Command,
+		Args:     args.ExecArgs,
+	})
+}
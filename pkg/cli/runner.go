@@ -3,8 +3,11 @@ package cli
 import (
 	"context"
 
+	"github.com/aquaproj/aqua/v2/pkg/cli/audit"
 	"github.com/aquaproj/aqua/v2/pkg/cli/cliargs"
 	"github.com/aquaproj/aqua/v2/pkg/cli/cp"
+	"github.com/aquaproj/aqua/v2/pkg/cli/doctor"
+	"github.com/aquaproj/aqua/v2/pkg/cli/envcmd"
 	"github.com/aquaproj/aqua/v2/pkg/cli/exec"
 	"github.com/aquaproj/aqua/v2/pkg/cli/generate"
 	"github.com/aquaproj/aqua/v2/pkg/cli/genr"
@@ -15,12 +18,18 @@ import (
 	cpolicy "github.com/aquaproj/aqua/v2/pkg/cli/policy"
 	"github.com/aquaproj/aqua/v2/pkg/cli/remove"
 	"github.com/aquaproj/aqua/v2/pkg/cli/root"
+	"github.com/aquaproj/aqua/v2/pkg/cli/run"
+	"github.com/aquaproj/aqua/v2/pkg/cli/sbom"
+	"github.com/aquaproj/aqua/v2/pkg/cli/shellinit"
+	"github.com/aquaproj/aqua/v2/pkg/cli/stats"
+	"github.com/aquaproj/aqua/v2/pkg/cli/suggest"
 	"github.com/aquaproj/aqua/v2/pkg/cli/token"
 	"github.com/aquaproj/aqua/v2/pkg/cli/upc"
 	"github.com/aquaproj/aqua/v2/pkg/cli/update"
 	"github.com/aquaproj/aqua/v2/pkg/cli/updateaqua"
 	"github.com/aquaproj/aqua/v2/pkg/cli/util"
 	"github.com/aquaproj/aqua/v2/pkg/cli/vacuum"
+	"github.com/aquaproj/aqua/v2/pkg/cli/verify"
 	"github.com/aquaproj/aqua/v2/pkg/cli/which"
 	"github.com/aquaproj/aqua/v2/pkg/runtime"
 	"github.com/suzuki-shunsuke/slog-util/slogutil"
@@ -75,6 +84,9 @@ func Run(ctx context.Context, logger *slogutil.Logger, env *urfave.Env) error {
 			info.New,
 			remove.New,
 			vacuum.New,
+			verify.New,
+			sbom.New,
+			audit.New,
 			token.New,
 			cp.New,
 			cpolicy.New,
@@ -83,15 +95,22 @@ func Run(ctx context.Context, logger *slogutil.Logger, env *urfave.Env) error {
 			list.New,
 			genr.New,
 			root.New,
+			shellinit.New,
+			envcmd.New,
+			envcmd.NewActivate,
+			run.New,
+			suggest.New,
+			doctor.New,
+			stats.New,
 		),
 	}).Run(ctx, env.Args)
 }
 
 // exitErrHandlerFunc handles exit errors for CLI commands.
-// It provides special handling for the "exec" command by skipping the default
-// error handling, allowing the exec command to manage its own exit codes.
+// It provides special handling for the "exec" and "run" commands by skipping the default
+// error handling, allowing them to manage their own exit codes.
 func exitErrHandlerFunc(_ context.Context, cmd *cli.Command, err error) {
-	if cmd.Name != "exec" {
+	if cmd.Name != "exec" && cmd.Name != "run" {
 		cli.HandleExitCoder(err)
 		return
 	}
@@ -0,0 +1,99 @@
+// Package sbom implements the aqua sbom command for outputting the Software Bill of Materials of packages.
+package sbom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/cliargs"
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/urfave/cli/v3"
+)
+
+const description = `Output the Software Bill of Materials (SBOM) of packages in CycloneDX JSON or SPDX JSON.
+
+	$ aqua sbom > bom.cdx.json
+	$ aqua sbom -f spdx > bom.spdx.json
+
+The SBOM includes the package name, version, Package URL (purl), download URL,
+checksum in aqua-checksums.json, and configured signature and provenance verifications.
+Packages aren't downloaded, so checksums which aren't in aqua-checksums.json aren't output.
+
+By default, packages in aqua.yaml are output.
+If the -all option is set, packages in global configuration files are also output.
+
+	$ aqua sbom -a
+`
+
+// Args holds command-line arguments for the sbom command.
+type Args struct {
+	*cliargs.GlobalArgs
+
+	All    bool
+	Format string
+}
+
+type command struct {
+	r *util.Param
+}
+
+// New creates and returns a new CLI command for outputting the SBOM.
+func New(r *util.Param, globalArgs *cliargs.GlobalArgs) *cli.Command {
+	args := &Args{
+		GlobalArgs: globalArgs,
+	}
+	i := &command{
+		r: r,
+	}
+	return &cli.Command{
+		Name:        "sbom",
+		Usage:       "Output the SBOM of packages",
+		Description: description,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "all",
+				Aliases:     []string{"a"},
+				Usage:       "output packages in global configuration files too",
+				Destination: &args.All,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Aliases:     []string{"f"},
+				Usage:       "SBOM format (cyclonedx or spdx)",
+				Value:       "cyclonedx",
+				Destination: &args.Format,
+			},
+		},
+		Action: func(ctx context.Context, _ *cli.Command) error {
+			return i.action(ctx, args)
+		},
+	}
+}
+
+// action implements the main logic for the sbom command.
+func (i *command) action(ctx context.Context, args *Args) error {
+	profiler, err := profile.Start(args.Trace, args.CPUProfile)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(args.GlobalArgs, i.r.Logger, param, i.r.Version); err != nil {
+		return fmt.Errorf("set param: %w", err)
+	}
+	param.All = args.All
+	param.OutputFormat = args.Format
+	ctrl, err := controller.InitializeSBOMCommandController(ctx, i.r.Logger.Logger, param, http.DefaultClient, i.r.Runtime)
+	if err != nil {
+		return fmt.Errorf("initialize a SBOMController: %w", err)
+	}
+	if err := ctrl.SBOM(ctx, i.r.Logger.Logger, param); err != nil {
+		return err //nolint:wrapcheck
+	}
+	return nil
+}
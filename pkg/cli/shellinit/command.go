@@ -0,0 +1,103 @@
+// Package shellinit implements the aqua shell-init command.
+// The shell-init command outputs a shell snippet which loads shell completions
+// and man pages of packages in configuration files.
+package shellinit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/cliargs"
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/urfave/cli/v3"
+)
+
+// Args holds command-line arguments for the shell-init command.
+type Args struct {
+	*cliargs.GlobalArgs
+
+	Shell string
+}
+
+// command holds the parameters and configuration for the shell-init command.
+type command struct {
+	r *util.Param
+}
+
+// New creates and returns a new CLI command for outputting a shell snippet
+// which loads shell completions and man pages of packages.
+func New(r *util.Param, globalArgs *cliargs.GlobalArgs) *cli.Command {
+	args := &Args{
+		GlobalArgs: globalArgs,
+	}
+	i := &command{
+		r: r,
+	}
+	return &cli.Command{
+		Name:      "shell-init",
+		Usage:     "Output a shell snippet to load shell completions and man pages of packages",
+		ArgsUsage: `<bash|zsh|fish>`,
+		Description: `Output a shell snippet to load shell completions and man pages of packages in configuration files.
+
+aqua links shell completions and man pages of packages into $AQUA_ROOT_DIR/share when packages are installed.
+The snippet loads ones of packages in the current configuration files and global configuration files.
+
+e.g.
+
+.bashrc
+
+if command -v aqua &> /dev/null; then
+  source <(aqua shell-init bash)
+fi
+
+.zshrc (Please run it before compinit)
+
+if command -v aqua &> /dev/null; then
+  source <(aqua shell-init zsh)
+fi
+
+config.fish
+
+if type -q aqua
+  aqua shell-init fish | source
+end
+`,
+		Action: func(ctx context.Context, _ *cli.Command) error {
+			return i.action(ctx, args)
+		},
+		Arguments: []cli.Argument{
+			&cli.StringArg{
+				Name:        "shell",
+				Destination: &args.Shell,
+			},
+		},
+	}
+}
+
+func (i *command) action(ctx context.Context, args *Args) error {
+	profiler, err := profile.Start(args.Trace, args.CPUProfile)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	if args.Shell == "" {
+		return errShellIsRequired
+	}
+	param := &config.Param{}
+	if err := util.SetParam(args.GlobalArgs, i.r.Logger, param, i.r.Version); err != nil {
+		return fmt.Errorf("set param: %w", err)
+	}
+	ctrl, err := controller.InitializeShellInitCommandController(ctx, i.r.Logger.Logger, param, http.DefaultClient, i.r.Runtime)
+	if err != nil {
+		return fmt.Errorf("initialize a ShellInitController: %w", err)
+	}
+	return ctrl.ShellInit(ctx, i.r.Logger.Logger, param, args.Shell) //nolint:wrapcheck
+}
+
+var errShellIsRequired = errors.New("shell is required")
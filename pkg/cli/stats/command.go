@@ -0,0 +1,120 @@
+// Package stats implements the aqua stats command.
+// The stats command reports usage statistics of installed packages recorded on every execution,
+// which helps to find unused packages.
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/cliargs"
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/urfave/cli/v3"
+)
+
+var (
+	errStatsTrackingDisabled = errors.New(`the stats command isn't available. Tracking is disabled`)
+	errStatsDisabled         = errors.New(`the stats command isn't available. Set the environment variable AQUA_ENABLE_STATS to true to record usage statistics`)
+)
+
+const description = `Output usage statistics of installed packages.
+
+aqua records invocation counts and first and last used date times of commands every time they are executed.
+This command outputs them from the most used packages.
+
+	$ aqua stats
+
+Installed packages which have never been used are also output, so you can find and remove unused packages.
+
+	$ aqua stats --least -limit 10
+
+You can output the result as JSON.
+
+	$ aqua stats -format json
+
+Statistics are stored in $AQUA_ROOT_DIR/metadata and never sent anywhere.
+aqua records statistics only if the environment variable $AQUA_ENABLE_STATS is true.
+If $AQUA_ENABLE_STATS isn't true or $AQUA_DISABLE_TRACKING is true, aqua doesn't record statistics, so this command fails.
+`
+
+// Args holds command-line arguments for the stats command.
+type Args struct {
+	*cliargs.GlobalArgs
+
+	Format string
+	Limit  int
+	Least  bool
+}
+
+type command struct {
+	r *util.Param
+}
+
+// New creates and returns a new CLI command for outputting usage statistics.
+func New(r *util.Param, globalArgs *cliargs.GlobalArgs) *cli.Command {
+	args := &Args{
+		GlobalArgs: globalArgs,
+	}
+	i := &command{
+		r: r,
+	}
+	return &cli.Command{
+		Name:        "stats",
+		Usage:       "Output usage statistics of installed packages",
+		Description: description,
+		Action: func(ctx context.Context, _ *cli.Command) error {
+			return i.action(ctx, args)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "output format (text or json)",
+				Value:       "text",
+				Destination: &args.Format,
+			},
+			&cli.IntFlag{
+				Name:        "limit",
+				Aliases:     []string{"l"},
+				Usage:       "The maximum number of packages. Non-positive number refers to no limit.",
+				Destination: &args.Limit,
+			},
+			&cli.BoolFlag{
+				Name:        "least",
+				Usage:       "Output packages from the least used ones",
+				Destination: &args.Least,
+			},
+		},
+	}
+}
+
+// action implements the main logic for the stats command.
+func (i *command) action(ctx context.Context, args *Args) error {
+	profiler, err := profile.Start(args.Trace, args.CPUProfile)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(args.GlobalArgs, i.r.Logger, param, i.r.Version); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+
+	if param.DisableTracking {
+		// aqua doesn't record usage statistics, so they are stale.
+		return errStatsTrackingDisabled
+	}
+	if !param.EnableStats {
+		return errStatsDisabled
+	}
+
+	param.OutputFormat = args.Format
+	param.Limit = args.Limit
+	param.LeastUsed = args.Least
+	ctrl := controller.InitializeStatsCommandController(ctx, param)
+	return ctrl.Stats(i.r.Logger.Logger, param) //nolint:wrapcheck
+}
@@ -0,0 +1,130 @@
+// Package suggest implements the aqua suggest command.
+// The suggest command searches registries for packages which provide
+// a given command, and outputs shell hooks which run it when a command isn't found.
+package suggest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/cliargs"
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/aquaproj/aqua/v2/pkg/controller/suggest"
+	"github.com/urfave/cli/v3"
+)
+
+// Args holds command-line arguments for the suggest command.
+type Args struct {
+	*cliargs.GlobalArgs
+
+	Command string
+	Hook    string
+	Insert  bool
+}
+
+// command holds the parameters and configuration for the suggest command.
+type command struct {
+	r *util.Param
+}
+
+// New creates and returns a new CLI command for suggesting packages which provide a command.
+func New(r *util.Param, globalArgs *cliargs.GlobalArgs) *cli.Command {
+	args := &Args{
+		GlobalArgs: globalArgs,
+	}
+	i := &command{
+		r: r,
+	}
+	return &cli.Command{
+		Name:      "suggest",
+		Usage:     "Suggest packages which provide the given command",
+		ArgsUsage: `<command name>`,
+		Description: `Search packages which provide the given command in registries of configuration files,
+and output commands to add them to the configuration file.
+Package files, aliases, and search words are searched.
+
+e.g.
+
+$ aqua suggest gh
+The command "gh" is provided by the following packages:
+
+aqua g -i cli/cli # GitHub’s official command line tool
+
+If --insert is set, you're asked to add a package to the nearest configuration file.
+
+$ aqua suggest --insert gh
+
+If no package is found, this command fails.
+
+--hook outputs a shell snippet which runs this command when a command isn't found.
+bash, zsh, and fish are supported.
+
+# .bashrc
+eval "$(aqua suggest --hook bash)"
+
+# .zshrc
+eval "$(aqua suggest --hook zsh)"
+
+# config.fish
+aqua suggest --hook fish | source
+
+If --insert is set with --hook, the snippet asks you to add a package.
+
+$ eval "$(aqua suggest --hook bash --insert)"
+`,
+		Action: func(ctx context.Context, _ *cli.Command) error {
+			return i.action(ctx, args)
+		},
+		Arguments: []cli.Argument{
+			&cli.StringArg{
+				Name:        "command",
+				Destination: &args.Command,
+			},
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "hook",
+				Usage:       "Output a shell snippet of the command-not-found handler for the given shell",
+				Destination: &args.Hook,
+			},
+			&cli.BoolFlag{
+				Name:        "insert",
+				Aliases:     []string{"i"},
+				Usage:       "Ask to add a package to the configuration file",
+				Destination: &args.Insert,
+			},
+		},
+	}
+}
+
+// action implements the main logic for the suggest command.
+func (i *command) action(ctx context.Context, args *Args) error {
+	if args.Hook != "" {
+		return suggest.WriteHook(os.Stdout, args.Hook, args.Insert) //nolint:wrapcheck
+	}
+	profiler, err := profile.Start(args.Trace, args.CPUProfile)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(args.GlobalArgs, i.r.Logger, param, i.r.Version); err != nil {
+		return fmt.Errorf("set param: %w", err)
+	}
+	if args.Command == "" {
+		return errors.New("command is required")
+	}
+	ctrl, err := controller.InitializeSuggestCommandController(ctx, i.r.Logger.Logger, param, http.DefaultClient, i.r.Runtime)
+	if err != nil {
+		return fmt.Errorf("initialize a SuggestController: %w", err)
+	}
+	return ctrl.Suggest(ctx, i.r.Logger.Logger, param, filepath.Base(args.Command), args.Insert) //nolint:wrapcheck
+}
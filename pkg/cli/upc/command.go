@@ -97,6 +97,7 @@ func (i *command) action(ctx context.Context, args *Args) error {
 	if err := util.SetParam(args.GlobalArgs, i.r.Logger, param, i.r.Version); err != nil {
 		return fmt.Errorf("set param: %w", err)
 	}
+	param.SubCommand = "update-checksum"
 	param.All = args.All
 	param.Prune = args.Prune
 	ctrl, err := controller.InitializeUpdateChecksumCommandController(ctx, i.r.Logger.Logger, param, http.DefaultClient, i.r.Runtime)
@@ -14,6 +14,8 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/cli/cliargs"
 	"github.com/aquaproj/aqua/v2/pkg/config"
 	finder "github.com/aquaproj/aqua/v2/pkg/config-finder"
+	"github.com/aquaproj/aqua/v2/pkg/cosign"
+	"github.com/aquaproj/aqua/v2/pkg/hostlimit"
 	"github.com/aquaproj/aqua/v2/pkg/policy"
 	"github.com/aquaproj/aqua/v2/pkg/runtime"
 	"github.com/suzuki-shunsuke/go-osenv/osenv"
@@ -62,14 +64,33 @@ func SetParam(args *cliargs.GlobalArgs, logger *slogutil.Logger, param *config.P
 	param.GlobalConfigFilePaths = finder.ParseGlobalConfigFilePaths(wd, os.Getenv("AQUA_GLOBAL_CONFIG"))
 	param.CWD = wd
 	param.ProgressBar = os.Getenv("AQUA_PROGRESS_BAR") == "true"
+	param.EventLog = os.Getenv("AQUA_EVENT_LOG")
+	param.CosignVerifier = os.Getenv("AQUA_COSIGN_VERIFIER")
+	if err := cosign.ValidateVerifier(param.CosignVerifier); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if p := os.Getenv("AQUA_COSIGN_TRUSTED_ROOT"); p != "" {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(param.CWD, p)
+		}
+		param.CosignTrustedRoot = p
+	}
+
+	bandwidth, err := hostlimit.ParseBandwidth(args.DownloadBandwidthLimit)
+	if err != nil {
+		return fmt.Errorf("parse the download bandwidth limit: %w", err)
+	}
+	hostlimit.SetDefault(hostlimit.New(args.MaxConnsPerHost, bandwidth))
 
 	for _, e := range []struct {
 		envName string
 		target  *bool
 	}{
 		{"AQUA_DISABLE_LAZY_INSTALL", &param.DisableLazyInstall},
+		{"AQUA_DISABLE_EXEC_CACHE", &param.DisableExecCache},
 		{"AQUA_DISABLE_POLICY", &param.DisablePolicy},
 		{"AQUA_DISABLE_TRACKING", &param.DisableTracking},
+		{"AQUA_ENABLE_STATS", &param.EnableStats},
 		{"AQUA_CHECKSUM", &param.Checksum},
 		{"AQUA_REQUIRE_CHECKSUM", &param.RequireChecksum},
 		{"AQUA_ENFORCE_CHECKSUM", &param.EnforceChecksum},
@@ -86,6 +107,7 @@ func SetParam(args *cliargs.GlobalArgs, logger *slogutil.Logger, param *config.P
 				param.PolicyConfigFilePaths[i] = filepath.Join(param.CWD, p)
 			}
 		}
+		param.PolicyAuditLog = policy.AuditLogPath(os.Getenv("AQUA_POLICY_AUDIT_LOG"), param.RootDir, param.CWD)
 	}
 	return nil
 }
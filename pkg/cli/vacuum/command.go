@@ -25,6 +25,7 @@ This command removes unused installed packages, which is useful to save storage
 	$ aqua vacuum
 
 It removes installed packages which haven't been used for over the expiration days.
+It also removes entries of the resolution cache of "aqua exec" which haven't been updated for over the expiration days.
 The default expiration days is 60, but you can change it by the environment variable $AQUA_VACUUM_DAYS or the command line option "-days <expiration days>".
 
 e.g.
@@ -0,0 +1,107 @@
+// Package verify implements the aqua verify command for detecting modification of installed packages.
+// The verify command hashes files of installed packages again and compares them with
+// manifests recorded at installation.
+package verify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/cliargs"
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/urfave/cli/v3"
+)
+
+const description = `Detect modification of installed packages.
+
+aqua records hashes of files of packages when it installs them.
+This command hashes files of installed packages again and outputs modified, missing, and extra files.
+
+	$ aqua verify
+	modified	cli/cli@v2.40.0	gh_2.40.0_linux_amd64/bin/gh
+
+By default, this command verifies packages in aqua.yaml.
+If the -all option is set, all installed packages are verified.
+
+	$ aqua verify -a
+
+If some packages don't match, this command fails.
+If the -reinstall option is set, this command reinstalls them.
+Packages which aren't found in aqua.yaml are removed with the -all option, and they are installed again when they are executed.
+
+	$ aqua verify -reinstall
+
+Packages installed by old aqua don't have records, so they can't be verified.
+Please reinstall them with "aqua rm" and "aqua i".
+`
+
+// Args holds command-line arguments for the verify command.
+type Args struct {
+	*cliargs.GlobalArgs
+
+	All       bool
+	Reinstall bool
+}
+
+type command struct {
+	r *util.Param
+}
+
+// New creates and returns a new CLI command for verifying installed packages.
+func New(r *util.Param, globalArgs *cliargs.GlobalArgs) *cli.Command {
+	args := &Args{
+		GlobalArgs: globalArgs,
+	}
+	i := &command{
+		r: r,
+	}
+	return &cli.Command{
+		Name:        "verify",
+		Usage:       "Detect modification of installed packages",
+		Description: description,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "all",
+				Aliases:     []string{"a"},
+				Usage:       "verify all installed packages",
+				Destination: &args.All,
+			},
+			&cli.BoolFlag{
+				Name:        "reinstall",
+				Usage:       "reinstall modified packages",
+				Destination: &args.Reinstall,
+			},
+		},
+		Action: func(ctx context.Context, _ *cli.Command) error {
+			return i.action(ctx, args)
+		},
+	}
+}
+
+// action implements the main logic for the verify command.
+func (i *command) action(ctx context.Context, args *Args) error {
+	profiler, err := profile.Start(args.Trace, args.CPUProfile)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(args.GlobalArgs, i.r.Logger, param, i.r.Version); err != nil {
+		return fmt.Errorf("set param: %w", err)
+	}
+	param.All = args.All
+	param.Reinstall = args.Reinstall
+	ctrl, err := controller.InitializeVerifyCommandController(ctx, i.r.Logger.Logger, param, http.DefaultClient, i.r.Runtime)
+	if err != nil {
+		return fmt.Errorf("initialize a VerifyController: %w", err)
+	}
+	if err := ctrl.Verify(ctx, i.r.Logger.Logger, param); err != nil {
+		return err //nolint:wrapcheck
+	}
+	return nil
+}
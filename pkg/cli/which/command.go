@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/cli/util"
 	"github.com/aquaproj/aqua/v2/pkg/config"
 	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/aquaproj/aqua/v2/pkg/controller/which"
 	"github.com/suzuki-shunsuke/slog-error/slogerr"
 	"github.com/urfave/cli/v3"
 )
@@ -24,6 +26,7 @@ type Args struct {
 	*cliargs.GlobalArgs
 
 	ShowVersion bool
+	All         bool
 	Command     string
 }
 
@@ -65,6 +68,27 @@ If you want the package version, "--version" option is useful.
 
 $ aqua which --version gh
 v2.4.0
+
+If the package has environment variables, they are also output after the version.
+They are set when the command is executed.
+
+$ aqua which --version java
+21.0.2
+JAVA_HOME=/home/foo/.aqua/pkgs/http/download.java.net/java/GA/jdk21.0.2/openjdk-21.0.2_linux-x64_bin.tar.gz/jdk-21.0.2
+
+If you want to know why the command is resolved, "--all" option is useful.
+It outputs all packages and files in PATH which provide the command in the order of precedence,
+and the reason why they aren't used.
+
+$ aqua which --all gh
+[selected] cli/cli@v2.40.0 (registry: standard) in /home/foo/workspace/aqua.yaml
+  path: /home/foo/.aqua/pkgs/github_release/github.com/cli/cli/v2.40.0/gh_2.40.0_linux_amd64.tar.gz/gh_2.40.0_linux_amd64/bin/gh
+[shadowed] cli/cli@v2.30.0 (registry: standard) in /home/foo/.config/aquaproj-aqua/aqua.yaml
+  path: /home/foo/.aqua/pkgs/github_release/github.com/cli/cli/v2.30.0/gh_2.30.0_linux_amd64.tar.gz/gh_2.30.0_linux_amd64/bin/gh
+  reason: cli/cli@v2.40.0 in /home/foo/workspace/aqua.yaml takes precedence
+[shadowed] PATH
+  path: /usr/bin/gh
+  reason: cli/cli@v2.40.0 in /home/foo/workspace/aqua.yaml takes precedence
 `,
 		Action: func(ctx context.Context, _ *cli.Command) error {
 			return i.action(ctx, args)
@@ -73,9 +97,15 @@ v2.4.0
 			&cli.BoolFlag{
 				Name:        "version",
 				Aliases:     []string{"v"},
-				Usage:       "Output the given package version",
+				Usage:       "Output the given package version and environment variables",
 				Destination: &args.ShowVersion,
 			},
+			&cli.BoolFlag{
+				Name:        "all",
+				Aliases:     []string{"a"},
+				Usage:       "Output all packages and files in PATH which provide the given command and why they're used or not",
+				Destination: &args.All,
+			},
 		},
 		Arguments: []cli.Argument{
 			&cli.StringArg{
@@ -106,6 +136,14 @@ func (i *command) action(ctx context.Context, args *Args) error {
 		return errCommandIsRequired
 	}
 	logger := i.r.Logger.With("exe_name", args.Command)
+	if args.All {
+		candidates, err := ctrl.WhichAll(ctx, logger, param, args.Command)
+		if err != nil {
+			return slogerr.With(err, "exe_name", args.Command) //nolint:wrapcheck
+		}
+		outputCandidates(os.Stdout, candidates)
+		return nil
+	}
 	which, err := ctrl.Which(ctx, logger, param, args.Command)
 	if err != nil {
 		return slogerr.With(err, "exe_name", args.Command) //nolint:wrapcheck
@@ -118,7 +156,27 @@ func (i *command) action(ctx context.Context, args *Args) error {
 		return slogerr.With(errors.New("aqua can't get the command version because the command isn't managed by aqua"), "exe_name", args.Command) //nolint:wrapcheck
 	}
 	fmt.Fprintln(os.Stdout, which.Package.Package.Version)
+	for _, env := range which.Env {
+		fmt.Fprintln(os.Stdout, env)
+	}
 	return nil
 }
 
+// outputCandidates outputs candidates of aqua which --all.
+func outputCandidates(w io.Writer, candidates []*which.Candidate) {
+	for _, cd := range candidates {
+		if cd.PackageName == "" {
+			fmt.Fprintf(w, "[%s] PATH\n", cd.Status)
+		} else {
+			fmt.Fprintf(w, "[%s] %s@%s (registry: %s) in %s\n", cd.Status, cd.PackageName, cd.Version, cd.Registry, cd.ConfigFilePath)
+		}
+		if cd.ExePath != "" {
+			fmt.Fprintf(w, "  path: %s\n", cd.ExePath)
+		}
+		if cd.Reason != "" {
+			fmt.Fprintf(w, "  reason: %s\n", cd.Reason)
+		}
+	}
+}
+
 var errCommandIsRequired = errors.New("command is required")
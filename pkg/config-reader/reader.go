@@ -13,6 +13,7 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
 	"github.com/aquaproj/aqua/v2/pkg/expr"
 	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/aquaproj/aqua/v2/pkg/versionfile"
 	"github.com/suzuki-shunsuke/slog-error/slogerr"
 	"go.yaml.in/yaml/v2"
 )
@@ -70,7 +71,7 @@ func (r *ConfigReader) readPackages(logger *slog.Logger, configFilePath string,
 		if pkg == nil {
 			continue
 		}
-		subPkgs, err := r.readPackage(logger, configFilePath, pkg)
+		subPkgs, err := r.readPackage(logger, configFilePath, cfg, pkg)
 		if err != nil {
 			slogerr.WithError(logger, err).Error("read a package")
 			continue
@@ -92,29 +93,35 @@ func (r *ConfigReader) readImportDir(logger *slog.Logger, configFilePath string,
 	if cfg.ImportDir == "" {
 		return nil
 	}
-	pkgs1, err := r.importFiles(logger, configFilePath, filepath.Join(cfg.ImportDir, "*.yml"))
+	pkgs1, err := r.importFiles(logger, configFilePath, cfg, filepath.Join(cfg.ImportDir, "*.yml"))
 	if err != nil {
 		slogerr.WithError(logger, err).Error("read import files")
 	}
-	pkgs2, err := r.importFiles(logger, configFilePath, filepath.Join(cfg.ImportDir, "*.yaml"))
+	pkgs2, err := r.importFiles(logger, configFilePath, cfg, filepath.Join(cfg.ImportDir, "*.yaml"))
 	if err != nil {
 		slogerr.WithError(logger, err).Error("read import files")
 	}
 	return append(pkgs1, pkgs2...)
 }
 
-func (r *ConfigReader) readPackage(logger *slog.Logger, configFilePath string, pkg *aqua.Package) ([]*aqua.Package, error) {
-	if pkg.GoVersionFile != "" {
-		// go_version_file
-		if err := readGoVersionFile(configFilePath, pkg); err != nil {
-			return nil, fmt.Errorf("read a go version file: %w", slogerr.With(err,
-				"go_version_file", pkg.GoVersionFile,
+func (r *ConfigReader) readPackage(logger *slog.Logger, configFilePath string, cfg *aqua.Config, pkg *aqua.Package) ([]*aqua.Package, error) {
+	if vf := pkg.GetVersionFile(); vf != nil {
+		// version_file and go_version_file
+		dir := filepath.Dir(configFilePath)
+		cfg.Inputs = append(cfg.Inputs, versionfile.Path(dir, vf))
+		f, err := versionfile.Read(dir, vf)
+		if err != nil {
+			return nil, fmt.Errorf("read a version file: %w", slogerr.With(err,
+				"version_file", vf.Path,
 			))
 		}
+		pkg.Version = f.Version()
 		return nil, nil
 	}
 	if pkg.VersionExpr != "" {
 		// version_expr
+		// The expression can read arbitrary files, so the result can't be tied to Inputs.
+		cfg.Dynamic = true
 		dir := filepath.Dir(configFilePath)
 		s, err := expr.EvalVersionExpr(dir, pkg.VersionExpr)
 		if err != nil {
@@ -131,11 +138,13 @@ func (r *ConfigReader) readPackage(logger *slog.Logger, configFilePath string, p
 	}
 	// import
 	logger = logger.With("import", pkg.Import)
-	return r.importFiles(logger, configFilePath, pkg.Import)
+	return r.importFiles(logger, configFilePath, cfg, pkg.Import)
 }
 
-func (r *ConfigReader) importFiles(logger *slog.Logger, configFilePath string, importGlob string) ([]*aqua.Package, error) {
+func (r *ConfigReader) importFiles(logger *slog.Logger, configFilePath string, cfg *aqua.Config, importGlob string) ([]*aqua.Package, error) {
 	p := filepath.Join(filepath.Dir(configFilePath), importGlob)
+	// The directory is recorded so that adding or removing a matching file is detected.
+	cfg.Inputs = append(cfg.Inputs, filepath.Dir(p))
 	filePaths, err := filepath.Glob(p)
 	if err != nil {
 		return nil, fmt.Errorf("find files with a glob pattern: %w", err)
@@ -144,11 +153,14 @@ func (r *ConfigReader) importFiles(logger *slog.Logger, configFilePath string, i
 	pkgs := []*aqua.Package{}
 	for _, filePath := range filePaths {
 		logger := logger.With("imported_file", filePath)
+		cfg.Inputs = append(cfg.Inputs, filePath)
 		subCfg := &aqua.Config{}
 		if err := r.Read(logger, filePath, subCfg); err != nil {
 			slogerr.WithError(logger, err).Error("read an import file")
 			continue
 		}
+		cfg.Inputs = append(cfg.Inputs, subCfg.Inputs...)
+		cfg.Dynamic = cfg.Dynamic || subCfg.Dynamic
 		pkgs = append(pkgs, subCfg.Packages...)
 	}
 	return pkgs, nil
@@ -104,6 +104,56 @@ packages:
 							FilePath: filepath.Join(dir, fileAquaInstallerYaml),
 						},
 					},
+					Inputs: []string{
+						dir,
+						filepath.Join(dir, fileAquaInstallerYaml),
+					},
+				}
+			},
+		},
+		{
+			name: "version file",
+			files: map[string]string{
+				fileAquaYaml: `packages:
+- name: hashicorp/terraform
+  version_file:
+    path: .terraform-version
+    prefix: v
+- name: golang/go
+  go_version_file: go.mod
+`,
+				".terraform-version": "1.10.2\n",
+				"go.mod": `module example.com/foo
+
+go 1.22.3
+`,
+			},
+			configFilePath: fileAquaYaml,
+			exp: func(dir string) *aqua.Config {
+				return &aqua.Config{
+					Packages: []*aqua.Package{
+						{
+							Name:     "hashicorp/terraform",
+							Registry: regTypeStandard,
+							Version:  "v1.10.2",
+							VersionFile: &aqua.VersionFile{
+								Path:   ".terraform-version",
+								Prefix: "v",
+							},
+							FilePath: filepath.Join(dir, fileAquaYaml),
+						},
+						{
+							Name:          "golang/go",
+							Registry:      regTypeStandard,
+							Version:       "1.22.3",
+							GoVersionFile: "go.mod",
+							FilePath:      filepath.Join(dir, fileAquaYaml),
+						},
+					},
+					Inputs: []string{
+						filepath.Join(dir, ".terraform-version"),
+						filepath.Join(dir, "go.mod"),
+					},
 				}
 			},
 		},
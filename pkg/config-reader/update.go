@@ -10,6 +10,7 @@ import (
 
 	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
 	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/aquaproj/aqua/v2/pkg/versionfile"
 	"go.yaml.in/yaml/v2"
 )
 
@@ -51,7 +52,21 @@ func (r *ConfigReader) readImportsToUpdate(configFilePath string, cfg *aqua.Conf
 		if pkg == nil {
 			continue
 		}
-		if pkg.VersionExpr != "" || pkg.GoVersionFile != "" || pkg.Pin {
+		if vf := pkg.GetVersionFile(); vf != nil {
+			if !vf.Update {
+				// Exclude it from the update targets
+				continue
+			}
+			// The current version is read from the version file, and the new version is written to it.
+			f, err := versionfile.Read(filepath.Dir(configFilePath), vf)
+			if err != nil {
+				return nil, fmt.Errorf("read a version file: %w", err)
+			}
+			pkg.Version = f.Version()
+			pkgs = append(pkgs, pkg)
+			continue
+		}
+		if pkg.VersionExpr != "" || pkg.Pin {
 			// Exclude them from the update targets
 			continue
 		}
@@ -0,0 +1,54 @@
+package aqua
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// CommandSuffixAuto is a special value of command_suffix.
+// "@" and the major version of the package are appended to command names.
+const CommandSuffixAuto = "auto"
+
+var errInvalidCommandSuffix = errors.New("command_suffix must not contain path separators")
+
+// GetCommandSuffix returns the suffix appended to command names of the package.
+// If command_suffix is "auto", "@" and the major version are returned.
+// e.g. v3.14.0 => @3
+func (p *Package) GetCommandSuffix() string {
+	if p.CommandSuffix != CommandSuffixAuto {
+		return p.CommandSuffix
+	}
+	major, _, _ := strings.Cut(strings.TrimPrefix(p.Version, "v"), ".")
+	return "@" + major
+}
+
+// ValidateCommandSuffix returns an error if the command suffix can't be a part of file names.
+func (p *Package) ValidateCommandSuffix() error {
+	if strings.ContainsAny(p.GetCommandSuffix(), `/\`) {
+		return slogerr.With(errInvalidCommandSuffix, "command_suffix", p.CommandSuffix) //nolint:wrapcheck
+	}
+	return nil
+}
+
+// CommandName returns the name of the command provided by the file of the package.
+// The command suffix is appended to the file name.
+func (p *Package) CommandName(fileName string) string {
+	return fileName + p.GetCommandSuffix()
+}
+
+// TrimCommandSuffix returns the file name of the command.
+// If the package has a command suffix and exeName doesn't end with it, false is returned
+// because the package doesn't provide the command.
+func (p *Package) TrimCommandSuffix(exeName string) (string, bool) {
+	suffix := p.GetCommandSuffix()
+	if suffix == "" {
+		return exeName, true
+	}
+	fileName, ok := strings.CutSuffix(exeName, suffix)
+	if !ok || fileName == "" {
+		return "", false
+	}
+	return fileName, true
+}
@@ -0,0 +1,136 @@
+package aqua_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+)
+
+func TestPackage_GetCommandSuffix(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name string
+		pkg  *aqua.Package
+		exp  string
+	}{
+		{
+			name: "no suffix",
+			pkg:  &aqua.Package{Version: "v3.14.0"},
+			exp:  "",
+		},
+		{
+			name: "custom suffix",
+			pkg:  &aqua.Package{Version: "v3.14.0", CommandSuffix: "-3"},
+			exp:  "-3",
+		},
+		{
+			name: "auto",
+			pkg:  &aqua.Package{Version: "v3.14.0", CommandSuffix: "auto"},
+			exp:  "@3",
+		},
+		{
+			name: "auto without v prefix",
+			pkg:  &aqua.Package{Version: "14.1.0", CommandSuffix: "auto"},
+			exp:  "@14",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if suffix := d.pkg.GetCommandSuffix(); suffix != d.exp {
+				t.Fatalf("wanted %s, got %s", d.exp, suffix)
+			}
+		})
+	}
+}
+
+func TestPackage_ValidateCommandSuffix(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name  string
+		pkg   *aqua.Package
+		isErr bool
+	}{
+		{
+			name: "no suffix",
+			pkg:  &aqua.Package{Version: "v1.0.0"},
+		},
+		{
+			name: "auto",
+			pkg:  &aqua.Package{Version: "v1.0.0", CommandSuffix: "auto"},
+		},
+		{
+			name:  "slash",
+			pkg:   &aqua.Package{Version: "v1.0.0", CommandSuffix: "/1"},
+			isErr: true,
+		},
+		{
+			name:  "backslash",
+			pkg:   &aqua.Package{Version: "v1.0.0", CommandSuffix: `\1`},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			err := d.pkg.ValidateCommandSuffix()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("error must be returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestPackage_TrimCommandSuffix(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name     string
+		pkg      *aqua.Package
+		exeName  string
+		expName  string
+		expFound bool
+	}{
+		{
+			name:     "no suffix",
+			pkg:      &aqua.Package{Version: "v2.40.0"},
+			exeName:  "gh",
+			expName:  "gh",
+			expFound: true,
+		},
+		{
+			name:     "suffix",
+			pkg:      &aqua.Package{Version: "v2.40.0", CommandSuffix: "auto"},
+			exeName:  "gh@2",
+			expName:  "gh",
+			expFound: true,
+		},
+		{
+			name:    "the command doesn't have the suffix",
+			pkg:     &aqua.Package{Version: "v2.40.0", CommandSuffix: "auto"},
+			exeName: "gh",
+		},
+		{
+			name:    "only the suffix",
+			pkg:     &aqua.Package{Version: "v2.40.0", CommandSuffix: "auto"},
+			exeName: "@2",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			name, found := d.pkg.TrimCommandSuffix(d.exeName)
+			if found != d.expFound {
+				t.Fatalf("found: wanted %v, got %v", d.expFound, found)
+			}
+			if name != d.expName {
+				t.Fatalf("wanted %s, got %s", d.expName, name)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/invopop/jsonschema"
 	"github.com/suzuki-shunsuke/slog-error/slogerr"
 )
@@ -11,21 +12,24 @@ import (
 // Package represents a package definition in aqua.yaml configuration.
 // It contains package identification, version constraints, and customization options.
 type Package struct {
-	Name              string          `json:"name,omitempty"`                                                                                                         // Package name
-	Registry          string          `yaml:",omitempty" json:"registry,omitempty" jsonschema:"description=Registry name,example=foo,example=local,default=standard"` // Registry containing the package
-	Version           string          `yaml:",omitempty" json:"version,omitempty"`                                                                                    // Package version
-	Import            string          `yaml:",omitempty" json:"import,omitempty"`                                                                                     // Import path for configuration inclusion
-	Tags              []string        `yaml:",omitempty" json:"tags,omitempty"`                                                                                       // Package tags for filtering
-	Description       string          `yaml:",omitempty" json:"description,omitempty"`                                                                                // Package description
-	Link              string          `yaml:",omitempty" json:"link,omitempty"`                                                                                       // Package homepage link
-	Update            *Update         `yaml:",omitempty" json:"update,omitempty"`                                                                                     // Update configuration
-	FilePath          string          `yaml:"-" json:"-"`                                                                                                             // File path where package is defined
-	GoVersionFile     string          `yaml:"go_version_file,omitempty" json:"go_version_file,omitempty"`                                                             // Go version file path
-	VersionExpr       string          `yaml:"version_expr,omitempty" json:"version_expr,omitempty"`                                                                   // Version expression for dynamic versions
-	VersionExprPrefix string          `yaml:"version_expr_prefix,omitempty" json:"version_expr_prefix,omitempty"`                                                     // Prefix for version expressions
-	Vars              map[string]any  `yaml:",omitempty" json:"vars,omitempty"`                                                                                       // Package-specific variables
-	CommandAliases    []*CommandAlias `yaml:"command_aliases,omitempty" json:"command_aliases,omitempty"`                                                             // Command aliases for the package
-	Pin               bool            `yaml:"-" json:"-"`                                                                                                             // Whether the package version is pinned
+	Name              string             `json:"name,omitempty"`                                                                                                         // Package name
+	Registry          string             `yaml:",omitempty" json:"registry,omitempty" jsonschema:"description=Registry name,example=foo,example=local,default=standard"` // Registry containing the package
+	Version           string             `yaml:",omitempty" json:"version,omitempty"`                                                                                    // Package version
+	Import            string             `yaml:",omitempty" json:"import,omitempty"`                                                                                     // Import path for configuration inclusion
+	Tags              []string           `yaml:",omitempty" json:"tags,omitempty"`                                                                                       // Package tags for filtering
+	Description       string             `yaml:",omitempty" json:"description,omitempty"`                                                                                // Package description
+	Link              string             `yaml:",omitempty" json:"link,omitempty"`                                                                                       // Package homepage link
+	Update            *Update            `yaml:",omitempty" json:"update,omitempty"`                                                                                     // Update configuration
+	FilePath          string             `yaml:"-" json:"-"`                                                                                                             // File path where package is defined
+	GoVersionFile     string             `yaml:"go_version_file,omitempty" json:"go_version_file,omitempty"`                                                             // Go version file path
+	VersionFile       *VersionFile       `yaml:"version_file,omitempty" json:"version_file,omitempty"`                                                                   // File which the package version is read from
+	VersionExpr       string             `yaml:"version_expr,omitempty" json:"version_expr,omitempty"`                                                                   // Version expression for dynamic versions
+	VersionExprPrefix string             `yaml:"version_expr_prefix,omitempty" json:"version_expr_prefix,omitempty"`                                                     // Prefix for version expressions
+	Vars              map[string]any     `yaml:",omitempty" json:"vars,omitempty"`                                                                                       // Package-specific variables
+	CommandAliases    []*CommandAlias    `yaml:"command_aliases,omitempty" json:"command_aliases,omitempty"`                                                             // Command aliases for the package
+	CommandSuffix     string             `yaml:"command_suffix,omitempty" json:"command_suffix,omitempty"`                                                               // Suffix appended to command names of the package. "auto" means "@" and the major version
+	Env               []*registry.EnvVar `yaml:",omitempty" json:"env,omitempty"`                                                                                        // Environment variables set when commands of the package are executed
+	Pin               bool               `yaml:"-" json:"-"`                                                                                                             // Whether the package version is pinned
 }
 
 // CommandAlias defines an alias for a package command.
@@ -106,6 +110,8 @@ type Config struct {
 	Registries Registries `json:"registries"`                                       // Registry configurations
 	Checksum   *Checksum  `json:"checksum,omitempty"`                               // Checksum validation settings
 	ImportDir  string     `yaml:"import_dir,omitempty" json:"import_dir,omitempty"` // Directory for importing configurations
+	Inputs     []string   `yaml:"-" json:"-"`                                       // Files and directories other than the configuration file itself which the configuration is read from
+	Dynamic    bool       `yaml:"-" json:"-"`                                       // Whether the configuration depends on something other than Inputs (e.g. version_expr)
 }
 
 // Validate validates the configuration for correctness.
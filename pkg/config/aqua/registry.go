@@ -29,6 +29,23 @@ const (
 	RegistryTypeStandard = "standard"
 )
 
+// StandardRegistryVersion is the version of the standard registry used when it can't be determined otherwise,
+// e.g. when "aqua init" fails to get the latest version or "aqua run" runs without configuration files.
+const StandardRegistryVersion = "v4.546.0" // renovate: depName=aquaproj/aqua-registry
+
+// NewStandardRegistry returns the standard registry of StandardRegistryVersion.
+// This is used when no configuration file has the standard registry.
+func NewStandardRegistry() *Registry {
+	return &Registry{
+		Name:      RegistryTypeStandard,
+		Type:      RegistryTypeGitHubContent,
+		RepoOwner: "aquaproj",
+		RepoName:  "aqua-registry",
+		Ref:       StandardRegistryVersion,
+		Path:      "registry.yaml",
+	}
+}
+
 // Validate validates the registry configuration based on its type.
 // It ensures all required fields are present and valid for the registry type.
 func (r *Registry) Validate() error {
@@ -0,0 +1,64 @@
+package aqua
+
+import "path/filepath"
+
+// Types of version files.
+const (
+	// VersionFileTypeGoMod reads the go directive of go.mod or go.work.
+	VersionFileTypeGoMod = "go_mod"
+	// VersionFileTypePlain reads the first line of a file which has only a version,
+	// such as .node-version, .nvmrc, .python-version, and .terraform-version.
+	VersionFileTypePlain = "plain"
+	// VersionFileTypeRustToolchain reads the channel of rust-toolchain.toml.
+	VersionFileTypeRustToolchain = "rust_toolchain"
+	// VersionFileTypeToolVersions reads the version of a tool in .tool-versions.
+	VersionFileTypeToolVersions = "tool_versions"
+	// VersionFileTypeRegexp reads the first capture group of a regular expression.
+	VersionFileTypeRegexp = "regexp"
+)
+
+// VersionFile is a file which the package version is read from.
+type VersionFile struct {
+	Path    string `json:"path"`                                                                                                                    // File path relative to the configuration file
+	Type    string `yaml:",omitempty" json:"type,omitempty" jsonschema:"enum=go_mod,enum=plain,enum=rust_toolchain,enum=tool_versions,enum=regexp"` // Parser of the file. By default, it's decided by the file name
+	Tool    string `yaml:",omitempty" json:"tool,omitempty"`                                                                                        // Tool name in .tool-versions
+	Pattern string `yaml:",omitempty" json:"pattern,omitempty"`                                                                                     // Regular expression whose first capture group is the version
+	Prefix  string `yaml:",omitempty" json:"prefix,omitempty"`                                                                                      // Prefix prepended to the version in the file
+	Update  bool   `yaml:",omitempty" json:"update,omitempty"`                                                                                      // Whether aqua update updates the version in the file
+}
+
+// GetType returns the type of the version file.
+// If type isn't set, it's decided by pattern and the file name.
+func (v *VersionFile) GetType() string {
+	if v.Type != "" {
+		return v.Type
+	}
+	if v.Pattern != "" {
+		return VersionFileTypeRegexp
+	}
+	switch filepath.Base(v.Path) {
+	case "go.mod", "go.work":
+		return VersionFileTypeGoMod
+	case "rust-toolchain.toml":
+		return VersionFileTypeRustToolchain
+	case ".tool-versions":
+		return VersionFileTypeToolVersions
+	}
+	return VersionFileTypePlain
+}
+
+// GetVersionFile returns the version file of the package.
+// go_version_file is a shorthand of version_file whose type is go_mod.
+// If neither of them is set, nil is returned.
+func (p *Package) GetVersionFile() *VersionFile {
+	if p.VersionFile != nil {
+		return p.VersionFile
+	}
+	if p.GoVersionFile != "" {
+		return &VersionFile{
+			Path: p.GoVersionFile,
+			Type: VersionFileTypeGoMod,
+		}
+	}
+	return nil
+}
@@ -112,7 +112,7 @@ func (p *Package) RenderChecksumURL(rt *runtime.Runtime) (string, error) {
 	replacements := pkgInfo.GetChecksumReplacements()
 	m := map[string]any{
 		tmplVersion: pkg.Version,
-		tmplSemVer:  p.semVer(),
+		tmplSemVer:  p.SemVer(),
 		tmplGOOS:    rt.GOOS,
 		tmplGOARCH:  rt.GOARCH,
 		"OS":        replace(rt.GOOS, replacements),
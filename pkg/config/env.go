@@ -0,0 +1,70 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/template"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+var errEnvNameEmpty = errors.New("the name of an environment variable is empty")
+
+// RenderEnv returns environment variables which are set when a command of the package is executed.
+// Each element is formatted as NAME=VALUE in the order of the registry.
+// Environment variables in aqua.yaml override ones in the registry with the same name.
+func (p *Package) RenderEnv(rootDir string, rt *runtime.Runtime) ([]string, error) {
+	envs := mergeEnv(p.PackageInfo.Env, p.Package.Env)
+	if len(envs) == 0 {
+		return nil, nil
+	}
+	pkgPath, err := p.AbsPkgPath(rootDir, rt)
+	if err != nil {
+		return nil, err
+	}
+	pkgInfo := p.PackageInfo
+	pkg := p.Package
+	input := map[string]any{
+		"PkgPath":   pkgPath,
+		tmplVersion: pkg.Version,
+		tmplSemVer:  p.SemVer(),
+		tmplGOOS:    rt.GOOS,
+		tmplGOARCH:  rt.GOARCH,
+		"OS":        replace(rt.GOOS, pkgInfo.Replacements),
+		tmplArch:    getArch(pkgInfo.Rosetta2, pkgInfo.WindowsARMEmulation, pkgInfo.Replacements, rt),
+		tmplFormat:  pkgInfo.GetFormat(),
+		tmplVars:    pkg.Vars,
+	}
+	ret := make([]string, len(envs))
+	for i, env := range envs {
+		if env.Name == "" {
+			return nil, errEnvNameEmpty
+		}
+		s, err := template.Execute(env.Value, input)
+		if err != nil {
+			return nil, fmt.Errorf("render the value of an environment variable: %w", slogerr.With(err, "env_name", env.Name))
+		}
+		ret[i] = env.Name + "=" + s
+	}
+	return ret, nil
+}
+
+// mergeEnv merges environment variables in the registry and aqua.yaml.
+func mergeEnv(base, envs []*registry.EnvVar) []*registry.EnvVar {
+	if len(envs) == 0 {
+		return base
+	}
+	ret := make([]*registry.EnvVar, 0, len(base)+len(envs))
+	indexes := make(map[string]int, len(base)+len(envs))
+	for _, env := range append(append([]*registry.EnvVar{}, base...), envs...) {
+		if i, ok := indexes[env.Name]; ok {
+			ret[i] = env
+			continue
+		}
+		indexes[env.Name] = len(ret)
+		ret = append(ret, env)
+	}
+	return ret
+}
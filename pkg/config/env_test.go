@@ -0,0 +1,123 @@
+package config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPackage_RenderEnv(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	rootDir := "/tmp/aqua"
+	rt := &runtime.Runtime{
+		GOOS:   "linux",
+		GOARCH: "amd64",
+	}
+	pkgPath := filepath.Join(rootDir, "pkgs", "github_archive", "github.com", "foo", "bar", versionV1)
+	data := []struct {
+		title string
+		pkg   *config.Package
+		exp   []string
+		isErr bool
+	}{
+		{
+			title: "no env",
+			pkg: &config.Package{
+				PackageInfo: &registry.PackageInfo{
+					Type: pkgTypeGitHubArchive,
+				},
+				Package: &aqua.Package{
+					Version: versionV1,
+				},
+			},
+		},
+		{
+			title: "templates",
+			pkg: &config.Package{
+				PackageInfo: &registry.PackageInfo{
+					Type:      pkgTypeGitHubArchive,
+					RepoOwner: "foo",
+					RepoName:  "bar",
+					Env: []*registry.EnvVar{
+						{Name: "BAR_HOME", Value: "{{.PkgPath}}/bar-{{trimV .Version}}"},
+						{Name: "BAR_PLATFORM", Value: "{{.OS}}-{{.Arch}}"},
+					},
+				},
+				Package: &aqua.Package{
+					Version: versionV1,
+				},
+			},
+			exp: []string{
+				"BAR_HOME=" + pkgPath + "/bar-1.0.0",
+				"BAR_PLATFORM=linux-amd64",
+			},
+		},
+		{
+			title: "aqua.yaml overrides the registry",
+			pkg: &config.Package{
+				PackageInfo: &registry.PackageInfo{
+					Type:      pkgTypeGitHubArchive,
+					RepoOwner: "foo",
+					RepoName:  "bar",
+					Env: []*registry.EnvVar{
+						{Name: "BAR_HOME", Value: "{{.PkgPath}}"},
+						{Name: "BAR_CONFIG_DIR", Value: "{{.PkgPath}}/config"},
+					},
+				},
+				Package: &aqua.Package{
+					Version: versionV1,
+					Env: []*registry.EnvVar{
+						{Name: "BAR_CONFIG_DIR", Value: "/etc/bar"},
+						{Name: "BAR_LOG_LEVEL", Value: "{{.Vars.log_level}}"},
+					},
+					Vars: map[string]any{
+						"log_level": "debug",
+					},
+				},
+			},
+			exp: []string{
+				"BAR_HOME=" + pkgPath,
+				"BAR_CONFIG_DIR=/etc/bar",
+				"BAR_LOG_LEVEL=debug",
+			},
+		},
+		{
+			title: "name is empty",
+			pkg: &config.Package{
+				PackageInfo: &registry.PackageInfo{
+					Type: pkgTypeGitHubArchive,
+					Env: []*registry.EnvVar{
+						{Value: "foo"},
+					},
+				},
+				Package: &aqua.Package{
+					Version: versionV1,
+				},
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			env, err := d.pkg.RenderEnv(rootDir, rt)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.exp, env); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -114,6 +114,9 @@ func listPackage(logger *slog.Logger, cfg *aqua.Config, rt *runtime.Runtime, reg
 			logger = logger.With("registry_ref", rgst.Ref)
 		}
 	}
+	if err := pkg.ValidateCommandSuffix(); err != nil {
+		return nil, fmt.Errorf("validate the command suffix: %w", err)
+	}
 	pkgInfo, err := getPkgInfoFromRegistries(logger, registries, pkg, m)
 	if err != nil {
 		return nil, fmt.Errorf("install the package: %w", err)
@@ -74,7 +74,7 @@ func (p *Package) TemplateArtifact(rt *runtime.Runtime, aset string) *template.A
 	assetWithoutExt, _ := asset.RemoveExtFromAsset(aset)
 	return &template.Artifact{
 		Version:         pkg.Version,
-		SemVer:          p.semVer(),
+		SemVer:          p.SemVer(),
 		OS:              replace(rt.GOOS, pkgInfo.Replacements),
 		Arch:            getArch(pkgInfo.Rosetta2, pkgInfo.WindowsARMEmulation, pkgInfo.Replacements, rt),
 		Format:          pkgInfo.GetFormat(),
@@ -179,7 +179,7 @@ func (p *Package) RenderDir(file *registry.File, rt *runtime.Runtime) (string, e
 	pkg := p.Package
 	return template.Execute(file.Dir, map[string]any{ //nolint:wrapcheck
 		tmplVersion: pkg.Version,
-		tmplSemVer:  p.semVer(),
+		tmplSemVer:  p.SemVer(),
 		tmplGOOS:    rt.GOOS,
 		tmplGOARCH:  rt.GOARCH,
 		"OS":        replace(rt.GOOS, pkgInfo.Replacements),
@@ -232,7 +232,7 @@ func (p *Package) renderSrc(assetName string, file *registry.File, rt *runtime.R
 	assetWithoutExt, _ := asset.RemoveExtFromAsset(assetName)
 	s, err := template.Execute(file.Src, map[string]any{
 		tmplVersion:       pkg.Version,
-		tmplSemVer:        p.semVer(),
+		tmplSemVer:        p.SemVer(),
 		tmplGOOS:          rt.GOOS,
 		tmplGOARCH:        rt.GOARCH,
 		"OS":              replace(rt.GOOS, pkgInfo.Replacements),
@@ -350,6 +350,13 @@ type Param struct {
 	Dest                              string
 	HomeDir                           string
 	OutTestData                       string
+	OutputFormat                      string
+	EventLog                          string
+	PolicyAuditLog                    string
+	SubCommand                        string
+	ArtifactDir                       string
+	CosignVerifier                    string
+	CosignTrustedRoot                 string
 	Limit                             int
 	MaxParallelism                    int
 	VacuumDays                        int
@@ -360,8 +367,10 @@ type Param struct {
 	Tags                              map[string]struct{}
 	ExcludedTags                      map[string]struct{}
 	DisableLazyInstall                bool
+	DisableExecCache                  bool
 	OnlyLink                          bool
 	All                               bool
+	LeastUsed                         bool
 	Global                            bool
 	Insert                            bool
 	SelectVersion                     bool
@@ -377,6 +386,7 @@ type Param struct {
 	EnforceRequireChecksum            bool
 	DisablePolicy                     bool
 	DisableTracking                   bool
+	EnableStats                       bool
 	Detail                            bool
 	OnlyPackage                       bool
 	OnlyRegistry                      bool
@@ -385,6 +395,11 @@ type Param struct {
 	SLSADisabled                      bool
 	Installed                         bool
 	InitConfig                        bool
+	DryRun                            bool
+	Reinstall                         bool
+	OSVDB                             string
+	MinSeverity                       string
+	FailOnVulnerability               bool
 }
 
 // appendExt appends the appropriate file extension based on format.
@@ -445,7 +460,7 @@ func (p *Package) renderChecksumFile(asset string, rt *runtime.Runtime) (string,
 	replacements := pkgInfo.GetChecksumReplacements()
 	uS, err := template.ExecuteTemplate(tpl, map[string]any{
 		tmplVersion: pkg.Version,
-		tmplSemVer:  p.semVer(),
+		tmplSemVer:  p.SemVer(),
 		tmplGOOS:    rt.GOOS,
 		tmplGOARCH:  rt.GOARCH,
 		"OS":        replace(rt.GOOS, replacements),
@@ -467,7 +482,7 @@ func (p *Package) renderTemplate(tpl *texttemplate.Template, rt *runtime.Runtime
 	pkg := p.Package
 	uS, err := template.ExecuteTemplate(tpl, map[string]any{
 		tmplVersion: pkg.Version,
-		tmplSemVer:  p.semVer(),
+		tmplSemVer:  p.SemVer(),
 		tmplGOOS:    rt.GOOS,
 		tmplGOARCH:  rt.GOARCH,
 		"OS":        replace(rt.GOOS, pkgInfo.Replacements),
@@ -481,9 +496,9 @@ func (p *Package) renderTemplate(tpl *texttemplate.Template, rt *runtime.Runtime
 	return uS, nil
 }
 
-// semVer returns the semantic version by removing the version prefix.
+// SemVer returns the semantic version by removing the version prefix.
 // It strips configured prefixes (like 'v') to get clean semantic versions.
-func (p *Package) semVer() string {
+func (p *Package) SemVer() string {
 	v := p.Package.Version
 	prefix := p.PackageInfo.VersionPrefix
 	if prefix == "" {
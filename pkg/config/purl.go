@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// PURL returns the Package URL (purl) of the package.
+// https://github.com/package-url/purl-spec
+// Packages of GitHub repositories are identified as github purls, and packages without repositories are identified as generic purls.
+func (p *Package) PURL() (string, error) {
+	pkgInfo := p.PackageInfo
+	version := purlEscape(p.Package.Version)
+	switch pkgInfo.Type {
+	case PkgInfoTypeCargo:
+		return "pkg:cargo/" + purlEscape(pkgInfo.Crate) + "@" + version, nil
+	case PkgInfoTypeGoInstall:
+		path, err := p.RenderPath()
+		if err != nil {
+			return "", fmt.Errorf("render Go Module Path: %w", err)
+		}
+		return "pkg:golang/" + purlEscapePath(path) + "@" + version, nil
+	}
+	if pkgInfo.HasRepo() {
+		return "pkg:github/" + purlEscape(strings.ToLower(pkgInfo.RepoOwner)) + "/" + purlEscape(strings.ToLower(pkgInfo.RepoName)) + "@" + version, nil
+	}
+	return "pkg:generic/" + purlEscapePath(pkgInfo.GetName()) + "@" + version, nil
+}
+
+func purlEscape(s string) string {
+	return strings.ReplaceAll(url.PathEscape(s), "+", "%2B")
+}
+
+func purlEscapePath(s string) string {
+	segments := strings.Split(s, "/")
+	for i, segment := range segments {
+		segments[i] = purlEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
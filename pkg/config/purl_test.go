@@ -0,0 +1,100 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+)
+
+func TestPackage_PURL(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name string
+		pkg  *config.Package
+		exp  string
+	}{
+		{
+			name: pkgTypeGitHubRelease,
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Version: versionV2170,
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type:      pkgTypeGitHubRelease,
+					RepoOwner: repoOwnerCli,
+					RepoName:  repoOwnerCli,
+				},
+			},
+			exp: "pkg:github/cli/cli@v2.17.0",
+		},
+		{
+			name: "http with a repository",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Version: "v1.3.0",
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type:      pkgTypeHTTP,
+					RepoOwner: "HashiCorp",
+					RepoName:  "terraform",
+					URL:       "https://releases.hashicorp.com/terraform/{{trimV .Version}}/terraform_{{trimV .Version}}_{{.OS}}_{{.Arch}}.zip",
+				},
+			},
+			exp: "pkg:github/hashicorp/terraform@v1.3.0",
+		},
+		{
+			name: "http without a repository",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Version: "1.0.0+build.1",
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type: pkgTypeHTTP,
+					Name: "example.com/foo",
+					URL:  "https://example.com/foo/{{.Version}}/foo.tar.gz",
+				},
+			},
+			exp: "pkg:generic/example.com/foo@1.0.0%2Bbuild.1",
+		},
+		{
+			name: "go_install",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Version: "v0.5.0",
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type: "go_install",
+					Path: "golang.org/x/tools/gopls",
+				},
+			},
+			exp: "pkg:golang/golang.org/x/tools/gopls@v0.5.0",
+		},
+		{
+			name: "cargo",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Version: "14.1.0",
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type:  "cargo",
+					Crate: "ripgrep",
+				},
+			},
+			exp: "pkg:cargo/ripgrep@14.1.0",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			purl, err := d.pkg.PURL()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if purl != d.exp {
+				t.Fatalf("wanted %s, got %s", d.exp, purl)
+			}
+		})
+	}
+}
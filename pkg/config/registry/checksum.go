@@ -23,6 +23,8 @@ type Checksum struct {
 	Cosign *Cosign `yaml:",omitempty" json:"cosign,omitempty"`
 	// Minisign configuration for signature verification of checksums.
 	Minisign *Minisign `yaml:",omitempty" json:"minisign,omitempty"`
+	// PGP configuration for OpenPGP signature verification of checksums.
+	PGP *PGP `yaml:"pgp,omitempty" json:"pgp,omitempty"`
 	// GitHubArtifactAttestations configuration for GitHub artifact attestation verification.
 	GitHubArtifactAttestations *GitHubArtifactAttestations `yaml:"github_artifact_attestations,omitempty" json:"github_artifact_attestations,omitempty"`
 }
@@ -84,6 +86,15 @@ func (c *Checksum) GetMinisign() *Minisign {
 	return c.Minisign
 }
 
+// GetPGP returns the OpenPGP configuration for signature verification.
+// It returns nil if the checksum is nil.
+func (c *Checksum) GetPGP() *PGP {
+	if c == nil {
+		return nil
+	}
+	return c.PGP
+}
+
 // GetGitHubArtifactAttestations returns the GitHub artifact attestation configuration.
 // It returns nil if the checksum is nil.
 func (c *Checksum) GetGitHubArtifactAttestations() *GitHubArtifactAttestations {
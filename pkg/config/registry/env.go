@@ -0,0 +1,11 @@
+package registry
+
+// EnvVar represents an environment variable which aqua sets when a command of the package is executed.
+// Some tools require environment variables such as JAVA_HOME pointing inside the package.
+type EnvVar struct {
+	// Name is the name of the environment variable.
+	Name string `json:"name"`
+	// Value is the value of the environment variable. It supports templates.
+	// e.g. {{.PkgPath}}/jdk
+	Value string `json:"value"`
+}
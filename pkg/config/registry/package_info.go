@@ -77,12 +77,15 @@ type PackageInfo struct {
 	Overrides                  []*Override                 `yaml:",omitempty" json:"overrides,omitempty"`
 	FormatOverrides            []*FormatOverride           `yaml:"format_overrides,omitempty" json:"format_overrides,omitempty"`
 	Files                      []*File                     `yaml:",omitempty" json:"files,omitempty"`
+	ShareFiles                 []*ShareFile                `yaml:"share_files,omitempty" json:"share_files,omitempty"`
+	Env                        []*EnvVar                   `yaml:",omitempty" json:"env,omitempty"`
 	Replacements               Replacements                `yaml:",omitempty" json:"replacements,omitempty"`
 	SupportedEnvs              SupportedEnvs               `yaml:"supported_envs,omitempty" json:"supported_envs,omitempty"`
 	Checksum                   *Checksum                   `yaml:",omitempty" json:"checksum,omitempty"`
 	Cosign                     *Cosign                     `yaml:",omitempty" json:"cosign,omitempty"`
 	SLSAProvenance             *SLSAProvenance             `yaml:"slsa_provenance,omitempty" json:"slsa_provenance,omitempty"`
 	Minisign                   *Minisign                   `yaml:",omitempty" json:"minisign,omitempty"`
+	PGP                        *PGP                        `yaml:"pgp,omitempty" json:"pgp,omitempty"`
 	GitHubArtifactAttestations *GitHubArtifactAttestations `yaml:"github_artifact_attestations,omitempty" json:"github_artifact_attestations,omitempty"`
 	Vars                       []*Var                      `yaml:",omitempty" json:"vars,omitempty"`
 	VersionConstraints         string                      `yaml:"version_constraint,omitempty" json:"version_constraint,omitempty"`
@@ -163,12 +166,15 @@ type VersionOverride struct {
 	AppendExt                  *bool                       `yaml:"append_ext,omitempty" json:"append_ext,omitempty"`
 	Cargo                      *Cargo                      `json:"cargo,omitempty"`
 	Files                      []*File                     `yaml:",omitempty" json:"files,omitempty"`
+	ShareFiles                 []*ShareFile                `yaml:"share_files,omitempty" json:"share_files,omitempty"`
+	Env                        []*EnvVar                   `yaml:",omitempty" json:"env,omitempty"`
 	FormatOverrides            FormatOverrides             `yaml:"format_overrides,omitempty" json:"format_overrides,omitempty"`
 	Replacements               Replacements                `yaml:",omitempty" json:"replacements,omitempty"`
 	Checksum                   *Checksum                   `json:"checksum,omitempty"`
 	Cosign                     *Cosign                     `json:"cosign,omitempty"`
 	SLSAProvenance             *SLSAProvenance             `yaml:"slsa_provenance,omitempty" json:"slsa_provenance,omitempty"`
 	Minisign                   *Minisign                   `yaml:",omitempty" json:"minisign,omitempty"`
+	PGP                        *PGP                        `yaml:"pgp,omitempty" json:"pgp,omitempty"`
 	GitHubArtifactAttestations *GitHubArtifactAttestations `yaml:"github_artifact_attestations,omitempty" json:"github_artifact_attestations,omitempty"`
 	Build                      *Build                      `yaml:",omitempty" json:"build,omitempty"`
 	Vars                       []*Var                      `yaml:",omitempty" json:"vars,omitempty"`
@@ -214,11 +220,14 @@ type Override struct {
 	AppendExt                  *bool                       `yaml:"append_ext,omitempty" json:"append_ext,omitempty"`
 	Cargo                      *Cargo                      `yaml:",omitempty" json:"cargo,omitempty"`
 	Files                      []*File                     `yaml:",omitempty" json:"files,omitempty"`
+	ShareFiles                 []*ShareFile                `yaml:"share_files,omitempty" json:"share_files,omitempty"`
+	Env                        []*EnvVar                   `yaml:",omitempty" json:"env,omitempty"`
 	Replacements               Replacements                `yaml:",omitempty" json:"replacements,omitempty"`
 	Checksum                   *Checksum                   `yaml:",omitempty" json:"checksum,omitempty"`
 	Cosign                     *Cosign                     `yaml:",omitempty" json:"cosign,omitempty"`
 	SLSAProvenance             *SLSAProvenance             `yaml:"slsa_provenance,omitempty" json:"slsa_provenance,omitempty"`
 	Minisign                   *Minisign                   `yaml:",omitempty" json:"minisign,omitempty"`
+	PGP                        *PGP                        `yaml:"pgp,omitempty" json:"pgp,omitempty"`
 	GitHubArtifactAttestations *GitHubArtifactAttestations `yaml:"github_artifact_attestations,omitempty" json:"github_artifact_attestations,omitempty"`
 	Vars                       []*Var                      `yaml:",omitempty" json:"vars,omitempty"`
 	Envs                       SupportedEnvs               `yaml:",omitempty" json:"envs,omitempty"`
@@ -240,6 +249,8 @@ func (p *PackageInfo) Copy() *PackageInfo {
 		Path:                       p.Path,
 		Format:                     p.Format,
 		Files:                      p.Files,
+		ShareFiles:                 p.ShareFiles,
+		Env:                        p.Env,
 		URL:                        p.URL,
 		Description:                p.Description,
 		Link:                       p.Link,
@@ -262,6 +273,7 @@ func (p *PackageInfo) Copy() *PackageInfo {
 		Cosign:                     p.Cosign,
 		SLSAProvenance:             p.SLSAProvenance,
 		Minisign:                   p.Minisign,
+		PGP:                        p.PGP,
 		GitHubArtifactAttestations: p.GitHubArtifactAttestations,
 		Private:                    p.Private,
 		ErrorMessage:               p.ErrorMessage,
@@ -321,6 +333,14 @@ func (p *PackageInfo) OverrideByRuntime(rt *runtime.Runtime) { //nolint:cyclop,f
 		p.Files = ov.Files
 	}
 
+	if ov.ShareFiles != nil {
+		p.ShareFiles = ov.ShareFiles
+	}
+
+	if ov.Env != nil {
+		p.Env = ov.Env
+	}
+
 	if p.Replacements == nil {
 		p.Replacements = ov.Replacements
 	} else {
@@ -354,6 +374,10 @@ func (p *PackageInfo) OverrideByRuntime(rt *runtime.Runtime) { //nolint:cyclop,f
 		p.Minisign = ov.Minisign
 	}
 
+	if ov.PGP != nil {
+		p.PGP = ov.PGP
+	}
+
 	if ov.GitHubArtifactAttestations != nil {
 		p.GitHubArtifactAttestations = ov.GitHubArtifactAttestations
 	}
@@ -786,6 +810,12 @@ func (p *PackageInfo) overrideVersion(child *VersionOverride) *PackageInfo { //n
 	if child.Files != nil {
 		pkg.Files = child.Files
 	}
+	if child.ShareFiles != nil {
+		pkg.ShareFiles = child.ShareFiles
+	}
+	if child.Env != nil {
+		pkg.Env = child.Env
+	}
 	if child.URL != "" {
 		pkg.URL = child.URL
 	}
@@ -837,6 +867,9 @@ func (p *PackageInfo) overrideVersion(child *VersionOverride) *PackageInfo { //n
 	if child.Minisign != nil {
 		pkg.Minisign = child.Minisign
 	}
+	if child.PGP != nil {
+		pkg.PGP = child.PGP
+	}
 	if child.GitHubArtifactAttestations != nil {
 		pkg.GitHubArtifactAttestations = child.GitHubArtifactAttestations
 	}
@@ -896,6 +929,7 @@ func (p *PackageInfo) resetByPkgType(typ string) { //nolint:funlen
 		p.Cosign = nil
 		p.SLSAProvenance = nil
 		p.Minisign = nil
+		p.PGP = nil
 		p.GitHubArtifactAttestations = nil
 		p.Format = ""
 		p.Rosetta2 = false
@@ -911,6 +945,7 @@ func (p *PackageInfo) resetByPkgType(typ string) { //nolint:funlen
 		p.Cosign = nil
 		p.SLSAProvenance = nil
 		p.Minisign = nil
+		p.PGP = nil
 		p.GitHubArtifactAttestations = nil
 		p.Format = ""
 		p.Rosetta2 = false
@@ -925,6 +960,7 @@ func (p *PackageInfo) resetByPkgType(typ string) { //nolint:funlen
 		p.Cosign = nil
 		p.SLSAProvenance = nil
 		p.Minisign = nil
+		p.PGP = nil
 		p.GitHubArtifactAttestations = nil
 		p.Format = ""
 		p.Rosetta2 = false
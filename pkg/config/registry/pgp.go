@@ -0,0 +1,49 @@
+package registry
+
+// PGP defines configuration for verifying packages using OpenPGP detached signatures.
+// Signatures are verified natively, so gpg isn't needed.
+type PGP struct {
+	// Enabled controls whether OpenPGP verification is active.
+	Enabled *bool `yaml:",omitempty" json:"enabled,omitempty"`
+	// Type specifies where to download the signature file from.
+	Type string `yaml:",omitempty" json:"type,omitempty" jsonschema:"enum=github_release,enum=http"`
+	// RepoOwner is the GitHub repository owner (for github_release type).
+	RepoOwner string `yaml:"repo_owner,omitempty" json:"repo_owner,omitempty"`
+	// RepoName is the GitHub repository name (for github_release type).
+	RepoName string `yaml:"repo_name,omitempty" json:"repo_name,omitempty"`
+	// Asset is the name of the signature file asset (for github_release type).
+	Asset *string `yaml:",omitempty" json:"asset,omitempty"`
+	// URL is the direct URL to the signature file (for http type).
+	URL *string `yaml:",omitempty" json:"url,omitempty"`
+	// PublicKey is the ASCII-armored public key for verification.
+	PublicKey string `yaml:"public_key,omitempty" json:"public_key,omitempty"`
+	// PublicKeyFile is the public key file to download if PublicKey isn't set.
+	PublicKeyFile *DownloadedFile `yaml:"public_key_file,omitempty" json:"public_key_file,omitempty"`
+	// Fingerprints pins the fingerprints of the primary keys allowed to sign.
+	// This is required if the public key is downloaded with PublicKeyFile.
+	Fingerprints []string `yaml:",omitempty" json:"fingerprints,omitempty"`
+}
+
+// ToDownloadedFile converts the PGP configuration to a DownloadedFile.
+// This is used for downloading the signature file.
+func (p *PGP) ToDownloadedFile() *DownloadedFile {
+	return &DownloadedFile{
+		Type:      p.Type,
+		RepoOwner: p.RepoOwner,
+		RepoName:  p.RepoName,
+		Asset:     p.Asset,
+		URL:       p.URL,
+	}
+}
+
+// GetEnabled returns whether OpenPGP verification is enabled.
+// If Enabled is nil, it defaults to true.
+func (p *PGP) GetEnabled() bool {
+	if p == nil {
+		return false
+	}
+	if p.Enabled != nil {
+		return *p.Enabled
+	}
+	return true
+}
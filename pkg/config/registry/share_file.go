@@ -0,0 +1,77 @@
+package registry
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+const (
+	// ShareFileKindBashCompletion is a bash completion script.
+	ShareFileKindBashCompletion = "bash_completion"
+	// ShareFileKindZshCompletion is a zsh completion function.
+	ShareFileKindZshCompletion = "zsh_completion"
+	// ShareFileKindFishCompletion is a fish completion script.
+	ShareFileKindFishCompletion = "fish_completion"
+	// ShareFileKindMan is a man page such as foo.1 or foo.1.gz.
+	ShareFileKindMan = "man"
+)
+
+// ShareFile represents a non-executable file in a package, such as a shell
+// completion script or a man page.
+// aqua links it into a well-known directory under $AQUA_ROOT_DIR/share.
+type ShareFile struct {
+	// Kind is the kind of the file.
+	Kind string `json:"kind" jsonschema:"enum=bash_completion,enum=zsh_completion,enum=fish_completion,enum=man"`
+	// Src is the path of the file in the package. It supports templates.
+	Src string `json:"src"`
+	// Name is the name of the link. By default the base name of Src is used.
+	// zsh requires completion functions to be named _<command>.
+	Name string `yaml:",omitempty" json:"name,omitempty"`
+}
+
+var (
+	errUnknownShareFileKind = errors.New("unknown share file kind")
+	errManSectionNotFound   = errors.New("the section of the man page can't be determined by the file name")
+)
+
+// LinkName returns the name of the link.
+// src is the rendered Src.
+func (f *ShareFile) LinkName(src string) string {
+	if f.Name != "" {
+		return f.Name
+	}
+	return path.Base(strings.ReplaceAll(src, "\\", "/"))
+}
+
+// Dir returns the slash-separated directory relative to $AQUA_ROOT_DIR/share
+// where the link named name is created.
+func (f *ShareFile) Dir(name string) (string, error) {
+	switch f.Kind {
+	case ShareFileKindBashCompletion:
+		return "bash-completion/completions", nil
+	case ShareFileKindZshCompletion:
+		return "zsh/site-functions", nil
+	case ShareFileKindFishCompletion:
+		return "fish/vendor_completions.d", nil
+	case ShareFileKindMan:
+		section := manSection(name)
+		if section == "" {
+			return "", errManSectionNotFound
+		}
+		return "man/man" + section, nil
+	default:
+		return "", errUnknownShareFileKind
+	}
+}
+
+// manSection returns the section of a man page from its file name.
+// e.g. foo.1 => 1, foo.1.gz => 1, foo.3p => 3.
+func manSection(name string) string {
+	name = strings.TrimSuffix(name, ".gz")
+	ext := path.Ext(name)
+	if len(ext) < 2 || ext[1] < '1' || ext[1] > '9' { //nolint:mnd
+		return ""
+	}
+	return ext[1:2]
+}
@@ -0,0 +1,74 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+)
+
+func TestShareFile_Dir(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name  string
+		file  *registry.ShareFile
+		src   string
+		exp   string
+		isErr bool
+	}{
+		{
+			name: "bash",
+			file: &registry.ShareFile{Kind: registry.ShareFileKindBashCompletion},
+			src:  "completions/gh.bash",
+			exp:  "bash-completion/completions/gh.bash",
+		},
+		{
+			name: "zsh with name",
+			file: &registry.ShareFile{Kind: registry.ShareFileKindZshCompletion, Name: "_gh"},
+			src:  "completions/gh.zsh",
+			exp:  "zsh/site-functions/_gh",
+		},
+		{
+			name: "fish",
+			file: &registry.ShareFile{Kind: registry.ShareFileKindFishCompletion},
+			src:  "gh.fish",
+			exp:  "fish/vendor_completions.d/gh.fish",
+		},
+		{
+			name: "gzipped man page",
+			file: &registry.ShareFile{Kind: registry.ShareFileKindMan},
+			src:  "share/man/man1/gh-pr.1.gz",
+			exp:  "man/man1/gh-pr.1.gz",
+		},
+		{
+			name:  "man page without section",
+			file:  &registry.ShareFile{Kind: registry.ShareFileKindMan},
+			src:   "README.md",
+			isErr: true,
+		},
+		{
+			name:  "unknown kind",
+			file:  &registry.ShareFile{Kind: "foo"},
+			src:   "foo",
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			name := d.file.LinkName(d.src)
+			dir, err := d.file.Dir(name)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if p := dir + "/" + name; p != d.exp {
+				t.Fatalf("wanted %s, got %s", d.exp, p)
+			}
+		})
+	}
+}
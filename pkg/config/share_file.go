@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+)
+
+// ShareFilePath returns the absolute path to a share file in the package
+// and the path to the link created under the share directory of the package.
+// Links are created per package version so that packages and versions sharing
+// the same file name such as _gh don't overwrite each other's links.
+func (p *Package) ShareFilePath(rootDir string, file *registry.ShareFile, rt *runtime.Runtime) (string, string, error) {
+	pkgPath, err := p.PkgPath(rt)
+	if err != nil {
+		return "", "", err
+	}
+	assetName, err := p.RenderAsset(rt)
+	if err != nil {
+		return "", "", fmt.Errorf("render the asset name: %w", err)
+	}
+	src, err := p.renderSrc(assetName, &registry.File{
+		Name: file.Name,
+		Src:  file.Src,
+	}, rt)
+	if err != nil {
+		return "", "", fmt.Errorf("render the share file src: %w", err)
+	}
+	name := file.LinkName(src)
+	dir, err := file.Dir(name)
+	if err != nil {
+		return "", "", err //nolint:wrapcheck
+	}
+	return filepath.Join(rootDir, pkgPath, src), filepath.Join(ShareDir(rootDir), pkgPath, filepath.FromSlash(dir), name), nil
+}
+
+// ShareDir returns the directory where share files such as shell completions and man pages are linked.
+// Each package version has its own directory under it like $AQUA_ROOT_DIR/pkgs.
+func ShareDir(rootDir string) string {
+	return filepath.Join(rootDir, "share")
+}
+
+// ExecCacheDir returns the directory where aqua exec caches resolutions of commands.
+func ExecCacheDir(rootDir string) string {
+	return filepath.Join(rootDir, "exec-cache")
+}
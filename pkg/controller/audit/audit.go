@@ -0,0 +1,220 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/osv"
+	"github.com/hashicorp/go-version"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+var (
+	errOSVDBRequired       = errors.New("the OSV database is required. Please set the -db option or the environment variable AQUA_OSV_DB")
+	errVulnerable          = errors.New("vulnerable packages are found")
+	errUnknownOutputFormat = errors.New("unknown output format")
+	errUnknownSeverity     = errors.New("unknown severity")
+)
+
+// Finding is a vulnerability of a package in a configuration file.
+type Finding struct {
+	Package        string   `json:"package"`
+	Version        string   `json:"version"`
+	Registry       string   `json:"registry"`
+	ConfigFilePath string   `json:"config_file_path"`
+	ID             string   `json:"id"`
+	Aliases        []string `json:"aliases,omitempty"`
+	Severity       string   `json:"severity"`
+	FixedVersions  []string `json:"fixed_versions,omitempty"`
+	Summary        string   `json:"summary,omitempty"`
+}
+
+// Result is the output of "aqua audit -format json".
+type Result struct {
+	Findings []*Finding `json:"findings"`
+}
+
+// Audit finds known vulnerabilities of packages in configuration files from an OSV database.
+// It doesn't access external services, so the database has to be downloaded in advance.
+// Findings whose severities are unknown are always output.
+func (c *Controller) Audit(ctx context.Context, logger *slog.Logger, param *config.Param) error {
+	if param.OSVDB == "" {
+		return errOSVDBRequired
+	}
+	minLevel := 0
+	if param.MinSeverity != "" {
+		minLevel = osv.SeverityLevel(param.MinSeverity)
+		if minLevel == 0 {
+			return slogerr.With(errUnknownSeverity, "severity", param.MinSeverity) //nolint:wrapcheck
+		}
+	}
+	db, err := osv.Read(param.OSVDB)
+	if err != nil {
+		return fmt.Errorf("read the OSV database: %w", slogerr.With(err, "osv_db", param.OSVDB))
+	}
+
+	cfgFilePaths := c.configFinder.Finds(param.CWD, param.ConfigFilePath)
+	if param.All {
+		for _, cfgFilePath := range param.GlobalConfigFilePaths {
+			if _, err := os.Stat(cfgFilePath); err != nil {
+				continue
+			}
+			cfgFilePaths = append(cfgFilePaths, cfgFilePath)
+		}
+	}
+
+	result := &Result{
+		Findings: []*Finding{},
+	}
+	audited := map[string]struct{}{}
+	for _, cfgFilePath := range cfgFilePaths {
+		findings, err := c.auditConfig(ctx, logger, param, db, cfgFilePath, audited)
+		if err != nil {
+			return fmt.Errorf("audit packages: %w", slogerr.With(err,
+				"config_file_path", cfgFilePath,
+			))
+		}
+		for _, finding := range findings {
+			if level := osv.SeverityLevel(finding.Severity); level != 0 && level < minLevel {
+				continue
+			}
+			result.Findings = append(result.Findings, finding)
+		}
+	}
+	if err := output(c.stdout, param.OutputFormat, result); err != nil {
+		return err
+	}
+	if param.FailOnVulnerability && len(result.Findings) != 0 {
+		return slogerr.With(errVulnerable, "vulnerabilities", len(result.Findings)) //nolint:wrapcheck
+	}
+	return nil
+}
+
+func (c *Controller) auditConfig(ctx context.Context, logger *slog.Logger, param *config.Param, db *osv.DB, cfgFilePath string, audited map[string]struct{}) ([]*Finding, error) {
+	cfg := &aqua.Config{}
+	if err := c.configReader.Read(logger, cfgFilePath, cfg); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("validate the configuration: %w", err)
+	}
+
+	// Audit is read-only, so the checksum file is read to verify registries but isn't updated.
+	checksums, _, err := checksum.Open(
+		logger, cfgFilePath, param.ChecksumEnabled(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("read a checksum JSON: %w", err)
+	}
+
+	registryContents, err := c.registryInstaller.InstallRegistries(ctx, logger, cfg, cfgFilePath, checksums)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	pkgs, _ := config.ListPackages(logger, cfg, c.runtime, registryContents)
+	var findings []*Finding
+	for _, pkg := range pkgs {
+		key := pkg.Package.Registry + "," + pkg.Package.Name + "@" + pkg.Package.Version
+		if _, ok := audited[key]; ok {
+			continue
+		}
+		audited[key] = struct{}{}
+		osvPkgs, err := osvPackages(pkg)
+		if err != nil {
+			return nil, fmt.Errorf("get identifiers of the package: %w", slogerr.With(err,
+				"package_name", pkg.Package.Name,
+				"package_version", pkg.Package.Version,
+			))
+		}
+		pkgFindings := db.Find(osvPkgs, pkg.SemVer())
+		slices.SortStableFunc(pkgFindings, func(a, b *osv.Finding) int {
+			return osv.SeverityLevel(b.Severity) - osv.SeverityLevel(a.Severity)
+		})
+		for _, f := range pkgFindings {
+			findings = append(findings, &Finding{
+				Package:        pkg.Package.Name,
+				Version:        pkg.Package.Version,
+				Registry:       pkg.Package.Registry,
+				ConfigFilePath: cfgFilePath,
+				ID:             f.Vulnerability.ID,
+				Aliases:        f.Vulnerability.Aliases,
+				Severity:       f.Severity,
+				FixedVersions:  f.Fixed,
+				Summary:        f.Vulnerability.Summary,
+			})
+		}
+	}
+	return findings, nil
+}
+
+// osvPackages maps a package to identifiers in OSV databases.
+// Tools hosted on GitHub are often Go modules, so they're also looked up as Go modules.
+func osvPackages(pkg *config.Package) ([]*osv.Package, error) {
+	pkgInfo := pkg.PackageInfo
+	purl, err := pkg.PURL()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	pkgs := []*osv.Package{
+		{PURL: purl},
+	}
+	switch pkgInfo.Type {
+	case config.PkgInfoTypeCargo:
+		pkgs = append(pkgs, &osv.Package{Ecosystem: osv.EcosystemCrates, Name: pkgInfo.Crate})
+	case config.PkgInfoTypeGoInstall:
+		path, err := pkg.RenderPath()
+		if err != nil {
+			return nil, fmt.Errorf("render Go Module Path: %w", err)
+		}
+		pkgs = append(pkgs, &osv.Package{Ecosystem: osv.EcosystemGo, Name: path})
+	}
+	if pkgInfo.HasRepo() {
+		module := "github.com/" + pkgInfo.RepoOwner + "/" + pkgInfo.RepoName
+		// Go modules whose major versions are 2 or later have major version suffixes.
+		if v, err := version.NewVersion(pkg.SemVer()); err == nil {
+			if major := v.Segments()[0]; major >= 2 { //nolint:mnd
+				module += "/v" + strconv.Itoa(major)
+			}
+		}
+		pkgs = append(pkgs, &osv.Package{Ecosystem: osv.EcosystemGo, Name: module})
+	}
+	return pkgs, nil
+}
+
+func output(w io.Writer, format string, result *Result) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("encode the result as JSON: %w", err)
+		}
+		return nil
+	case "", "text":
+		for _, f := range result.Findings {
+			id := f.ID
+			if len(f.Aliases) != 0 {
+				id += " (" + strings.Join(f.Aliases, ", ") + ")"
+			}
+			fixed := "no fixed version"
+			if len(f.FixedVersions) != 0 {
+				fixed = "fixed in " + strings.Join(f.FixedVersions, ", ")
+			}
+			fmt.Fprintf(w, "%s\t%s@%s\t%s\t%s\t%s\n", f.Severity, f.Package, f.Version, id, fixed, f.Summary)
+		}
+		return nil
+	default:
+		return slogerr.With(errUnknownOutputFormat, "format", format) //nolint:wrapcheck
+	}
+}
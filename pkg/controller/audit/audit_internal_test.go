@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/osv"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_osvPackages(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name string
+		pkg  *config.Package
+		exp  []*osv.Package
+	}{
+		{
+			name: "github_release",
+			pkg: &config.Package{
+				Package: &aqua.Package{Name: "cli/cli", Version: "v2.40.0"},
+				PackageInfo: &registry.PackageInfo{
+					Type:      "github_release",
+					RepoOwner: "cli",
+					RepoName:  "cli",
+				},
+			},
+			exp: []*osv.Package{
+				{PURL: "pkg:github/cli/cli@v2.40.0"},
+				{Ecosystem: "Go", Name: "github.com/cli/cli/v2"},
+			},
+		},
+		{
+			name: "version prefix",
+			pkg: &config.Package{
+				Package: &aqua.Package{Name: "kubernetes-sigs/kustomize", Version: "kustomize/v5.3.0"},
+				PackageInfo: &registry.PackageInfo{
+					Type:          "github_release",
+					RepoOwner:     "kubernetes-sigs",
+					RepoName:      "kustomize",
+					VersionPrefix: "kustomize/",
+				},
+			},
+			exp: []*osv.Package{
+				{PURL: "pkg:github/kubernetes-sigs/kustomize@kustomize%2Fv5.3.0"},
+				{Ecosystem: "Go", Name: "github.com/kubernetes-sigs/kustomize/v5"},
+			},
+		},
+		{
+			name: "go_install",
+			pkg: &config.Package{
+				Package: &aqua.Package{Name: "golang.org/x/tools/gopls", Version: "v0.5.1"},
+				PackageInfo: &registry.PackageInfo{
+					Type: "go_install",
+					Path: "golang.org/x/tools/gopls",
+				},
+			},
+			exp: []*osv.Package{
+				{PURL: "pkg:golang/golang.org/x/tools/gopls@v0.5.1"},
+				{Ecosystem: "Go", Name: "golang.org/x/tools/gopls"},
+			},
+		},
+		{
+			name: "cargo",
+			pkg: &config.Package{
+				Package: &aqua.Package{Name: "crates.io/ripgrep", Version: "14.1.0"},
+				PackageInfo: &registry.PackageInfo{
+					Type:  "cargo",
+					Crate: "ripgrep",
+				},
+			},
+			exp: []*osv.Package{
+				{PURL: "pkg:cargo/ripgrep@14.1.0"},
+				{Ecosystem: "crates.io", Name: "ripgrep"},
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			pkgs, err := osvPackages(d.pkg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(d.exp, pkgs); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
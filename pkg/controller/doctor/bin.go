@@ -0,0 +1,102 @@
+//go:build !windows
+
+package doctor
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	proxyName = "aqua-proxy"
+	aquaName  = "aqua"
+)
+
+func isExecutable(p string) bool {
+	fi, err := os.Stat(p)
+	if err != nil {
+		return false
+	}
+	return !fi.IsDir() && fi.Mode()&0o111 != 0
+}
+
+// checkBinLinks checks if files in $AQUA_ROOT_DIR/bin are symbolic links to aqua-proxy.
+// Dangling links are errors, and files which aren't managed by aqua are warnings.
+func (c *Controller) checkBinLinks() []*Finding {
+	binDir := filepath.Join(c.rootDir, "bin")
+	entries, err := os.ReadDir(binDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return []*Finding{failure(checkBin,
+			fmt.Sprintf("failed to read the directory %s: %v", binDir, err),
+			"Fix the permission of the directory")}
+	}
+	var findings []*Finding
+	for _, entry := range entries {
+		if f := c.checkBinLink(filepath.Join(binDir, entry.Name())); f != nil {
+			findings = append(findings, f)
+		}
+	}
+	if len(findings) == 0 {
+		return []*Finding{ok(checkBin, "all files in "+binDir+" are links to aqua-proxy")}
+	}
+	return findings
+}
+
+func (c *Controller) checkBinLink(p string) *Finding {
+	fi, err := c.linker.Lstat(p)
+	if err != nil {
+		return failure(checkBin, fmt.Sprintf("failed to get the file stat of %s: %v", p, err), "Fix the permission of the file")
+	}
+	if fi.Mode()&fs.ModeSymlink == 0 {
+		return warning(checkBin,
+			p+" isn't a symbolic link created by aqua",
+			"Move the file to another directory, because aqua manages "+filepath.Dir(p))
+	}
+	dest, err := c.linker.Readlink(p)
+	if err != nil {
+		return failure(checkBin, fmt.Sprintf("failed to read the symbolic link %s: %v", p, err), "Remove the file and run `aqua i -l`")
+	}
+	if !filepath.IsAbs(dest) {
+		dest = filepath.Join(filepath.Dir(p), dest)
+	}
+	if _, err := os.Stat(dest); err != nil {
+		return failure(checkBin,
+			fmt.Sprintf("%s is a dangling symbolic link to %s", p, dest),
+			fmt.Sprintf("Remove the link by `rm %s` and run `aqua i -l`", p))
+	}
+	if filepath.Base(dest) == proxyName {
+		return nil
+	}
+	if filepath.Base(p) == aquaName {
+		// aqua itself is linked by aqua update-aqua.
+		return nil
+	}
+	return warning(checkBin,
+		fmt.Sprintf("%s is a symbolic link to %s, which isn't aqua-proxy", p, dest),
+		"Move the link to another directory, because aqua manages "+filepath.Dir(p))
+}
+
+// checkProxyLink checks if $AQUA_ROOT_DIR/aqua-proxy is a symbolic link to the aqua-proxy which this version of aqua requires.
+func (c *Controller) checkProxyLink(proxyPath string) *Finding {
+	p := filepath.Join(c.rootDir, proxyName)
+	dest, err := c.linker.Readlink(p)
+	if err != nil {
+		return failure(checkProxy,
+			fmt.Sprintf("failed to read the symbolic link %s: %v", p, err),
+			"Run `aqua i -l`")
+	}
+	if !filepath.IsAbs(dest) {
+		dest = filepath.Join(c.rootDir, dest)
+	}
+	if dest != proxyPath {
+		return warning(checkProxy,
+			fmt.Sprintf("%s is linked to %s, but this version of aqua requires %s", p, dest, proxyPath),
+			"Run `aqua i -l`")
+	}
+	return nil
+}
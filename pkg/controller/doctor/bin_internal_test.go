@@ -0,0 +1,48 @@
+//go:build !windows
+
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/link"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+)
+
+func TestController_checkBinLinks(t *testing.T) {
+	t.Parallel()
+	rootDir := t.TempDir()
+	binDir := filepath.Join(rootDir, "bin")
+	writeExecutable(t, filepath.Join(rootDir, "aqua-proxy"))
+	writeExecutable(t, filepath.Join(rootDir, "other"))
+	writeExecutable(t, filepath.Join(binDir, "foreign"))
+	for name, dest := range map[string]string{
+		"gh":      filepath.Join("..", "aqua-proxy"),
+		"dangle":  filepath.Join("..", "missing"),
+		"another": filepath.Join("..", "other"),
+	} {
+		if err := os.Symlink(dest, filepath.Join(binDir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctrl := New(&config.Param{RootDir: rootDir}, nil, nil, nil, &runtime.Runtime{GOOS: "linux", GOARCH: "amd64"}, osenv.NewMock(nil), link.New())
+	findings := ctrl.checkBinLinks()
+	got := map[string]Status{}
+	for _, f := range findings {
+		got[strings.Fields(f.Message)[0]] = f.Status
+	}
+	exp := map[string]Status{
+		filepath.Join(binDir, "another"): StatusWarning,
+		filepath.Join(binDir, "dangle"):  StatusError,
+		filepath.Join(binDir, "foreign"): StatusWarning,
+	}
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,25 @@
+//go:build windows
+
+package doctor
+
+import (
+	"os"
+)
+
+func isExecutable(p string) bool {
+	fi, err := os.Stat(p)
+	if err != nil {
+		return false
+	}
+	return !fi.IsDir()
+}
+
+// checkBinLinks is skipped on Windows, because files in $AQUA_ROOT_DIR/bin are hard links and can't be distinguished from other files.
+func (c *Controller) checkBinLinks() []*Finding {
+	return nil
+}
+
+// checkProxyLink is skipped on Windows, because aqua-proxy is executed via hard links.
+func (c *Controller) checkProxyLink(_ string) *Finding {
+	return nil
+}
@@ -0,0 +1,220 @@
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+)
+
+// provider is a package which provides a command.
+type provider struct {
+	pkgName      string
+	cfgFilePath  string
+	registryName string
+}
+
+func (p *provider) String() string {
+	return fmt.Sprintf("%s (%s)", p.pkgName, p.cfgFilePath)
+}
+
+// configFilePaths returns local and global configuration files in the order they are searched.
+func (c *Controller) configFilePaths(param *config.Param) []string {
+	var filePaths []string
+	if param.ConfigFilePath != "" {
+		filePaths = []string{osfile.Abs(param.CWD, param.ConfigFilePath)}
+	}
+	filePaths = append(filePaths, c.configFinder.Finds(param.CWD, "")...)
+	for _, p := range param.GlobalConfigFilePaths {
+		if f, err := osfile.Exists(p); err == nil && f {
+			filePaths = append(filePaths, p)
+		}
+	}
+	return filePaths
+}
+
+// checkConfigs checks if registries of configuration files can be installed,
+// and if multiple packages provide the same command.
+func (c *Controller) checkConfigs(ctx context.Context, logger *slog.Logger, param *config.Param) []*Finding {
+	cfgFilePaths := c.configFilePaths(param)
+	if len(cfgFilePaths) == 0 {
+		return []*Finding{ok(checkRegistry, "no configuration file is found")}
+	}
+	var findings []*Finding
+	var cmds []string
+	providers := map[string][]*provider{}
+	for _, cfgFilePath := range cfgFilePaths {
+		fs, pkgs := c.checkConfig(ctx, logger.With("config_file_path", cfgFilePath), param, cfgFilePath)
+		findings = append(findings, fs...)
+		for _, pkg := range pkgs {
+			for _, cmd := range commands(pkg) {
+				if _, ok := providers[cmd]; !ok {
+					cmds = append(cmds, cmd)
+				}
+				providers[cmd] = appendProvider(providers[cmd], &provider{
+					pkgName:      pkg.Package.Name,
+					cfgFilePath:  cfgFilePath,
+					registryName: pkg.Package.Registry,
+				})
+			}
+		}
+	}
+	return append(findings, checkCollisions(cmds, providers)...)
+}
+
+// appendProvider appends a provider unless the same package already provides the command.
+// The same package in multiple configuration files isn't a collision,
+// because it's a common way to override the version of a global configuration file.
+func appendProvider(providers []*provider, p *provider) []*provider {
+	for _, a := range providers {
+		if a.pkgName == p.pkgName && a.registryName == p.registryName {
+			return providers
+		}
+	}
+	return append(providers, p)
+}
+
+func checkCollisions(cmds []string, providers map[string][]*provider) []*Finding {
+	var findings []*Finding
+	for _, cmd := range cmds {
+		ps := providers[cmd]
+		if len(ps) < 2 { //nolint:mnd
+			continue
+		}
+		names := make([]string, len(ps))
+		for i, p := range ps {
+			names[i] = p.String()
+		}
+		findings = append(findings, warning(checkCollision,
+			fmt.Sprintf("the command %q is provided by multiple packages: %s. %s is used", cmd, strings.Join(names, ", "), ps[0].pkgName),
+			"Remove either package, or rename the command by command_aliases"))
+	}
+	if len(findings) == 0 {
+		return []*Finding{ok(checkCollision, "no command is provided by multiple packages")}
+	}
+	return findings
+}
+
+// commands returns commands which the package provides, including command aliases.
+func commands(pkg *config.Package) []string {
+	var cmds []string
+	for _, file := range pkg.PackageInfo.GetFiles() {
+		cmds = append(cmds, pkg.Package.CommandName(file.Name))
+		for _, alias := range pkg.Package.CommandAliases {
+			if alias.Command == file.Name {
+				cmds = append(cmds, alias.Alias)
+			}
+		}
+	}
+	return cmds
+}
+
+func (c *Controller) checkConfig(ctx context.Context, logger *slog.Logger, param *config.Param, cfgFilePath string) ([]*Finding, []*config.Package) {
+	cfg := &aqua.Config{}
+	if err := c.configReader.Read(logger, cfgFilePath, cfg); err != nil {
+		return []*Finding{failure(checkRegistry,
+			fmt.Sprintf("failed to read the configuration file %s: %v", cfgFilePath, err),
+			"Fix the configuration file")}, nil
+	}
+	// doctor only diagnoses the environment, so it doesn't update the checksum file.
+	checksums, _, err := checksum.Open(logger, cfgFilePath, param.ChecksumEnabled(cfg))
+	if err != nil {
+		return []*Finding{failure(checkRegistry,
+			fmt.Sprintf("failed to read the checksum file of %s: %v", cfgFilePath, err),
+			"Fix the checksum file, or run `aqua upc -prune` to recreate it")}, nil
+	}
+
+	names := make([]string, 0, len(cfg.Registries))
+	for name := range cfg.Registries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var findings []*Finding
+	registries := make(map[string]*registry.Config, len(names))
+	for _, name := range names {
+		rg := cfg.Registries[name]
+		if rg == nil {
+			continue
+		}
+		content, err := c.registryInstaller.InstallRegistry(ctx, logger, rg, cfgFilePath, checksums)
+		if err != nil {
+			findings = append(findings, failure(checkRegistry,
+				fmt.Sprintf("failed to install the registry %s of %s: %v", name, cfgFilePath, err),
+				c.registryFix(rg, cfgFilePath)))
+			continue
+		}
+		registries[name] = content
+	}
+	if len(findings) == 0 {
+		findings = append(findings, ok(checkRegistry, "registries of "+cfgFilePath+" are installed"))
+	}
+	pkgs, _ := config.ListPackages(logger, cfg, c.runtime, registries)
+	return findings, pkgs
+}
+
+func (c *Controller) registryFix(rg *aqua.Registry, cfgFilePath string) string {
+	p, err := rg.FilePath(c.rootDir, cfgFilePath)
+	if err != nil {
+		return "Fix the registry configuration"
+	}
+	if rg.Type == aqua.RegistryTypeLocal {
+		return "Check if the registry file exists and is valid: " + p
+	}
+	return fmt.Sprintf("Check the registry configuration and the network. If the registry file is broken, remove it by `rm %s` and run `aqua i`", p)
+}
+
+// checkRegistryCache checks if registry cache files can be parsed.
+// Broken cache files are warnings because they can be removed safely.
+func (c *Controller) checkRegistryCache() []*Finding {
+	dir := filepath.Join(c.rootDir, "registry-cache")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return []*Finding{failure(checkRegistryCache,
+			fmt.Sprintf("failed to read the directory %s: %v", dir, err),
+			"Fix the permission of the directory")}
+	}
+	var findings []*Finding
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		p := filepath.Join(dir, entry.Name())
+		if err := readRegistryCache(p); err != nil {
+			findings = append(findings, warning(checkRegistryCache,
+				fmt.Sprintf("the registry cache %s is broken: %v", p, err),
+				fmt.Sprintf("Remove the file by `rm %s`. It's recreated automatically", p)))
+		}
+	}
+	if len(findings) == 0 {
+		return []*Finding{ok(checkRegistryCache, "registry cache files are valid")}
+	}
+	return findings
+}
+
+func readRegistryCache(p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return fmt.Errorf("open a registry cache: %w", err)
+	}
+	defer f.Close()
+	m := map[string]map[string]*registry.PackageInfo{}
+	if err := json.NewDecoder(f).Decode(&m); err != nil {
+		return fmt.Errorf("parse the registry cache file: %w", err)
+	}
+	return nil
+}
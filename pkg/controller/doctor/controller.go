@@ -0,0 +1,56 @@
+package doctor
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+)
+
+type Controller struct {
+	stdout            io.Writer
+	rootDir           string
+	configFinder      ConfigFinder
+	configReader      ConfigReader
+	registryInstaller RegistryInstaller
+	runtime           *runtime.Runtime
+	osenv             osenv.OSEnv
+	linker            Linker
+}
+
+func New(param *config.Param, configFinder ConfigFinder, configReader ConfigReader, registryInstaller RegistryInstaller, rt *runtime.Runtime, osEnv osenv.OSEnv, linker Linker) *Controller {
+	return &Controller{
+		stdout:            os.Stdout,
+		rootDir:           param.RootDir,
+		configFinder:      configFinder,
+		configReader:      configReader,
+		registryInstaller: registryInstaller,
+		runtime:           rt,
+		osenv:             osEnv,
+		linker:            linker,
+	}
+}
+
+type ConfigFinder interface {
+	Finds(wd, configFilePath string) []string
+}
+
+type ConfigReader interface {
+	Read(logger *slog.Logger, configFilePath string, cfg *aqua.Config) error
+}
+
+type RegistryInstaller interface {
+	InstallRegistry(ctx context.Context, logger *slog.Logger, regist *aqua.Registry, cfgFilePath string, checksums *checksum.Checksums) (*registry.Config, error)
+}
+
+type Linker interface {
+	Lstat(s string) (os.FileInfo, error)
+	Readlink(src string) (string, error)
+}
@@ -0,0 +1,128 @@
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+var (
+	errProblemsFound       = errors.New("problems are found")
+	errUnknownOutputFormat = errors.New("unknown output format")
+)
+
+// Status is a result of a check.
+type Status string
+
+const (
+	StatusOK      Status = "ok"
+	StatusWarning Status = "warning"
+	StatusError   Status = "error"
+)
+
+// Names of checks.
+const (
+	checkRootDir       = "root_dir"
+	checkPath          = "path"
+	checkBin           = "bin"
+	checkProxy         = "aqua_proxy"
+	checkGitHubToken   = "github_token"
+	checkRegistry      = "registry"
+	checkRegistryCache = "registry_cache"
+	checkCollision     = "command_collision"
+)
+
+// Finding is a result of a check.
+type Finding struct {
+	Check   string `json:"check"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	// Fix is an action to fix the problem.
+	Fix string `json:"fix,omitempty"`
+}
+
+// Result is the output of "aqua doctor -format json".
+type Result struct {
+	Findings []*Finding `json:"findings"`
+}
+
+func ok(check, msg string) *Finding {
+	return &Finding{Check: check, Status: StatusOK, Message: msg}
+}
+
+func warning(check, msg, fix string) *Finding {
+	return &Finding{Check: check, Status: StatusWarning, Message: msg, Fix: fix}
+}
+
+func failure(check, msg, fix string) *Finding {
+	return &Finding{Check: check, Status: StatusError, Message: msg, Fix: fix}
+}
+
+// Doctor diagnoses the environment and outputs problems and how to fix them.
+// If any error is found, errProblemsFound is returned.
+// Warnings don't make the command fail.
+func (c *Controller) Doctor(ctx context.Context, logger *slog.Logger, param *config.Param) error {
+	switch param.OutputFormat {
+	case "", "text", "json":
+	default:
+		return slogerr.With(errUnknownOutputFormat, "format", param.OutputFormat) //nolint:wrapcheck
+	}
+	findings := c.diagnose(ctx, logger, param)
+	if err := output(c.stdout, param.OutputFormat, findings); err != nil {
+		return err
+	}
+	numErrors := 0
+	for _, f := range findings {
+		if f.Status == StatusError {
+			numErrors++
+		}
+	}
+	if numErrors > 0 {
+		return slogerr.With(errProblemsFound, "num_of_errors", numErrors) //nolint:wrapcheck
+	}
+	return nil
+}
+
+func (c *Controller) diagnose(ctx context.Context, logger *slog.Logger, param *config.Param) []*Finding {
+	findings := c.checkRootDir()
+	findings = append(findings, c.checkPath()...)
+	findings = append(findings, c.checkBinLinks()...)
+	findings = append(findings, c.checkProxy()...)
+	findings = append(findings, c.checkGitHubToken(logger))
+	findings = append(findings, c.checkConfigs(ctx, logger, param)...)
+	return append(findings, c.checkRegistryCache()...)
+}
+
+func output(w io.Writer, format string, findings []*Finding) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(&Result{Findings: findings}); err != nil {
+			return fmt.Errorf("encode the result as JSON: %w", err)
+		}
+		return nil
+	}
+	numWarnings := 0
+	numErrors := 0
+	for _, f := range findings {
+		switch f.Status {
+		case StatusWarning:
+			numWarnings++
+		case StatusError:
+			numErrors++
+		case StatusOK:
+		}
+		fmt.Fprintf(w, "[%s] %s: %s\n", f.Status, f.Check, f.Message)
+		if f.Fix != "" {
+			fmt.Fprintf(w, "  fix: %s\n", f.Fix)
+		}
+	}
+	fmt.Fprintf(w, "\n%d errors, %d warnings\n", numErrors, numWarnings)
+	return nil
+}
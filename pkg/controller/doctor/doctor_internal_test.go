@@ -0,0 +1,191 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/link"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+)
+
+func statuses(findings []*Finding) []Status {
+	ret := make([]Status, len(findings))
+	for i, f := range findings {
+		ret[i] = f.Status
+	}
+	return ret
+}
+
+func writeExecutable(t *testing.T, p string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte("#!/bin/sh\n"), 0o755); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+}
+
+func TestController_checkPath(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name string
+		path func(rootDir, otherDir string) string
+		exp  []Status
+	}{
+		{
+			name: "not in PATH",
+			path: func(_, otherDir string) string {
+				return otherDir
+			},
+			exp: []Status{StatusError},
+		},
+		{
+			name: "first",
+			path: func(rootDir, otherDir string) string {
+				return filepath.Join(rootDir, "bin") + string(os.PathListSeparator) + otherDir
+			},
+			exp: []Status{StatusOK},
+		},
+		{
+			name: "shadowed",
+			path: func(rootDir, otherDir string) string {
+				return otherDir + string(os.PathListSeparator) + filepath.Join(rootDir, "bin")
+			},
+			exp: []Status{StatusWarning},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			rootDir := t.TempDir()
+			otherDir := t.TempDir()
+			writeExecutable(t, filepath.Join(rootDir, "bin", "gh"))
+			writeExecutable(t, filepath.Join(otherDir, "gh"))
+			writeExecutable(t, filepath.Join(otherDir, "rg"))
+			ctrl := New(&config.Param{RootDir: rootDir}, nil, nil, nil, &runtime.Runtime{GOOS: "linux", GOARCH: "amd64"}, osenv.NewMock(map[string]string{
+				"PATH": d.path(rootDir, otherDir),
+			}), link.New())
+			if diff := cmp.Diff(d.exp, statuses(ctrl.checkPath())); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_checkCollisions(t *testing.T) {
+	t.Parallel()
+	pkg := func(name, registryName string, files []string, aliases ...*aqua.CommandAlias) *config.Package {
+		fs := make([]*registry.File, len(files))
+		for i, f := range files {
+			fs[i] = &registry.File{Name: f}
+		}
+		return &config.Package{
+			Package: &aqua.Package{
+				Name:           name,
+				Registry:       registryName,
+				CommandAliases: aliases,
+			},
+			PackageInfo: &registry.PackageInfo{
+				Files: fs,
+			},
+		}
+	}
+	data := []struct {
+		name string
+		pkgs map[string][]*config.Package
+		exp  []Status
+	}{
+		{
+			name: "no collision",
+			pkgs: map[string][]*config.Package{
+				"aqua.yaml": {
+					pkg("cli/cli", "standard", []string{"gh"}),
+					pkg("BurntSushi/ripgrep", "standard", []string{"rg"}),
+				},
+			},
+			exp: []Status{StatusOK},
+		},
+		{
+			name: "collision",
+			pkgs: map[string][]*config.Package{
+				"aqua.yaml": {
+					pkg("cli/cli", "standard", []string{"gh"}),
+					pkg("foo/gh", "standard", []string{"gh"}),
+				},
+			},
+			exp: []Status{StatusWarning},
+		},
+		{
+			name: "collision of a command alias",
+			pkgs: map[string][]*config.Package{
+				"aqua.yaml": {
+					pkg("cli/cli", "standard", []string{"gh"}),
+					pkg("foo/bar", "standard", []string{"bar"}, &aqua.CommandAlias{Command: "bar", Alias: "gh"}),
+				},
+			},
+			exp: []Status{StatusWarning},
+		},
+		{
+			name: "the same package in multiple configuration files",
+			pkgs: map[string][]*config.Package{
+				"aqua.yaml": {
+					pkg("cli/cli", "standard", []string{"gh"}),
+				},
+				"global.yaml": {
+					pkg("cli/cli", "standard", []string{"gh"}),
+				},
+			},
+			exp: []Status{StatusOK},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			var cmds []string
+			providers := map[string][]*provider{}
+			for cfgFilePath, pkgs := range d.pkgs {
+				for _, pkg := range pkgs {
+					for _, cmd := range commands(pkg) {
+						if _, ok := providers[cmd]; !ok {
+							cmds = append(cmds, cmd)
+						}
+						providers[cmd] = appendProvider(providers[cmd], &provider{
+							pkgName:      pkg.Package.Name,
+							cfgFilePath:  cfgFilePath,
+							registryName: pkg.Package.Registry,
+						})
+					}
+				}
+			}
+			if diff := cmp.Diff(d.exp, statuses(checkCollisions(cmds, providers))); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestController_checkRegistryCache(t *testing.T) {
+	t.Parallel()
+	rootDir := t.TempDir()
+	dir := filepath.Join(rootDir, "registry-cache")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "valid.json"), []byte(`{"registry.yaml":{"cli/cli":{"repo_owner":"cli","repo_name":"cli"}}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ctrl := New(&config.Param{RootDir: rootDir}, nil, nil, nil, &runtime.Runtime{}, osenv.NewMock(nil), link.New())
+	if diff := cmp.Diff([]Status{StatusWarning}, statuses(ctrl.checkRegistryCache())); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,98 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// checkRootDir checks if the root directory is a writable directory.
+func (c *Controller) checkRootDir() []*Finding {
+	fi, err := os.Stat(c.rootDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []*Finding{warning(checkRootDir,
+				"the root directory doesn't exist: "+c.rootDir,
+				"Run `aqua i` to create it")}
+		}
+		return []*Finding{failure(checkRootDir,
+			fmt.Sprintf("failed to get the file stat of the root directory %s: %v", c.rootDir, err),
+			"Fix the permission of the root directory or change AQUA_ROOT_DIR")}
+	}
+	if !fi.IsDir() {
+		return []*Finding{failure(checkRootDir,
+			"the root directory isn't a directory: "+c.rootDir,
+			"Remove the file or change AQUA_ROOT_DIR")}
+	}
+	f, err := os.CreateTemp(c.rootDir, ".aqua-doctor-")
+	if err != nil {
+		return []*Finding{failure(checkRootDir,
+			fmt.Sprintf("the root directory %s isn't writable: %v", c.rootDir, err),
+			"Fix the permission of the root directory. e.g. sudo chown -R \"$(id -un)\" "+c.rootDir)}
+	}
+	f.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		return []*Finding{warning(checkRootDir,
+			fmt.Sprintf("failed to remove a temporary file %s: %v", f.Name(), err),
+			"Remove the file")}
+	}
+	return []*Finding{ok(checkRootDir, "the root directory is writable: "+c.rootDir)}
+}
+
+// checkPath checks if $AQUA_ROOT_DIR/bin is in PATH and commands in it aren't shadowed by preceding directories.
+func (c *Controller) checkPath() []*Finding {
+	binDir := filepath.Join(c.rootDir, "bin")
+	paths := filepath.SplitList(c.osenv.Getenv("PATH"))
+	idx := -1
+	for i, p := range paths {
+		if p != "" && filepath.Clean(p) == binDir {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return []*Finding{failure(checkPath,
+			binDir+" isn't in the environment variable PATH",
+			c.pathFix())}
+	}
+	entries, err := os.ReadDir(binDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []*Finding{ok(checkPath, binDir+" is in PATH")}
+		}
+		return []*Finding{failure(checkPath,
+			fmt.Sprintf("failed to read the directory %s: %v", binDir, err),
+			"Fix the permission of the directory")}
+	}
+	var findings []*Finding
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		for _, dir := range paths[:idx] {
+			if dir == "" {
+				continue
+			}
+			p := filepath.Join(dir, entry.Name())
+			if !isExecutable(p) {
+				continue
+			}
+			findings = append(findings, warning(checkPath,
+				fmt.Sprintf("%s is used instead of %s, because %s precedes %s in PATH", p, filepath.Join(binDir, entry.Name()), dir, binDir),
+				c.pathFix()))
+			break
+		}
+	}
+	if len(findings) == 0 {
+		return []*Finding{ok(checkPath, binDir+" is in PATH")}
+	}
+	return findings
+}
+
+func (c *Controller) pathFix() string {
+	if c.runtime.IsWindows() {
+		return "Add " + filepath.Join(c.rootDir, "bin") + " to the beginning of the environment variable PATH"
+	}
+	return `Add "$(aqua root-dir)/bin" to the beginning of PATH. e.g. export PATH="$(aqua root-dir)/bin:$PATH"`
+}
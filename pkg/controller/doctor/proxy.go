@@ -0,0 +1,25 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aquaproj/aqua/v2/pkg/installpackage"
+)
+
+// checkProxy checks if the aqua-proxy which this version of aqua requires is installed.
+func (c *Controller) checkProxy() []*Finding {
+	proxyPath, err := installpackage.ProxyPath(c.rootDir, c.runtime)
+	if err != nil {
+		return []*Finding{failure(checkProxy, fmt.Sprintf("failed to get the path of aqua-proxy: %v", err), "")}
+	}
+	if _, err := os.Stat(proxyPath); err != nil {
+		return []*Finding{failure(checkProxy,
+			fmt.Sprintf("aqua-proxy %s isn't installed: %s", installpackage.ProxyVersion, proxyPath),
+			"Run `aqua i -l`")}
+	}
+	if f := c.checkProxyLink(proxyPath); f != nil {
+		return []*Finding{f}
+	}
+	return []*Finding{ok(checkProxy, "aqua-proxy "+installpackage.ProxyVersion+" is installed")}
+}
@@ -0,0 +1,43 @@
+package doctor
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/aquaproj/aqua/v2/pkg/keyring"
+	"github.com/suzuki-shunsuke/ghtkn-go-sdk/ghtkn"
+	"github.com/suzuki-shunsuke/urfave-cli-v3-util/keyring/ghtoken"
+)
+
+const tokenFix = "Set a GitHub access token to the environment variable AQUA_GITHUB_TOKEN or GITHUB_TOKEN, " +
+	"or run `aqua token set` and set AQUA_KEYRING_ENABLED=true"
+
+// checkGitHubToken checks if a GitHub access token is available in the same order as the GitHub API client.
+// Without a token, aqua may hit the rate limit of GitHub API.
+func (c *Controller) checkGitHubToken(logger *slog.Logger) *Finding {
+	for _, envName := range []string{"AQUA_GITHUB_TOKEN", "GITHUB_TOKEN"} {
+		if c.osenv.Getenv(envName) != "" {
+			return ok(checkGitHubToken, "a GitHub access token is set to the environment variable "+envName)
+		}
+	}
+	if c.osenv.Getenv("AQUA_KEYRING_ENABLED") == "true" {
+		if _, err := ghtoken.NewTokenSource(logger, keyring.KeyService).Token(); err != nil {
+			return failure(checkGitHubToken,
+				fmt.Sprintf("AQUA_KEYRING_ENABLED is true, but a GitHub access token can't be got from the keyring: %v", err),
+				"Run `aqua token set`")
+		}
+		return ok(checkGitHubToken, "a GitHub access token is got from the keyring")
+	}
+	ghtknEnabled, err := ghtkn.Enabled(&ghtkn.InputEnabled{
+		Envs: []string{
+			"AQUA_GHTKN_ENABLED",
+		},
+	})
+	if err != nil {
+		return failure(checkGitHubToken, fmt.Sprintf("failed to check if ghtkn is enabled: %v", err), "Fix AQUA_GHTKN_ENABLED")
+	}
+	if ghtknEnabled {
+		return ok(checkGitHubToken, "a GitHub access token is got by ghtkn")
+	}
+	return warning(checkGitHubToken, "no GitHub access token is available, so aqua may hit the rate limit of GitHub API", tokenFix)
+}
@@ -0,0 +1,64 @@
+package env
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/installpackage"
+	"github.com/aquaproj/aqua/v2/pkg/policy"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+)
+
+type Controller struct {
+	stdout            io.Writer
+	rootDir           string
+	runtime           *runtime.Runtime
+	configFinder      ConfigFinder
+	configReader      ConfigReader
+	registryInstaller RegistryInstaller
+	packageInstaller  Installer
+	policyReader      PolicyReader
+	osenv             osenv.OSEnv
+}
+
+func New(param *config.Param, configFinder ConfigFinder, configReader ConfigReader, registryInstaller RegistryInstaller, pkgInstaller Installer, rt *runtime.Runtime, policyReader PolicyReader, osEnv osenv.OSEnv) *Controller {
+	return &Controller{
+		stdout:            os.Stdout,
+		rootDir:           param.RootDir,
+		runtime:           rt,
+		configFinder:      configFinder,
+		configReader:      configReader,
+		registryInstaller: registryInstaller,
+		packageInstaller:  pkgInstaller,
+		policyReader:      policyReader,
+		osenv:             osEnv,
+	}
+}
+
+type ConfigFinder interface {
+	Finds(wd, configFilePath string) []string
+}
+
+type ConfigReader interface {
+	Read(logger *slog.Logger, configFilePath string, cfg *aqua.Config) error
+}
+
+type RegistryInstaller interface {
+	InstallRegistries(ctx context.Context, logger *slog.Logger, cfg *aqua.Config, cfgFilePath string, checksums *checksum.Checksums) (map[string]*registry.Config, error)
+}
+
+type Installer interface {
+	InstallPackages(ctx context.Context, logger *slog.Logger, param *installpackage.ParamInstallPackages) error
+}
+
+type PolicyReader interface {
+	Read(policyFilePaths []string) ([]*policy.Config, error)
+	Append(logger *slog.Logger, aquaYAMLPath string, policies []*policy.Config, globalPolicyPaths map[string]struct{}) ([]*policy.Config, error)
+}
@@ -0,0 +1,271 @@
+package env
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/installpackage"
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/aquaproj/aqua/v2/pkg/policy"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+const (
+	// envPath holds directories which the last activation prepended to PATH.
+	// They are removed from PATH on the next activation.
+	envPath = "AQUA_ENV_PATH"
+	// envState holds a hash of configuration files at the last activation.
+	// In hook mode, nothing is output if the hash isn't changed.
+	envState = "AQUA_ENV_STATE"
+)
+
+var errInstallFailure = errors.New("failed to install some packages")
+
+// configFile is a configuration file and its content.
+type configFile struct {
+	path string
+	cfg  *aqua.Config
+}
+
+// Env installs packages in configuration files and outputs a shell snippet
+// which prepends directories of links to their commands to PATH.
+// If hook is true, nothing is output as long as configuration files aren't changed since the last activation.
+// Even if some packages fail to be installed, the snippet is output so that a hook doesn't retry the installation on every prompt.
+func (c *Controller) Env(ctx context.Context, logger *slog.Logger, param *config.Param, shell string, hook bool) error {
+	if !isSupportedShell(shell) {
+		return slogerr.With(errUnsupportedShell, "shell", shell) //nolint:wrapcheck
+	}
+	cfgFiles, err := c.readConfigs(logger, param)
+	if err != nil {
+		return err
+	}
+	state := c.state(param, cfgFiles)
+	if hook && state == c.osenv.Getenv(envState) {
+		logger.Debug("configuration files aren't changed since the last activation")
+		return nil
+	}
+	dirs, installErr := c.install(ctx, logger, param, cfgFiles)
+	if installErr != nil && !errors.Is(installErr, errInstallFailure) {
+		return installErr
+	}
+	path := buildPath(c.osenv.Getenv("PATH"), c.osenv.Getenv(envPath), dirs)
+	if err := writeEnv(c.stdout, shell, []*envVar{
+		{name: "PATH", value: path, list: true},
+		{name: envPath, value: strings.Join(dirs, string(os.PathListSeparator))},
+		{name: envState, value: state},
+	}); err != nil {
+		return err
+	}
+	return installErr
+}
+
+// readConfigs reads local configuration files, and global configuration files if param.All is true.
+func (c *Controller) readConfigs(logger *slog.Logger, param *config.Param) ([]*configFile, error) {
+	cfgFilePaths := c.configFinder.Finds(param.CWD, param.ConfigFilePath)
+	if param.All {
+		for _, cfgFilePath := range param.GlobalConfigFilePaths {
+			if f, err := osfile.Exists(cfgFilePath); err != nil {
+				return nil, err //nolint:wrapcheck
+			} else if f {
+				cfgFilePaths = append(cfgFilePaths, cfgFilePath)
+			}
+		}
+	}
+	seen := make(map[string]struct{}, len(cfgFilePaths))
+	cfgFiles := make([]*configFile, 0, len(cfgFilePaths))
+	for _, cfgFilePath := range cfgFilePaths {
+		if _, ok := seen[cfgFilePath]; ok {
+			continue
+		}
+		seen[cfgFilePath] = struct{}{}
+		cfg := &aqua.Config{}
+		if err := c.configReader.Read(logger, cfgFilePath, cfg); err != nil {
+			return nil, fmt.Errorf("read a configuration file: %w", slogerr.With(err, "config_file_path", cfgFilePath))
+		}
+		if err := cfg.Validate(); err != nil {
+			return nil, fmt.Errorf("validate the configuration: %w", slogerr.With(err, "config_file_path", cfgFilePath))
+		}
+		cfgFiles = append(cfgFiles, &configFile{
+			path: cfgFilePath,
+			cfg:  cfg,
+		})
+	}
+	return cfgFiles, nil
+}
+
+// state returns a hash of configuration files.
+// It covers packages after version_expr and version files are evaluated,
+// and files which configuration files and local registries are read from.
+func (c *Controller) state(param *config.Param, cfgFiles []*configFile) string {
+	h := sha256.New()
+	write := func(ss ...string) {
+		for _, s := range ss {
+			h.Write([]byte(s))
+			h.Write([]byte{0})
+		}
+	}
+	write(param.AQUAVersion, c.rootDir, c.runtime.GOOS, c.runtime.GOARCH, c.runtime.LibC)
+	for _, cfgFile := range cfgFiles {
+		writeStamp(h, cfgFile.path)
+		for _, p := range cfgFile.cfg.Inputs {
+			writeStamp(h, p)
+		}
+		for _, rgst := range cfgFile.cfg.Registries {
+			write(rgst.Name, rgst.Type, rgst.RepoOwner, rgst.RepoName, rgst.Ref, rgst.Path)
+			if rgst.Type == aqua.RegistryTypeLocal {
+				writeStamp(h, rgst.Path)
+			}
+		}
+		for _, pkg := range cfgFile.cfg.Packages {
+			write(pkg.Registry, pkg.Name, pkg.Version)
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+func writeStamp(h hash.Hash, p string) {
+	h.Write([]byte(p))
+	h.Write([]byte{0})
+	fi, err := os.Stat(p)
+	if err != nil {
+		h.Write([]byte("missing"))
+		h.Write([]byte{0})
+		return
+	}
+	h.Write([]byte(strconv.FormatInt(fi.ModTime().UnixNano(), 10) + ":" + strconv.FormatInt(fi.Size(), 10)))
+	h.Write([]byte{0})
+}
+
+// install installs packages and returns directories of links to their commands.
+// The directories of configuration files closer to the current directory come first.
+// If some packages fail to be installed, directories are returned with an error.
+func (c *Controller) install(ctx context.Context, logger *slog.Logger, param *config.Param, cfgFiles []*configFile) ([]string, error) {
+	policyCfgs, err := c.policyReader.Read(param.PolicyConfigFilePaths)
+	if err != nil {
+		return nil, fmt.Errorf("read policy files: %w", err)
+	}
+	globalPolicyPaths := make(map[string]struct{}, len(param.PolicyConfigFilePaths))
+	for _, p := range param.PolicyConfigFilePaths {
+		globalPolicyPaths[p] = struct{}{}
+	}
+
+	dirs := []string{}
+	seen := map[string]struct{}{}
+	var installErr error
+	for _, cfgFile := range cfgFiles {
+		logger := logger.With("config_file_path", cfgFile.path)
+		policyCfgs, err := c.policyReader.Append(logger, cfgFile.path, policyCfgs, globalPolicyPaths)
+		if err != nil {
+			return nil, fmt.Errorf("append policy configs: %w", slogerr.With(err,
+				"config_file_path", cfgFile.path,
+			))
+		}
+		pkgDirs, err := c.installConfig(ctx, logger, param, cfgFile, policyCfgs)
+		if err != nil {
+			if !errors.Is(err, errInstallFailure) {
+				return nil, slogerr.With(err, "config_file_path", cfgFile.path) //nolint:wrapcheck
+			}
+			installErr = err
+		}
+		for _, dir := range pkgDirs {
+			if _, ok := seen[dir]; ok {
+				continue
+			}
+			seen[dir] = struct{}{}
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs, installErr
+}
+
+func (c *Controller) installConfig(ctx context.Context, logger *slog.Logger, param *config.Param, cfgFile *configFile, policyCfgs []*policy.Config) ([]string, error) {
+	checksums, updateChecksum, err := checksum.Open(
+		logger, cfgFile.path, param.ChecksumEnabled(cfgFile.cfg))
+	if err != nil {
+		return nil, fmt.Errorf("read a checksum JSON: %w", err)
+	}
+	defer updateChecksum()
+
+	registryContents, err := c.registryInstaller.InstallRegistries(ctx, logger, cfgFile.cfg, cfgFile.path, checksums)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	var installErr error
+	if err := c.packageInstaller.InstallPackages(ctx, logger, &installpackage.ParamInstallPackages{
+		Config:          cfgFile.cfg,
+		Registries:      registryContents,
+		ConfigFilePath:  cfgFile.path,
+		SkipLink:        true,
+		Tags:            param.Tags,
+		ExcludedTags:    param.ExcludedTags,
+		PolicyConfigs:   policyCfgs,
+		Checksums:       checksums,
+		RequireChecksum: cfgFile.cfg.RequireChecksum(param.EnforceRequireChecksum, param.RequireChecksum),
+		DisablePolicy:   param.DisablePolicy,
+	}); err != nil {
+		slogerr.WithError(logger, err).Error("install packages")
+		installErr = errInstallFailure
+	}
+
+	pkgs, _ := config.ListPackages(logger, cfgFile.cfg, c.runtime, registryContents)
+	links := map[string]string{}
+	for _, pkg := range pkgs {
+		if !aqua.FilterPackageByTag(pkg.Package, param.Tags, param.ExcludedTags) {
+			continue
+		}
+		for _, file := range pkg.PackageInfo.GetFiles() {
+			fileLinks, err := c.commandLinks(pkg, file)
+			if err != nil {
+				slogerr.WithError(logger, err).Warn("get the execution file path",
+					"package_name", pkg.Package.Name,
+					"package_version", pkg.Package.Version,
+					"file_name", file.Name)
+				continue
+			}
+			for cmd, exePath := range fileLinks {
+				// As with aqua-proxy, the first package in the configuration file wins.
+				if _, ok := links[cmd]; !ok {
+					links[cmd] = exePath
+				}
+			}
+		}
+	}
+	dir := c.binDir(param, cfgFile.path)
+	if err := syncLinks(logger, dir, links); err != nil {
+		return nil, fmt.Errorf("create links to commands: %w", err)
+	}
+	return []string{dir}, installErr
+}
+
+// buildPath prepends dirs to path.
+// Directories which the previous activation prepended are removed first,
+// so that leaving a directory deactivates packages of its configuration files.
+func buildPath(path, prevDirs string, dirs []string) string {
+	removed := map[string]struct{}{}
+	for _, dir := range filepath.SplitList(prevDirs) {
+		removed[dir] = struct{}{}
+	}
+	for _, dir := range dirs {
+		removed[dir] = struct{}{}
+	}
+	paths := append([]string{}, dirs...)
+	for _, p := range filepath.SplitList(path) {
+		if _, ok := removed[p]; ok {
+			continue
+		}
+		paths = append(paths, p)
+	}
+	return strings.Join(paths, string(os.PathListSeparator))
+}
@@ -0,0 +1,226 @@
+package env
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	finder "github.com/aquaproj/aqua/v2/pkg/config-finder"
+	reader "github.com/aquaproj/aqua/v2/pkg/config-reader"
+	registry "github.com/aquaproj/aqua/v2/pkg/install-registry"
+	"github.com/aquaproj/aqua/v2/pkg/installpackage"
+	"github.com/aquaproj/aqua/v2/pkg/policy"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+)
+
+func joinPath(paths ...string) string {
+	return strings.Join(paths, string(os.PathListSeparator))
+}
+
+func Test_buildPath(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name     string
+		path     string
+		prevDirs string
+		dirs     []string
+		exp      string
+	}{
+		{
+			name: "first activation",
+			path: joinPath("/usr/local/bin", "/usr/bin"),
+			dirs: []string{"/aqua/pkgs/gh/bin", "/aqua/pkgs/rg"},
+			exp:  joinPath("/aqua/pkgs/gh/bin", "/aqua/pkgs/rg", "/usr/local/bin", "/usr/bin"),
+		},
+		{
+			name:     "previous directories are removed",
+			path:     joinPath("/aqua/pkgs/gh/bin", "/aqua/pkgs/rg", "/usr/local/bin", "/usr/bin"),
+			prevDirs: joinPath("/aqua/pkgs/gh/bin", "/aqua/pkgs/rg"),
+			dirs:     []string{"/aqua/pkgs/rg"},
+			exp:      joinPath("/aqua/pkgs/rg", "/usr/local/bin", "/usr/bin"),
+		},
+		{
+			name:     "deactivate",
+			path:     joinPath("/aqua/pkgs/gh/bin", "/usr/bin"),
+			prevDirs: "/aqua/pkgs/gh/bin",
+			exp:      "/usr/bin",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(d.exp, buildPath(d.path, d.prevDirs, d.dirs)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_writeEnv(t *testing.T) {
+	t.Parallel()
+	vars := []*envVar{
+		{name: "PATH", value: joinPath("/aqua/pkgs/it's", "/usr/bin"), list: true},
+		{name: envState, value: "abc"},
+	}
+	data := []struct {
+		name  string
+		shell string
+		exp   string
+	}{
+		{
+			name:  "bash",
+			shell: "bash",
+			exp: `export PATH='` + joinPath("/aqua/pkgs/it'\\''s", "/usr/bin") + `';
+export AQUA_ENV_STATE='abc';
+`,
+		},
+		{
+			name:  "fish",
+			shell: "fish",
+			exp: `set -gx PATH '/aqua/pkgs/it\'s' '/usr/bin';
+set -gx AQUA_ENV_STATE 'abc';
+`,
+		},
+		{
+			name:  "powershell",
+			shell: "powershell",
+			exp: `$env:PATH = '` + joinPath("/aqua/pkgs/it''s", "/usr/bin") + `'
+$env:AQUA_ENV_STATE = 'abc'
+`,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			buf := &strings.Builder{}
+			if err := writeEnv(buf, d.shell, vars); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(d.exp, buf.String()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+type mockInstaller struct {
+	count int
+}
+
+func (m *mockInstaller) InstallPackages(ctx context.Context, logger *slog.Logger, param *installpackage.ParamInstallPackages) error {
+	m.count++
+	return nil
+}
+
+func TestController_Env(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	logger := slog.New(slog.DiscardHandler)
+	dir := t.TempDir()
+	testutil.WriteFiles(t, dir, map[string]string{
+		"workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: cli/cli@v2.40.0
+- name: jqlang/jq@jq-1.7.1
+  command_aliases:
+  - command: jq
+    alias: jq-1.7
+`,
+		"workspace/registry.yaml": `packages:
+- type: github_release
+  repo_owner: cli
+  repo_name: cli
+  asset: gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz
+  files:
+  - name: gh
+    src: gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}/bin/gh
+- type: github_release
+  repo_owner: jqlang
+  repo_name: jq
+  asset: jq-{{.OS}}-{{.Arch}}
+  format: raw
+`,
+	})
+	// The installer is mocked, so executables are created here.
+	// The executable of a raw asset is named after the asset, not the command.
+	pkgsDir := filepath.Join(dir, "root", "pkgs", "github_release", "github.com")
+	ghPath := filepath.Join(pkgsDir, "cli", "cli", "v2.40.0", "gh_2.40.0_linux_amd64.tar.gz", "gh_2.40.0_linux_amd64", "bin", "gh")
+	jqPath := filepath.Join(pkgsDir, "jqlang", "jq", "jq-1.7.1", "jq-linux-amd64", "jq-linux-amd64")
+	testutil.WriteFiles(t, dir, map[string]string{
+		"root/pkgs/github_release/github.com/cli/cli/v2.40.0/gh_2.40.0_linux_amd64.tar.gz/gh_2.40.0_linux_amd64/bin/gh": "",
+		"root/pkgs/github_release/github.com/jqlang/jq/jq-1.7.1/jq-linux-amd64/jq-linux-amd64":                          "",
+	})
+	param := &config.Param{
+		CWD:            filepath.Join(dir, "workspace"),
+		RootDir:        filepath.Join(dir, "root"),
+		MaxParallelism: 5,
+	}
+	rt := &runtime.Runtime{
+		GOOS:   "linux",
+		GOARCH: "amd64",
+	}
+	installer := &mockInstaller{}
+	env := map[string]string{
+		"PATH": "/usr/bin",
+	}
+	newController := func(buf *strings.Builder) *Controller {
+		ctrl := New(param, finder.NewConfigFinder(), reader.New(param), registry.New(param, nil, rt, nil, nil), installer, rt, &policy.MockReader{}, osenv.NewMock(env))
+		ctrl.stdout = buf
+		return ctrl
+	}
+
+	buf := &strings.Builder{}
+	if err := newController(buf).Env(ctx, logger, param, "bash", true); err != nil {
+		t.Fatal(err)
+	}
+	ctrl := newController(&strings.Builder{})
+	binDir := ctrl.binDir(param, filepath.Join(param.CWD, "aqua.yaml"))
+	if !strings.Contains(buf.String(), "export PATH='"+joinPath(binDir, "/usr/bin")+"';") {
+		t.Fatalf("PATH isn't set: %s", buf.String())
+	}
+	links := map[string]string{}
+	for _, cmd := range []string{"gh", "jq", "jq-1.7"} {
+		dest, err := os.Readlink(filepath.Join(binDir, cmd))
+		if err != nil {
+			t.Fatal(err)
+		}
+		links[cmd] = dest
+	}
+	if diff := cmp.Diff(map[string]string{
+		"gh":     ghPath,
+		"jq":     jqPath,
+		"jq-1.7": jqPath,
+	}, links); diff != "" {
+		t.Fatal(diff)
+	}
+	if installer.count != 1 {
+		t.Fatalf("packages must be installed once, but installed %d times", installer.count)
+	}
+
+	// The hook outputs nothing if configuration files aren't changed.
+	for line := range strings.SplitSeq(buf.String(), "\n") {
+		if s, ok := strings.CutPrefix(line, "export AQUA_ENV_STATE='"); ok {
+			env["AQUA_ENV_STATE"] = strings.TrimSuffix(s, "';")
+		}
+	}
+	buf = &strings.Builder{}
+	if err := newController(buf).Env(ctx, logger, param, "bash", true); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "" {
+		t.Fatalf("nothing must be output: %s", buf.String())
+	}
+	if installer.count != 1 {
+		t.Fatal("packages must not be installed")
+	}
+}
@@ -0,0 +1,100 @@
+package env
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// binDir returns the directory where links to commands of the configuration file are created.
+// Each configuration file and tag filter has its own directory,
+// so that shells in different directories don't overwrite each other's links.
+func (c *Controller) binDir(param *config.Param, cfgFilePath string) string {
+	h := sha256.New()
+	for _, s := range append(
+		append([]string{cfgFilePath}, slices.Sorted(maps.Keys(param.Tags))...),
+		slices.Sorted(maps.Keys(param.ExcludedTags))...) {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return filepath.Join(c.rootDir, "env", fmt.Sprintf("%x", h.Sum(nil))[:16], "bin")
+}
+
+// commandLinks returns commands of the file and their link targets.
+// Like links to aqua-proxy, command names have the command suffix, and command aliases are included.
+func (c *Controller) commandLinks(pkg *config.Package, file *registry.File) (map[string]string, error) {
+	exePath, err := pkg.ExePath(c.rootDir, file, c.runtime)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	if file.Link != "" {
+		exePath = filepath.Join(filepath.Dir(exePath), file.Link)
+	}
+	ext := ""
+	if c.runtime.IsWindows() {
+		// Windows finds commands by the extension.
+		ext = filepath.Ext(exePath)
+	}
+	links := map[string]string{
+		pkg.Package.CommandName(file.Name) + ext: exePath,
+	}
+	for _, alias := range pkg.Package.CommandAliases {
+		if alias.Command != file.Name || alias.NoLink {
+			continue
+		}
+		links[alias.Alias+ext] = exePath
+	}
+	return links, nil
+}
+
+// syncLinks makes dir have only the given symbolic links.
+// Links to files which don't exist aren't created, so that the command falls back to aqua-proxy.
+func syncLinks(logger *slog.Logger, dir string, links map[string]string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:mnd
+		return fmt.Errorf("create a directory: %w", slogerr.With(err, "dir", dir))
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read a directory: %w", slogerr.With(err, "dir", dir))
+	}
+	for _, entry := range entries {
+		p := filepath.Join(dir, entry.Name())
+		if dest, ok := links[entry.Name()]; ok {
+			if lnDest, err := os.Readlink(p); err == nil && lnDest == dest {
+				continue
+			}
+		}
+		if err := os.Remove(p); err != nil {
+			return fmt.Errorf("remove an old link: %w", slogerr.With(err, "link", p))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(links)) {
+		p := filepath.Join(dir, name)
+		dest := links[name]
+		if _, err := os.Stat(dest); err != nil {
+			logger.Debug("skip a link because the command isn't installed",
+				"command", name,
+				"exe_path", dest)
+			if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("remove a link: %w", slogerr.With(err, "link", p))
+			}
+			continue
+		}
+		if lnDest, err := os.Readlink(p); err == nil && lnDest == dest {
+			continue
+		}
+		if err := os.Symlink(dest, p); err != nil {
+			return fmt.Errorf("create a symbolic link: %w", slogerr.With(err, "link", p, "exe_path", dest))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+const (
+	shellBash       = "bash"
+	shellZsh        = "zsh"
+	shellFish       = "fish"
+	shellPowerShell = "powershell"
+)
+
+var errUnsupportedShell = errors.New("unsupported shell. bash, zsh, fish, and powershell are supported")
+
+func isSupportedShell(shell string) bool {
+	switch shell {
+	case shellBash, shellZsh, shellFish, shellPowerShell:
+		return true
+	default:
+		return false
+	}
+}
+
+// envVar is an environment variable set by the snippet.
+// If list is true, fish sets the variable as a list.
+type envVar struct {
+	name  string
+	value string
+	list  bool
+}
+
+func writeEnv(w io.Writer, shell string, vars []*envVar) error {
+	lines := make([]string, len(vars))
+	for i, v := range vars {
+		lines[i] = setEnv(shell, v)
+	}
+	if _, err := fmt.Fprintln(w, strings.Join(lines, "\n")); err != nil {
+		return fmt.Errorf("output a shell snippet: %w", err)
+	}
+	return nil
+}
+
+func setEnv(shell string, v *envVar) string {
+	switch shell {
+	case shellFish:
+		if !v.list {
+			return fmt.Sprintf("set -gx %s %s;", v.name, quoteFish(v.value))
+		}
+		elems := filepath.SplitList(v.value)
+		for i, e := range elems {
+			elems[i] = quoteFish(e)
+		}
+		return fmt.Sprintf("set -gx %s %s;", v.name, strings.Join(elems, " "))
+	case shellPowerShell:
+		return fmt.Sprintf("$env:%s = %s", v.name, quotePowerShell(v.value))
+	default:
+		return fmt.Sprintf("export %s=%s;", v.name, quote(v.value))
+	}
+}
+
+const (
+	hookBash = `_aqua_hook() {
+  local previous_exit_status=$?
+  eval "$(aqua env --hook bash)"
+  return $previous_exit_status
+}
+if [[ ";${PROMPT_COMMAND:-};" != *";_aqua_hook;"* ]]; then
+  PROMPT_COMMAND="_aqua_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi`
+	hookZsh = `_aqua_hook() {
+  eval "$(aqua env --hook zsh)"
+}
+typeset -ag precmd_functions
+if (( ! ${precmd_functions[(I)_aqua_hook]} )); then
+  precmd_functions=(_aqua_hook $precmd_functions)
+fi`
+	hookFish = `function _aqua_hook --on-event fish_prompt
+  aqua env --hook fish | source
+end`
+	hookPowerShell = `if (-not (Test-Path variable:global:_AquaPreviousPrompt)) {
+  $global:_AquaPreviousPrompt = $function:prompt
+  function global:prompt {
+    aqua env --hook powershell | Out-String | Invoke-Expression
+    & $global:_AquaPreviousPrompt
+  }
+}`
+)
+
+// WriteHook outputs a shell snippet which runs "aqua env --hook" before every prompt,
+// so that packages are activated when the current directory or configuration files are changed.
+func WriteHook(w io.Writer, shell string) error {
+	hooks := map[string]string{
+		shellBash:       hookBash,
+		shellZsh:        hookZsh,
+		shellFish:       hookFish,
+		shellPowerShell: hookPowerShell,
+	}
+	hook, ok := hooks[shell]
+	if !ok {
+		return slogerr.With(errUnsupportedShell, "shell", shell) //nolint:wrapcheck
+	}
+	if _, err := fmt.Fprintln(w, hook); err != nil {
+		return fmt.Errorf("output a shell snippet: %w", err)
+	}
+	return nil
+}
+
+// quote quotes s for POSIX shells.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// quoteFish quotes s for fish.
+func quoteFish(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+// quotePowerShell quotes s for PowerShell.
+func quotePowerShell(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
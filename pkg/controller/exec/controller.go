@@ -32,6 +32,7 @@ type Controller struct {
 
 type Vacuum interface {
 	Update(pkgPath string, timestamp time.Time) error
+	RecordUsage(pkgPath, pkgName, version, exeName string, timestamp time.Time) error
 }
 
 type Installer interface {
@@ -55,7 +56,7 @@ func New(pkgInstaller Installer, whichCtrl WhichController, executor Executor, o
 
 type Executor interface {
 	Exec(cmd *osexec.Cmd) (int, error)
-	ExecXSys(exePath, name string, args ...string) error
+	ExecXSys(exePath, name string, env []string, args ...string) error
 }
 
 type PolicyReader interface {
@@ -64,5 +65,5 @@ type PolicyReader interface {
 }
 
 type WhichController interface {
-	Which(ctx context.Context, logger *slog.Logger, param *config.Param, exeName string) (*which.FindResult, error)
+	WhichWithCache(ctx context.Context, logger *slog.Logger, param *config.Param, exeName string) (*which.FindResult, error)
 }
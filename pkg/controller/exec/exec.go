@@ -21,7 +21,7 @@ import (
 	"github.com/suzuki-shunsuke/slog-error/slogerr"
 )
 
-func (c *Controller) Exec(ctx context.Context, logger *slog.Logger, param *config.Param, exeName string, args ...string) (gErr error) { //nolint:cyclop
+func (c *Controller) Exec(ctx context.Context, logger *slog.Logger, param *config.Param, exeName string, args ...string) (gErr error) {
 	attrs := slogerr.NewAttrs(1)
 	logger = attrs.Add(logger, "exe_name", exeName)
 	defer func() {
@@ -30,22 +30,35 @@ func (c *Controller) Exec(ctx context.Context, logger *slog.Logger, param *confi
 		}
 	}()
 
-	policyCfgs, err := c.policyReader.Read(param.PolicyConfigFilePaths)
+	findResult, err := c.which.WhichWithCache(ctx, logger, param, exeName)
 	if err != nil {
-		return fmt.Errorf("read policy files: %w", err)
+		return err //nolint:wrapcheck
 	}
+	return c.execFindResult(ctx, logger, attrs, param, findResult, exeName, args...)
+}
 
-	globalPolicyPaths := make(map[string]struct{}, len(param.PolicyConfigFilePaths))
-	for _, p := range param.PolicyConfigFilePaths {
-		globalPolicyPaths[p] = struct{}{}
-	}
+// ExecFindResult installs the package of findResult if it isn't installed yet and executes the command.
+// This is used by "run" command, which resolves a package without configuration files.
+// If findResult.ConfigFilePath is empty, policy files and checksum files of configuration files are ignored.
+func (c *Controller) ExecFindResult(ctx context.Context, logger *slog.Logger, param *config.Param, findResult *which.FindResult, exeName string, args ...string) (gErr error) {
+	attrs := slogerr.NewAttrs(1)
+	logger = attrs.Add(logger, "exe_name", exeName)
+	defer func() {
+		if gErr != nil {
+			gErr = attrs.With(gErr)
+		}
+	}()
+	return c.execFindResult(ctx, logger, attrs, param, findResult, exeName, args...)
+}
 
-	findResult, err := c.which.Which(ctx, logger, param, exeName)
+func (c *Controller) execFindResult(ctx context.Context, logger *slog.Logger, attrs *slogerr.Attrs, param *config.Param, findResult *which.FindResult, exeName string, args ...string) error { //nolint:cyclop
+	policyCfgs, err := c.policyReader.Read(param.PolicyConfigFilePaths)
 	if err != nil {
-		return err //nolint:wrapcheck
+		return fmt.Errorf("read policy files: %w", err)
 	}
+
 	if findResult.Package == nil {
-		return c.execCommandWithRetry(ctx, logger, findResult.ExePath, exeName, args...)
+		return c.execCommandWithRetry(ctx, logger, findResult.ExePath, exeName, nil, args...)
 	}
 
 	logger = attrs.Add(logger,
@@ -54,9 +67,15 @@ func (c *Controller) Exec(ctx context.Context, logger *slog.Logger, param *confi
 		"registry", findResult.Package.Package.Registry,
 	)
 
-	policyCfgs, err = c.policyReader.Append(logger, findResult.ConfigFilePath, policyCfgs, globalPolicyPaths)
-	if err != nil {
-		return err //nolint:wrapcheck
+	if findResult.ConfigFilePath != "" {
+		globalPolicyPaths := make(map[string]struct{}, len(param.PolicyConfigFilePaths))
+		for _, p := range param.PolicyConfigFilePaths {
+			globalPolicyPaths[p] = struct{}{}
+		}
+		policyCfgs, err = c.policyReader.Append(logger, findResult.ConfigFilePath, policyCfgs, globalPolicyPaths)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
 	}
 
 	if param.DisableLazyInstall {
@@ -68,7 +87,7 @@ func (c *Controller) Exec(ctx context.Context, logger *slog.Logger, param *confi
 		return err
 	}
 
-	if err := c.updateTimestamp(ctx, findResult.Package); err != nil {
+	if err := c.updateTimestamp(ctx, findResult.Package, exeName); err != nil {
 		slogerr.WithError(logger, err).Warn("update the last used datetime")
 	}
 	exeName, exePath, args, err := c.wrapExec(exeName, findResult.ExePath, args...)
@@ -76,7 +95,13 @@ func (c *Controller) Exec(ctx context.Context, logger *slog.Logger, param *confi
 		return err
 	}
 
-	return c.execCommandWithRetry(ctx, logger, exePath, exeName, args...)
+	var env []string
+	if len(findResult.Env) != 0 {
+		logger.Debug("set environment variables of the package", "env", findResult.Env)
+		env = osexec.Environ(findResult.Env)
+	}
+
+	return c.execCommandWithRetry(ctx, logger, exePath, exeName, env, args...)
 }
 
 func (c *Controller) wrapExec(exeName, exePath string, args ...string) (string, string, []string, error) {
@@ -99,21 +124,25 @@ func wrapExec(lookPath func(exeName string) (string, error), exeName, exePath st
 	return exeJava, p, append([]string{flagJar, exePath}, args...), nil
 }
 
-func (c *Controller) updateTimestamp(ctx context.Context, pkg *config.Package) error {
+func (c *Controller) updateTimestamp(ctx context.Context, pkg *config.Package, exeName string) error {
 	pkgPath, err := pkg.PkgPath(runtime.New(ctx))
 	if err != nil {
 		return fmt.Errorf("get a package path: %w", err)
 	}
-	if err := c.vacuum.Update(pkgPath, time.Now()); err != nil {
+	now := time.Now()
+	if err := c.vacuum.Update(pkgPath, now); err != nil {
 		return fmt.Errorf("update the last used datetime: %w", err)
 	}
+	if err := c.vacuum.RecordUsage(pkgPath, pkg.Package.Name, pkg.Package.Version, exeName, now); err != nil {
+		return fmt.Errorf("record usage statistics: %w", err)
+	}
 	return nil
 }
 
 func (c *Controller) install(ctx context.Context, logger *slog.Logger, findResult *which.FindResult, policies []*policy.Config, param *config.Param) error {
 	checksums, updateChecksum, err := checksum.Open(
 		logger, findResult.ConfigFilePath,
-		param.ChecksumEnabled(findResult.Config))
+		findResult.ConfigFilePath != "" && param.ChecksumEnabled(findResult.Config))
 	if err != nil {
 		return fmt.Errorf("read a checksum JSON: %w", err)
 	}
@@ -156,15 +185,18 @@ func wait(ctx context.Context, duration time.Duration) error {
 
 var errFailedToStartProcess = errors.New("it failed to start the process")
 
-func (c *Controller) execCommand(ctx context.Context, exePath, name string, args ...string) (bool, error) {
+// execCommand executes the command.
+// If env is nil, the environment variables of aqua are inherited.
+func (c *Controller) execCommand(ctx context.Context, exePath, name string, env []string, args ...string) (bool, error) {
 	if c.enabledXSysExec {
-		if err := c.executor.ExecXSys(exePath, name, args...); err != nil {
+		if err := c.executor.ExecXSys(exePath, name, env, args...); err != nil {
 			return true, fmt.Errorf("call execve(2): %w", err)
 		}
 		return false, nil
 	}
 	cmd := osexec.Command(ctx, exePath, args...)
 	cmd.Args[0] = name
+	cmd.Env = env
 	if exitCode, err := c.executor.Exec(cmd); err != nil {
 		// https://pkg.go.dev/os#ProcessState.ExitCode
 		// > ExitCode returns the exit code of the exited process,
@@ -177,10 +209,10 @@ func (c *Controller) execCommand(ctx context.Context, exePath, name string, args
 	return false, nil
 }
 
-func (c *Controller) execCommandWithRetry(ctx context.Context, logger *slog.Logger, exePath, name string, args ...string) error {
+func (c *Controller) execCommandWithRetry(ctx context.Context, logger *slog.Logger, exePath, name string, env []string, args ...string) error {
 	for i := range 10 {
 		logger.Debug("execute the command")
-		retried, err := c.execCommand(ctx, exePath, name, args...)
+		retried, err := c.execCommand(ctx, exePath, name, env, args...)
 		if !retried {
 			return err
 		}
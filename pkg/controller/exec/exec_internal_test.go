@@ -37,7 +37,7 @@ func TestController_execCommand(t *testing.T) {
 				stderr:   os.Stderr,
 				executor: d.executor,
 			}
-			err := ctrl.execCommandWithRetry(ctx, logger, d.exePath, d.exeName, d.args...)
+			err := ctrl.execCommandWithRetry(ctx, logger, d.exePath, d.exeName, nil, d.args...)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/aquaproj/aqua/v2/pkg/asset"
@@ -240,6 +242,7 @@ func (c *Controller) patchRelease(logger *slog.Logger, pkgInfo *registry.Package
 			break
 		}
 	}
+	pgpFound := false
 	if len(checksumNames) > 0 && pkgInfo.Checksum == nil {
 		for checksumName := range checksumNames {
 			chksum := checksum.GetChecksumConfigFromFilename(checksumName, tagName)
@@ -247,11 +250,23 @@ func (c *Controller) patchRelease(logger *slog.Logger, pkgInfo *registry.Package
 				assetInfo := asset.ParseAssetName(checksumName, tagName)
 				chksum.Asset = assetInfo.Template
 				chksum.Cosign = checkChecksumCosign(pkgInfo, checksumName, assetNames)
+				// .sig is also used by Cosign, so it's regarded as an OpenPGP signature only if Cosign isn't found.
+				if suffix := findPGPSignature(assetNames, checksumName, chksum.Cosign == nil); suffix != "" {
+					pgpFound = suggestPGP(logger, assetNames, checksumName+suffix)
+				}
 				pkgInfo.Checksum = chksum
 				break
 			}
 		}
 	}
+	if !pgpFound {
+		for _, assetName := range slices.Sorted(maps.Keys(assetNames)) {
+			if suffix := findPGPSignature(assetNames, assetName, false); suffix != "" {
+				suggestPGP(logger, assetNames, "{{.Asset}}"+suffix)
+				break
+			}
+		}
+	}
 	asset.ParseAssetInfos(pkgInfo, assetInfos)
 }
 
@@ -393,3 +408,53 @@ func checkChecksumCosign(pkgInfo *registry.PackageInfo, checksumAssetName string
 	)
 	return cosign
 }
+
+func findPGPSignature(assetNames map[string]struct{}, assetName string, sig bool) string {
+	suffixes := []string{".asc", ".gpg", ".gpgsig"}
+	if sig {
+		suffixes = append(suffixes, ".sig")
+	}
+	for _, suf := range suffixes {
+		if _, ok := assetNames[assetName+suf]; ok {
+			return suf
+		}
+	}
+	return ""
+}
+
+// findPGPPublicKey finds an OpenPGP public key such as KEYS and pubkey.asc.
+// Asset names are sorted so that the result doesn't depend on the order of the map iteration.
+func findPGPPublicKey(assetNames map[string]struct{}) string {
+	for _, assetName := range slices.Sorted(maps.Keys(assetNames)) {
+		s := strings.ToLower(assetName)
+		if s == "keys" || s == "keys.asc" {
+			return assetName
+		}
+		if !strings.Contains(s, "key") {
+			continue
+		}
+		for _, ext := range []string{".asc", ".gpg", ".pgp"} {
+			if strings.HasSuffix(s, ext) {
+				return assetName
+			}
+		}
+	}
+	return ""
+}
+
+// suggestPGP outputs a log to suggest OpenPGP verification if a public key is found in release assets.
+// It returns true if the log is output.
+// A pgp block isn't generated because fingerprints of the public key can't be detected,
+// and a downloaded public key without fingerprints can't be used for verification.
+// Maintainers should add it with fingerprints.
+func suggestPGP(logger *slog.Logger, assetNames map[string]struct{}, signatureAsset string) bool {
+	pubKeyAssetName := findPGPPublicKey(assetNames)
+	if pubKeyAssetName == "" {
+		return false
+	}
+	logger.Info("an OpenPGP signature and a public key are found. Please add pgp with fingerprints of the public key",
+		"signature_asset", signatureAsset,
+		"public_key_asset", pubKeyAssetName,
+		"doc", "https://aquaproj.github.io/docs/reference/security/pgp")
+	return true
+}
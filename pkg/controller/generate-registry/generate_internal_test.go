@@ -460,3 +460,101 @@ func TestGetChecksum(t *testing.T) { //nolint:funlen
 		})
 	}
 }
+
+func TestFindPGPSignature(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		assetNames map[string]struct{}
+		sig        bool
+		want       string
+	}{
+		{
+			name: "asc",
+			assetNames: map[string]struct{}{
+				fileChecksumsTxt:        {},
+				"checksums.txt.asc":     {},
+				fileChecksumsTxtSig:     {},
+				"checksums.txt.unknown": {},
+			},
+			want: ".asc",
+		},
+		{
+			name: "sig is ignored if cosign is found",
+			assetNames: map[string]struct{}{
+				fileChecksumsTxt:    {},
+				fileChecksumsTxtSig: {},
+			},
+		},
+		{
+			name: "sig",
+			assetNames: map[string]struct{}{
+				fileChecksumsTxt:    {},
+				fileChecksumsTxtSig: {},
+			},
+			sig:  true,
+			want: ".sig",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := findPGPSignature(tt.assetNames, fileChecksumsTxt, tt.sig); got != tt.want {
+				t.Errorf("findPGPSignature() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindPGPPublicKey(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		assetNames map[string]struct{}
+		want       string
+	}{
+		{
+			name: "KEYS",
+			assetNames: map[string]struct{}{
+				"checksums.txt.asc": {},
+				"KEYS":              {},
+			},
+			want: "KEYS",
+		},
+		{
+			name: "versioned public key",
+			assetNames: map[string]struct{}{
+				"checksums.txt.asc":       {},
+				"foo-v1.0.0-pubkey.asc":   {},
+				"foo-v1.0.0-linux.tar.gz": {},
+			},
+			want: "foo-v1.0.0-pubkey.asc",
+		},
+		{
+			name: "multiple public keys",
+			assetNames: map[string]struct{}{
+				"signing-key.pgp": {},
+				"pubkey.asc":      {},
+				"release-key.gpg": {},
+				"key.gpg":         {},
+			},
+			want: "key.gpg",
+		},
+		{
+			name: "no public key",
+			assetNames: map[string]struct{}{
+				"checksums.txt.asc": {},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			for range 10 {
+				if got := findPGPPublicKey(tt.assetNames); got != tt.want {
+					t.Fatalf("findPGPPublicKey() = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
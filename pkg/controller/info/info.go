@@ -102,6 +102,7 @@ func (c *Controller) Info(_ context.Context, _ *slog.Logger, param *config.Param
 		"AQUA_DISABLE_SLSA",
 		"AQUA_DISABLE_GITHUB_ARTIFACT_ATTESTATION",
 		"AQUA_DISABLE_TRACKING",
+		"AQUA_ENABLE_STATS",
 		"AQUA_EXPERIMENTAL_X_SYS_EXEC",
 		"AQUA_GENERATE_WITH_DETAIL",
 		"AQUA_GLOBAL_CONFIG",
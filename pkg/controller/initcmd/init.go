@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	finder "github.com/aquaproj/aqua/v2/pkg/config-finder"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
 	"github.com/aquaproj/aqua/v2/pkg/osfile"
 	"github.com/suzuki-shunsuke/slog-error/slogerr"
 )
@@ -75,7 +76,7 @@ func (c *Controller) Init(ctx context.Context, logger *slog.Logger, cfgFilePath
 		}
 	}
 
-	registryVersion := "v4.546.0" // renovate: depName=aquaproj/aqua-registry
+	registryVersion := aqua.StandardRegistryVersion
 	release, _, err := c.github.GetLatestRelease(ctx, "aquaproj", "aqua-registry")
 	if err != nil {
 		slogerr.WithError(logger, err).Warn("get the latest release", "repo_owner", "aquaproj", "repo_name", "aqua-registry")
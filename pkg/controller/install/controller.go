@@ -2,7 +2,9 @@ package install
 
 import (
 	"context"
+	"io"
 	"log/slog"
+	"os"
 
 	"github.com/aquaproj/aqua/v2/pkg/checksum"
 	"github.com/aquaproj/aqua/v2/pkg/config"
@@ -24,6 +26,7 @@ type Controller struct {
 	excludedTags      map[string]struct{}
 	policyReader      PolicyReader
 	skipLink          bool
+	stdout            io.Writer
 }
 
 func New(param *config.Param, configFinder ConfigFinder, configReader ConfigReader, registryInstaller RegistryInstaller, pkgInstaller Installer, rt *runtime.Runtime, policyReader PolicyReader) *Controller {
@@ -38,6 +41,7 @@ func New(param *config.Param, configFinder ConfigFinder, configReader ConfigRead
 		tags:              param.Tags,
 		excludedTags:      param.ExcludedTags,
 		policyReader:      policyReader,
+		stdout:            os.Stdout,
 	}
 }
 
@@ -45,6 +49,7 @@ type Installer interface {
 	InstallPackage(ctx context.Context, logger *slog.Logger, param *installpackage.ParamInstallPackage) error
 	InstallPackages(ctx context.Context, logger *slog.Logger, param *installpackage.ParamInstallPackages) error
 	InstallProxy(ctx context.Context, logger *slog.Logger) error
+	PlanPackages(logger *slog.Logger, param *installpackage.ParamInstallPackages) ([]*installpackage.PackagePlan, error)
 }
 
 type ConfigReader interface {
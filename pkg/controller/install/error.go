@@ -0,0 +1,8 @@
+package install
+
+import "errors"
+
+var (
+	errPlanFailure         = errors.New("some packages can't be installed")
+	errUnknownOutputFormat = errors.New("output format must be either text or json")
+)
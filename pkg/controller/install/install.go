@@ -20,6 +20,9 @@ import (
 // Install is a main method of "install" command.
 // This method is also called by "cp" command.
 func (c *Controller) Install(ctx context.Context, logger *slog.Logger, param *config.Param) error {
+	if param.DryRun {
+		return c.plan(ctx, logger, param)
+	}
 	if param.Dest == "" {
 		// Create a "bin" directory and install aqua-proxy in advance.
 		// If param.Dest isn't empty, this means this method is called by "copy" command.
@@ -32,6 +35,20 @@ func (c *Controller) Install(ctx context.Context, logger *slog.Logger, param *co
 		}
 	}
 
+	return c.walkConfigs(logger, param, func(cfgFilePath string, policyCfgs []*policy.Config) error {
+		if err := c.install(ctx, logger, cfgFilePath, policyCfgs, param); err != nil {
+			return fmt.Errorf("install packages: %w", slogerr.With(err,
+				"config_file_path", cfgFilePath,
+			))
+		}
+		return nil
+	})
+}
+
+// walkConfigs calls fn for each configuration file which "install" command handles,
+// with the policies applied to the configuration file.
+// Global configuration files are handled only if param.All is true.
+func (c *Controller) walkConfigs(logger *slog.Logger, param *config.Param, fn func(cfgFilePath string, policyCfgs []*policy.Config) error) error {
 	policyCfgs, err := c.policyReader.Read(param.PolicyConfigFilePaths)
 	if err != nil {
 		return fmt.Errorf("read policy files: %w", err)
@@ -49,14 +66,29 @@ func (c *Controller) Install(ctx context.Context, logger *slog.Logger, param *co
 				"config_file_path", cfgFilePath,
 			))
 		}
-		if err := c.install(ctx, logger, cfgFilePath, policyCfgs, param); err != nil {
-			return fmt.Errorf("install packages: %w", slogerr.With(err,
+		if err := fn(cfgFilePath, policyCfgs); err != nil {
+			return err
+		}
+	}
+
+	if !param.All {
+		return nil
+	}
+	for _, cfgFilePath := range param.GlobalConfigFilePaths {
+		if _, err := os.Stat(cfgFilePath); err != nil {
+			continue
+		}
+		policyCfgs, err := c.policyReader.Append(logger, cfgFilePath, policyCfgs, globalPolicyPaths)
+		if err != nil {
+			return fmt.Errorf("append policy configs: %w", slogerr.With(err,
 				"config_file_path", cfgFilePath,
 			))
 		}
+		if err := fn(cfgFilePath, policyCfgs); err != nil {
+			return err
+		}
 	}
-
-	return c.installAll(ctx, logger, param, policyCfgs, globalPolicyPaths)
+	return nil
 }
 
 func (c *Controller) mkBinDir() error {
@@ -71,54 +103,46 @@ func (c *Controller) mkBinDir() error {
 	return nil
 }
 
-func (c *Controller) installAll(ctx context.Context, logger *slog.Logger, param *config.Param, policyConfigs []*policy.Config, globalPolicyPaths map[string]struct{}) error {
-	if !param.All {
-		return nil
+func (c *Controller) install(ctx context.Context, logger *slog.Logger, cfgFilePath string, policyConfigs []*policy.Config, param *config.Param) error {
+	pkgsParam, updateChecksum, err := c.newParamInstallPackages(ctx, logger, cfgFilePath, policyConfigs, param)
+	if updateChecksum != nil {
+		defer updateChecksum()
 	}
-	for _, cfgFilePath := range param.GlobalConfigFilePaths {
-		if _, err := os.Stat(cfgFilePath); err != nil {
-			continue
-		}
-		policyConfigs, err := c.policyReader.Append(logger, cfgFilePath, policyConfigs, globalPolicyPaths)
-		if err != nil {
-			return fmt.Errorf("append policy configs: %w", slogerr.With(err,
-				"config_file_path", cfgFilePath,
-			))
-		}
-		if err := c.install(ctx, logger, cfgFilePath, policyConfigs, param); err != nil {
-			return fmt.Errorf("install packages: %w", slogerr.With(err,
-				"config_file_path", cfgFilePath,
-			))
-		}
+	if err != nil {
+		return err
 	}
-	return nil
+
+	return c.packageInstaller.InstallPackages(ctx, logger, pkgsParam) //nolint:wrapcheck
 }
 
-func (c *Controller) install(ctx context.Context, logger *slog.Logger, cfgFilePath string, policyConfigs []*policy.Config, param *config.Param) error {
+// newParamInstallPackages reads a configuration file and its checksum file and installs registries.
+// The returned function updates the checksum file.
+// It's returned even if installing registries fails once the checksum file is read,
+// and the caller decides whether the checksum file is updated. --dry-run never updates it.
+func (c *Controller) newParamInstallPackages(ctx context.Context, logger *slog.Logger, cfgFilePath string, policyConfigs []*policy.Config, param *config.Param) (*installpackage.ParamInstallPackages, func(), error) {
 	cfg := &aqua.Config{}
 	if cfgFilePath == "" {
-		return finder.ErrConfigFileNotFound
+		return nil, nil, finder.ErrConfigFileNotFound
 	}
 	if err := c.configReader.Read(logger, cfgFilePath, cfg); err != nil {
-		return err //nolint:wrapcheck
+		return nil, nil, err //nolint:wrapcheck
 	}
 	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("validate the configuration: %w", err)
+		return nil, nil, fmt.Errorf("validate the configuration: %w", err)
 	}
 
 	checksums, updateChecksum, err := checksum.Open(
 		logger, cfgFilePath, param.ChecksumEnabled(cfg))
 	if err != nil {
-		return fmt.Errorf("read a checksum JSON: %w", err)
+		return nil, nil, fmt.Errorf("read a checksum JSON: %w", err)
 	}
-	defer updateChecksum()
 
 	registryContents, err := c.registryInstaller.InstallRegistries(ctx, logger, cfg, cfgFilePath, checksums)
 	if err != nil {
-		return err //nolint:wrapcheck
+		return nil, updateChecksum, err //nolint:wrapcheck
 	}
 
-	return c.packageInstaller.InstallPackages(ctx, logger, &installpackage.ParamInstallPackages{ //nolint:wrapcheck
+	return &installpackage.ParamInstallPackages{
 		Config:          cfg,
 		Registries:      registryContents,
 		ConfigFilePath:  cfgFilePath,
@@ -129,5 +153,5 @@ func (c *Controller) install(ctx context.Context, logger *slog.Logger, cfgFilePa
 		Checksums:       checksums,
 		RequireChecksum: cfg.RequireChecksum(param.EnforceRequireChecksum, param.RequireChecksum),
 		DisablePolicy:   param.DisablePolicy,
-	})
+	}, updateChecksum, nil
 }
@@ -0,0 +1,117 @@
+package install
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/installpackage"
+	"github.com/aquaproj/aqua/v2/pkg/policy"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// Plan is the output of "aqua install --dry-run".
+type Plan struct {
+	Packages []*installpackage.PackagePlan `json:"packages"`
+}
+
+// plan resolves packages without downloading them and outputs what "aqua install" would do.
+// Registries are downloaded, but neither aqua-proxy, links, nor aqua-checksums.json are changed.
+func (c *Controller) plan(ctx context.Context, logger *slog.Logger, param *config.Param) error {
+	plan := &Plan{
+		Packages: []*installpackage.PackagePlan{},
+	}
+	failed := false
+	if err := c.walkConfigs(logger, param, func(cfgFilePath string, policyCfgs []*policy.Config) error {
+		pkgsParam, _, err := c.newParamInstallPackages(ctx, logger, cfgFilePath, policyCfgs, param)
+		if err != nil {
+			return fmt.Errorf("resolve packages: %w", slogerr.With(err,
+				"config_file_path", cfgFilePath,
+			))
+		}
+		plans, err := c.packageInstaller.PlanPackages(logger, pkgsParam)
+		if err != nil {
+			failed = true
+		}
+		plan.Packages = append(plan.Packages, plans...)
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, p := range plan.Packages {
+		if p.Error != "" {
+			failed = true
+		}
+	}
+	if err := outputPlan(c.stdout, param.OutputFormat, plan); err != nil {
+		return err
+	}
+	if failed {
+		return errPlanFailure
+	}
+	return nil
+}
+
+func outputPlan(w io.Writer, format string, plan *Plan) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(plan); err != nil {
+			return fmt.Errorf("encode the install plan as JSON: %w", err)
+		}
+		return nil
+	case "", "text":
+		for _, p := range plan.Packages {
+			outputPackagePlan(w, p)
+		}
+		return nil
+	default:
+		return slogerr.With(errUnknownOutputFormat, "format", format) //nolint:wrapcheck
+	}
+}
+
+func outputPackagePlan(w io.Writer, p *installpackage.PackagePlan) {
+	status := "install"
+	if p.Installed {
+		status = "already installed"
+	}
+	if p.Error != "" {
+		status = "error"
+	}
+	fmt.Fprintf(w, "%s@%s (registry: %s, type: %s) [%s]\n", p.Name, p.Version, p.Registry, p.Type, status)
+	fmt.Fprintf(w, "  config: %s\n", p.ConfigFilePath)
+	if p.Error != "" {
+		fmt.Fprintf(w, "  error: %s\n", p.Error)
+		return
+	}
+	if p.URL != "" {
+		fmt.Fprintf(w, "  source: %s\n", p.URL)
+	}
+	if p.Asset != "" {
+		fmt.Fprintf(w, "  asset: %s\n", p.Asset)
+	}
+	if p.ArtifactPath != "" {
+		fmt.Fprintf(w, "  local artifact: %s\n", p.ArtifactPath)
+	}
+	fmt.Fprintf(w, "  path: %s\n", p.PkgPath)
+	switch {
+	case p.Checksum != nil:
+		fmt.Fprintf(w, "  checksum: %s %s (%s)\n", p.Checksum.Algorithm, p.Checksum.Checksum, p.ChecksumSource)
+	case p.ChecksumSource != "":
+		fmt.Fprintf(w, "  checksum: (%s)\n", p.ChecksumSource)
+	}
+	if len(p.Verifications) != 0 {
+		fmt.Fprintf(w, "  verifications: %s\n", strings.Join(p.Verifications, ", "))
+	}
+	if len(p.ChecksumVerifications) != 0 {
+		fmt.Fprintf(w, "  checksum file verifications: %s\n", strings.Join(p.ChecksumVerifications, ", "))
+	}
+	for _, link := range p.Links {
+		fmt.Fprintf(w, "  link: %s -> %s\n", link.Path, link.Target)
+	}
+}
@@ -216,6 +216,9 @@ func (c *Controller) removePackage(logger *slog.Logger, rootDir string, pkg *reg
 		if err := c.removeMetadataPath(logger, rootDir, path); err != nil {
 			return err
 		}
+		if err := c.removeSharePath(logger, rootDir, path); err != nil {
+			return err
+		}
 	}
 	return gErr
 }
@@ -250,6 +253,22 @@ func (c *Controller) removeMetadataPath(logger *slog.Logger, rootDir string, pat
 	return nil
 }
 
+// removeSharePath removes links to shell completions and man pages of the package.
+func (c *Controller) removeSharePath(logger *slog.Logger, rootDir string, path string) error {
+	pkgPath := filepath.Join(config.ShareDir(rootDir), "pkgs", path)
+	arr, err := filepath.Glob(pkgPath)
+	if err != nil {
+		return fmt.Errorf("find directories: %w", err)
+	}
+	for _, p := range arr {
+		logger.Debug("removing a directory", "removed_path", p)
+		if err := os.RemoveAll(p); err != nil {
+			return fmt.Errorf("remove directories: %w", err)
+		}
+	}
+	return nil
+}
+
 func parsePkgName(pkgName string) (string, string) {
 	registryName, pkgName, ok := strings.Cut(pkgName, ",")
 	if ok {
@@ -289,5 +308,8 @@ func (c *Controller) removeAll(rootDir string) error {
 	if err := os.RemoveAll(filepath.Join(rootDir, "metadata")); err != nil {
 		return fmt.Errorf("remove all package metadata: %w", err)
 	}
+	if err := os.RemoveAll(config.ShareDir(rootDir)); err != nil {
+		return fmt.Errorf("remove all links to shell completions and man pages: %w", err)
+	}
 	return gErr
 }
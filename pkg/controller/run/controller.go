@@ -0,0 +1,57 @@
+package run
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/controller/which"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+)
+
+type Controller struct {
+	rootDir           string
+	runtime           *runtime.Runtime
+	configFinder      ConfigFinder
+	configReader      ConfigReader
+	registryInstaller RegistryInstaller
+	fuzzyGetter       FuzzyGetter
+	executor          Executor
+}
+
+func New(param *config.Param, configFinder ConfigFinder, configReader ConfigReader, registryInstaller RegistryInstaller, fuzzyGetter FuzzyGetter, executor Executor, rt *runtime.Runtime) *Controller {
+	return &Controller{
+		rootDir:           param.RootDir,
+		runtime:           rt,
+		configFinder:      configFinder,
+		configReader:      configReader,
+		registryInstaller: registryInstaller,
+		fuzzyGetter:       fuzzyGetter,
+		executor:          executor,
+	}
+}
+
+type ConfigFinder interface {
+	Finds(wd, configFilePath string) []string
+}
+
+type ConfigReader interface {
+	Read(logger *slog.Logger, configFilePath string, cfg *aqua.Config) error
+}
+
+type RegistryInstaller interface {
+	InstallRegistry(ctx context.Context, logger *slog.Logger, regist *aqua.Registry, cfgFilePath string, checksums *checksum.Checksums) (*registry.Config, error)
+}
+
+type FuzzyGetter interface {
+	Get(ctx context.Context, logger *slog.Logger, pkg *registry.PackageInfo, currentVersion string, useFinder bool, limit int) string
+}
+
+// Executor installs and executes a resolved package.
+// This is implemented by exec.Controller, so "run" command shares the way to execute commands with "exec" command.
+type Executor interface {
+	ExecFindResult(ctx context.Context, logger *slog.Logger, param *config.Param, findResult *which.FindResult, exeName string, args ...string) error
+}
@@ -0,0 +1,12 @@
+package run
+
+import "errors"
+
+var (
+	errRegistryNotFound    = errors.New("the registry isn't found")
+	errPackageNotFound     = errors.New("the package isn't found in the registry")
+	errVersionNotFound     = errors.New("failed to get the latest version of the package. Please specify the version")
+	errPackageNotSupported = errors.New("the package isn't supported on this environment")
+	errCommandNotFound     = errors.New("the package doesn't have the command")
+	errCommandIsRequired   = errors.New("the package has multiple commands. Please specify the command")
+)
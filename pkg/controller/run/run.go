@@ -0,0 +1,224 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/controller/which"
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// Param is the parameter of "run" command.
+type Param struct {
+	// Package is a package name with an optional version such as cli/cli@v2.0.0.
+	Package string
+	// Registry is a registry name. The default is "standard".
+	Registry string
+	// Command is an executed command. It's required if the package has multiple commands.
+	Command string
+	Args    []string
+}
+
+// Run installs a package and executes its command without configuration files.
+//
+// The package is looked up in the registry of the nearest configuration file which has the registry.
+// The policies and checksum settings of the configuration file are applied.
+// If no configuration file has the registry "standard", the standard registry is used.
+// If the version is omitted, the version in the configuration file is used if the package is there,
+// otherwise the latest version is used.
+func (c *Controller) Run(ctx context.Context, logger *slog.Logger, param *config.Param, runParam *Param) error {
+	pkgName, version, _ := strings.Cut(runParam.Package, "@")
+	registryName := runParam.Registry
+	if registryName == "" {
+		registryName = aqua.RegistryTypeStandard
+	}
+	logger = logger.With(
+		"package_name", pkgName,
+		"registry", registryName,
+	)
+	findResult, err := c.resolve(ctx, logger, param, registryName, pkgName, version, runParam.Command)
+	if err != nil {
+		return slogerr.With(err, //nolint:wrapcheck
+			"package_name", pkgName,
+			"registry", registryName,
+		)
+	}
+	return c.executor.ExecFindResult(ctx, logger, param, findResult, findResult.File.Name, runParam.Args...) //nolint:wrapcheck
+}
+
+func (c *Controller) resolve(ctx context.Context, logger *slog.Logger, param *config.Param, registryName, pkgName, version, cmdName string) (*which.FindResult, error) { //nolint:cyclop
+	cfgFilePath, cfg, err := c.findConfig(logger, param, registryName)
+	if err != nil {
+		return nil, err
+	}
+	rgst, ok := cfg.Registries[registryName]
+	if !ok {
+		return nil, errRegistryNotFound
+	}
+
+	pkgInfo, err := c.getPackageInfo(ctx, logger, param, cfgFilePath, cfg, rgst, pkgName)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == "" {
+		version = configuredVersion(cfg, registryName, pkgInfo.GetName())
+	}
+	if version == "" {
+		version = c.fuzzyGetter.Get(ctx, logger, pkgInfo, "", false, 0)
+		if version == "" {
+			return nil, errVersionNotFound
+		}
+		logger.Info("run the latest version", "package_version", version)
+	}
+
+	pkgInfo, err = pkgInfo.Override(logger, version, c.runtime)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate version constraints: %w", err)
+	}
+	supported, err := pkgInfo.CheckSupported(c.runtime, c.runtime.GOOS+"/"+c.runtime.GOARCH)
+	if err != nil {
+		return nil, fmt.Errorf("check if the package is supported: %w", err)
+	}
+	if !supported {
+		return nil, slogerr.With(errPackageNotSupported, "env", c.runtime.GOOS+"/"+c.runtime.GOARCH) //nolint:wrapcheck
+	}
+
+	pkg := &config.Package{
+		Package: &aqua.Package{
+			Name:     pkgInfo.GetName(),
+			Registry: registryName,
+			Version:  version,
+			FilePath: cfgFilePath,
+		},
+		PackageInfo: pkgInfo,
+		Registry:    rgst,
+	}
+	if err := pkg.ApplyVars(); err != nil {
+		return nil, fmt.Errorf("apply package variables: %w", err)
+	}
+
+	file, err := selectFile(pkgInfo, cmdName)
+	if err != nil {
+		return nil, err
+	}
+	exePath, err := pkg.ExePath(c.rootDir, file, c.runtime)
+	if err != nil {
+		return nil, fmt.Errorf("get the execution file path: %w", err)
+	}
+	if file.Link != "" {
+		exePath = filepath.Join(filepath.Dir(exePath), file.Link)
+	}
+	env, err := pkg.RenderEnv(c.rootDir, c.runtime)
+	if err != nil {
+		return nil, fmt.Errorf("render environment variables: %w", err)
+	}
+	return &which.FindResult{
+		Package:        pkg,
+		File:           file,
+		Config:         cfg,
+		ExePath:        exePath,
+		ConfigFilePath: cfgFilePath,
+		Env:            env,
+	}, nil
+}
+
+// findConfig returns the first configuration file which has the registry.
+// Local configuration files are searched first, then global configuration files.
+// If no configuration file has the registry, an empty configuration with the standard registry is returned.
+func (c *Controller) findConfig(logger *slog.Logger, param *config.Param, registryName string) (string, *aqua.Config, error) {
+	var cfgFilePaths []string
+	if param.ConfigFilePath != "" {
+		cfgFilePaths = []string{osfile.Abs(param.CWD, param.ConfigFilePath)}
+	}
+	cfgFilePaths = append(cfgFilePaths, c.configFinder.Finds(param.CWD, "")...)
+	for _, cfgFilePath := range param.GlobalConfigFilePaths {
+		if f, err := osfile.Exists(cfgFilePath); err != nil {
+			return "", nil, err //nolint:wrapcheck
+		} else if f {
+			cfgFilePaths = append(cfgFilePaths, cfgFilePath)
+		}
+	}
+	for _, cfgFilePath := range cfgFilePaths {
+		cfg := &aqua.Config{}
+		if err := c.configReader.Read(logger, cfgFilePath, cfg); err != nil {
+			return "", nil, fmt.Errorf("read a configuration file: %w", slogerr.With(err, "config_file_path", cfgFilePath))
+		}
+		if _, ok := cfg.Registries[registryName]; ok {
+			logger.Debug("use the registry in the configuration file", "config_file_path", cfgFilePath)
+			return cfgFilePath, cfg, nil
+		}
+	}
+	if registryName != aqua.RegistryTypeStandard {
+		return "", &aqua.Config{}, nil
+	}
+	logger.Debug("no configuration file has the standard registry. Use the standard registry " + aqua.StandardRegistryVersion)
+	return "", &aqua.Config{
+		Registries: aqua.Registries{
+			aqua.RegistryTypeStandard: aqua.NewStandardRegistry(),
+		},
+	}, nil
+}
+
+func (c *Controller) getPackageInfo(ctx context.Context, logger *slog.Logger, param *config.Param, cfgFilePath string, cfg *aqua.Config, rgst *aqua.Registry, pkgName string) (*registry.PackageInfo, error) {
+	if err := rgst.Validate(); err != nil {
+		return nil, fmt.Errorf("validate the registry: %w", err)
+	}
+	// The checksum file is updated before the command is executed, because the process may be replaced by execve(2).
+	checksums, updateChecksum, err := checksum.Open(
+		logger, cfgFilePath,
+		cfgFilePath != "" && param.ChecksumEnabled(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("read a checksum JSON: %w", err)
+	}
+	defer updateChecksum()
+	rc, err := c.registryInstaller.InstallRegistry(ctx, logger, rgst, cfgFilePath, checksums)
+	if err != nil {
+		return nil, fmt.Errorf("install a registry: %w", err)
+	}
+	pkgInfo := rc.Package(logger, pkgName)
+	if pkgInfo == nil {
+		return nil, errPackageNotFound
+	}
+	return pkgInfo, nil
+}
+
+// configuredVersion returns the version of the package in the configuration file.
+func configuredVersion(cfg *aqua.Config, registryName, pkgName string) string {
+	for _, pkg := range cfg.Packages {
+		if pkg.Registry == registryName && pkg.Name == pkgName {
+			return pkg.Version
+		}
+	}
+	return ""
+}
+
+// selectFile returns the file of the command.
+// If cmdName is empty, the package must have only one command.
+func selectFile(pkgInfo *registry.PackageInfo, cmdName string) (*registry.File, error) {
+	files := pkgInfo.GetFiles()
+	if cmdName == "" {
+		if len(files) == 1 {
+			return files[0], nil
+		}
+		names := make([]string, len(files))
+		for i, file := range files {
+			names[i] = file.Name
+		}
+		return nil, slogerr.With(errCommandIsRequired, "commands", strings.Join(names, ", ")) //nolint:wrapcheck
+	}
+	for _, file := range files {
+		if file.Name == cmdName {
+			return file, nil
+		}
+	}
+	return nil, slogerr.With(errCommandNotFound, "exe_name", cmdName) //nolint:wrapcheck
+}
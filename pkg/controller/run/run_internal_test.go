@@ -0,0 +1,225 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	finder "github.com/aquaproj/aqua/v2/pkg/config-finder"
+	reader "github.com/aquaproj/aqua/v2/pkg/config-reader"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/controller/which"
+	rgst "github.com/aquaproj/aqua/v2/pkg/install-registry"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+type mockExecutor struct {
+	findResult *which.FindResult
+	exeName    string
+	args       []string
+}
+
+func (m *mockExecutor) ExecFindResult(ctx context.Context, logger *slog.Logger, param *config.Param, findResult *which.FindResult, exeName string, args ...string) error {
+	m.findResult = findResult
+	m.exeName = exeName
+	m.args = args
+	return nil
+}
+
+type mockFuzzyGetter struct {
+	version string
+}
+
+func (m *mockFuzzyGetter) Get(ctx context.Context, logger *slog.Logger, pkg *registry.PackageInfo, currentVersion string, useFinder bool, limit int) string {
+	return m.version
+}
+
+func TestController_Run(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name       string
+		param      *Param
+		latest     string
+		isErr      error
+		expVersion string
+		expExe     string
+		expPath    string
+	}{
+		{
+			name: "version is specified",
+			param: &Param{
+				Package: "cli/cli@v2.0.0",
+				Args:    []string{"version"},
+			},
+			expVersion: "v2.0.0",
+			expExe:     "gh",
+			expPath:    "github_release/github.com/cli/cli/v2.0.0/gh_2.0.0_linux_amd64.tar.gz/gh_2.0.0_linux_amd64/bin/gh",
+		},
+		{
+			name: "version in the configuration file",
+			param: &Param{
+				Package: "cli/cli",
+				Args:    []string{"version"},
+			},
+			expVersion: "v2.40.0",
+			expExe:     "gh",
+			expPath:    "github_release/github.com/cli/cli/v2.40.0/gh_2.40.0_linux_amd64.tar.gz/gh_2.40.0_linux_amd64/bin/gh",
+		},
+		{
+			name: "latest version",
+			param: &Param{
+				Package: "kubernetes-sigs/krew",
+				Command: "kubectl-krew",
+				Args:    []string{"version"},
+			},
+			latest:     "v0.4.4",
+			expVersion: "v0.4.4",
+			expExe:     "kubectl-krew",
+			expPath:    "github_release/github.com/kubernetes-sigs/krew/v0.4.4/krew-linux_amd64.tar.gz/krew-linux_amd64",
+		},
+		{
+			name: "command is required",
+			param: &Param{
+				Package: "kubernetes-sigs/krew@v0.4.4",
+			},
+			isErr: errCommandIsRequired,
+		},
+		{
+			name: "command isn't found",
+			param: &Param{
+				Package: "kubernetes-sigs/krew@v0.4.4",
+				Command: "foo",
+			},
+			isErr: errCommandNotFound,
+		},
+		{
+			name: "latest version isn't found",
+			param: &Param{
+				Package: "kubernetes-sigs/krew",
+				Command: "kubectl-krew",
+			},
+			isErr: errVersionNotFound,
+		},
+		{
+			name: "package isn't found",
+			param: &Param{
+				Package: "foo/bar@v1.0.0",
+			},
+			isErr: errPackageNotFound,
+		},
+		{
+			name: "registry isn't found",
+			param: &Param{
+				Package:  "cli/cli@v2.0.0",
+				Registry: "foo",
+			},
+			isErr: errRegistryNotFound,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+			logger := slog.New(slog.DiscardHandler)
+			dir := t.TempDir()
+			testutil.WriteFiles(t, dir, map[string]string{
+				"workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: cli/cli@v2.40.0
+`,
+				"workspace/registry.yaml": `packages:
+- type: github_release
+  repo_owner: cli
+  repo_name: cli
+  asset: gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz
+  files:
+  - name: gh
+    src: gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}/bin/gh
+- type: github_release
+  repo_owner: kubernetes-sigs
+  repo_name: krew
+  asset: krew-{{.OS}}_{{.Arch}}.tar.gz
+  files:
+  - name: kubectl-krew
+    src: krew-{{.OS}}_{{.Arch}}
+  - name: krew
+    src: krew-{{.OS}}_{{.Arch}}
+`,
+			})
+			param := &config.Param{
+				CWD:            filepath.Join(dir, "workspace"),
+				RootDir:        filepath.Join(dir, "root"),
+				MaxParallelism: 5,
+			}
+			rt := &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			}
+			executor := &mockExecutor{}
+			ctrl := New(param, finder.NewConfigFinder(), reader.New(param), rgst.New(param, nil, rt, nil, nil), &mockFuzzyGetter{version: d.latest}, executor, rt)
+			if err := ctrl.Run(ctx, logger, param, d.param); err != nil {
+				if d.isErr == nil {
+					t.Fatal(err)
+				}
+				if !errors.Is(err, d.isErr) {
+					t.Fatalf("wanted %v, got %v", d.isErr, err)
+				}
+				return
+			}
+			if d.isErr != nil {
+				t.Fatalf("error must be returned: %v", d.isErr)
+			}
+			findResult := executor.findResult
+			if findResult.Package.Package.Version != d.expVersion {
+				t.Fatalf("version: wanted %s, got %s", d.expVersion, findResult.Package.Package.Version)
+			}
+			if executor.exeName != d.expExe {
+				t.Fatalf("command: wanted %s, got %s", d.expExe, executor.exeName)
+			}
+			if diff := cmp.Diff(filepath.Join(param.RootDir, "pkgs", filepath.FromSlash(d.expPath)), findResult.ExePath); diff != "" {
+				t.Fatal(diff)
+			}
+			if diff := cmp.Diff(filepath.Join(param.CWD, "aqua.yaml"), findResult.ConfigFilePath); diff != "" {
+				t.Fatal(diff)
+			}
+			if diff := cmp.Diff(d.param.Args, executor.args); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestController_findConfig(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.DiscardHandler)
+	dir := t.TempDir()
+	param := &config.Param{
+		CWD:     dir,
+		RootDir: filepath.Join(dir, "root"),
+	}
+	ctrl := New(param, finder.NewConfigFinder(), reader.New(param), nil, nil, nil, &runtime.Runtime{})
+	cfgFilePath, cfg, err := ctrl.findConfig(logger, param, "standard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfgFilePath != "" {
+		t.Fatalf("configuration file path must be empty: %s", cfgFilePath)
+	}
+	// Without configuration files, the standard registry is used.
+	if diff := cmp.Diff("aquaproj/aqua-registry", cfg.Registries["standard"].RepoOwner+"/"+cfg.Registries["standard"].RepoName); diff != "" {
+		t.Fatal(diff)
+	}
+	if _, cfg, err := ctrl.findConfig(logger, param, "foo"); err != nil {
+		t.Fatal(err)
+	} else if len(cfg.Registries) != 0 {
+		t.Fatal("other registries must not be added")
+	}
+}
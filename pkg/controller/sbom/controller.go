@@ -0,0 +1,47 @@
+package sbom
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/installpackage"
+)
+
+type Controller struct {
+	configFinder      ConfigFinder
+	configReader      ConfigReader
+	registryInstaller RegistryInstaller
+	packageInstaller  Installer
+	stdout            io.Writer
+}
+
+func New(configFinder ConfigFinder, configReader ConfigReader, registryInstaller RegistryInstaller, pkgInstaller Installer) *Controller {
+	return &Controller{
+		configFinder:      configFinder,
+		configReader:      configReader,
+		registryInstaller: registryInstaller,
+		packageInstaller:  pkgInstaller,
+		stdout:            os.Stdout,
+	}
+}
+
+type ConfigFinder interface {
+	Finds(wd, configFilePath string) []string
+}
+
+type ConfigReader interface {
+	Read(logger *slog.Logger, configFilePath string, cfg *aqua.Config) error
+}
+
+type RegistryInstaller interface {
+	InstallRegistries(ctx context.Context, logger *slog.Logger, cfg *aqua.Config, cfgFilePath string, checksums *checksum.Checksums) (map[string]*registry.Config, error)
+}
+
+type Installer interface {
+	PlanPackages(logger *slog.Logger, param *installpackage.ParamInstallPackages) ([]*installpackage.PackagePlan, error)
+}
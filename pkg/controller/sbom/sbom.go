@@ -0,0 +1,119 @@
+package sbom
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/installpackage"
+	"github.com/aquaproj/aqua/v2/pkg/sbom"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// SBOM outputs the Software Bill of Materials of packages in configuration files.
+// Packages are resolved in the same way as "aqua install --dry-run", so nothing is downloaded.
+// Checksums are read from aqua-checksums.json, and neither aqua-checksums.json nor packages are changed.
+func (c *Controller) SBOM(ctx context.Context, logger *slog.Logger, param *config.Param) error {
+	cfgFilePaths := c.configFinder.Finds(param.CWD, param.ConfigFilePath)
+	if param.All {
+		for _, cfgFilePath := range param.GlobalConfigFilePaths {
+			if _, err := os.Stat(cfgFilePath); err != nil {
+				continue
+			}
+			cfgFilePaths = append(cfgFilePaths, cfgFilePath)
+		}
+	}
+
+	pkgs := []*sbom.Package{}
+	added := map[string]struct{}{}
+	for _, cfgFilePath := range cfgFilePaths {
+		plans, err := c.plan(ctx, logger, cfgFilePath)
+		if err != nil {
+			return fmt.Errorf("resolve packages: %w", slogerr.With(err,
+				"config_file_path", cfgFilePath,
+			))
+		}
+		for _, plan := range plans {
+			key := plan.Name + "@" + plan.Version + " " + plan.URL
+			if _, ok := added[key]; ok {
+				continue
+			}
+			added[key] = struct{}{}
+			if plan.Error != "" {
+				logger.Warn("the package can't be resolved completely",
+					"package_name", plan.Name,
+					"package_version", plan.Version,
+					"config_file_path", cfgFilePath,
+					"error", plan.Error,
+				)
+			}
+			pkgs = append(pkgs, newPackage(plan))
+		}
+	}
+	return sbom.Write(c.stdout, param.OutputFormat, pkgs, &sbom.Param{ //nolint:wrapcheck
+		Version:   param.AQUAVersion,
+		Timestamp: time.Now(),
+	})
+}
+
+// plan resolves packages in a configuration file.
+// Policies aren't applied because the SBOM is the inventory of packages and nothing is installed.
+func (c *Controller) plan(ctx context.Context, logger *slog.Logger, cfgFilePath string) ([]*installpackage.PackagePlan, error) {
+	cfg := &aqua.Config{}
+	if err := c.configReader.Read(logger, cfgFilePath, cfg); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("validate the configuration: %w", err)
+	}
+
+	// aqua-checksums.json is read even if checksum verification is disabled,
+	// and it isn't updated.
+	checksums := checksum.New()
+	checksumFilePath, err := checksum.GetChecksumFilePathFromConfigFilePath(cfgFilePath)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	if err := checksums.ReadFile(checksumFilePath); err != nil {
+		return nil, fmt.Errorf("read a checksum JSON: %w", err)
+	}
+
+	registryContents, err := c.registryInstaller.InstallRegistries(ctx, logger, cfg, cfgFilePath, checksums)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	plans, err := c.packageInstaller.PlanPackages(logger, &installpackage.ParamInstallPackages{
+		Config:         cfg,
+		Registries:     registryContents,
+		ConfigFilePath: cfgFilePath,
+		SkipLink:       true,
+		Checksums:      checksums,
+		DisablePolicy:  true,
+	})
+	if err != nil {
+		slogerr.WithError(logger, err).Warn("some packages can't be resolved")
+	}
+	return plans, nil
+}
+
+// newPackage converts an install plan to a SBOM component.
+// Only checksums in aqua-checksums.json are output because other checksums aren't known without downloading assets.
+func newPackage(plan *installpackage.PackagePlan) *sbom.Package {
+	return &sbom.Package{
+		Name:                  plan.Name,
+		Version:               plan.Version,
+		Registry:              plan.Registry,
+		Type:                  plan.Type,
+		PURL:                  plan.PURL,
+		URL:                   plan.URL,
+		Checksum:              plan.Checksum,
+		Verifications:         plan.Verifications,
+		ChecksumVerifications: plan.ChecksumVerifications,
+	}
+}
@@ -0,0 +1,206 @@
+package shellinit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+const (
+	shellBash = "bash"
+	shellZsh  = "zsh"
+	shellFish = "fish"
+)
+
+var errUnsupportedShell = errors.New("unsupported shell. bash, zsh, and fish are supported")
+
+// ShellInit outputs a shell snippet which loads shell completions and man pages of packages in configuration files.
+func (c *Controller) ShellInit(ctx context.Context, logger *slog.Logger, param *config.Param, shell string) error {
+	switch shell {
+	case shellBash, shellZsh, shellFish:
+	default:
+		return slogerr.With(errUnsupportedShell, "shell", shell) //nolint:wrapcheck
+	}
+	files, err := c.listShareFiles(ctx, logger, param)
+	if err != nil {
+		return err
+	}
+	return writeSnippet(c.stdout, shell, files)
+}
+
+// shareFiles holds paths to links of share files by kind.
+// Links are created per package version, so directories which zsh and man search are also collected.
+type shareFiles struct {
+	seen  map[string]struct{}
+	links map[string][]string
+	dirs  map[string][]string
+}
+
+func newShareFiles() *shareFiles {
+	return &shareFiles{
+		seen:  map[string]struct{}{},
+		links: map[string][]string{},
+		dirs:  map[string][]string{},
+	}
+}
+
+// add adds a link.
+// If links of several package versions have the same name, the first one is used like aqua exec.
+func (f *shareFiles) add(kind, link string) {
+	key := kind + "/" + filepath.Base(link)
+	if _, ok := f.seen[key]; ok {
+		return
+	}
+	f.seen[key] = struct{}{}
+	f.links[kind] = append(f.links[kind], link)
+	var dir string
+	switch kind {
+	case registry.ShareFileKindZshCompletion:
+		// <package share directory>/zsh/site-functions/_foo
+		dir = filepath.Dir(link)
+	case registry.ShareFileKindMan:
+		// <package share directory>/man/man1/foo.1
+		dir = filepath.Dir(filepath.Dir(link))
+	default:
+		return
+	}
+	if slices.Contains(f.dirs[kind], dir) {
+		return
+	}
+	f.dirs[kind] = append(f.dirs[kind], dir)
+}
+
+func (c *Controller) listShareFiles(ctx context.Context, logger *slog.Logger, param *config.Param) (*shareFiles, error) {
+	files := newShareFiles()
+	cfgFilePaths := c.configFinder.Finds(param.CWD, param.ConfigFilePath)
+	for _, cfgFilePath := range param.GlobalConfigFilePaths {
+		if f, err := osfile.Exists(cfgFilePath); err != nil {
+			return nil, err //nolint:wrapcheck
+		} else if f {
+			cfgFilePaths = append(cfgFilePaths, cfgFilePath)
+		}
+	}
+	cfgFileMap := map[string]struct{}{}
+	for _, cfgFilePath := range cfgFilePaths {
+		if _, ok := cfgFileMap[cfgFilePath]; ok {
+			continue
+		}
+		cfgFileMap[cfgFilePath] = struct{}{}
+		logger := logger.With("config_file_path", cfgFilePath)
+		if err := c.listShareFilesByConfig(ctx, logger, param, cfgFilePath, files); err != nil {
+			return nil, slogerr.With(err, "config_file_path", cfgFilePath) //nolint:wrapcheck
+		}
+	}
+	return files, nil
+}
+
+func (c *Controller) listShareFilesByConfig(ctx context.Context, logger *slog.Logger, param *config.Param, cfgFilePath string, files *shareFiles) error {
+	cfg := &aqua.Config{}
+	if err := c.configReader.Read(logger, cfgFilePath, cfg); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	checksums, updateChecksum, err := checksum.Open(
+		logger, cfgFilePath,
+		param.ChecksumEnabled(cfg))
+	if err != nil {
+		return fmt.Errorf("read a checksum JSON: %w", err)
+	}
+	defer updateChecksum()
+
+	registryContents, err := c.registryInstaller.InstallRegistries(ctx, logger, cfg, cfgFilePath, checksums)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	pkgs, _ := config.ListPackages(logger, cfg, c.runtime, registryContents)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.PackageInfo.ShareFiles {
+			_, link, err := pkg.ShareFilePath(c.rootDir, file, c.runtime)
+			if err != nil {
+				slogerr.WithError(logger, err).Warn("get the path of the share file",
+					"package_name", pkg.Package.Name,
+					"package_version", pkg.Package.Version,
+					"share_file_src", file.Src)
+				continue
+			}
+			files.add(file.Kind, link)
+		}
+	}
+	return nil
+}
+
+// writeSnippet outputs a shell snippet.
+// Packages in configuration files found earlier take precedence, like aqua exec.
+func writeSnippet(w io.Writer, shell string, files *shareFiles) error {
+	var lines []string
+	switch shell {
+	case shellBash:
+		for _, link := range files.links[registry.ShareFileKindBashCompletion] {
+			lines = append(lines, fmt.Sprintf("if [ -f %s ]; then . %s; fi", quote(link), quote(link)))
+		}
+	case shellZsh:
+		if dirs := files.dirs[registry.ShareFileKindZshCompletion]; len(dirs) != 0 {
+			quoted := make([]string, len(dirs))
+			for i, dir := range dirs {
+				quoted[i] = quote(dir)
+			}
+			lines = append(lines, fmt.Sprintf("fpath=(%s $fpath)", strings.Join(quoted, " ")))
+		}
+	case shellFish:
+		for _, link := range files.links[registry.ShareFileKindFishCompletion] {
+			lines = append(lines, fmt.Sprintf("test -f %s; and source %s", quoteFish(link), quoteFish(link)))
+		}
+	}
+	lines = append(lines, manPath(shell, files.dirs[registry.ShareFileKindMan])...)
+	if len(lines) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(w, strings.Join(lines, "\n")); err != nil {
+		return fmt.Errorf("output a shell snippet: %w", err)
+	}
+	return nil
+}
+
+// manPath returns a snippet which prepends dirs to MANPATH.
+// dirs are prepended in reverse order so that the first one takes precedence.
+// MANPATH keeps an empty element so that man still searches the default paths.
+func manPath(shell string, dirs []string) []string {
+	if len(dirs) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(dirs)+1)
+	for _, dir := range slices.Backward(dirs) {
+		if shell == shellFish {
+			lines = append(lines, fmt.Sprintf("contains -- %s $MANPATH; or set -gx MANPATH %s $MANPATH", quoteFish(dir), quoteFish(dir)))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf(`case ":${MANPATH:-}:" in *:%s:*) ;; *) export MANPATH=%s:"${MANPATH:-}" ;; esac`, quote(dir), quote(dir)))
+	}
+	if shell == shellFish {
+		lines = append(lines, "contains -- '' $MANPATH; or set -gx MANPATH $MANPATH ''")
+	}
+	return lines
+}
+
+// quote quotes s for POSIX shells.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// quoteFish quotes s for fish.
+func quoteFish(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
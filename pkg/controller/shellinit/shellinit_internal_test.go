@@ -0,0 +1,73 @@
+package shellinit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_writeSnippet(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	const (
+		gh2 = "/root/.aqua/share/pkgs/github_release/github.com/cli/cli/v2.40.0/gh.tar.gz"
+		gh1 = "/root/.aqua/share/pkgs/github_release/github.com/cli/cli/v1.14.0/gh.tar.gz"
+	)
+	files := newShareFiles()
+	files.add(registry.ShareFileKindBashCompletion, gh2+"/bash-completion/completions/gh")
+	files.add(registry.ShareFileKindBashCompletion, gh2+"/bash-completion/completions/gh")
+	files.add(registry.ShareFileKindZshCompletion, gh2+"/zsh/site-functions/_gh")
+	files.add(registry.ShareFileKindFishCompletion, gh2+"/fish/vendor_completions.d/it's.fish")
+	files.add(registry.ShareFileKindMan, gh2+"/man/man1/gh.1")
+	files.add(registry.ShareFileKindMan, gh2+"/man/man1/gh-pr.1")
+	// Another version of the same package in another configuration file.
+	// Links with the same name are shadowed by the first version.
+	files.add(registry.ShareFileKindBashCompletion, gh1+"/bash-completion/completions/gh")
+	files.add(registry.ShareFileKindZshCompletion, gh1+"/zsh/site-functions/_gh")
+	files.add(registry.ShareFileKindMan, gh1+"/man/man1/gh.1")
+	files.add(registry.ShareFileKindMan, gh1+"/man/man1/gh-old.1")
+	data := []struct {
+		name  string
+		shell string
+		exp   string
+	}{
+		{
+			name:  "bash",
+			shell: "bash",
+			exp: `if [ -f '` + gh2 + `/bash-completion/completions/gh' ]; then . '` + gh2 + `/bash-completion/completions/gh'; fi
+case ":${MANPATH:-}:" in *:'` + gh1 + `/man':*) ;; *) export MANPATH='` + gh1 + `/man':"${MANPATH:-}" ;; esac
+case ":${MANPATH:-}:" in *:'` + gh2 + `/man':*) ;; *) export MANPATH='` + gh2 + `/man':"${MANPATH:-}" ;; esac
+`,
+		},
+		{
+			name:  "zsh",
+			shell: "zsh",
+			exp: `fpath=('` + gh2 + `/zsh/site-functions' $fpath)
+case ":${MANPATH:-}:" in *:'` + gh1 + `/man':*) ;; *) export MANPATH='` + gh1 + `/man':"${MANPATH:-}" ;; esac
+case ":${MANPATH:-}:" in *:'` + gh2 + `/man':*) ;; *) export MANPATH='` + gh2 + `/man':"${MANPATH:-}" ;; esac
+`,
+		},
+		{
+			name:  "fish",
+			shell: "fish",
+			exp: `test -f '` + gh2 + `/fish/vendor_completions.d/it\'s.fish'; and source '` + gh2 + `/fish/vendor_completions.d/it\'s.fish'
+contains -- '` + gh1 + `/man' $MANPATH; or set -gx MANPATH '` + gh1 + `/man' $MANPATH
+contains -- '` + gh2 + `/man' $MANPATH; or set -gx MANPATH '` + gh2 + `/man' $MANPATH
+contains -- '' $MANPATH; or set -gx MANPATH $MANPATH ''
+`,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			buf := &strings.Builder{}
+			if err := writeSnippet(buf, d.shell, files); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(d.exp, buf.String()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
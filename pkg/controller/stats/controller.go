@@ -0,0 +1,32 @@
+package stats
+
+import (
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/aquaproj/aqua/v2/pkg/manifest"
+	"github.com/aquaproj/aqua/v2/pkg/vacuum"
+)
+
+type Controller struct {
+	stdout   io.Writer
+	vacuum   Vacuum
+	manifest Manifest
+}
+
+func New(vc Vacuum, mf Manifest) *Controller {
+	return &Controller{
+		stdout:   os.Stdout,
+		vacuum:   vc,
+		manifest: mf,
+	}
+}
+
+type Vacuum interface {
+	FindAllUsages(logger *slog.Logger) (map[string]*vacuum.Usage, error)
+}
+
+type Manifest interface {
+	FindAll() (map[string]*manifest.Manifest, error)
+}
@@ -0,0 +1,166 @@
+package stats
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/vacuum"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+var errUnknownOutputFormat = errors.New("unknown output format")
+
+// Result is the JSON output of aqua stats.
+type Result struct {
+	Packages []*Package `json:"packages"`
+}
+
+// Package is usage statistics of an installed package version.
+type Package struct {
+	Package     string `json:"package"`
+	Version     string `json:"version"`
+	PackagePath string `json:"package_path"`
+	Count       int    `json:"count"`
+	// FirstUsed and LastUsed are nil if the package has never been used since usage statistics were enabled.
+	FirstUsed *time.Time `json:"first_used,omitempty"`
+	LastUsed  *time.Time `json:"last_used,omitempty"`
+	Commands  []*Command `json:"commands,omitempty"`
+}
+
+// Command is usage statistics of a command of a package.
+type Command struct {
+	Name      string    `json:"name"`
+	Count     int       `json:"count"`
+	FirstUsed time.Time `json:"first_used"`
+	LastUsed  time.Time `json:"last_used"`
+}
+
+// Stats outputs usage statistics of installed packages.
+// Packages are sorted from the most used ones, or from the least used ones if param.LeastUsed is true.
+// Installed packages which have never been used are also output so that unused packages can be found.
+func (c *Controller) Stats(logger *slog.Logger, param *config.Param) error {
+	switch param.OutputFormat {
+	case "", "text", "json":
+	default:
+		return slogerr.With(errUnknownOutputFormat, "format", param.OutputFormat) //nolint:wrapcheck
+	}
+	usages, err := c.vacuum.FindAllUsages(logger)
+	if err != nil {
+		return fmt.Errorf("find usage statistics: %w", err)
+	}
+	manifests, err := c.manifest.FindAll()
+	if err != nil {
+		return fmt.Errorf("find manifests of installed packages: %w", err)
+	}
+	pkgs := make([]*Package, 0, len(usages))
+	for pkgPath, usage := range usages {
+		pkgs = append(pkgs, newPackage(pkgPath, usage))
+	}
+	for pkgPath, m := range manifests {
+		if _, ok := usages[pkgPath]; ok {
+			continue
+		}
+		pkgs = append(pkgs, &Package{
+			Package:     m.Package,
+			Version:     m.Version,
+			PackagePath: pkgPath,
+		})
+	}
+	sortPackages(pkgs, param.LeastUsed)
+	if param.Limit > 0 && len(pkgs) > param.Limit {
+		pkgs = pkgs[:param.Limit]
+	}
+	return output(c.stdout, param.OutputFormat, pkgs)
+}
+
+func newPackage(pkgPath string, usage *vacuum.Usage) *Package {
+	pkg := &Package{
+		Package:     usage.Package,
+		Version:     usage.Version,
+		PackagePath: pkgPath,
+		Commands:    make([]*Command, 0, len(usage.Commands)),
+	}
+	for name, cmd := range usage.Commands {
+		pkg.Count += cmd.Count
+		if pkg.FirstUsed == nil || cmd.FirstUsed.Before(*pkg.FirstUsed) {
+			pkg.FirstUsed = &cmd.FirstUsed
+		}
+		if pkg.LastUsed == nil || cmd.LastUsed.After(*pkg.LastUsed) {
+			pkg.LastUsed = &cmd.LastUsed
+		}
+		pkg.Commands = append(pkg.Commands, &Command{
+			Name:      name,
+			Count:     cmd.Count,
+			FirstUsed: cmd.FirstUsed,
+			LastUsed:  cmd.LastUsed,
+		})
+	}
+	slices.SortFunc(pkg.Commands, func(a, b *Command) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Name, b.Name))
+	})
+	return pkg
+}
+
+// sortPackages sorts packages by the invocation count and then the last used date time.
+func sortPackages(pkgs []*Package, leastUsed bool) {
+	slices.SortFunc(pkgs, func(a, b *Package) int {
+		c := cmp.Or(cmp.Compare(a.Count, b.Count), compareTime(a.LastUsed, b.LastUsed))
+		if !leastUsed {
+			c = -c
+		}
+		return cmp.Or(c, strings.Compare(a.PackagePath, b.PackagePath))
+	})
+}
+
+// compareTime compares date times. nil means the package has never been used, so it's the oldest.
+func compareTime(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return a.Compare(*b)
+}
+
+func output(w io.Writer, format string, pkgs []*Package) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(&Result{Packages: pkgs}); err != nil {
+			return fmt.Errorf("encode usage statistics as JSON: %w", err)
+		}
+		return nil
+	}
+	if len(pkgs) == 0 {
+		fmt.Fprintln(w, "no usage statistics are recorded")
+		return nil
+	}
+	fmt.Fprintf(w, "%6s  %-25s  %s\n", "COUNT", "LAST USED", "PACKAGE")
+	for _, pkg := range pkgs {
+		lastUsed := "-"
+		if pkg.LastUsed != nil {
+			lastUsed = vacuum.FormatTime(*pkg.LastUsed)
+		}
+		cmds := make([]string, len(pkg.Commands))
+		for i, cmd := range pkg.Commands {
+			cmds[i] = fmt.Sprintf("%s: %d", cmd.Name, cmd.Count)
+		}
+		line := fmt.Sprintf("%6d  %-25s  %s@%s", pkg.Count, lastUsed, pkg.Package, pkg.Version)
+		if len(cmds) != 0 {
+			line += " (" + strings.Join(cmds, ", ") + ")"
+		}
+		fmt.Fprintln(w, line)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package stats
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/manifest"
+	"github.com/aquaproj/aqua/v2/pkg/vacuum"
+	"github.com/google/go-cmp/cmp"
+)
+
+type mockVacuum struct {
+	usages map[string]*vacuum.Usage
+}
+
+func (m *mockVacuum) FindAllUsages(_ *slog.Logger) (map[string]*vacuum.Usage, error) {
+	return m.usages, nil
+}
+
+type mockManifest struct {
+	manifests map[string]*manifest.Manifest
+}
+
+func (m *mockManifest) FindAll() (map[string]*manifest.Manifest, error) {
+	return m.manifests, nil
+}
+
+func TestController_Stats(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	day := func(d int) time.Time {
+		return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	usages := map[string]*vacuum.Usage{
+		"pkgs/github_release/github.com/cli/cli/v2.65.0/gh.zip": {
+			Package: "cli/cli",
+			Version: "v2.65.0",
+			Commands: map[string]*vacuum.CommandUsage{
+				"gh": {Count: 5, FirstUsed: day(2), LastUsed: day(10)},
+			},
+		},
+		"pkgs/github_release/github.com/suzuki-shunsuke/tfcmt/v4.0.0/tfcmt.tar.gz": {
+			Package: "suzuki-shunsuke/tfcmt",
+			Version: "v4.0.0",
+			Commands: map[string]*vacuum.CommandUsage{
+				"tfcmt":      {Count: 2, FirstUsed: day(1), LastUsed: day(3)},
+				"tfcmt-hook": {Count: 3, FirstUsed: day(4), LastUsed: day(5)},
+			},
+		},
+		"pkgs/github_release/github.com/BurntSushi/ripgrep/14.1.0/rg.tar.gz": {
+			Package: "BurntSushi/ripgrep",
+			Version: "14.1.0",
+			Commands: map[string]*vacuum.CommandUsage{
+				"rg": {Count: 1, FirstUsed: day(1), LastUsed: day(1)},
+			},
+		},
+	}
+	manifests := map[string]*manifest.Manifest{
+		"pkgs/github_release/github.com/cli/cli/v2.65.0/gh.zip": {
+			Package: "cli/cli",
+			Version: "v2.65.0",
+		},
+		"pkgs/github_release/github.com/golangci/golangci-lint/v1.60.0/golangci-lint.tar.gz": {
+			Package: "golangci/golangci-lint",
+			Version: "v1.60.0",
+		},
+	}
+	data := []struct {
+		name  string
+		param *config.Param
+		exp   string
+		isErr bool
+	}{
+		{
+			name:  "most used",
+			param: &config.Param{},
+			exp: ` COUNT  LAST USED                  PACKAGE
+     5  2025-01-10T00:00:00Z       cli/cli@v2.65.0 (gh: 5)
+     5  2025-01-05T00:00:00Z       suzuki-shunsuke/tfcmt@v4.0.0 (tfcmt-hook: 3, tfcmt: 2)
+     1  2025-01-01T00:00:00Z       BurntSushi/ripgrep@14.1.0 (rg: 1)
+     0  -                          golangci/golangci-lint@v1.60.0
+`,
+		},
+		{
+			name: "least used",
+			param: &config.Param{
+				LeastUsed: true,
+				Limit:     2,
+			},
+			exp: ` COUNT  LAST USED                  PACKAGE
+     0  -                          golangci/golangci-lint@v1.60.0
+     1  2025-01-01T00:00:00Z       BurntSushi/ripgrep@14.1.0 (rg: 1)
+`,
+		},
+		{
+			name: "unknown format",
+			param: &config.Param{
+				OutputFormat: "yaml",
+			},
+			isErr: true,
+		},
+	}
+	logger := slog.New(slog.DiscardHandler)
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := New(&mockVacuum{usages: usages}, &mockManifest{manifests: manifests})
+			buf := &bytes.Buffer{}
+			ctrl.stdout = buf
+			if err := ctrl.Stats(logger, d.param); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.exp, buf.String()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
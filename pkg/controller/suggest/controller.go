@@ -0,0 +1,51 @@
+package suggest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+)
+
+type Controller struct {
+	stdin             io.Reader
+	stdout            io.Writer
+	configFinder      ConfigFinder
+	configReader      ConfigReader
+	registryInstaller RegistryInstaller
+	generator         Generator
+}
+
+func New(configFinder ConfigFinder, configReader ConfigReader, registryInstaller RegistryInstaller, generator Generator) *Controller {
+	return &Controller{
+		stdin:             os.Stdin,
+		stdout:            os.Stdout,
+		configFinder:      configFinder,
+		configReader:      configReader,
+		registryInstaller: registryInstaller,
+		generator:         generator,
+	}
+}
+
+type ConfigFinder interface {
+	Finds(wd, configFilePath string) []string
+}
+
+type ConfigReader interface {
+	Read(logger *slog.Logger, configFilePath string, cfg *aqua.Config) error
+}
+
+type RegistryInstaller interface {
+	InstallRegistries(ctx context.Context, logger *slog.Logger, cfg *aqua.Config, cfgFilePath string, checksums *checksum.Checksums) (map[string]*registry.Config, error)
+}
+
+// Generator inserts packages into a configuration file.
+// This is implemented by generate.Controller, so packages are inserted in the same way as "aqua g -i".
+type Generator interface {
+	Generate(ctx context.Context, logger *slog.Logger, param *config.Param, args ...string) error
+}
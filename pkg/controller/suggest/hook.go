@@ -0,0 +1,78 @@
+package suggest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+var errUnsupportedShell = errors.New("unsupported shell. bash, zsh, and fish are supported")
+
+// The placeholder {{SUGGEST}} is replaced with the "aqua suggest" command.
+// Suggestions are output to the standard error not to break command substitutions,
+// and errors of "aqua suggest" are discarded not to bother users.
+// If no package is found, the handler which was defined before the snippet is called.
+// The handler is saved as __aqua_<handler name>, and it isn't saved again if the snippet is evaluated twice,
+// otherwise the saved handler would call itself.
+// If no handler was defined, the default message of the shell is output.
+const (
+	hookBash = `if declare -F command_not_found_handle >/dev/null 2>&1 && [[ "$(declare -f command_not_found_handle)" != *__aqua_command_not_found_handle* ]]; then
+  eval "__aqua_$(declare -f command_not_found_handle)"
+fi
+command_not_found_handle() {
+  if command -v aqua >/dev/null 2>&1 && {{SUGGEST}} -- "$1" >&2 2>/dev/null; then
+    return 127
+  fi
+  if declare -F __aqua_command_not_found_handle >/dev/null 2>&1; then
+    __aqua_command_not_found_handle "$@"
+    return
+  fi
+  printf 'bash: %s: command not found\n' "$1" >&2
+  return 127
+}`
+	hookZsh = `if (( $+functions[command_not_found_handler] )) && [[ $functions[command_not_found_handler] != *__aqua_command_not_found_handler* ]]; then
+  functions[__aqua_command_not_found_handler]=$functions[command_not_found_handler]
+fi
+command_not_found_handler() {
+  if (( $+commands[aqua] )) && {{SUGGEST}} -- "$1" >&2 2>/dev/null; then
+    return 127
+  fi
+  if (( $+functions[__aqua_command_not_found_handler] )); then
+    __aqua_command_not_found_handler "$@"
+    return
+  fi
+  printf 'zsh: command not found: %s\n' "$1" >&2
+  return 127
+}`
+	hookFish = `function fish_command_not_found
+  if command -q aqua; and {{SUGGEST}} -- $argv[1] >&2 2>/dev/null
+    return 127
+  end
+  __fish_default_command_not_found_handler $argv
+end`
+)
+
+// WriteHook outputs a shell snippet which runs "aqua suggest" when a command isn't found.
+// If insert is true, the snippet asks the user to add a package to the configuration file.
+func WriteHook(w io.Writer, shell string, insert bool) error {
+	hooks := map[string]string{
+		"bash": hookBash,
+		"zsh":  hookZsh,
+		"fish": hookFish,
+	}
+	hook, ok := hooks[shell]
+	if !ok {
+		return slogerr.With(errUnsupportedShell, "shell", shell) //nolint:wrapcheck
+	}
+	cmd := "aqua suggest"
+	if insert {
+		cmd += " --insert"
+	}
+	if _, err := fmt.Fprintln(w, strings.ReplaceAll(hook, "{{SUGGEST}}", cmd)); err != nil {
+		return fmt.Errorf("output a shell snippet: %w", err)
+	}
+	return nil
+}
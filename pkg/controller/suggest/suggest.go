@@ -0,0 +1,240 @@
+package suggest
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// maxSuggestions is the maximum number of suggested packages.
+const maxSuggestions = 10
+
+var (
+	errPackageNotFound  = errors.New("no package provides the command")
+	errInvalidSelection = errors.New("invalid selection")
+)
+
+// Ranks of suggestions. A smaller rank is suggested first.
+const (
+	// rankCommand means the package has the command.
+	rankCommand = iota
+	// rankName means the name or an alias of the package ends with the command name.
+	rankName
+	// rankSearchWord means the search words of the package include the command name.
+	rankSearchWord
+)
+
+type suggestion struct {
+	registryName string
+	pkgInfo      *registry.PackageInfo
+	rank         int
+}
+
+// generateArg returns the argument of "aqua g" to add the package.
+func (s *suggestion) generateArg() string {
+	if s.registryName == aqua.RegistryTypeStandard {
+		return s.pkgInfo.GetName()
+	}
+	return s.registryName + "," + s.pkgInfo.GetName()
+}
+
+type registryContent struct {
+	name string
+	cfg  *registry.Config
+}
+
+// Suggest searches packages which provide the command in registries of configuration files,
+// and outputs commands to add them to the configuration file.
+// If insert is true, the user is asked to add a package to the configuration file.
+func (c *Controller) Suggest(ctx context.Context, logger *slog.Logger, param *config.Param, exeName string, insert bool) error {
+	registries, err := c.readRegistries(ctx, logger, param)
+	if err != nil {
+		return err
+	}
+	suggestions := search(registries, exeName)
+	if len(suggestions) == 0 {
+		return slogerr.With(errPackageNotFound, "exe_name", exeName) //nolint:wrapcheck
+	}
+	numbered := insert && len(suggestions) > 1
+	fmt.Fprintf(c.stdout, "The command %q is provided by the following packages:\n\n", exeName)
+	for i, s := range suggestions {
+		line := "aqua g -i " + s.generateArg()
+		if s.pkgInfo.Description != "" {
+			line += " # " + s.pkgInfo.Description
+		}
+		if numbered {
+			line = fmt.Sprintf("[%d] %s", i+1, line)
+		}
+		fmt.Fprintln(c.stdout, line)
+	}
+	if !insert {
+		return nil
+	}
+	s, err := c.choose(suggestions)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return nil
+	}
+	generateParam := *param
+	generateParam.Insert = true
+	return c.generator.Generate(ctx, logger, &generateParam, s.generateArg()) //nolint:wrapcheck
+}
+
+// choose asks the user to choose a package added to the configuration file.
+// If the user chooses nothing, nil is returned.
+func (c *Controller) choose(suggestions []*suggestion) (*suggestion, error) {
+	if len(suggestions) == 1 {
+		fmt.Fprintf(c.stdout, "\nAdd %s to the configuration file? [y/N]: ", suggestions[0].pkgInfo.GetName())
+	} else {
+		fmt.Fprintf(c.stdout, "\nSelect a package to add to the configuration file [1-%d] (press Enter to skip): ", len(suggestions))
+	}
+	answer, err := bufio.NewReader(c.stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return nil, nil //nolint:nilnil
+	}
+	answer = strings.TrimSpace(answer)
+	if len(suggestions) == 1 {
+		if strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes") {
+			return suggestions[0], nil
+		}
+		return nil, nil //nolint:nilnil
+	}
+	if answer == "" {
+		return nil, nil //nolint:nilnil
+	}
+	idx, err := strconv.Atoi(answer)
+	if err != nil || idx < 1 || idx > len(suggestions) {
+		return nil, slogerr.With(errInvalidSelection, "answer", answer) //nolint:wrapcheck
+	}
+	return suggestions[idx-1], nil
+}
+
+// readRegistries reads registries of local and global configuration files.
+// If a registry name is used in multiple configuration files, the registry of the nearest configuration file is used.
+// If there is no configuration file, the standard registry is used.
+// Registries which fail to be installed are skipped, because suggestions are best effort.
+func (c *Controller) readRegistries(ctx context.Context, logger *slog.Logger, param *config.Param) ([]*registryContent, error) {
+	cfgFilePaths := c.configFinder.Finds(param.CWD, param.ConfigFilePath)
+	for _, cfgFilePath := range param.GlobalConfigFilePaths {
+		if f, err := osfile.Exists(cfgFilePath); err != nil {
+			return nil, err //nolint:wrapcheck
+		} else if f {
+			cfgFilePaths = append(cfgFilePaths, cfgFilePath)
+		}
+	}
+	if len(cfgFilePaths) == 0 {
+		logger.Debug("no configuration file is found. Use the standard registry " + aqua.StandardRegistryVersion)
+		return c.installRegistries(ctx, logger, param, "", &aqua.Config{
+			Registries: aqua.Registries{
+				aqua.RegistryTypeStandard: aqua.NewStandardRegistry(),
+			},
+		}, map[string]struct{}{}), nil
+	}
+	registries := []*registryContent{}
+	seen := map[string]struct{}{}
+	for _, cfgFilePath := range cfgFilePaths {
+		cfg := &aqua.Config{}
+		if err := c.configReader.Read(logger, cfgFilePath, cfg); err != nil {
+			return nil, fmt.Errorf("read a configuration file: %w", slogerr.With(err, "config_file_path", cfgFilePath))
+		}
+		registries = append(registries, c.installRegistries(ctx, logger, param, cfgFilePath, cfg, seen)...)
+	}
+	return registries, nil
+}
+
+func (c *Controller) installRegistries(ctx context.Context, logger *slog.Logger, param *config.Param, cfgFilePath string, cfg *aqua.Config, seen map[string]struct{}) []*registryContent {
+	logger = logger.With("config_file_path", cfgFilePath)
+	// The checksum file isn't updated because suggestions shouldn't change files other than the configuration file.
+	checksums, _, err := checksum.Open(
+		logger, cfgFilePath, cfgFilePath != "" && param.ChecksumEnabled(cfg))
+	if err != nil {
+		slogerr.WithError(logger, err).Warn("read a checksum JSON")
+		return nil
+	}
+	contents, err := c.registryInstaller.InstallRegistries(ctx, logger, cfg, cfgFilePath, checksums)
+	if err != nil {
+		slogerr.WithError(logger, err).Warn("install registries")
+	}
+	names := make([]string, 0, len(contents))
+	for name := range contents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	registries := make([]*registryContent, 0, len(names))
+	for _, name := range names {
+		if contents[name] == nil {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		registries = append(registries, &registryContent{
+			name: name,
+			cfg:  contents[name],
+		})
+	}
+	return registries
+}
+
+// search returns packages which provide the command.
+// Packages which have the command come first, followed by packages whose name or alias matches the command,
+// and packages whose search words include the command.
+func search(registries []*registryContent, exeName string) []*suggestion {
+	suggestions := []*suggestion{}
+	for _, rc := range registries {
+		for _, pkgInfo := range rc.cfg.PackageInfos {
+			rank, ok := match(pkgInfo, exeName)
+			if !ok {
+				continue
+			}
+			suggestions = append(suggestions, &suggestion{
+				registryName: rc.name,
+				pkgInfo:      pkgInfo,
+				rank:         rank,
+			})
+		}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].rank < suggestions[j].rank
+	})
+	if len(suggestions) > maxSuggestions {
+		return suggestions[:maxSuggestions]
+	}
+	return suggestions
+}
+
+func match(pkgInfo *registry.PackageInfo, exeName string) (int, bool) {
+	if pkgInfo.MaybeHasCommand(exeName) {
+		return rankCommand, true
+	}
+	if path.Base(pkgInfo.GetName()) == exeName {
+		return rankName, true
+	}
+	for _, alias := range pkgInfo.Aliases {
+		if alias.Name != "" && path.Base(alias.Name) == exeName {
+			return rankName, true
+		}
+	}
+	for _, word := range pkgInfo.SearchWords {
+		if strings.EqualFold(word, exeName) {
+			return rankSearchWord, true
+		}
+	}
+	return 0, false
+}
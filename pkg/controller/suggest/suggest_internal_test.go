@@ -0,0 +1,196 @@
+package suggest
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	finder "github.com/aquaproj/aqua/v2/pkg/config-finder"
+	reader "github.com/aquaproj/aqua/v2/pkg/config-reader"
+	registry "github.com/aquaproj/aqua/v2/pkg/install-registry"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+type mockGenerator struct {
+	args   []string
+	insert bool
+}
+
+func (m *mockGenerator) Generate(ctx context.Context, logger *slog.Logger, param *config.Param, args ...string) error {
+	m.args = args
+	m.insert = param.Insert
+	return nil
+}
+
+const testRegistryYAML = `packages:
+- type: github_release
+  repo_owner: cli
+  repo_name: cli
+  description: GitHub’s official command line tool
+  asset: gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz
+  files:
+  - name: gh
+- type: github_release
+  repo_owner: foo
+  repo_name: github-cli
+  asset: github-cli.tar.gz
+  search_words:
+  - gh
+- type: github_release
+  repo_owner: bar
+  repo_name: gh
+  asset: gh.tar.gz
+  files:
+  - name: bar
+- type: github_release
+  repo_owner: kubernetes-sigs
+  repo_name: krew
+  asset: krew.tar.gz
+`
+
+func TestController_Suggest(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name      string
+		exeName   string
+		insert    bool
+		stdin     string
+		isErr     bool
+		expOutput string
+		expArgs   []string
+	}{
+		{
+			name:    "ranked suggestions",
+			exeName: "gh",
+			expOutput: `The command "gh" is provided by the following packages:
+
+aqua g -i local,cli/cli # GitHub’s official command line tool
+aqua g -i local,bar/gh
+aqua g -i local,foo/github-cli
+`,
+		},
+		{
+			name:    "default command name",
+			exeName: "krew",
+			expOutput: `The command "krew" is provided by the following packages:
+
+aqua g -i local,kubernetes-sigs/krew
+`,
+		},
+		{
+			name:    "not found",
+			exeName: "foo",
+			isErr:   true,
+		},
+		{
+			name:    "insert",
+			exeName: "krew",
+			insert:  true,
+			stdin:   "y\n",
+			expOutput: `The command "krew" is provided by the following packages:
+
+aqua g -i local,kubernetes-sigs/krew
+
+Add kubernetes-sigs/krew to the configuration file? [y/N]: `,
+			expArgs: []string{"local,kubernetes-sigs/krew"},
+		},
+		{
+			name:    "select a package",
+			exeName: "gh",
+			insert:  true,
+			stdin:   "2\n",
+			expOutput: `The command "gh" is provided by the following packages:
+
+[1] aqua g -i local,cli/cli # GitHub’s official command line tool
+[2] aqua g -i local,bar/gh
+[3] aqua g -i local,foo/github-cli
+
+Select a package to add to the configuration file [1-3] (press Enter to skip): `,
+			expArgs: []string{"local,bar/gh"},
+		},
+		{
+			name:    "skip",
+			exeName: "gh",
+			insert:  true,
+			stdin:   "\n",
+			expOutput: `The command "gh" is provided by the following packages:
+
+[1] aqua g -i local,cli/cli # GitHub’s official command line tool
+[2] aqua g -i local,bar/gh
+[3] aqua g -i local,foo/github-cli
+
+Select a package to add to the configuration file [1-3] (press Enter to skip): `,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+			logger := slog.New(slog.DiscardHandler)
+			dir := t.TempDir()
+			testutil.WriteFiles(t, dir, map[string]string{
+				"workspace/aqua.yaml": `registries:
+- type: local
+  name: local
+  path: registry.yaml
+packages:
+`,
+				"workspace/registry.yaml": testRegistryYAML,
+			})
+			param := &config.Param{
+				CWD:            filepath.Join(dir, "workspace"),
+				RootDir:        filepath.Join(dir, "root"),
+				MaxParallelism: 1,
+			}
+			rt := &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			}
+			generator := &mockGenerator{}
+			ctrl := New(finder.NewConfigFinder(), reader.New(param), registry.New(param, nil, rt, nil, nil), generator)
+			stdout := &strings.Builder{}
+			ctrl.stdout = stdout
+			ctrl.stdin = strings.NewReader(d.stdin)
+			if err := ctrl.Suggest(ctx, logger, param, d.exeName, d.insert); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.expOutput, stdout.String()); diff != "" {
+				t.Fatal(diff)
+			}
+			if diff := cmp.Diff(d.expArgs, generator.args); diff != "" {
+				t.Fatal(diff)
+			}
+			if d.expArgs != nil && !generator.insert {
+				t.Fatal("packages must be inserted")
+			}
+		})
+	}
+}
+
+func TestWriteHook(t *testing.T) {
+	t.Parallel()
+	buf := &strings.Builder{}
+	if err := WriteHook(buf, "bash", true); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `aqua suggest --insert -- "$1"`) {
+		t.Fatalf("the hook must run aqua suggest --insert: %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), `__aqua_command_not_found_handle "$@"`) {
+		t.Fatalf("the hook must call the existing handler: %s", buf.String())
+	}
+	if err := WriteHook(buf, "tcsh", false); err == nil {
+		t.Fatal("unsupported shell must be rejected")
+	}
+}
@@ -0,0 +1,50 @@
+package testpolicy
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/policy"
+)
+
+type Controller struct {
+	configFinder       ConfigFinder
+	configReader       ConfigReader
+	registryInstaller  RegistryInstaller
+	policyReader       PolicyReader
+	policyConfigReader policy.ConfigReader
+	stdout             io.Writer
+}
+
+func New(configFinder ConfigFinder, configReader ConfigReader, registryInstaller RegistryInstaller, policyReader PolicyReader, policyConfigReader policy.ConfigReader) *Controller {
+	return &Controller{
+		configFinder:       configFinder,
+		configReader:       configReader,
+		registryInstaller:  registryInstaller,
+		policyReader:       policyReader,
+		policyConfigReader: policyConfigReader,
+		stdout:             os.Stdout,
+	}
+}
+
+type ConfigFinder interface {
+	Finds(wd, configFilePath string) []string
+}
+
+type ConfigReader interface {
+	Read(logger *slog.Logger, configFilePath string, cfg *aqua.Config) error
+}
+
+type RegistryInstaller interface {
+	InstallRegistries(ctx context.Context, logger *slog.Logger, cfg *aqua.Config, cfgFilePath string, checksums *checksum.Checksums) (map[string]*registry.Config, error)
+}
+
+type PolicyReader interface {
+	Read(policyFilePaths []string) ([]*policy.Config, error)
+	Append(logger *slog.Logger, aquaYAMLPath string, policies []*policy.Config, globalPolicyPaths map[string]struct{}) ([]*policy.Config, error)
+}
@@ -0,0 +1,248 @@
+package testpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/aquaproj/aqua/v2/pkg/policy"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+var (
+	errDenied              = errors.New("some packages aren't allowed by policies")
+	errUnresolvedPackages  = errors.New("some packages can't be resolved from registries")
+	errUnknownOutputFormat = errors.New("unknown output format")
+)
+
+// Decision is whether policies allow a package in a configuration file.
+type Decision struct {
+	ConfigFilePath string `json:"config_file_path"`
+	Package        string `json:"package"`
+	Version        string `json:"version"`
+	Registry       string `json:"registry"`
+	Outcome        string `json:"outcome"`
+	PolicyFile     string `json:"policy_file,omitempty"`
+	DefaultPolicy  bool   `json:"default_policy,omitempty"`
+	Rule           string `json:"rule,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// Result is the output of "aqua policy test -format json".
+type Result struct {
+	Decisions []*Decision `json:"decisions"`
+}
+
+// Test evaluates packages in configuration files against policies without installing them.
+// Registries are downloaded to resolve packages, but assets of packages aren't downloaded.
+// If policyFilePaths are given, only they are evaluated regardless of whether they're allowed by "aqua policy allow".
+// Otherwise, policies are read in the same way as "aqua install".
+// Packages which can't be resolved from registries can't be evaluated, so they fail the command.
+func (c *Controller) Test(ctx context.Context, logger *slog.Logger, param *config.Param, policyFilePaths []string) error {
+	if err := validateOutputFormat(param.OutputFormat); err != nil {
+		return err
+	}
+	cfgFilePaths := make([]string, 0, len(param.Args))
+	for _, arg := range param.Args {
+		cfgFilePaths = append(cfgFilePaths, osfile.Abs(param.CWD, arg))
+	}
+	if len(cfgFilePaths) == 0 {
+		cfgFilePaths = c.configFinder.Finds(param.CWD, param.ConfigFilePath)
+	}
+
+	policies, err := c.readPolicies(param, policyFilePaths)
+	if err != nil {
+		return err
+	}
+
+	result := &Result{
+		Decisions: []*Decision{},
+	}
+	unresolved := false
+	for _, cfgFilePath := range cfgFilePaths {
+		decisions, failed, err := c.test(ctx, logger, param, cfgFilePath, policies)
+		if err != nil {
+			return fmt.Errorf("test packages: %w", slogerr.With(err,
+				"config_file_path", cfgFilePath,
+			))
+		}
+		if failed {
+			unresolved = true
+		}
+		result.Decisions = append(result.Decisions, decisions...)
+	}
+	if err := output(c.stdout, param.OutputFormat, result); err != nil {
+		return err
+	}
+	denied := 0
+	for _, decision := range result.Decisions {
+		if decision.Outcome == policy.OutcomeDeny {
+			denied++
+		}
+	}
+	if denied != 0 {
+		return slogerr.With(errDenied, "denied_packages", denied) //nolint:wrapcheck
+	}
+	if unresolved {
+		return errUnresolvedPackages
+	}
+	return nil
+}
+
+type policies struct {
+	configs     []*policy.Config
+	globalPaths map[string]struct{}
+	// fixed is true if policy files are given by the command line.
+	// Then policy files aren't searched from configuration files.
+	fixed bool
+}
+
+func (c *Controller) readPolicies(param *config.Param, policyFilePaths []string) (*policies, error) {
+	if len(policyFilePaths) != 0 {
+		paths := make([]string, len(policyFilePaths))
+		for i, p := range policyFilePaths {
+			paths[i] = osfile.Abs(param.CWD, p)
+		}
+		cfgs, err := c.policyConfigReader.Read(paths)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		return &policies{
+			configs: cfgs,
+			fixed:   true,
+		}, nil
+	}
+	cfgs, err := c.policyReader.Read(param.PolicyConfigFilePaths)
+	if err != nil {
+		return nil, fmt.Errorf("read policy files: %w", err)
+	}
+	globalPaths := make(map[string]struct{}, len(param.PolicyConfigFilePaths))
+	for _, p := range param.PolicyConfigFilePaths {
+		globalPaths[p] = struct{}{}
+	}
+	return &policies{
+		configs:     cfgs,
+		globalPaths: globalPaths,
+	}, nil
+}
+
+// test evaluates packages in a configuration file.
+// It returns true if some packages can't be resolved.
+func (c *Controller) test(ctx context.Context, logger *slog.Logger, param *config.Param, cfgFilePath string, plcs *policies) ([]*Decision, bool, error) {
+	cfg := &aqua.Config{}
+	if err := c.configReader.Read(logger, cfgFilePath, cfg); err != nil {
+		return nil, false, err //nolint:wrapcheck
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, false, fmt.Errorf("validate the configuration: %w", err)
+	}
+
+	policyCfgs := plcs.configs
+	if !plcs.fixed {
+		a, err := c.policyReader.Append(logger, cfgFilePath, plcs.configs, plcs.globalPaths)
+		if err != nil {
+			return nil, false, err //nolint:wrapcheck
+		}
+		policyCfgs = a
+	}
+
+	// Checksums of registries are verified, but the checksum file isn't updated
+	// because this command only reports decisions.
+	checksums, _, err := checksum.Open(
+		logger, cfgFilePath, param.ChecksumEnabled(cfg))
+	if err != nil {
+		return nil, false, fmt.Errorf("read a checksum JSON: %w", err)
+	}
+
+	registryContents, err := c.registryInstaller.InstallRegistries(ctx, logger, cfg, cfgFilePath, checksums)
+	if err != nil {
+		return nil, false, err //nolint:wrapcheck
+	}
+
+	// Errors of packages which can't be resolved are logged by ListPackagesNotOverride.
+	pkgs, failed := config.ListPackagesNotOverride(logger, cfg, registryContents)
+	decisions := make([]*Decision, 0, len(pkgs))
+	disabled := policy.DisabledVerifications(param, param.ChecksumEnabled(cfg))
+	for _, pkg := range pkgs {
+		pkg.Registry = cfg.Registries[pkg.Package.Registry]
+		decisions = append(decisions, evaluate(logger, cfgFilePath, pkg, policyCfgs, disabled))
+	}
+	return decisions, failed, nil
+}
+
+func evaluate(logger *slog.Logger, cfgFilePath string, pkg *config.Package, policyCfgs []*policy.Config, disabled []string) *Decision {
+	decision := &Decision{
+		ConfigFilePath: cfgFilePath,
+		Package:        pkg.Package.Name,
+		Version:        pkg.Package.Version,
+		Registry:       pkg.Package.Registry,
+		Outcome:        policy.OutcomeDeny,
+	}
+	if err := policy.ValidateRegistry(logger, pkg.Registry, policyCfgs); err != nil {
+		decision.Reason = err.Error()
+		return decision
+	}
+	d, err := policy.EvaluatePackage(logger, pkg, policyCfgs, disabled)
+	decision.Outcome = d.Outcome
+	decision.PolicyFile = d.PolicyFile
+	decision.DefaultPolicy = d.DefaultPolicy
+	decision.Rule = d.Rule
+	if err != nil {
+		decision.Reason = err.Error()
+	}
+	return decision
+}
+
+func validateOutputFormat(format string) error {
+	switch format {
+	case "", "text", "json":
+		return nil
+	default:
+		return slogerr.With(errUnknownOutputFormat, "format", format) //nolint:wrapcheck
+	}
+}
+
+func output(w io.Writer, format string, result *Result) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("encode the result as JSON: %w", err)
+		}
+		return nil
+	case "", "text":
+		for _, d := range result.Decisions {
+			detail := d.Reason
+			if rule := ruleLocation(d); rule != "" {
+				if detail == "" {
+					detail = rule
+				} else {
+					detail += " (" + rule + ")"
+				}
+			}
+			fmt.Fprintf(w, "%s\t%s@%s\t%s\t%s\t%s\n", d.Outcome, d.Package, d.Version, d.Registry, d.ConfigFilePath, detail)
+		}
+		return nil
+	default:
+		return slogerr.With(errUnknownOutputFormat, "format", format) //nolint:wrapcheck
+	}
+}
+
+// ruleLocation returns the policy rule which allows the package or whose requirements the package doesn't meet.
+func ruleLocation(d *Decision) string {
+	if d.Rule == "" {
+		return ""
+	}
+	if d.DefaultPolicy {
+		return "default policy " + d.Rule
+	}
+	return d.PolicyFile + " " + d.Rule
+}
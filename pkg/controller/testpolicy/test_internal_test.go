@@ -0,0 +1,165 @@
+package testpolicy
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/policy"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_evaluate(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	localRegistry := &aqua.Registry{
+		Name: "local",
+		Type: "local",
+		Path: "/home/foo/registry.yaml",
+	}
+	localPolicies := []*policy.Config{
+		{
+			Path: "/home/foo/aqua-policy.yaml",
+			YAML: &policy.ConfigYAML{
+				Registries: []*policy.Registry{
+					{
+						Name: "local",
+						Type: "local",
+						Path: "/home/foo/registry.yaml",
+					},
+				},
+				Packages: []*policy.Package{
+					{
+						Name: "cli/cli",
+						Registry: &policy.Registry{
+							Name: "local",
+							Type: "local",
+							Path: "/home/foo/registry.yaml",
+						},
+					},
+				},
+			},
+		},
+	}
+	data := []struct {
+		name     string
+		pkg      *config.Package
+		policies []*policy.Config
+		exp      *Decision
+	}{
+		{
+			name: "allowed",
+			pkg: &config.Package{
+				Package:     &aqua.Package{Name: "cli/cli", Version: "v2.40.0", Registry: "local"},
+				PackageInfo: &registry.PackageInfo{},
+				Registry:    localRegistry,
+			},
+			policies: localPolicies,
+			exp: &Decision{
+				ConfigFilePath: "aqua.yaml",
+				Package:        "cli/cli",
+				Version:        "v2.40.0",
+				Registry:       "local",
+				Outcome:        policy.OutcomeAllow,
+				PolicyFile:     "/home/foo/aqua-policy.yaml",
+				Rule:           "packages[0]",
+			},
+		},
+		{
+			name: "package isn't allowed",
+			pkg: &config.Package{
+				Package:     &aqua.Package{Name: "suzuki-shunsuke/tfcmt", Version: "v4.0.0", Registry: "local"},
+				PackageInfo: &registry.PackageInfo{},
+				Registry:    localRegistry,
+			},
+			policies: localPolicies,
+			exp: &Decision{
+				ConfigFilePath: "aqua.yaml",
+				Package:        "suzuki-shunsuke/tfcmt",
+				Version:        "v4.0.0",
+				Registry:       "local",
+				Outcome:        policy.OutcomeDeny,
+				Reason:         "this package isn't allowed",
+			},
+		},
+		{
+			name: "registry isn't allowed by the default policy",
+			pkg: &config.Package{
+				Package:     &aqua.Package{Name: "cli/cli", Version: "v2.40.0", Registry: "local"},
+				PackageInfo: &registry.PackageInfo{},
+				Registry:    localRegistry,
+			},
+			exp: &Decision{
+				ConfigFilePath: "aqua.yaml",
+				Package:        "cli/cli",
+				Version:        "v2.40.0",
+				Registry:       "local",
+				Outcome:        policy.OutcomeDeny,
+				Reason:         "this registry isn't allowed",
+			},
+		},
+	}
+	logger := slog.New(slog.DiscardHandler)
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			decision := evaluate(logger, "aqua.yaml", d.pkg, d.policies, nil)
+			if diff := cmp.Diff(d.exp, decision); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_output(t *testing.T) {
+	t.Parallel()
+	result := &Result{
+		Decisions: []*Decision{
+			{
+				ConfigFilePath: "aqua.yaml",
+				Package:        "cli/cli",
+				Version:        "v2.40.0",
+				Registry:       "standard",
+				Outcome:        policy.OutcomeAllow,
+				DefaultPolicy:  true,
+				Rule:           "packages[0]",
+			},
+			{
+				ConfigFilePath: "aqua.yaml",
+				Package:        "suzuki-shunsuke/tfcmt",
+				Version:        "v4.0.0",
+				Registry:       "standard",
+				Outcome:        policy.OutcomeDeny,
+				PolicyFile:     "aqua-policy.yaml",
+				Rule:           "packages[1]",
+				Reason:         "the package doesn't meet requirements of the policy",
+			},
+		},
+	}
+	buf := &bytes.Buffer{}
+	if err := output(buf, "text", result); err != nil {
+		t.Fatal(err)
+	}
+	exp := "allow\tcli/cli@v2.40.0\tstandard\taqua.yaml\tdefault policy packages[0]\n" +
+		"deny\tsuzuki-shunsuke/tfcmt@v4.0.0\tstandard\taqua.yaml\tthe package doesn't meet requirements of the policy (aqua-policy.yaml packages[1])\n"
+	if diff := cmp.Diff(exp, buf.String()); diff != "" {
+		t.Fatal(diff)
+	}
+	if err := output(buf, "yaml", result); err == nil {
+		t.Fatal("error must be returned")
+	}
+}
+
+func Test_validateOutputFormat(t *testing.T) {
+	t.Parallel()
+	for _, format := range []string{"", "text", "json"} {
+		if err := validateOutputFormat(format); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := validateOutputFormat("yaml"); err == nil {
+		t.Fatal("error must be returned")
+	}
+}
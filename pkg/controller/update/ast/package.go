@@ -10,6 +10,13 @@ import (
 	"github.com/goccy/go-yaml/ast"
 )
 
+// PackageKey returns the key of newVersions passed to UpdatePackages.
+// The current version is included so that entries of the same package used side by side
+// with command_suffix are updated separately.
+func PackageKey(registryName, pkgName, version string) string {
+	return fmt.Sprintf("%s,%s@%s", registryName, pkgName, version)
+}
+
 func UpdatePackages(logger *slog.Logger, file *ast.File, newVersions map[string]string) (bool, error) {
 	body := file.Docs[0].Body // DocumentNode
 	mv, err := wast.FindMappingValueFromNode(body, "packages")
@@ -77,7 +84,7 @@ func parsePackageNode(logger *slog.Logger, node ast.Node, newVersions map[string
 	if pkgName == "" {
 		return false, nil
 	}
-	newVersion, ok := newVersions[fmt.Sprintf("%s,%s", registryName, pkgName)]
+	newVersion, ok := newVersions[PackageKey(registryName, pkgName, pkgVersion)]
 	if !ok {
 		logger.Debug("version isn't found")
 		return false, nil
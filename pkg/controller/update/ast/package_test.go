@@ -42,9 +42,26 @@ func TestUpdatePackages(t *testing.T) { //nolint:funlen
     version: v3.0.0
 `,
 			newVersions: map[string]string{
-				"standard,cli/cli":                 "v2.1.0",
-				"standard,suzuki-shunsuke/ci-info": "v4.0.0",
-				"custom,suzuki-shunsuke/tfcmt":     "v4.6.0",
+				"standard,cli/cli@v2.0.0":                 "v2.1.0",
+				"standard,suzuki-shunsuke/ci-info@v3.0.0": "v4.0.0",
+				"custom,suzuki-shunsuke/tfcmt@v4.1.0":     "v4.6.0",
+			},
+			updated: true,
+		},
+		{
+			name: "side by side",
+			file: `packages:
+  - name: helm/helm@v3.14.0
+    command_suffix: auto
+  - name: helm/helm@v4.0.0
+`,
+			expFile: `packages:
+  - name: helm/helm@v3.14.0
+    command_suffix: auto
+  - name: helm/helm@v4.1.0
+`,
+			newVersions: map[string]string{
+				"standard,helm/helm@v4.0.0": "v4.1.0",
 			},
 			updated: true,
 		},
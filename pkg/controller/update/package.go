@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 
 	"github.com/aquaproj/aqua/v2/pkg/config"
 	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
 	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/aquaproj/aqua/v2/pkg/controller/update/ast"
 	"github.com/aquaproj/aqua/v2/pkg/fuzzyfinder"
+	"github.com/aquaproj/aqua/v2/pkg/versionfile"
 	"github.com/goccy/go-yaml/parser"
 )
 
@@ -66,10 +68,19 @@ func (c *Controller) updatePackagesInFile(ctx context.Context, logger *slog.Logg
 			logger.Debug("skip updating the package because package tags are unmatched")
 			continue
 		}
-		if newVersion := c.getPackageNewVersion(ctx, logger, param, updatedPkgs, pkg); newVersion != "" {
-			newVersions[fmt.Sprintf("%s,%s", pkg.Package.Registry, pkg.PackageInfo.GetName())] = newVersion
-			newVersions[fmt.Sprintf("%s,%s", pkg.Package.Registry, pkg.Package.Name)] = newVersion
+		newVersion := c.getPackageNewVersion(ctx, logger, param, updatedPkgs, pkg)
+		if newVersion == "" {
+			continue
+		}
+		if vf := pkg.Package.GetVersionFile(); vf != nil {
+			// The version is managed by the version file, so the version file is updated instead of the configuration file.
+			if err := updateVersionFile(logger, cfgFilePath, vf, newVersion); err != nil {
+				return err
+			}
+			continue
 		}
+		newVersions[ast.PackageKey(pkg.Package.Registry, pkg.PackageInfo.GetName(), pkg.Package.Version)] = newVersion
+		newVersions[ast.PackageKey(pkg.Package.Registry, pkg.Package.Name, pkg.Package.Version)] = newVersion
 	}
 	if len(newVersions) == 0 {
 		return nil
@@ -92,6 +103,12 @@ func (c *Controller) getPackageNewVersion(ctx context.Context, logger *slog.Logg
 			return ""
 		}
 	}
+	if pkg.Package.CommandSuffix != "" && !param.SelectVersion {
+		// The package is used side by side with other versions, so updating it to the latest version may break it.
+		// e.g. helm@3 would be updated to v4.
+		logger.Info("skip updating the package because command_suffix is set. Please select the version with -s or specify the version with <command>@<version>")
+		return ""
+	}
 	return c.fuzzyGetter.Get(ctx, logger, pkg.PackageInfo, pkg.Package.Version, param.SelectVersion, param.Limit)
 }
 
@@ -133,6 +150,22 @@ func (c *Controller) selectPackages(logger *slog.Logger, cfgFilePath string) (ma
 	return updatedPkgs, nil
 }
 
+// updateVersionFile updates the version in the version file of the package.
+func updateVersionFile(logger *slog.Logger, cfgFilePath string, vf *aqua.VersionFile, newVersion string) error {
+	f, err := versionfile.Read(filepath.Dir(cfgFilePath), vf)
+	if err != nil {
+		return fmt.Errorf("read a version file: %w", err)
+	}
+	updated, err := f.Update(newVersion)
+	if err != nil {
+		return fmt.Errorf("update a version file: %w", err)
+	}
+	if updated {
+		logger.Info("updated the version file", "version_file", vf.Path, "new_version", newVersion)
+	}
+	return nil
+}
+
 func (c *Controller) updateFile(logger *slog.Logger, cfgFilePath string, newVersions map[string]string) error {
 	b, err := os.ReadFile(cfgFilePath)
 	if err != nil {
@@ -11,6 +11,8 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/config"
 	finder "github.com/aquaproj/aqua/v2/pkg/config-finder"
 	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/controller/update/ast"
+	"github.com/aquaproj/aqua/v2/pkg/controller/which"
 )
 
 func (c *Controller) Update(ctx context.Context, logger *slog.Logger, param *config.Param) error {
@@ -42,10 +44,9 @@ func (c *Controller) updateCommands(ctx context.Context, logger *slog.Logger, pa
 }
 
 func (c *Controller) updateCommand(ctx context.Context, logger *slog.Logger, param *config.Param, newVersions map[string]string, cmd string) error {
-	command, newVersion, _ := strings.Cut(cmd, "@")
-	findResult, err := c.which.Which(ctx, logger, param, command)
+	findResult, newVersion, err := c.findCommand(ctx, logger, param, cmd)
 	if err != nil {
-		return fmt.Errorf("find a command: %w", err)
+		return err
 	}
 
 	if findResult.Package == nil {
@@ -53,12 +54,15 @@ func (c *Controller) updateCommand(ctx context.Context, logger *slog.Logger, par
 	}
 
 	pkg := findResult.Package
+	if vf := pkg.Package.GetVersionFile(); vf != nil {
+		return c.updateCommandVersionFile(ctx, logger, param, findResult, vf, newVersion)
+	}
 	if newVersion != "" {
-		newVersions[fmt.Sprintf("%s,%s", pkg.Package.Registry, pkg.PackageInfo.GetName())] = newVersion
-		newVersions[fmt.Sprintf("%s,%s", pkg.Package.Registry, pkg.Package.Name)] = newVersion
+		newVersions[ast.PackageKey(pkg.Package.Registry, pkg.PackageInfo.GetName(), pkg.Package.Version)] = newVersion
+		newVersions[ast.PackageKey(pkg.Package.Registry, pkg.Package.Name, pkg.Package.Version)] = newVersion
 	} else if newVersion := c.getPackageNewVersion(ctx, logger, param, nil, pkg); newVersion != "" {
-		newVersions[fmt.Sprintf("%s,%s", pkg.Package.Registry, pkg.PackageInfo.GetName())] = newVersion
-		newVersions[fmt.Sprintf("%s,%s", pkg.Package.Registry, pkg.Package.Name)] = newVersion
+		newVersions[ast.PackageKey(pkg.Package.Registry, pkg.PackageInfo.GetName(), pkg.Package.Version)] = newVersion
+		newVersions[ast.PackageKey(pkg.Package.Registry, pkg.Package.Name, pkg.Package.Version)] = newVersion
 	}
 	filePath := findResult.ConfigFilePath
 	if pkg.Package.FilePath != "" {
@@ -70,6 +74,51 @@ func (c *Controller) updateCommand(ctx context.Context, logger *slog.Logger, par
 	return nil
 }
 
+// findCommand finds the package of the command and returns the version specified with <command>@<version>.
+// A command may contain "@" because of command_suffix such as helm@3,
+// so the whole argument is looked up first, and then it's split at the last "@".
+func (c *Controller) findCommand(ctx context.Context, logger *slog.Logger, param *config.Param, cmd string) (*which.FindResult, string, error) {
+	findResult, err := c.which.Which(ctx, logger, param, cmd)
+	if err == nil && findResult.Package != nil {
+		return findResult, "", nil
+	}
+	idx := strings.LastIndex(cmd, "@")
+	if idx == -1 {
+		if err != nil {
+			return nil, "", fmt.Errorf("find a command: %w", err)
+		}
+		return findResult, "", nil
+	}
+	findResult, err = c.which.Which(ctx, logger, param, cmd[:idx])
+	if err != nil {
+		return nil, "", fmt.Errorf("find a command: %w", err)
+	}
+	return findResult, cmd[idx+1:], nil
+}
+
+// updateCommandVersionFile updates the version file of the package providing the command.
+// If the update of the version file is disabled, the package is left alone like the update without arguments.
+func (c *Controller) updateCommandVersionFile(ctx context.Context, logger *slog.Logger, param *config.Param, findResult *which.FindResult, vf *aqua.VersionFile, newVersion string) error {
+	pkg := findResult.Package
+	if !vf.Update {
+		logger.Warn("skip updating the package because the version is read from the version file. Please set version_file.update to true to update the version file",
+			"package_name", pkg.Package.Name,
+			"version_file", vf.Path)
+		return nil
+	}
+	if newVersion == "" {
+		newVersion = c.getPackageNewVersion(ctx, logger, param, nil, pkg)
+		if newVersion == "" {
+			return nil
+		}
+	}
+	filePath := findResult.ConfigFilePath
+	if pkg.Package.FilePath != "" {
+		filePath = pkg.Package.FilePath
+	}
+	return updateVersionFile(logger, filePath, vf, newVersion)
+}
+
 func (c *Controller) update(ctx context.Context, logger *slog.Logger, param *config.Param, cfgFilePath string) error { //nolint:cyclop
 	cfg := &aqua.Config{}
 	if cfgFilePath == "" {
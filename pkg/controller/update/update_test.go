@@ -190,6 +190,62 @@ registries:
 packages:
 - name: suzuki-shunsuke/tfcmt@v4.0.0
 - name: cli/cli@v2.30.0
+`,
+			},
+			releases: []*github.RepositoryRelease{
+				{
+					TagName: "v4.60.0",
+				},
+			},
+		},
+		{
+			name: "side by side",
+			rt: &runtime.Runtime{
+				GOOS:   osDarwin,
+				GOARCH: archArm64,
+			},
+			param: &config.Param{
+				CWD: pathWorkspace,
+			},
+			versions: map[string]string{
+				repoCliCli: "v2.30.0",
+			},
+			registries: map[string]*registry.Config{
+				regTypeStandard: {
+					PackageInfos: registry.PackageInfos{
+						{
+							Type:      pkgTypeGitHubRelease,
+							RepoOwner: repoOwnerCli,
+							RepoName:  repoOwnerCli,
+							Asset:     tmplGhAsset,
+							Files: []*registry.File{
+								{
+									Name: "gh",
+									Src:  tmplGhBinSrc,
+								},
+							},
+						},
+					},
+				},
+			},
+			files: map[string]string{
+				pathWorkspaceYaml: `registries:
+- type: standard
+  ref: v4.60.0
+packages:
+- name: cli/cli@v1.14.0
+  command_suffix: auto
+- name: cli/cli@v2.0.0
+`,
+			},
+			expFiles: map[string]string{
+				pathWorkspaceYaml: `registries:
+- type: standard
+  ref: v4.60.0
+packages:
+- name: cli/cli@v1.14.0
+  command_suffix: auto
+- name: cli/cli@v2.30.0
 `,
 			},
 			releases: []*github.RepositoryRelease{
@@ -285,6 +341,77 @@ packages:
 `,
 			},
 		},
+		{
+			name: "version files",
+			rt: &runtime.Runtime{
+				GOOS:   osDarwin,
+				GOARCH: archArm64,
+			},
+			param: &config.Param{
+				CWD:         pathWorkspace,
+				OnlyPackage: true,
+			},
+			versions: map[string]string{
+				repoSuzukiTfcmt: "v4.0.0",
+				repoCliCli:      "v2.30.0",
+			},
+			registries: map[string]*registry.Config{
+				regTypeStandard: {
+					PackageInfos: registry.PackageInfos{
+						{
+							Type:      pkgTypeGitHubRelease,
+							RepoOwner: repoOwnerSuzuki,
+							RepoName:  pkgNameTfcmt,
+							Asset:     tmplTfcmtAsset,
+						},
+						{
+							Type:      pkgTypeGitHubRelease,
+							RepoOwner: repoOwnerCli,
+							RepoName:  repoOwnerCli,
+							Asset:     tmplGhAsset,
+						},
+					},
+				},
+			},
+			files: map[string]string{
+				pathWorkspaceYaml: `registries:
+- type: standard
+  ref: v4.0.0
+packages:
+- name: suzuki-shunsuke/tfcmt
+  version_file:
+    path: .tool-versions
+    tool: tfcmt
+    prefix: v
+    update: true
+- name: cli/cli
+  version_file:
+    path: .gh-version
+    prefix: v
+`,
+				"/workspace/.tool-versions": "tfcmt 3.0.0\n",
+				"/workspace/.gh-version":    "2.0.0\n",
+			},
+			expFiles: map[string]string{
+				pathWorkspaceYaml: `registries:
+- type: standard
+  ref: v4.0.0
+packages:
+- name: suzuki-shunsuke/tfcmt
+  version_file:
+    path: .tool-versions
+    tool: tfcmt
+    prefix: v
+    update: true
+- name: cli/cli
+  version_file:
+    path: .gh-version
+    prefix: v
+`,
+				"/workspace/.tool-versions": "tfcmt 4.0.0\n",
+				"/workspace/.gh-version":    "2.0.0\n",
+			},
+		},
 		{
 			name: "select packages",
 			rt: &runtime.Runtime{
@@ -10,6 +10,7 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/aquaproj/aqua/v2/pkg/domain"
 	"github.com/aquaproj/aqua/v2/pkg/download"
+	"github.com/aquaproj/aqua/v2/pkg/policy"
 	"github.com/aquaproj/aqua/v2/pkg/runtime"
 )
 
@@ -27,10 +28,13 @@ type Controller struct {
 	chkDL                download.ChecksumDownloader
 	downloader           download.ClientAPI
 	checksumFileVerifier ChecksumFileVerifier
+	policyReader         PolicyReader
+	policyAuditLog       *policy.AuditLog
 	prune                bool
+	disablePolicy        bool
 }
 
-func New(param *config.Param, configFinder ConfigFinder, configReader ConfigReader, registryInstaller RegistryInstaller, rt *runtime.Runtime, chkDL download.ChecksumDownloader, pkgDownloader download.ClientAPI, registryDownloader GitHubContentFileDownloader, checksumFileVerifier ChecksumFileVerifier) *Controller {
+func New(param *config.Param, configFinder ConfigFinder, configReader ConfigReader, registryInstaller RegistryInstaller, rt *runtime.Runtime, chkDL download.ChecksumDownloader, pkgDownloader download.ClientAPI, registryDownloader GitHubContentFileDownloader, checksumFileVerifier ChecksumFileVerifier, policyReader PolicyReader) *Controller {
 	return &Controller{
 		rootDir:              param.RootDir,
 		configFinder:         configFinder,
@@ -41,10 +45,18 @@ func New(param *config.Param, configFinder ConfigFinder, configReader ConfigRead
 		chkDL:                chkDL,
 		downloader:           pkgDownloader,
 		checksumFileVerifier: checksumFileVerifier,
+		policyReader:         policyReader,
+		policyAuditLog:       policy.NewAuditLog(param.PolicyAuditLog, param.SubCommand),
 		prune:                param.Prune,
+		disablePolicy:        param.DisablePolicy,
 	}
 }
 
+type PolicyReader interface {
+	Read(policyFilePaths []string) ([]*policy.Config, error)
+	Append(logger *slog.Logger, aquaYAMLPath string, policies []*policy.Config, globalPolicyPaths map[string]struct{}) ([]*policy.Config, error)
+}
+
 type ConfigFinder interface {
 	Finds(wd, configFilePath string) []string
 }
@@ -16,21 +16,29 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/aquaproj/aqua/v2/pkg/domain"
 	"github.com/aquaproj/aqua/v2/pkg/download"
+	"github.com/aquaproj/aqua/v2/pkg/policy"
 	"github.com/aquaproj/aqua/v2/pkg/runtime"
 	"github.com/suzuki-shunsuke/slog-error/slogerr"
 )
 
+type policies struct {
+	configs     []*policy.Config
+	globalPaths map[string]struct{}
+}
+
 func (c *Controller) UpdateChecksum(ctx context.Context, logger *slog.Logger, param *config.Param) error {
+	plcs := c.readPolicies(logger, param)
+
 	for _, cfgFilePath := range c.configFinder.Finds(param.CWD, param.ConfigFilePath) {
-		if err := c.updateChecksum(ctx, logger, cfgFilePath); err != nil {
+		if err := c.updateChecksum(ctx, logger, cfgFilePath, plcs); err != nil {
 			return err
 		}
 	}
 
-	return c.updateGlobalChecksumFiles(ctx, logger, param)
+	return c.updateGlobalChecksumFiles(ctx, logger, param, plcs)
 }
 
-func (c *Controller) updateGlobalChecksumFiles(ctx context.Context, logger *slog.Logger, param *config.Param) error {
+func (c *Controller) updateGlobalChecksumFiles(ctx context.Context, logger *slog.Logger, param *config.Param, plcs *policies) error {
 	if !param.All {
 		return nil
 	}
@@ -38,14 +46,14 @@ func (c *Controller) updateGlobalChecksumFiles(ctx context.Context, logger *slog
 		if _, err := os.Stat(cfgFilePath); err != nil {
 			continue
 		}
-		if err := c.updateChecksum(ctx, logger, cfgFilePath); err != nil {
+		if err := c.updateChecksum(ctx, logger, cfgFilePath, plcs); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (c *Controller) updateChecksum(ctx context.Context, logger *slog.Logger, cfgFilePath string) (namedErr error) { //nolint:cyclop
+func (c *Controller) updateChecksum(ctx context.Context, logger *slog.Logger, cfgFilePath string, plcs *policies) (namedErr error) { //nolint:cyclop,funlen
 	cfg := &aqua.Config{}
 	if cfgFilePath == "" {
 		return finder.ErrConfigFileNotFound
@@ -54,6 +62,8 @@ func (c *Controller) updateChecksum(ctx context.Context, logger *slog.Logger, cf
 		return err //nolint:wrapcheck
 	}
 
+	policyCfgs, record := c.appendPolicies(logger, cfgFilePath, plcs)
+
 	checksums := checksum.New()
 	checksums.EnableOutput()
 	checksumFilePath, err := checksum.GetChecksumFilePathFromConfigFilePath(cfgFilePath)
@@ -97,6 +107,10 @@ func (c *Controller) updateChecksum(ctx context.Context, logger *slog.Logger, cf
 			"package_version", pkg.Package.Version,
 			"package_registry", pkg.Package.Registry,
 		)
+		pkg.Registry = cfg.Registries[pkg.Package.Registry]
+		if record {
+			c.recordDecision(logger, pkg, policyCfgs)
+		}
 		if err := c.updatePackage(ctx, logger, checksums, pkg, supportedEnvs); err != nil {
 			failed = true
 			slogerr.WithError(logger, err).Error("update checksums")
@@ -108,6 +122,53 @@ func (c *Controller) updateChecksum(ctx context.Context, logger *slog.Logger, cf
 	return nil
 }
 
+// readPolicies reads policy files to record policy decisions to the audit log.
+// update-checksum doesn't enforce policies, so it returns nil without failing
+// if decisions aren't recorded or policy files can't be read.
+func (c *Controller) readPolicies(logger *slog.Logger, param *config.Param) *policies {
+	if c.disablePolicy || c.policyAuditLog == nil {
+		return nil
+	}
+	policyCfgs, err := c.policyReader.Read(param.PolicyConfigFilePaths)
+	if err != nil {
+		slogerr.WithError(logger, err).Warn("read policy files, so policy decisions aren't recorded")
+		return nil
+	}
+	globalPolicyPaths := make(map[string]struct{}, len(param.PolicyConfigFilePaths))
+	for _, p := range param.PolicyConfigFilePaths {
+		globalPolicyPaths[p] = struct{}{}
+	}
+	return &policies{
+		configs:     policyCfgs,
+		globalPaths: globalPolicyPaths,
+	}
+}
+
+// appendPolicies appends policy files for the configuration file.
+// The second returned value is false if policy decisions aren't recorded.
+func (c *Controller) appendPolicies(logger *slog.Logger, cfgFilePath string, plcs *policies) ([]*policy.Config, bool) {
+	if plcs == nil {
+		return nil, false
+	}
+	policyCfgs, err := c.policyReader.Append(logger, cfgFilePath, plcs.configs, plcs.globalPaths)
+	if err != nil {
+		slogerr.WithError(logger, err).Warn("read policy files, so policy decisions aren't recorded",
+			"config_file_path", cfgFilePath)
+		return nil, false
+	}
+	return policyCfgs, true
+}
+
+// recordDecision records the policy decision on the package to the audit log.
+// update-checksum doesn't enforce policies, so packages denied by policies are still processed.
+func (c *Controller) recordDecision(logger *slog.Logger, pkg *config.Package, policyCfgs []*policy.Config) {
+	// update-checksum doesn't verify signatures, so disabled verifications don't matter.
+	decision, _ := policy.EvaluatePackage(logger, pkg, policyCfgs, nil)
+	if err := c.policyAuditLog.Record(decision); err != nil {
+		slogerr.WithError(logger, err).Warn("record the policy decision")
+	}
+}
+
 func (c *Controller) updateRegistry(ctx context.Context, logger *slog.Logger, checksums *checksum.Checksums, rgst *aqua.Registry) error {
 	if rgst.Type != "github_content" {
 		return nil
@@ -269,12 +330,12 @@ func (c *Controller) prefetchReleaseAssets(ctx context.Context, logger *slog.Log
 }
 
 // hasChecksumSignatureVerification returns true if the checksum has signature verification configured
-// (Cosign, Minisign, or GitHubArtifactAttestations).
+// (Cosign, Minisign, PGP, or GitHubArtifactAttestations).
 func hasChecksumSignatureVerification(chksum *registry.Checksum) bool {
 	if chksum == nil {
 		return false
 	}
-	return chksum.GetCosign() != nil || chksum.GetMinisign() != nil || chksum.GetGitHubArtifactAttestations() != nil
+	return chksum.GetCosign() != nil || chksum.GetMinisign() != nil || chksum.GetPGP() != nil || chksum.GetGitHubArtifactAttestations() != nil
 }
 
 func (c *Controller) getChecksums(ctx context.Context, logger *slog.Logger, pkg *config.Package, checksumFiles map[string]struct{}, rt *runtime.Runtime, assetNames map[string]struct{}, checksumID string, releaseAssets domain.ReleaseAssets) ([]*checksum.Checksum, error) {
@@ -16,6 +16,7 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/domain"
 	"github.com/aquaproj/aqua/v2/pkg/download"
 	rgst "github.com/aquaproj/aqua/v2/pkg/install-registry"
+	"github.com/aquaproj/aqua/v2/pkg/policy"
 	"github.com/aquaproj/aqua/v2/pkg/runtime"
 )
 
@@ -31,10 +32,12 @@ func TestController_UpdateChecksum(t *testing.T) { //nolint:funlen
 		// expChecksums are IDs that must appear in the checksum file the command
 		// writes next to the configuration file.
 		expChecksums []string
-		rt           *runtime.Runtime
-		chkDL        download.ChecksumDownloader
-		downloader   download.ClientAPI
-		isErr        bool
+		// expOutcome is the policy decision which must be recorded in the audit log.
+		expOutcome string
+		rt         *runtime.Runtime
+		chkDL      download.ChecksumDownloader
+		downloader download.ClientAPI
+		isErr      bool
 	}{
 		{
 			name: "normal",
@@ -170,6 +173,60 @@ ed2ed654e1afb92e5292a43213e17ecb0fe0ec50c19fe69f0d185316a17d39fa  gh_2.17.0_linu
 			},
 			downloader: &download.Mock{},
 		},
+		{
+			name: "a package denied by policies is recorded but its checksum is updated",
+			param: &config.Param{
+				All: true,
+			},
+			cfgFiles: []string{"aqua.yaml"},
+			expChecksums: []string{
+				"github_release/github.com/cli/cli/v2.17.0/",
+			},
+			expOutcome: policy.OutcomeDeny,
+			cfgReader: &reader.MockConfigReader{
+				Cfg: &aqua.Config{
+					Checksum: &aqua.Checksum{
+						Enabled: new(true),
+					},
+					Registries: aqua.Registries{
+						"local": {
+							Name: "local",
+							Type: "local",
+							Path: "registry.yaml",
+						},
+					},
+					Packages: []*aqua.Package{
+						{
+							Name:     "cli/cli",
+							Version:  "v2.17.0",
+							Registry: "local",
+						},
+					},
+				},
+			},
+			registryInstaller: &rgst.MockInstaller{
+				M: map[string]*registry.Config{
+					"local": {
+						PackageInfos: registry.PackageInfos{
+							{
+								RepoOwner: "cli",
+								RepoName:  "cli",
+								Type:      "github_release",
+								Asset:     "gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.{{.Format}}",
+							},
+						},
+					},
+				},
+			},
+			rt: &runtime.Runtime{
+				GOOS:   "darwin",
+				GOARCH: "arm64",
+			},
+			chkDL: &download.MockChecksumDownloader{},
+			downloader: &download.Mock{
+				RC: io.NopCloser(strings.NewReader("hello")),
+			},
+		},
 	}
 	logger := slog.New(slog.DiscardHandler)
 	for _, d := range data {
@@ -185,7 +242,11 @@ ed2ed654e1afb92e5292a43213e17ecb0fe0ec50c19fe69f0d185316a17d39fa  gh_2.17.0_linu
 				cfgFiles[i] = filepath.Join(dir, f)
 			}
 			cfgFinder := &updatechecksum.MockConfigFinder{Files: cfgFiles}
-			ctrl := updatechecksum.New(d.param, cfgFinder, d.cfgReader, d.registryInstaller, d.rt, d.chkDL, d.downloader, d.registryDownloader, &updatechecksum.MockChecksumFileVerifier{})
+			auditLogPath := filepath.Join(dir, "policy-audit.jsonl")
+			if d.expOutcome != "" {
+				d.param.PolicyAuditLog = auditLogPath
+			}
+			ctrl := updatechecksum.New(d.param, cfgFinder, d.cfgReader, d.registryInstaller, d.rt, d.chkDL, d.downloader, d.registryDownloader, &updatechecksum.MockChecksumFileVerifier{}, &policy.MockReader{})
 			if err := ctrl.UpdateChecksum(ctx, logger, d.param); err != nil {
 				if d.isErr {
 					return
@@ -210,6 +271,15 @@ ed2ed654e1afb92e5292a43213e17ecb0fe0ec50c19fe69f0d185316a17d39fa  gh_2.17.0_linu
 					}
 				}
 			}
+			if d.expOutcome != "" {
+				b, err := os.ReadFile(auditLogPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(string(b), `"outcome":"`+d.expOutcome+`"`) {
+					t.Fatalf("the audit log doesn't have the decision %s: %s", d.expOutcome, string(b))
+				}
+			}
 		})
 	}
 }
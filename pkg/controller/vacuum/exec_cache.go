@@ -0,0 +1,53 @@
+package vacuum
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/vacuum"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// vacuumExecCache removes entries of the exec cache which haven't been written for over the expiration days.
+// Entries are rewritten when they're outdated, so old entries are likely for configuration files
+// and aqua versions which are no longer used.
+// Even if a removed entry is still used, aqua exec just resolves the command again.
+func (c *Controller) vacuumExecCache(logger *slog.Logger, timestampChecker *vacuum.TimestampChecker) error {
+	dir := config.ExecCacheDir(c.rootDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("read the exec cache directory: %w", slogerr.With(err, "exec_cache_dir", dir))
+	}
+	removed := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return fmt.Errorf("get a file stat of the exec cache: %w", slogerr.With(err, "exec_cache", entry.Name()))
+		}
+		if !timestampChecker.Expired(info.ModTime()) {
+			continue
+		}
+		p := filepath.Join(dir, entry.Name())
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove the exec cache: %w", slogerr.With(err, "exec_cache", p))
+		}
+		removed++
+	}
+	if removed != 0 {
+		logger.Info("removed the exec cache", "removed_entries", removed)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package vacuum
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/vacuum"
+)
+
+func TestController_vacuumExecCache(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.DiscardHandler)
+	rootDir := t.TempDir()
+	dir := config.ExecCacheDir(rootDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for name, d := range map[string]time.Duration{
+		"old.json": -61 * 24 * time.Hour,
+		"new.json": -time.Hour,
+	} {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte("{}"), 0o644); err != nil { //nolint:gosec
+			t.Fatal(err)
+		}
+		tm := now.Add(d)
+		if err := os.Chtimes(p, tm, tm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := &Controller{rootDir: rootDir}
+	if err := c.vacuumExecCache(logger, vacuum.NewTimestampChecker(now, 60)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "old.json")); !os.IsNotExist(err) {
+		t.Fatal("the expired exec cache must be removed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "new.json")); err != nil {
+		t.Fatal(err)
+	}
+	// The exec cache directory may not exist.
+	c = &Controller{rootDir: t.TempDir()}
+	if err := c.vacuumExecCache(logger, vacuum.NewTimestampChecker(now, 60)); err != nil {
+		t.Fatal(err)
+	}
+}
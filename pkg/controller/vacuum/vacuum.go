@@ -30,11 +30,17 @@ func (c *Controller) Vacuum(logger *slog.Logger, param *config.Param) error {
 				"package_path", p,
 			))
 		}
+		// remove links to shell completions and man pages of the package
+		if err := os.RemoveAll(filepath.Join(config.ShareDir(c.rootDir), pkgPath)); err != nil {
+			return fmt.Errorf("remove share files of a package: %w", slogerr.With(err,
+				"package_path", pkgPath,
+			))
+		}
 		// remove the timestamp file
 		if err := c.vacuum.Remove(pkgPath); err != nil {
 			return fmt.Errorf("remove a timestamp file: %w", err)
 		}
 		logger.Info("removed the package")
 	}
-	return nil
+	return c.vacuumExecCache(logger, timestampChecker)
 }
@@ -0,0 +1,247 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/installpackage"
+	"github.com/aquaproj/aqua/v2/pkg/manifest"
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/aquaproj/aqua/v2/pkg/policy"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+var (
+	errTampered        = errors.New("some installed packages don't match their manifests")
+	errReinstallFailed = errors.New("failed to reinstall some packages")
+)
+
+// Verify hashes files of installed packages again and compares them with manifests recorded at installation.
+// If param.All is true, all installed packages are verified.
+// Otherwise, packages in configuration files are verified.
+func (c *Controller) Verify(ctx context.Context, logger *slog.Logger, param *config.Param) error {
+	if param.All {
+		return c.verifyAll(logger, param)
+	}
+	return c.verifyConfigs(ctx, logger, param)
+}
+
+func (c *Controller) verifyAll(logger *slog.Logger, param *config.Param) error {
+	manifests, err := c.manifest.FindAll()
+	if err != nil {
+		return fmt.Errorf("find manifests: %w", err)
+	}
+	pkgPaths := make([]string, 0, len(manifests))
+	for pkgPath := range manifests {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	slices.Sort(pkgPaths)
+	tampered := false
+	for _, pkgPath := range pkgPaths {
+		m := manifests[pkgPath]
+		logger := logger.With("package_name", m.Package, "package_version", m.Version)
+		ok, err := c.verifyPackage(logger, pkgPath, m)
+		if err != nil {
+			return err
+		}
+		if ok {
+			continue
+		}
+		if !param.Reinstall {
+			tampered = true
+			continue
+		}
+		// Packages which aren't in configuration files can't be installed here,
+		// but aqua installs them again when they are executed.
+		if err := c.remove(pkgPath); err != nil {
+			return err
+		}
+		logger.Info("removed the package. It will be installed again when it's executed")
+	}
+	if tampered {
+		return errTampered
+	}
+	return nil
+}
+
+func (c *Controller) verifyConfigs(ctx context.Context, logger *slog.Logger, param *config.Param) error {
+	policyCfgs, err := c.policyReader.Read(param.PolicyConfigFilePaths)
+	if err != nil {
+		return fmt.Errorf("read policy files: %w", err)
+	}
+	globalPolicyPaths := make(map[string]struct{}, len(param.PolicyConfigFilePaths))
+	for _, p := range param.PolicyConfigFilePaths {
+		globalPolicyPaths[p] = struct{}{}
+	}
+
+	verified := map[string]struct{}{}
+	var gErr error
+	for _, cfgFilePath := range c.configFinder.Finds(param.CWD, param.ConfigFilePath) {
+		policyCfgs, err := c.policyReader.Append(logger, cfgFilePath, policyCfgs, globalPolicyPaths)
+		if err != nil {
+			return fmt.Errorf("append policy configs: %w", slogerr.With(err,
+				"config_file_path", cfgFilePath,
+			))
+		}
+		if err := c.verifyConfig(ctx, logger, param, cfgFilePath, policyCfgs, verified); err != nil {
+			if !errors.Is(err, errTampered) && !errors.Is(err, errReinstallFailed) {
+				return fmt.Errorf("verify packages: %w", slogerr.With(err,
+					"config_file_path", cfgFilePath,
+				))
+			}
+			gErr = err
+		}
+	}
+	return gErr
+}
+
+func (c *Controller) verifyConfig(ctx context.Context, logger *slog.Logger, param *config.Param, cfgFilePath string, policyCfgs []*policy.Config, verified map[string]struct{}) error { //nolint:cyclop
+	cfg := &aqua.Config{}
+	if err := c.configReader.Read(logger, cfgFilePath, cfg); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("validate the configuration: %w", err)
+	}
+
+	// verify checks installed packages against the checksum file, so it never rewrites the file.
+	checksums, _, err := checksum.Open(
+		logger, cfgFilePath, param.ChecksumEnabled(cfg))
+	if err != nil {
+		return fmt.Errorf("read a checksum JSON: %w", err)
+	}
+
+	registryContents, err := c.registryInstaller.InstallRegistries(ctx, logger, cfg, cfgFilePath, checksums)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	pkgs, _ := config.ListPackages(logger, cfg, c.runtime, registryContents)
+	var gErr error
+	for _, pkg := range pkgs {
+		logger := logger.With(
+			"package_name", pkg.Package.Name,
+			"package_version", pkg.Package.Version,
+			"registry", pkg.Package.Registry,
+		)
+		pkgPath, err := pkg.PkgPath(c.runtime)
+		if err != nil {
+			return fmt.Errorf("get a package path: %w", err)
+		}
+		if _, ok := verified[pkgPath]; ok {
+			continue
+		}
+		verified[pkgPath] = struct{}{}
+		m, err := c.manifest.Read(pkgPath)
+		if err != nil {
+			if errors.Is(err, manifest.ErrNotFound) {
+				c.warnNoManifest(logger, pkgPath)
+				continue
+			}
+			return fmt.Errorf("read a manifest: %w", err)
+		}
+		ok, err := c.verifyPackage(logger, pkgPath, m)
+		if err != nil {
+			return err
+		}
+		if ok {
+			continue
+		}
+		if !param.Reinstall {
+			gErr = errTampered
+			continue
+		}
+		if err := c.reinstall(ctx, logger, pkgPath, &installpackage.ParamInstallPackage{
+			Pkg:             pkg,
+			Checksums:       checksums,
+			RequireChecksum: cfg.RequireChecksum(param.EnforceRequireChecksum, param.RequireChecksum),
+			PolicyConfigs:   policyCfgs,
+			DisablePolicy:   param.DisablePolicy,
+			ConfigFileDir:   filepath.Dir(cfgFilePath),
+		}); err != nil {
+			slogerr.WithError(logger, err).Error("reinstall the package")
+			gErr = errReinstallFailed
+		}
+	}
+	return gErr
+}
+
+// warnNoManifest outputs a warning if the package is installed but its manifest isn't recorded.
+func (c *Controller) warnNoManifest(logger *slog.Logger, pkgPath string) {
+	if f, err := osfile.Exists(filepath.Join(c.rootDir, pkgPath)); err != nil || !f {
+		logger.Debug("the package isn't installed")
+		return
+	}
+	logger.Warn("the package can't be verified because the manifest isn't recorded. Please reinstall the package to record the manifest")
+}
+
+// verifyPackage outputs modified, missing, and extra files of the package and returns true if there is no difference.
+// Packages which have been removed are skipped.
+func (c *Controller) verifyPackage(logger *slog.Logger, pkgPath string, m *manifest.Manifest) (bool, error) {
+	dir := filepath.Join(c.rootDir, pkgPath)
+	if _, err := os.Stat(dir); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			logger.Debug("the package isn't installed")
+			return true, nil
+		}
+		return false, fmt.Errorf("check if the package is installed: %w", err)
+	}
+	diff, err := m.Compare(dir)
+	if err != nil {
+		return false, fmt.Errorf("compare the package with the manifest: %w", slogerr.With(err,
+			"package_path", pkgPath,
+		))
+	}
+	if diff.OK() {
+		logger.Debug("the package matches the manifest")
+		return true, nil
+	}
+	pkg := m.Package + "@" + m.Version
+	for _, f := range diff.Modified {
+		fmt.Fprintf(c.stdout, "modified\t%s\t%s\n", pkg, f)
+	}
+	for _, f := range diff.Missing {
+		fmt.Fprintf(c.stdout, "missing\t%s\t%s\n", pkg, f)
+	}
+	for _, f := range diff.Extra {
+		fmt.Fprintf(c.stdout, "extra\t%s\t%s\n", pkg, f)
+	}
+	logger.Warn("the package doesn't match the manifest recorded at installation",
+		"modified", len(diff.Modified),
+		"missing", len(diff.Missing),
+		"extra", len(diff.Extra),
+	)
+	return false, nil
+}
+
+func (c *Controller) remove(pkgPath string) error {
+	if err := os.RemoveAll(filepath.Join(c.rootDir, pkgPath)); err != nil {
+		return fmt.Errorf("remove a package: %w", slogerr.With(err,
+			"package_path", pkgPath,
+		))
+	}
+	if err := c.manifest.Remove(pkgPath); err != nil {
+		return fmt.Errorf("remove a manifest: %w", err)
+	}
+	return nil
+}
+
+func (c *Controller) reinstall(ctx context.Context, logger *slog.Logger, pkgPath string, param *installpackage.ParamInstallPackage) error {
+	if err := c.remove(pkgPath); err != nil {
+		return err
+	}
+	logger.Info("reinstalling the package")
+	if err := c.packageInstaller.InstallPackage(ctx, logger, param); err != nil {
+		return fmt.Errorf("install the package: %w", err)
+	}
+	return nil
+}
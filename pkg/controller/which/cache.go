@@ -0,0 +1,296 @@
+package which
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// execCacheVersion is bumped when the format of cache entries changes,
+// so that entries written by an older aqua are ignored.
+const execCacheVersion = "2"
+
+// cacheEntry is a resolution of a command stored in the exec cache.
+// Inputs are the files the resolution was derived from.
+// The entry is valid as long as none of them has changed.
+type cacheEntry struct {
+	Inputs              []*fileStamp          `json:"inputs"`
+	ConfigFilePath      string                `json:"config_file_path"`
+	Package             *aqua.Package         `json:"package"`
+	PackageFilePath     string                `json:"package_file_path,omitempty"`
+	PackageInfo         *registry.PackageInfo `json:"package_info"`
+	PackageErrorMessage string                `json:"package_error_message,omitempty"`
+	Registry            *aqua.Registry        `json:"registry,omitempty"`
+	File                *registry.File        `json:"file"`
+	ExePath             string                `json:"exe_path"`
+	Env                 []string              `json:"env,omitempty"`
+	Checksum            *aqua.Checksum        `json:"checksum,omitempty"`
+}
+
+type fileStamp struct {
+	Path    string `json:"path"`
+	ModTime int64  `json:"mod_time,omitempty"`
+	Size    int64  `json:"size,omitempty"`
+	Missing bool   `json:"missing,omitempty"`
+}
+
+func newFileStamp(p string) (*fileStamp, error) {
+	fi, err := os.Stat(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &fileStamp{Path: p, Missing: true}, nil
+		}
+		return nil, fmt.Errorf("get a file stat: %w", slogerr.With(err, "path", p))
+	}
+	return &fileStamp{
+		Path:    p,
+		ModTime: fi.ModTime().UnixNano(),
+		Size:    fi.Size(),
+	}, nil
+}
+
+func (s *fileStamp) changed() bool {
+	a, err := newFileStamp(s.Path)
+	if err != nil {
+		return true
+	}
+	return *a != *s
+}
+
+// WhichWithCache is Which with a resolution cache used by aqua exec.
+// Reading configuration files and registries is skipped if the same command was resolved
+// before with the same configuration files, environment, and none of the files it depends on
+// has been changed since then.
+// Only commands managed by aqua are cached. Commands found in PATH are always looked up.
+func (c *Controller) WhichWithCache(ctx context.Context, logger *slog.Logger, param *config.Param, exeName string) (*FindResult, error) {
+	if param.DisableExecCache {
+		return c.Which(ctx, logger, param, exeName)
+	}
+	cfgFilePaths := c.configFilePaths(param)
+	cachePath := c.execCachePath(param, cfgFilePaths, exeName)
+	if findResult := readExecCache(logger, cachePath); findResult != nil {
+		logger.Debug("resolved a command from the exec cache", "exec_cache", cachePath)
+		return findResult, nil
+	}
+	findResult, err := c.which(ctx, logger, param, cfgFilePaths, exeName)
+	if err != nil {
+		return nil, err
+	}
+	if findResult.Package == nil {
+		return findResult, nil
+	}
+	if err := c.writeExecCache(logger, param, cfgFilePaths, cachePath, findResult); err != nil {
+		slogerr.WithError(logger, err).Debug("write the exec cache", "exec_cache", cachePath)
+	}
+	return findResult, nil
+}
+
+// execCachePath returns the cache file path.
+// The file name is a hash of everything that affects the resolution but isn't a file:
+// the version of aqua, the runtime (AQUA_GOOS, AQUA_GOARCH), the configuration file paths (which depend on the current directory, -c, and AQUA_GLOBAL_CONFIG), and the command name.
+func (c *Controller) execCachePath(param *config.Param, cfgFilePaths []string, exeName string) string {
+	h := sha256.New()
+	for _, s := range []string{
+		execCacheVersion, param.AQUAVersion, c.rootDir, param.HomeDir,
+		c.runtime.GOOS, c.runtime.GOARCH, c.runtime.LibC, exeName,
+	} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	for _, paths := range [][]string{cfgFilePaths, param.GlobalConfigFilePaths} {
+		for _, p := range paths {
+			h.Write([]byte(p))
+			h.Write([]byte{0})
+		}
+		h.Write([]byte{0})
+	}
+	return filepath.Join(config.ExecCacheDir(c.rootDir), hex.EncodeToString(h.Sum(nil))+".json")
+}
+
+// readExecCache returns the resolution in the cache file.
+// If the cache is broken or outdated, it's removed so that stale entries don't pile up
+// even if the command isn't cached again, for instance when the command is removed from configuration files.
+func readExecCache(logger *slog.Logger, cachePath string) *FindResult {
+	b, err := os.ReadFile(cachePath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slogerr.WithError(logger, err).Debug("read the exec cache", "exec_cache", cachePath)
+		}
+		return nil
+	}
+	entry := &cacheEntry{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	// Keep numbers in vars as they are written in configuration files.
+	decoder.UseNumber()
+	if err := decoder.Decode(entry); err != nil {
+		slogerr.WithError(logger, err).Debug("parse the exec cache", "exec_cache", cachePath)
+		removeExecCache(logger, cachePath)
+		return nil
+	}
+	if entry.Package == nil || entry.PackageInfo == nil || entry.File == nil {
+		removeExecCache(logger, cachePath)
+		return nil
+	}
+	for _, stamp := range entry.Inputs {
+		if stamp.changed() {
+			logger.Debug("the exec cache is outdated", "exec_cache", cachePath, "changed_file", stamp.Path)
+			removeExecCache(logger, cachePath)
+			return nil
+		}
+	}
+	entry.Package.FilePath = entry.PackageFilePath
+	entry.PackageInfo.ErrorMessage = entry.PackageErrorMessage
+	return &FindResult{
+		Package: &config.Package{
+			Package:     entry.Package,
+			PackageInfo: entry.PackageInfo,
+			Registry:    entry.Registry,
+		},
+		File: entry.File,
+		// exec only refers to the checksum settings of the configuration.
+		Config: &aqua.Config{
+			Checksum: entry.Checksum,
+		},
+		ExePath:        entry.ExePath,
+		Env:            entry.Env,
+		ConfigFilePath: entry.ConfigFilePath,
+	}
+}
+
+func removeExecCache(logger *slog.Logger, cachePath string) {
+	if err := os.Remove(cachePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		slogerr.WithError(logger, err).Debug("remove the exec cache", "exec_cache", cachePath)
+	}
+}
+
+func (c *Controller) writeExecCache(logger *slog.Logger, param *config.Param, cfgFilePaths []string, cachePath string, findResult *FindResult) error {
+	inputs, cacheable, err := c.execCacheInputs(logger, param, cfgFilePaths, findResult.ConfigFilePath)
+	if err != nil {
+		return err
+	}
+	if !cacheable {
+		logger.Debug("the resolution isn't cached because the configuration is dynamic")
+		return nil
+	}
+	entry := &cacheEntry{
+		Inputs:              inputs,
+		ConfigFilePath:      findResult.ConfigFilePath,
+		Package:             findResult.Package.Package,
+		PackageFilePath:     findResult.Package.Package.FilePath,
+		PackageInfo:         findResult.Package.PackageInfo,
+		PackageErrorMessage: findResult.Package.PackageInfo.ErrorMessage,
+		Registry:            findResult.Package.Registry,
+		File:                findResult.File,
+		ExePath:             findResult.ExePath,
+		Env:                 findResult.Env,
+	}
+	if findResult.Config != nil {
+		entry.Checksum = findResult.Config.Checksum
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode the exec cache: %w", err)
+	}
+	dir := filepath.Dir(cachePath)
+	if err := osfile.MkdirAll(dir); err != nil {
+		return fmt.Errorf("create a directory: %w", err)
+	}
+	// Write a temporary file and rename it so that concurrent executions never read a partially written cache.
+	f, err := os.CreateTemp(dir, "tmp-")
+	if err != nil {
+		return fmt.Errorf("create a temporary file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return fmt.Errorf("write the exec cache: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close the exec cache: %w", err)
+	}
+	if err := os.Rename(f.Name(), cachePath); err != nil {
+		return fmt.Errorf("rename the exec cache: %w", err)
+	}
+	return nil
+}
+
+// execCacheInputs returns stamps of the files the resolution depends on.
+// Those are configuration files read until the command was found, files they import,
+// and registries they use, because a change of any of them can change which package provides the command.
+// The second return value is false if the resolution can't be cached.
+func (c *Controller) execCacheInputs(logger *slog.Logger, param *config.Param, cfgFilePaths []string, foundCfgFilePath string) ([]*fileStamp, bool, error) {
+	inputs := []*fileStamp{}
+	add := func(p string) error {
+		stamp, err := newFileStamp(p)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, stamp)
+		return nil
+	}
+	for _, cfgFilePath := range append(cfgFilePaths, param.GlobalConfigFilePaths...) {
+		if err := add(cfgFilePath); err != nil {
+			return nil, false, err
+		}
+		if inputs[len(inputs)-1].Missing {
+			// A global configuration file which doesn't exist is skipped.
+			continue
+		}
+		cfg := &aqua.Config{}
+		if err := c.configReader.Read(logger, cfgFilePath, cfg); err != nil {
+			return nil, false, err //nolint:wrapcheck
+		}
+		if cfg.Dynamic {
+			return nil, false, nil
+		}
+		for _, p := range cfg.Inputs {
+			if err := add(p); err != nil {
+				return nil, false, err
+			}
+		}
+		for _, rg := range cfg.Registries {
+			p, err := c.registryFilePath(rg, cfgFilePath)
+			if err != nil {
+				return nil, false, err
+			}
+			if p == "" {
+				continue
+			}
+			if err := add(p); err != nil {
+				return nil, false, err
+			}
+		}
+		if cfgFilePath == foundCfgFilePath {
+			break
+		}
+	}
+	return inputs, true, nil
+}
+
+func (c *Controller) registryFilePath(rg *aqua.Registry, cfgFilePath string) (string, error) {
+	switch rg.Type {
+	case aqua.RegistryTypeLocal:
+		return rg.Path, nil
+	case aqua.RegistryTypeGitHubContent:
+		p, err := rg.FilePath(c.rootDir, cfgFilePath)
+		if err != nil {
+			return "", fmt.Errorf("get a registry file path: %w", err)
+		}
+		return p, nil
+	default:
+		return "", nil
+	}
+}
@@ -0,0 +1,305 @@
+package which_test
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	finder "github.com/aquaproj/aqua/v2/pkg/config-finder"
+	reader "github.com/aquaproj/aqua/v2/pkg/config-reader"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/controller/which"
+	"github.com/aquaproj/aqua/v2/pkg/cosign"
+	"github.com/aquaproj/aqua/v2/pkg/download"
+	registry "github.com/aquaproj/aqua/v2/pkg/install-registry"
+	"github.com/aquaproj/aqua/v2/pkg/link"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/slsa"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+)
+
+// countingReader counts how many times configuration files are read,
+// which tells whether a resolution came from the exec cache.
+type countingReader struct {
+	reader which.ConfigReader
+	count  int
+}
+
+func (r *countingReader) Read(logger *slog.Logger, configFilePath string, cfg *aqua.Config) error {
+	r.count++
+	return r.reader.Read(logger, configFilePath, cfg) //nolint:wrapcheck
+}
+
+const (
+	cacheTestAquaYAML = `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: aquaproj/aqua-installer@%s
+`
+	cacheTestRegistryYAML = `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: %s
+`
+)
+
+func newCacheTestController(dir string, param *config.Param, cfgReader which.ConfigReader) *which.Controller {
+	rt := &runtime.Runtime{
+		GOOS:   osLinux,
+		GOARCH: archAmd64,
+	}
+	logger := slog.New(slog.DiscardHandler)
+	downloader := download.NewGitHubContentFileDownloader(nil, download.NewHTTPDownloader(logger, http.DefaultClient))
+	return which.New(param, finder.NewConfigFinder(), cfgReader, registry.New(param, downloader, rt, &cosign.MockVerifier{}, &slsa.MockVerifier{}), rt, osenv.NewMock(testutil.RootEnv(dir, nil)), link.New())
+}
+
+// touch writes a file and moves its modification time forward,
+// so that the change is detected even on file systems with a coarse timestamp resolution.
+func touch(t *testing.T, p, body string, d time.Duration) {
+	t.Helper()
+	if err := os.WriteFile(p, []byte(body), 0o644); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+	tm := time.Now().Add(d)
+	if err := os.Chtimes(p, tm, tm); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestController_WhichWithCache(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	logger := slog.New(slog.DiscardHandler)
+	data := []struct {
+		name       string
+		change     func(t *testing.T, dir string)
+		disable    bool
+		expVersion string
+		expPath    string
+		expReads   int
+	}{
+		{
+			name:       "cache hit",
+			change:     func(*testing.T, string) {},
+			expVersion: versionV1,
+			expPath:    "v1.0.0/aqua-installer/aqua-installer",
+		},
+		{
+			name: "configuration file is changed",
+			change: func(t *testing.T, dir string) {
+				t.Helper()
+				touch(t, filepath.Join(dir, "workspace", "aqua.yaml"), fmt.Sprintf(cacheTestAquaYAML, "v2.0.0"), time.Hour)
+			},
+			expVersion: "v2.0.0",
+			expPath:    "v2.0.0/aqua-installer/aqua-installer",
+			expReads:   2,
+		},
+		{
+			name: "registry is changed",
+			change: func(t *testing.T, dir string) {
+				t.Helper()
+				touch(t, filepath.Join(dir, "workspace", "registry.yaml"), fmt.Sprintf(cacheTestRegistryYAML, "bin/aqua-installer"), time.Hour)
+			},
+			expVersion: versionV1,
+			expPath:    "v1.0.0/bin/aqua-installer/aqua-installer",
+			expReads:   2,
+		},
+		{
+			name:       "cache is disabled",
+			change:     func(*testing.T, string) {},
+			disable:    true,
+			expVersion: versionV1,
+			expPath:    "v1.0.0/aqua-installer/aqua-installer",
+			expReads:   1,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+			dir := t.TempDir()
+			testutil.WriteFiles(t, dir, map[string]string{
+				"workspace/aqua.yaml":     fmt.Sprintf(cacheTestAquaYAML, versionV1),
+				"workspace/registry.yaml": fmt.Sprintf(cacheTestRegistryYAML, pkgNameAquaInstaller),
+			})
+			param := &config.Param{
+				CWD:            filepath.Join(dir, "workspace"),
+				RootDir:        filepath.Join(dir, "root"),
+				MaxParallelism: 5,
+			}
+			cfgReader := &countingReader{reader: reader.New(param)}
+			ctrl := newCacheTestController(dir, param, cfgReader)
+			first, err := ctrl.WhichWithCache(ctx, logger, param, pkgNameAquaInstaller)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d.change(t, dir)
+			param.DisableExecCache = d.disable
+			cfgReader.count = 0
+			second, err := ctrl.WhichWithCache(ctx, logger, param, pkgNameAquaInstaller)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfgReader.count != d.expReads {
+				t.Fatalf("configuration files were read %d times, wanted %d", cfgReader.count, d.expReads)
+			}
+			if second.Package.Package.Version != d.expVersion {
+				t.Fatalf("version: wanted %s, got %s", d.expVersion, second.Package.Package.Version)
+			}
+			if !strings.HasSuffix(filepath.ToSlash(second.ExePath), "/"+d.expPath) {
+				t.Fatalf("exe path: wanted a path ending with %s, got %s", d.expPath, second.ExePath)
+			}
+			if d.expReads != 0 {
+				return
+			}
+			if diff := cmp.Diff(first.Package, second.Package); diff != "" {
+				t.Fatal(diff)
+			}
+			if diff := cmp.Diff(first.File, second.File); diff != "" {
+				t.Fatal(diff)
+			}
+			if first.ConfigFilePath != second.ConfigFilePath {
+				t.Fatalf("config file path: wanted %s, got %s", first.ConfigFilePath, second.ConfigFilePath)
+			}
+		})
+	}
+}
+
+func TestController_WhichWithCache_stale(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	logger := slog.New(slog.DiscardHandler)
+	dir := t.TempDir()
+	testutil.WriteFiles(t, dir, map[string]string{
+		"workspace/aqua.yaml":     fmt.Sprintf(cacheTestAquaYAML, versionV1),
+		"workspace/registry.yaml": fmt.Sprintf(cacheTestRegistryYAML, pkgNameAquaInstaller),
+	})
+	param := &config.Param{
+		CWD:            filepath.Join(dir, "workspace"),
+		RootDir:        filepath.Join(dir, "root"),
+		MaxParallelism: 5,
+	}
+	ctrl := newCacheTestController(dir, param, reader.New(param))
+	if _, err := ctrl.WhichWithCache(ctx, logger, param, pkgNameAquaInstaller); err != nil {
+		t.Fatal(err)
+	}
+	// The package is removed from the configuration file, so the cache entry becomes stale and isn't written again.
+	touch(t, filepath.Join(dir, "workspace", "aqua.yaml"), "packages: []\n", time.Hour)
+	_, _ = ctrl.WhichWithCache(ctx, logger, param, pkgNameAquaInstaller)
+	entries, err := os.ReadDir(config.ExecCacheDir(param.RootDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("the stale exec cache must be removed: %d entries are left", len(entries))
+	}
+}
+
+func TestController_WhichWithCache_path(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	logger := slog.New(slog.DiscardHandler)
+	dir := t.TempDir()
+	testutil.WriteFiles(t, dir, map[string]string{
+		"workspace/aqua.yaml":     fmt.Sprintf(cacheTestAquaYAML, versionV1),
+		"workspace/registry.yaml": fmt.Sprintf(cacheTestRegistryYAML, pkgNameAquaInstaller),
+		"usr/bin/gh":              "",
+	})
+	if err := os.Chmod(filepath.Join(dir, "usr", "bin", "gh"), 0o755); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+	param := &config.Param{
+		CWD:            filepath.Join(dir, "workspace"),
+		RootDir:        filepath.Join(dir, "root"),
+		MaxParallelism: 5,
+	}
+	rt := &runtime.Runtime{
+		GOOS:   osLinux,
+		GOARCH: archAmd64,
+	}
+	downloader := download.NewGitHubContentFileDownloader(nil, download.NewHTTPDownloader(logger, http.DefaultClient))
+	ctrl := which.New(param, finder.NewConfigFinder(), reader.New(param), registry.New(param, downloader, rt, &cosign.MockVerifier{}, &slsa.MockVerifier{}), rt, osenv.NewMock(testutil.RootEnv(dir, map[string]string{
+		"PATH": "/usr/bin",
+	})), link.New())
+	if _, err := ctrl.WhichWithCache(ctx, logger, param, "gh"); err != nil {
+		t.Fatal(err)
+	}
+	// Commands found in PATH aren't cached.
+	if _, err := os.Stat(filepath.Join(param.RootDir, "exec-cache")); !os.IsNotExist(err) {
+		t.Fatalf("exec cache must not be created: %v", err)
+	}
+}
+
+// benchmarkRegistry returns a local registry with n packages,
+// which makes the cost of reading registries visible as it is with the standard registry.
+func benchmarkRegistry(n int) string {
+	var b strings.Builder
+	b.WriteString("packages:\n")
+	for i := range n {
+		fmt.Fprintf(&b, `- type: github_release
+  repo_owner: foo
+  repo_name: tool-%d
+  asset: tool-%d_{{.OS}}_{{.Arch}}.tar.gz
+  files:
+    - name: tool-%d
+`, i, i, i)
+	}
+	return b.String()
+}
+
+func BenchmarkController_WhichWithCache(b *testing.B) {
+	for _, bc := range []struct {
+		name    string
+		disable bool
+	}{
+		{name: "without cache", disable: true},
+		{name: "with cache"},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			ctx := b.Context()
+			logger := slog.New(slog.DiscardHandler)
+			dir := b.TempDir()
+			files := map[string]string{
+				"workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: foo/tool-1000@v1.0.0
+`,
+				"workspace/registry.yaml": benchmarkRegistry(2000), //nolint:mnd
+			}
+			for name, body := range files {
+				p := filepath.Join(dir, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil { //nolint:gosec
+					b.Fatal(err)
+				}
+				if err := os.WriteFile(p, []byte(body), 0o644); err != nil { //nolint:gosec
+					b.Fatal(err)
+				}
+			}
+			param := &config.Param{
+				CWD:              filepath.Join(dir, "workspace"),
+				RootDir:          filepath.Join(dir, "root"),
+				MaxParallelism:   5,
+				DisableExecCache: bc.disable,
+			}
+			ctrl := newCacheTestController(dir, param, reader.New(param))
+			for b.Loop() {
+				if _, err := ctrl.WhichWithCache(ctx, logger, param, "tool-1000"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -12,6 +12,16 @@ import (
 const proxyName = "aqua-proxy"
 
 func (c *Controller) lookPath(envPath, exeName string) string {
+	if paths := c.lookPathAll(envPath, exeName, true); len(paths) != 0 {
+		return paths[0]
+	}
+	return ""
+}
+
+// lookPathAll returns commands in PATH except for aqua-proxy in the order of PATH.
+// If first is true, it returns only the first command.
+func (c *Controller) lookPathAll(envPath, exeName string, first bool) []string {
+	var paths []string
 	for _, p := range filepath.SplitList(envPath) {
 		bin := filepath.Join(p, exeName)
 		finfo, err := c.readLink(bin)
@@ -24,9 +34,12 @@ func (c *Controller) lookPath(envPath, exeName string) string {
 		if filepath.Base(finfo.Name()) == proxyName {
 			continue
 		}
-		return bin
+		paths = append(paths, bin)
+		if first {
+			break
+		}
 	}
-	return ""
+	return paths
 }
 
 func (c *Controller) readLink(p string) (os.FileInfo, error) {
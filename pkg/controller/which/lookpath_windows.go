@@ -27,6 +27,16 @@ func (c *Controller) listExts() []string {
 }
 
 func (c *Controller) lookPath(envPath, exeName string) string {
+	if paths := c.lookPathAll(envPath, exeName, true); len(paths) != 0 {
+		return paths[0]
+	}
+	return ""
+}
+
+// lookPathAll returns commands in PATH except for $AQUA_ROOT_DIR/bin in the order of PATH.
+// If first is true, it returns only the first command.
+func (c *Controller) lookPathAll(envPath, exeName string, first bool) []string {
+	var paths []string
 	binDir := filepath.Join(c.rootDir, "bin")
 	exts := c.listExts()
 	for _, p := range filepath.SplitList(envPath) {
@@ -43,8 +53,12 @@ func (c *Controller) lookPath(envPath, exeName string) string {
 			if finfo.IsDir() {
 				continue
 			}
-			return bin
+			paths = append(paths, bin)
+			break
+		}
+		if first && len(paths) != 0 {
+			break
 		}
 	}
-	return ""
+	return paths
 }
@@ -12,6 +12,10 @@ func (c *MockController) Which(ctx context.Context, logger *slog.Logger, param *
 	return c.FindResult, c.Err
 }
 
+func (c *MockController) WhichWithCache(ctx context.Context, logger *slog.Logger, param *config.Param, exeName string) (*FindResult, error) {
+	return c.Which(ctx, logger, param, exeName)
+}
+
 type MockMultiController struct {
 	FindResults map[string]*FindResult
 }
@@ -23,3 +27,7 @@ func (c *MockMultiController) Which(ctx context.Context, logger *slog.Logger, pa
 	}
 	return fr, nil
 }
+
+func (c *MockMultiController) WhichWithCache(ctx context.Context, logger *slog.Logger, param *config.Param, exeName string) (*FindResult, error) {
+	return c.Which(ctx, logger, param, exeName)
+}
@@ -21,14 +21,26 @@ type FindResult struct {
 	ExePath        string
 	ConfigFilePath string
 	EnableChecksum bool
+	// Env is environment variables set when the command is executed.
+	// Each element is formatted as NAME=VALUE.
+	Env []string
 }
 
 func (c *Controller) Which(ctx context.Context, logger *slog.Logger, param *config.Param, exeName string) (*FindResult, error) {
+	return c.which(ctx, logger, param, c.configFilePaths(param), exeName)
+}
+
+// configFilePaths returns local configuration files in the order they are searched.
+func (c *Controller) configFilePaths(param *config.Param) []string {
 	var filePaths []string
 	if param.ConfigFilePath != "" {
 		filePaths = []string{osfile.Abs(param.CWD, param.ConfigFilePath)}
 	}
-	for _, cfgFilePath := range append(filePaths, c.configFinder.Finds(param.CWD, "")...) {
+	return append(filePaths, c.configFinder.Finds(param.CWD, "")...)
+}
+
+func (c *Controller) which(ctx context.Context, logger *slog.Logger, param *config.Param, cfgFilePaths []string, exeName string) (*FindResult, error) {
+	for _, cfgFilePath := range cfgFilePaths {
 		logger := logger.With("config_file_path", cfgFilePath)
 		findResult, err := c.findExecFile(ctx, logger, param, cfgFilePath, exeName)
 		if err != nil {
@@ -97,16 +109,30 @@ func (c *Controller) getExePath(findResult *FindResult) (string, error) {
 }
 
 func (c *Controller) findExecFile(ctx context.Context, logger *slog.Logger, param *config.Param, cfgFilePath, exeName string) (*FindResult, error) {
+	var findResult *FindResult
+	if err := c.visitPackages(ctx, logger, param, cfgFilePath, exeName, func(_ *aqua.Package, fr *FindResult, _ string) bool {
+		findResult = fr
+		return fr == nil
+	}); err != nil {
+		return nil, err
+	}
+	return findResult, nil
+}
+
+// visitPackages calls fn for each package of the configuration file which provides the command in the order of packages.
+// If the package is skipped, findResult is nil and reason explains why.
+// Visiting stops when fn returns false.
+func (c *Controller) visitPackages(ctx context.Context, logger *slog.Logger, param *config.Param, cfgFilePath, exeName string, fn func(pkg *aqua.Package, findResult *FindResult, reason string) bool) error {
 	cfg := &aqua.Config{}
 	if err := c.configReader.Read(logger, cfgFilePath, cfg); err != nil {
-		return nil, err //nolint:wrapcheck
+		return err //nolint:wrapcheck
 	}
 
 	checksums, updateChecksum, err := checksum.Open(
 		logger, cfgFilePath,
 		param.ChecksumEnabled(cfg))
 	if err != nil {
-		return nil, fmt.Errorf("read a checksum JSON: %w", err)
+		return fmt.Errorf("read a checksum JSON: %w", err)
 	}
 	defer updateChecksum()
 
@@ -133,18 +159,23 @@ func (c *Controller) findExecFile(ctx context.Context, logger *slog.Logger, para
 	}()
 
 	for _, pkg := range cfg.Packages {
-		findResult, err := c.findExecFileFromPkg(ctx, logger, cfgFilePath, cfg, registryCache, rgPaths, registries, exeName, pkg, checksums)
+		findResult, reason, err := c.findExecFileFromPkg(ctx, logger, cfgFilePath, cfg, registryCache, rgPaths, registries, exeName, pkg, checksums)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if findResult == nil && reason == "" {
+			continue
 		}
 		if findResult != nil {
 			findResult.Config = cfg
 			findResult.ConfigFilePath = cfgFilePath
 			findResult.Package.Registry = cfg.Registries[pkg.Registry]
-			return findResult, nil
+		}
+		if !fn(pkg, findResult, reason) {
+			return nil
 		}
 	}
-	return nil, nil //nolint:nilnil
+	return nil
 }
 
 func (c *Controller) setRegistryCacheKeys(cfg *aqua.Config, cfgFilePath string, rgPaths map[string]string, cacheKeys map[string]map[string]struct{}) error {
@@ -177,10 +208,13 @@ func (c *Controller) setRegistryCacheKeys(cfg *aqua.Config, cfgFilePath string,
 	return nil
 }
 
-func (c *Controller) findExecFileFromPkg(ctx context.Context, logger *slog.Logger, cfgFilePath string, cfg *aqua.Config, rCache *registry.Cache, rgPaths map[string]string, registries map[string]*registry.Config, exeName string, pkg *aqua.Package, checksums *checksum.Checksums) (*FindResult, error) { //nolint:cyclop
+// findExecFileFromPkg returns the command of the package.
+// If the package doesn't provide the command, both the result and the reason are empty.
+// If the package provides the command but is skipped, the reason explains why.
+func (c *Controller) findExecFileFromPkg(ctx context.Context, logger *slog.Logger, cfgFilePath string, cfg *aqua.Config, rCache *registry.Cache, rgPaths map[string]string, registries map[string]*registry.Config, exeName string, pkg *aqua.Package, checksums *checksum.Checksums) (*FindResult, string, error) { //nolint:cyclop
 	if pkg.Registry == "" || pkg.Name == "" {
 		logger.Debug("ignore a package because the package name or package registry name is empty")
-		return nil, nil //nolint:nilnil
+		return nil, "", nil
 	}
 	logger = logger.With(
 		"registry_name", pkg.Registry,
@@ -188,44 +222,51 @@ func (c *Controller) findExecFileFromPkg(ctx context.Context, logger *slog.Logge
 	)
 	pkgInfo, err := c.findPkgInfo(ctx, logger, cfgFilePath, cfg, rCache, rgPaths, registries, pkg, checksums)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if pkgInfo == nil {
 		logger.Warn("package isn't found")
-		return nil, nil //nolint:nilnil
+		return nil, "", nil
 	}
 
-	if !pkgInfo.MaybeHasCommand(exeName) && !pkg.HasCommandAlias(exeName) {
-		return nil, nil //nolint:nilnil
+	fileName, ok := pkg.TrimCommandSuffix(exeName)
+	if (!ok || !pkgInfo.MaybeHasCommand(fileName)) && !pkg.HasCommandAlias(exeName) {
+		return nil, "", nil
+	}
+
+	if err := pkg.ValidateCommandSuffix(); err != nil {
+		slogerr.WithError(logger, err).Warn("command_suffix is invalid")
+		return nil, fmt.Sprintf("the command suffix is invalid: %v", err), nil
 	}
 
 	pkgInfo, err = pkgInfo.Override(logger, pkg.Version, c.runtime)
 	if err != nil {
 		slogerr.WithError(logger, err).Warn("version constraint is invalid")
-		return nil, nil //nolint:nilnil
+		return nil, fmt.Sprintf("the version constraint is invalid: %v", err), nil
 	}
 
-	supported, err := pkgInfo.CheckSupported(c.runtime, c.runtime.GOOS+"/"+c.runtime.GOARCH)
+	env := c.runtime.GOOS + "/" + c.runtime.GOARCH
+	supported, err := pkgInfo.CheckSupported(c.runtime, env)
 	if err != nil {
 		slogerr.WithError(logger, err).Error("check if the package is supported")
-		return nil, nil //nolint:nilnil
+		return nil, fmt.Sprintf("failed to check if the package is supported: %v", err), nil
 	}
 	if !supported {
 		logger.Debug("the package isn't supported on this environment")
-		return nil, nil //nolint:nilnil
+		return nil, "the package isn't supported on " + env, nil
 	}
 
 	for _, file := range pkgInfo.GetFiles() {
-		findResult, err := c.findExecFileFromFile(logger, exeName, pkg, pkgInfo, file)
+		findResult, reason, err := c.findExecFileFromFile(logger, exeName, pkg, pkgInfo, file)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
-		if findResult != nil {
-			return findResult, nil
+		if findResult != nil || reason != "" {
+			return findResult, reason, nil
 		}
 	}
-	return nil, nil //nolint:nilnil
+	return nil, "the version " + pkg.Version + " doesn't provide the command", nil
 }
 
 func (c *Controller) findPkgInfo(ctx context.Context, logger *slog.Logger, cfgFilePath string, cfg *aqua.Config, rCache *registry.Cache, rgPaths map[string]string, registries map[string]*registry.Config, pkg *aqua.Package, checksums *checksum.Checksums) (*registry.PackageInfo, error) { //nolint:cyclop,funlen
@@ -285,9 +326,9 @@ func (c *Controller) findPkgInfo(ctx context.Context, logger *slog.Logger, cfgFi
 	return pkgInfo, nil
 }
 
-func (c *Controller) findExecFileFromFile(logger *slog.Logger, exeName string, pkg *aqua.Package, pkgInfo *registry.PackageInfo, file *registry.File) (*FindResult, error) {
+func (c *Controller) findExecFileFromFile(logger *slog.Logger, exeName string, pkg *aqua.Package, pkgInfo *registry.PackageInfo, file *registry.File) (*FindResult, string, error) {
 	cmds := map[string]struct{}{
-		file.Name: {},
+		pkg.CommandName(file.Name): {},
 	}
 	for _, alias := range pkg.CommandAliases {
 		if file.Name != alias.Command {
@@ -296,7 +337,7 @@ func (c *Controller) findExecFileFromFile(logger *slog.Logger, exeName string, p
 		cmds[alias.Alias] = struct{}{}
 	}
 	if _, ok := cmds[exeName]; !ok {
-		return nil, nil //nolint:nilnil
+		return nil, "", nil
 	}
 	findResult := &FindResult{
 		Package: &config.Package{
@@ -306,13 +347,18 @@ func (c *Controller) findExecFileFromFile(logger *slog.Logger, exeName string, p
 		File: file,
 	}
 	if err := findResult.Package.ApplyVars(); err != nil {
-		return nil, fmt.Errorf("apply package variables: %w", err)
+		return nil, "", fmt.Errorf("apply package variables: %w", err)
 	}
 	exePath, err := c.getExePath(findResult)
 	if err != nil {
 		slogerr.WithError(logger, err).Error("get the execution file path")
-		return nil, nil //nolint:nilnil
+		return nil, fmt.Sprintf("failed to get the executable file path: %v", err), nil
 	}
 	findResult.ExePath = exePath
-	return findResult, nil
+	env, err := findResult.Package.RenderEnv(c.rootDir, c.runtime)
+	if err != nil {
+		return nil, "", fmt.Errorf("render environment variables: %w", err)
+	}
+	findResult.Env = env
+	return findResult, "", nil
 }
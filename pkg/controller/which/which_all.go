@@ -0,0 +1,129 @@
+package which
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// Statuses of candidates.
+const (
+	// CandidateSelected means the candidate is used.
+	CandidateSelected = "selected"
+	// CandidateShadowed means the candidate provides the command but a preceding candidate is used.
+	CandidateShadowed = "shadowed"
+	// CandidateSkipped means the candidate can't provide the command.
+	CandidateSkipped = "skipped"
+)
+
+// Candidate is a package or a file in PATH which provides a command.
+type Candidate struct {
+	Status string
+	// ConfigFilePath, PackageName, Version, and Registry are empty if the command is found in PATH.
+	ConfigFilePath string
+	PackageName    string
+	Version        string
+	Registry       string
+	// ExePath is empty if the candidate is skipped.
+	ExePath string
+	// Reason explains why the candidate isn't selected.
+	Reason string
+}
+
+func (cd *Candidate) String() string {
+	if cd.PackageName == "" {
+		return cd.ExePath
+	}
+	return fmt.Sprintf("%s@%s in %s", cd.PackageName, cd.Version, cd.ConfigFilePath)
+}
+
+// WhichAll returns all candidates of the command in the order of precedence,
+// that is, packages of local configuration files, packages of global configuration files, and commands in PATH.
+// The first candidate which isn't skipped is selected, and the others are shadowed by it.
+// Unlike Which, packages which are skipped because of the environment or the version are also returned.
+func (c *Controller) WhichAll(ctx context.Context, logger *slog.Logger, param *config.Param, exeName string) ([]*Candidate, error) {
+	var candidates []*Candidate
+	var selected *Candidate
+	add := func(cd *Candidate) {
+		switch {
+		case cd.Reason != "":
+			cd.Status = CandidateSkipped
+		case selected == nil:
+			cd.Status = CandidateSelected
+			selected = cd
+		default:
+			cd.Status = CandidateShadowed
+			cd.Reason = selected.String() + " takes precedence"
+		}
+		candidates = append(candidates, cd)
+	}
+
+	cfgFilePaths, err := c.allConfigFilePaths(param)
+	if err != nil {
+		return nil, err
+	}
+	for _, cfgFilePath := range cfgFilePaths {
+		logger := logger.With("config_file_path", cfgFilePath)
+		if err := c.visitPackages(ctx, logger, param, cfgFilePath, exeName, func(pkg *aqua.Package, findResult *FindResult, reason string) bool {
+			cd := &Candidate{
+				ConfigFilePath: cfgFilePath,
+				PackageName:    pkg.Name,
+				Version:        pkg.Version,
+				Registry:       pkg.Registry,
+				Reason:         reason,
+			}
+			if findResult != nil {
+				cd.ExePath = findResult.ExePath
+			}
+			add(cd)
+			return true
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, exePath := range c.lookPathAll(c.osenv.Getenv("PATH"), exeName, false) {
+		add(&Candidate{
+			ExePath: exePath,
+		})
+	}
+
+	if len(candidates) == 0 {
+		return nil, slogerr.With(ErrCommandIsNotFound, //nolint:wrapcheck
+			"exe_name", exeName,
+			"doc", "https://aquaproj.github.io/docs/reference/codes/004",
+		)
+	}
+	return candidates, nil
+}
+
+// allConfigFilePaths returns local configuration files and existing global configuration files in the order they are searched.
+func (c *Controller) allConfigFilePaths(param *config.Param) ([]string, error) {
+	var filePaths []string
+	seen := map[string]struct{}{}
+	for _, p := range c.configFilePaths(param) {
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		seen[p] = struct{}{}
+		filePaths = append(filePaths, p)
+	}
+	for _, p := range param.GlobalConfigFilePaths {
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		if f, err := osfile.Exists(p); err != nil {
+			return nil, err //nolint:wrapcheck
+		} else if !f {
+			continue
+		}
+		seen[p] = struct{}{}
+		filePaths = append(filePaths, p)
+	}
+	return filePaths, nil
+}
@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"net/http"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/aquaproj/aqua/v2/pkg/config"
@@ -303,3 +304,197 @@ packages:
 		})
 	}
 }
+
+func Test_controller_WhichAll(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name    string
+		files   map[string]string
+		env     map[string]string
+		param   *config.Param
+		exeName string
+		isErr   bool
+		exp     []*which.Candidate
+	}{
+		{
+			name: "all candidates",
+			param: &config.Param{
+				CWD:                   pathHomeFooWorkspace,
+				RootDir:               pathHomeFooLocalShare,
+				MaxParallelism:        5,
+				GlobalConfigFilePaths: []string{pathEtcAquaAquaYaml},
+			},
+			exeName: "gh",
+			env: map[string]string{
+				"PATH": "/home/foo/.local/share/aquaproj-aqua/bin:/usr/local/bin",
+			},
+			files: map[string]string{
+				pathHomeFooWorkspaceAquaYaml: `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: suzuki-shunsuke/darwin-gh@v1.0.0
+- name: cli/cli@v1.0.0
+`,
+				pathHomeFooWorkspaceRegistryYaml: `packages:
+- type: github_content
+  repo_owner: suzuki-shunsuke
+  repo_name: darwin-gh
+  path: gh
+  supported_envs:
+  - darwin
+  files:
+  - name: gh
+- type: github_content
+  repo_owner: cli
+  repo_name: cli
+  path: gh
+  files:
+  - name: gh
+`,
+				pathEtcAquaAquaYaml: `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: cli/cli@v2.0.0
+`,
+				pathEtcAquaRegistryYaml: `packages:
+- type: github_content
+  repo_owner: cli
+  repo_name: cli
+  path: gh
+  files:
+  - name: gh
+`,
+				"/usr/local/bin/gh": "",
+			},
+			exp: []*which.Candidate{
+				{
+					Status:         which.CandidateSkipped,
+					ConfigFilePath: pathHomeFooWorkspaceAquaYaml,
+					PackageName:    "suzuki-shunsuke/darwin-gh",
+					Version:        versionV1,
+					Registry:       regTypeStandard,
+					Reason:         "the package isn't supported on linux/amd64",
+				},
+				{
+					Status:         which.CandidateSelected,
+					ConfigFilePath: pathHomeFooWorkspaceAquaYaml,
+					PackageName:    "cli/cli",
+					Version:        versionV1,
+					Registry:       regTypeStandard,
+					ExePath:        "/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/cli/cli/v1.0.0/gh/gh",
+				},
+				{
+					Status:         which.CandidateShadowed,
+					ConfigFilePath: pathEtcAquaAquaYaml,
+					PackageName:    "cli/cli",
+					Version:        "v2.0.0",
+					Registry:       regTypeStandard,
+					ExePath:        "/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/cli/cli/v2.0.0/gh/gh",
+					Reason:         "cli/cli@v1.0.0 in /home/foo/workspace/aqua.yaml takes precedence",
+				},
+				{
+					Status:  which.CandidateShadowed,
+					ExePath: "/usr/local/bin/gh",
+					Reason:  "cli/cli@v1.0.0 in /home/foo/workspace/aqua.yaml takes precedence",
+				},
+			},
+		},
+		{
+			name: "command suffix",
+			param: &config.Param{
+				CWD:            pathHomeFooWorkspace,
+				RootDir:        pathHomeFooLocalShare,
+				MaxParallelism: 5,
+			},
+			exeName: "gh@1",
+			env: map[string]string{
+				"PATH": "/home/foo/.local/share/aquaproj-aqua/bin",
+			},
+			files: map[string]string{
+				pathHomeFooWorkspaceAquaYaml: `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: cli/cli@v2.0.0
+- name: cli/cli@v1.0.0
+  command_suffix: auto
+`,
+				pathHomeFooWorkspaceRegistryYaml: `packages:
+- type: github_content
+  repo_owner: cli
+  repo_name: cli
+  path: gh
+  files:
+  - name: gh
+`,
+			},
+			exp: []*which.Candidate{
+				{
+					Status:         which.CandidateSelected,
+					ConfigFilePath: pathHomeFooWorkspaceAquaYaml,
+					PackageName:    "cli/cli",
+					Version:        versionV1,
+					Registry:       regTypeStandard,
+					ExePath:        "/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/cli/cli/v1.0.0/gh/gh",
+				},
+			},
+		},
+		{
+			name: "not found",
+			param: &config.Param{
+				CWD:            pathHomeFooWorkspace,
+				RootDir:        pathHomeFooLocalShare,
+				MaxParallelism: 5,
+			},
+			exeName: "gh",
+			env: map[string]string{
+				"PATH": "/usr/local/bin",
+			},
+			files: map[string]string{
+				pathHomeFooWorkspaceAquaYaml: `packages:
+`,
+			},
+			isErr: true,
+		},
+	}
+	logger := slog.New(slog.DiscardHandler)
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+			dir := t.TempDir()
+			testutil.WriteFiles(t, dir, d.files)
+			testutil.RootParam(dir, d.param)
+			env := testutil.RootEnv(dir, d.env)
+			rt := &runtime.Runtime{
+				GOOS:   osLinux,
+				GOARCH: archAmd64,
+			}
+			downloader := download.NewGitHubContentFileDownloader(nil, download.NewHTTPDownloader(logger, http.DefaultClient))
+			ctrl := which.New(d.param, finder.NewConfigFinder(), reader.New(d.param), registry.New(d.param, downloader, rt, &cosign.MockVerifier{}, &slsa.MockVerifier{}), rt, osenv.NewMock(env), link.New())
+			candidates, err := ctrl.WhichAll(ctx, logger, d.param, d.exeName)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			for _, cd := range d.exp {
+				cd.ExePath = testutil.Abs(dir, cd.ExePath)
+				cd.ConfigFilePath = testutil.Abs(dir, cd.ConfigFilePath)
+				cd.Reason = strings.ReplaceAll(cd.Reason, pathHomeFooWorkspaceAquaYaml, testutil.Abs(dir, pathHomeFooWorkspaceAquaYaml))
+			}
+			if diff := cmp.Diff(d.exp, candidates); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
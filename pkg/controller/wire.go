@@ -14,8 +14,11 @@ import (
 	finder "github.com/aquaproj/aqua/v2/pkg/config-finder"
 	reader "github.com/aquaproj/aqua/v2/pkg/config-reader"
 	"github.com/aquaproj/aqua/v2/pkg/controller/allowpolicy"
+	"github.com/aquaproj/aqua/v2/pkg/controller/audit"
 	"github.com/aquaproj/aqua/v2/pkg/controller/cp"
 	"github.com/aquaproj/aqua/v2/pkg/controller/denypolicy"
+	"github.com/aquaproj/aqua/v2/pkg/controller/doctor"
+	"github.com/aquaproj/aqua/v2/pkg/controller/env"
 	cexec "github.com/aquaproj/aqua/v2/pkg/controller/exec"
 	"github.com/aquaproj/aqua/v2/pkg/controller/generate"
 	genrgst "github.com/aquaproj/aqua/v2/pkg/controller/generate-registry"
@@ -26,11 +29,18 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/controller/install"
 	"github.com/aquaproj/aqua/v2/pkg/controller/list"
 	"github.com/aquaproj/aqua/v2/pkg/controller/remove"
+	"github.com/aquaproj/aqua/v2/pkg/controller/run"
+	"github.com/aquaproj/aqua/v2/pkg/controller/sbom"
+	"github.com/aquaproj/aqua/v2/pkg/controller/shellinit"
+	"github.com/aquaproj/aqua/v2/pkg/controller/stats"
+	"github.com/aquaproj/aqua/v2/pkg/controller/suggest"
+	"github.com/aquaproj/aqua/v2/pkg/controller/testpolicy"
 	"github.com/aquaproj/aqua/v2/pkg/controller/update"
 	"github.com/aquaproj/aqua/v2/pkg/controller/updateaqua"
 	"github.com/aquaproj/aqua/v2/pkg/controller/updatechecksum"
 	cvacuum "github.com/aquaproj/aqua/v2/pkg/controller/vacuum"
 	"github.com/aquaproj/aqua/v2/pkg/controller/vacuum/initialize"
+	"github.com/aquaproj/aqua/v2/pkg/controller/verify"
 	"github.com/aquaproj/aqua/v2/pkg/controller/which"
 	"github.com/aquaproj/aqua/v2/pkg/cosign"
 	"github.com/aquaproj/aqua/v2/pkg/domain"
@@ -41,8 +51,10 @@ import (
 	registry "github.com/aquaproj/aqua/v2/pkg/install-registry"
 	"github.com/aquaproj/aqua/v2/pkg/installpackage"
 	"github.com/aquaproj/aqua/v2/pkg/link"
+	"github.com/aquaproj/aqua/v2/pkg/manifest"
 	"github.com/aquaproj/aqua/v2/pkg/minisign"
 	"github.com/aquaproj/aqua/v2/pkg/osexec"
+	"github.com/aquaproj/aqua/v2/pkg/pgp"
 	"github.com/aquaproj/aqua/v2/pkg/policy"
 	"github.com/aquaproj/aqua/v2/pkg/runtime"
 	"github.com/aquaproj/aqua/v2/pkg/slsa"
@@ -106,6 +118,58 @@ func InitializeListCommandController(ctx context.Context, logger *slog.Logger, p
 	return &list.Controller{}, nil
 }
 
+func InitializeShellInitCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*shellinit.Controller, error) {
+	wire.Build(
+		shellinit.NewController,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(shellinit.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			registry.New,
+			wire.Bind(new(shellinit.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(shellinit.ConfigReader), new(*reader.ConfigReader)),
+		),
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			osexec.New,
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+		),
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
+	)
+	return &shellinit.Controller{}, nil
+}
+
 func InitializeGenerateRegistryCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, stdout io.Writer) (*genrgst.Controller, error) {
 	wire.Build(
 		genrgst.NewController,
@@ -224,6 +288,94 @@ func InitializeGenerateCommandController(ctx context.Context, logger *slog.Logge
 	return &generate.Controller{}, nil
 }
 
+func InitializeSuggestCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*suggest.Controller, error) {
+	wire.Build(
+		suggest.New,
+		wire.NewSet(
+			generate.New,
+			wire.Bind(new(suggest.Generator), new(*generate.Controller)),
+		),
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(generate.ConfigFinder), new(*finder.ConfigFinder)),
+			wire.Bind(new(suggest.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(generate.RepositoriesService), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+			wire.Bind(new(versiongetter.GitHubTagClient), new(*github.RepositoriesService)),
+			wire.Bind(new(versiongetter.GitHubReleaseClient), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			registry.New,
+			wire.Bind(new(generate.RegistryInstaller), new(*registry.Installer)),
+			wire.Bind(new(suggest.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(generate.ConfigReader), new(*reader.ConfigReader)),
+			wire.Bind(new(suggest.ConfigReader), new(*reader.ConfigReader)),
+		),
+		wire.NewSet(
+			fuzzyfinder.New,
+			wire.Bind(new(generate.FuzzyFinder), new(*fuzzyfinder.Finder)),
+			wire.Bind(new(versiongetter.FuzzyFinder), new(*fuzzyfinder.Finder)),
+		),
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			osexec.New,
+			wire.Bind(new(installpackage.Executor), new(*osexec.Executor)),
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+		),
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
+		wire.NewSet(
+			cargo.NewClient,
+			wire.Bind(new(versiongetter.CargoClient), new(*cargo.Client)),
+		),
+		wire.NewSet(
+			versiongetter.NewFuzzy,
+			wire.Bind(new(generate.FuzzyGetter), new(*versiongetter.FuzzyGetter)),
+		),
+		wire.NewSet(
+			versiongetter.NewGeneralVersionGetter,
+			wire.Bind(new(versiongetter.VersionGetter), new(*versiongetter.GeneralVersionGetter)),
+		),
+		versiongetter.NewCargo,
+		versiongetter.NewGitHubRelease,
+		versiongetter.NewGitHubTag,
+		versiongetter.NewGoGetter,
+		wire.NewSet(
+			goproxy.New,
+			wire.Bind(new(versiongetter.GoProxyClient), new(*goproxy.Client)),
+		),
+	)
+	return &suggest.Controller{}, nil
+}
+
 func InitializeInstallCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*install.Controller, error) {
 	wire.Build(
 		install.New,
@@ -266,7 +418,6 @@ func InitializeInstallCommandController(ctx context.Context, logger *slog.Logger
 			wire.Bind(new(installpackage.Executor), new(*osexec.Executor)),
 			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
 			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
-			wire.Bind(new(minisign.CommandExecutor), new(*osexec.Executor)),
 			wire.Bind(new(ghattestation.CommandExecutor), new(*osexec.Executor)),
 			wire.Bind(new(unarchive.Executor), new(*osexec.Executor)),
 		),
@@ -316,6 +467,10 @@ func InitializeInstallCommandController(ctx context.Context, logger *slog.Logger
 			minisign.New,
 			wire.Bind(new(installpackage.MinisignVerifier), new(*minisign.Verifier)),
 		),
+		wire.NewSet(
+			pgp.New,
+			wire.Bind(new(installpackage.PGPVerifier), new(*pgp.Verifier)),
+		),
 		wire.NewSet(
 			ghattestation.New,
 			wire.Bind(new(installpackage.GitHubArtifactAttestationsVerifier), new(*ghattestation.Verifier)),
@@ -324,10 +479,6 @@ func InitializeInstallCommandController(ctx context.Context, logger *slog.Logger
 			ghattestation.NewExecutor,
 			wire.Bind(new(ghattestation.Executor), new(*ghattestation.ExecutorImpl)),
 		),
-		wire.NewSet(
-			minisign.NewExecutor,
-			wire.Bind(new(minisign.Executor), new(*minisign.ExecutorImpl)),
-		),
 		wire.NewSet(
 			installpackage.NewGoInstallInstallerImpl,
 			wire.Bind(new(installpackage.GoInstallInstaller), new(*installpackage.GoInstallInstallerImpl)),
@@ -344,16 +495,20 @@ func InitializeInstallCommandController(ctx context.Context, logger *slog.Logger
 			vacuum.New,
 			wire.Bind(new(installpackage.Vacuum), new(*vacuum.Client)),
 		),
+		wire.NewSet(
+			manifest.New,
+			wire.Bind(new(installpackage.Manifest), new(*manifest.Client)),
+		),
 	)
 	return &install.Controller{}, nil
 }
 
-func InitializeWhichCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*which.Controller, error) {
+func InitializeEnvCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*env.Controller, error) {
 	wire.Build(
-		which.New,
+		env.New,
 		wire.NewSet(
 			finder.NewConfigFinder,
-			wire.Bind(new(which.ConfigFinder), new(*finder.ConfigFinder)),
+			wire.Bind(new(env.ConfigFinder), new(*finder.ConfigFinder)),
 		),
 		wire.NewSet(
 			github.New,
@@ -362,7 +517,7 @@ func InitializeWhichCommandController(ctx context.Context, logger *slog.Logger,
 		),
 		wire.NewSet(
 			registry.New,
-			wire.Bind(new(which.RegistryInstaller), new(*registry.Installer)),
+			wire.Bind(new(env.RegistryInstaller), new(*registry.Installer)),
 		),
 		wire.NewSet(
 			download.NewGitHubContentFileDownloader,
@@ -370,85 +525,27 @@ func InitializeWhichCommandController(ctx context.Context, logger *slog.Logger,
 		),
 		wire.NewSet(
 			reader.New,
-			wire.Bind(new(which.ConfigReader), new(*reader.ConfigReader)),
-		),
-		osenv.New,
-		download.NewHTTPDownloader,
-		wire.NewSet(
-			link.New,
-			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
-			wire.Bind(new(which.Linker), new(*link.Linker)),
-		),
-		wire.NewSet(
-			cosign.NewVerifier,
-			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
-			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
-		),
-		wire.NewSet(
-			osexec.New,
-			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
-			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
-		),
-		wire.NewSet(
-			download.NewDownloader,
-			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
-		),
-		wire.NewSet(
-			slsa.New,
-			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
-			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
-		),
-		wire.NewSet(
-			slsa.NewExecutor,
-			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+			wire.Bind(new(env.ConfigReader), new(*reader.ConfigReader)),
 		),
-	)
-	return nil, nil
-}
-
-func InitializeExecCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*cexec.Controller, error) {
-	wire.Build(
-		cexec.New,
 		wire.NewSet(
-			finder.NewConfigFinder,
-			wire.Bind(new(which.ConfigFinder), new(*finder.ConfigFinder)),
+			installpackage.New,
+			wire.Bind(new(env.Installer), new(*installpackage.Installer)),
 		),
 		wire.NewSet(
 			download.NewDownloader,
 			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
 		),
 		wire.NewSet(
-			installpackage.New,
-			wire.Bind(new(cexec.Installer), new(*installpackage.Installer)),
-		),
-		wire.NewSet(
-			github.New,
-			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
-			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
-		),
-		wire.NewSet(
-			registry.New,
-			wire.Bind(new(which.RegistryInstaller), new(*registry.Installer)),
-		),
-		wire.NewSet(
-			download.NewGitHubContentFileDownloader,
-			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
-		),
-		wire.NewSet(
-			reader.New,
-			wire.Bind(new(which.ConfigReader), new(*reader.ConfigReader)),
-		),
-		wire.NewSet(
-			which.New,
-			wire.Bind(new(cexec.WhichController), new(*which.Controller)),
+			link.New,
+			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
 		),
+		download.NewHTTPDownloader,
+		osenv.New,
 		wire.NewSet(
 			osexec.New,
 			wire.Bind(new(installpackage.Executor), new(*osexec.Executor)),
-			wire.Bind(new(cexec.Executor), new(*osexec.Executor)),
 			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
 			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
-			wire.Bind(new(minisign.CommandExecutor), new(*osexec.Executor)),
 			wire.Bind(new(ghattestation.CommandExecutor), new(*osexec.Executor)),
 			wire.Bind(new(unarchive.Executor), new(*osexec.Executor)),
 		),
@@ -456,13 +553,6 @@ func InitializeExecCommandController(ctx context.Context, logger *slog.Logger, p
 			download.NewChecksumDownloader,
 			wire.Bind(new(download.ChecksumDownloader), new(*download.ChecksumDownloaderImpl)),
 		),
-		osenv.New,
-		wire.NewSet(
-			link.New,
-			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
-			wire.Bind(new(which.Linker), new(*link.Linker)),
-		),
-		download.NewHTTPDownloader,
 		wire.NewSet(
 			checksum.NewCalculator,
 			wire.Bind(new(installpackage.ChecksumCalculator), new(*checksum.Calculator)),
@@ -485,7 +575,7 @@ func InitializeExecCommandController(ctx context.Context, logger *slog.Logger, p
 		),
 		wire.NewSet(
 			policy.NewReader,
-			wire.Bind(new(cexec.PolicyReader), new(*policy.Reader)),
+			wire.Bind(new(env.PolicyReader), new(*policy.Reader)),
 		),
 		wire.NewSet(
 			cosign.NewVerifier,
@@ -506,8 +596,8 @@ func InitializeExecCommandController(ctx context.Context, logger *slog.Logger, p
 			wire.Bind(new(installpackage.MinisignVerifier), new(*minisign.Verifier)),
 		),
 		wire.NewSet(
-			minisign.NewExecutor,
-			wire.Bind(new(minisign.Executor), new(*minisign.ExecutorImpl)),
+			pgp.New,
+			wire.Bind(new(installpackage.PGPVerifier), new(*pgp.Verifier)),
 		),
 		wire.NewSet(
 			ghattestation.New,
@@ -532,58 +622,59 @@ func InitializeExecCommandController(ctx context.Context, logger *slog.Logger, p
 		wire.NewSet(
 			vacuum.New,
 			wire.Bind(new(installpackage.Vacuum), new(*vacuum.Client)),
-			wire.Bind(new(cexec.Vacuum), new(*vacuum.Client)),
+		),
+		wire.NewSet(
+			manifest.New,
+			wire.Bind(new(installpackage.Manifest), new(*manifest.Client)),
 		),
 	)
-	return &cexec.Controller{}, nil
+	return &env.Controller{}, nil
 }
 
-func InitializeUpdateAquaCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*updateaqua.Controller, error) {
+func InitializeWhichCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*which.Controller, error) {
 	wire.Build(
-		updateaqua.New,
+		which.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(which.ConfigFinder), new(*finder.ConfigFinder)),
+		),
 		wire.NewSet(
 			github.New,
 			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
-			wire.Bind(new(updateaqua.RepositoriesService), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
 		),
 		wire.NewSet(
-			installpackage.New,
-			wire.Bind(new(updateaqua.AquaInstaller), new(*installpackage.Installer)),
+			registry.New,
+			wire.Bind(new(which.RegistryInstaller), new(*registry.Installer)),
 		),
-		download.NewHTTPDownloader,
 		wire.NewSet(
-			download.NewDownloader,
-			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
 		),
 		wire.NewSet(
-			osexec.New,
-			wire.Bind(new(installpackage.Executor), new(*osexec.Executor)),
-			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
-			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
-			wire.Bind(new(minisign.CommandExecutor), new(*osexec.Executor)),
-			wire.Bind(new(ghattestation.CommandExecutor), new(*osexec.Executor)),
-			wire.Bind(new(unarchive.Executor), new(*osexec.Executor)),
+			reader.New,
+			wire.Bind(new(which.ConfigReader), new(*reader.ConfigReader)),
 		),
+		osenv.New,
+		download.NewHTTPDownloader,
 		wire.NewSet(
-			unarchive.New,
-			wire.Bind(new(installpackage.Unarchiver), new(*unarchive.Unarchiver)),
+			link.New,
+			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
+			wire.Bind(new(which.Linker), new(*link.Linker)),
 		),
 		wire.NewSet(
-			checksum.NewCalculator,
-			wire.Bind(new(installpackage.ChecksumCalculator), new(*checksum.Calculator)),
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
 		),
 		wire.NewSet(
-			download.NewChecksumDownloader,
-			wire.Bind(new(download.ChecksumDownloader), new(*download.ChecksumDownloaderImpl)),
-		),
-		wire.NewSet(
-			link.New,
-			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
+			osexec.New,
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
 		),
 		wire.NewSet(
-			cosign.NewVerifier,
-			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
-			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
 		),
 		wire.NewSet(
 			slsa.New,
@@ -594,53 +685,74 @@ func InitializeUpdateAquaCommandController(ctx context.Context, logger *slog.Log
 			slsa.NewExecutor,
 			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
 		),
+	)
+	return nil, nil
+}
+
+func InitializeDoctorCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*doctor.Controller, error) {
+	wire.Build(
+		doctor.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(doctor.ConfigFinder), new(*finder.ConfigFinder)),
+		),
 		wire.NewSet(
-			minisign.New,
-			wire.Bind(new(installpackage.MinisignVerifier), new(*minisign.Verifier)),
+			github.New,
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
 		),
 		wire.NewSet(
-			ghattestation.New,
-			wire.Bind(new(installpackage.GitHubArtifactAttestationsVerifier), new(*ghattestation.Verifier)),
+			registry.New,
+			wire.Bind(new(doctor.RegistryInstaller), new(*registry.Installer)),
 		),
 		wire.NewSet(
-			ghattestation.NewExecutor,
-			wire.Bind(new(ghattestation.Executor), new(*ghattestation.ExecutorImpl)),
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
 		),
 		wire.NewSet(
-			minisign.NewExecutor,
-			wire.Bind(new(minisign.Executor), new(*minisign.ExecutorImpl)),
+			reader.New,
+			wire.Bind(new(doctor.ConfigReader), new(*reader.ConfigReader)),
 		),
+		osenv.New,
+		download.NewHTTPDownloader,
 		wire.NewSet(
-			installpackage.NewGoInstallInstallerImpl,
-			wire.Bind(new(installpackage.GoInstallInstaller), new(*installpackage.GoInstallInstallerImpl)),
+			link.New,
+			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
+			wire.Bind(new(doctor.Linker), new(*link.Linker)),
 		),
 		wire.NewSet(
-			installpackage.NewGoBuildInstallerImpl,
-			wire.Bind(new(installpackage.GoBuildInstaller), new(*installpackage.GoBuildInstallerImpl)),
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
 		),
 		wire.NewSet(
-			installpackage.NewCargoPackageInstallerImpl,
-			wire.Bind(new(installpackage.CargoPackageInstaller), new(*installpackage.CargoPackageInstallerImpl)),
+			osexec.New,
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
 		),
 		wire.NewSet(
-			vacuum.New,
-			wire.Bind(new(installpackage.Vacuum), new(*vacuum.Client)),
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
 		),
 	)
-	return &updateaqua.Controller{}, nil
+	return nil, nil
 }
 
-func InitializeCopyCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*cp.Controller, error) {
+func InitializeExecCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*cexec.Controller, error) {
 	wire.Build(
-		cp.New,
+		cexec.New,
 		wire.NewSet(
 			finder.NewConfigFinder,
 			wire.Bind(new(which.ConfigFinder), new(*finder.ConfigFinder)),
-			wire.Bind(new(install.ConfigFinder), new(*finder.ConfigFinder)),
-		),
-		wire.NewSet(
-			install.New,
-			wire.Bind(new(cp.Installer), new(*install.Controller)),
 		),
 		wire.NewSet(
 			download.NewDownloader,
@@ -648,8 +760,7 @@ func InitializeCopyCommandController(ctx context.Context, logger *slog.Logger, p
 		),
 		wire.NewSet(
 			installpackage.New,
-			wire.Bind(new(install.Installer), new(*installpackage.Installer)),
-			wire.Bind(new(cp.PackageInstaller), new(*installpackage.Installer)),
+			wire.Bind(new(cexec.Installer), new(*installpackage.Installer)),
 		),
 		wire.NewSet(
 			github.New,
@@ -658,7 +769,6 @@ func InitializeCopyCommandController(ctx context.Context, logger *slog.Logger, p
 		),
 		wire.NewSet(
 			registry.New,
-			wire.Bind(new(install.RegistryInstaller), new(*registry.Installer)),
 			wire.Bind(new(which.RegistryInstaller), new(*registry.Installer)),
 		),
 		wire.NewSet(
@@ -668,21 +778,19 @@ func InitializeCopyCommandController(ctx context.Context, logger *slog.Logger, p
 		wire.NewSet(
 			reader.New,
 			wire.Bind(new(which.ConfigReader), new(*reader.ConfigReader)),
-			wire.Bind(new(install.ConfigReader), new(*reader.ConfigReader)),
 		),
 		wire.NewSet(
 			which.New,
-			wire.Bind(new(cp.WhichController), new(*which.Controller)),
+			wire.Bind(new(cexec.WhichController), new(*which.Controller)),
 		),
 		wire.NewSet(
 			osexec.New,
 			wire.Bind(new(installpackage.Executor), new(*osexec.Executor)),
 			wire.Bind(new(cexec.Executor), new(*osexec.Executor)),
 			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
-			wire.Bind(new(unarchive.Executor), new(*osexec.Executor)),
 			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
-			wire.Bind(new(minisign.CommandExecutor), new(*osexec.Executor)),
 			wire.Bind(new(ghattestation.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(unarchive.Executor), new(*osexec.Executor)),
 		),
 		wire.NewSet(
 			download.NewChecksumDownloader,
@@ -717,8 +825,7 @@ func InitializeCopyCommandController(ctx context.Context, logger *slog.Logger, p
 		),
 		wire.NewSet(
 			policy.NewReader,
-			wire.Bind(new(cp.PolicyReader), new(*policy.Reader)),
-			wire.Bind(new(install.PolicyReader), new(*policy.Reader)),
+			wire.Bind(new(cexec.PolicyReader), new(*policy.Reader)),
 		),
 		wire.NewSet(
 			cosign.NewVerifier,
@@ -738,6 +845,10 @@ func InitializeCopyCommandController(ctx context.Context, logger *slog.Logger, p
 			minisign.New,
 			wire.Bind(new(installpackage.MinisignVerifier), new(*minisign.Verifier)),
 		),
+		wire.NewSet(
+			pgp.New,
+			wire.Bind(new(installpackage.PGPVerifier), new(*pgp.Verifier)),
+		),
 		wire.NewSet(
 			ghattestation.New,
 			wire.Bind(new(installpackage.GitHubArtifactAttestationsVerifier), new(*ghattestation.Verifier)),
@@ -746,10 +857,6 @@ func InitializeCopyCommandController(ctx context.Context, logger *slog.Logger, p
 			ghattestation.NewExecutor,
 			wire.Bind(new(ghattestation.Executor), new(*ghattestation.ExecutorImpl)),
 		),
-		wire.NewSet(
-			minisign.NewExecutor,
-			wire.Bind(new(minisign.Executor), new(*minisign.ExecutorImpl)),
-		),
 		wire.NewSet(
 			installpackage.NewGoInstallInstallerImpl,
 			wire.Bind(new(installpackage.GoInstallInstaller), new(*installpackage.GoInstallInstallerImpl)),
@@ -765,77 +872,81 @@ func InitializeCopyCommandController(ctx context.Context, logger *slog.Logger, p
 		wire.NewSet(
 			vacuum.New,
 			wire.Bind(new(installpackage.Vacuum), new(*vacuum.Client)),
+			wire.Bind(new(cexec.Vacuum), new(*vacuum.Client)),
+		),
+		wire.NewSet(
+			manifest.New,
+			wire.Bind(new(installpackage.Manifest), new(*manifest.Client)),
 		),
 	)
-	return &cp.Controller{}, nil
+	return &cexec.Controller{}, nil
 }
 
-func InitializeUpdateChecksumCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*updatechecksum.Controller, error) {
+func InitializeRunCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*run.Controller, error) {
 	wire.Build(
-		updatechecksum.New,
+		run.New,
 		wire.NewSet(
-			finder.NewConfigFinder,
-			wire.Bind(new(updatechecksum.ConfigFinder), new(*finder.ConfigFinder)),
+			cexec.New,
+			wire.Bind(new(run.Executor), new(*cexec.Controller)),
 		),
 		wire.NewSet(
-			reader.New,
-			wire.Bind(new(updatechecksum.ConfigReader), new(*reader.ConfigReader)),
+			finder.NewConfigFinder,
+			wire.Bind(new(which.ConfigFinder), new(*finder.ConfigFinder)),
+			wire.Bind(new(run.ConfigFinder), new(*finder.ConfigFinder)),
 		),
 		wire.NewSet(
-			download.NewChecksumDownloader,
-			wire.Bind(new(download.ChecksumDownloader), new(*download.ChecksumDownloaderImpl)),
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
 		),
 		wire.NewSet(
-			registry.New,
-			wire.Bind(new(updatechecksum.RegistryInstaller), new(*registry.Installer)),
+			installpackage.New,
+			wire.Bind(new(cexec.Installer), new(*installpackage.Installer)),
 		),
 		wire.NewSet(
 			github.New,
 			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
 			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+			wire.Bind(new(versiongetter.GitHubTagClient), new(*github.RepositoriesService)),
+			wire.Bind(new(versiongetter.GitHubReleaseClient), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			registry.New,
+			wire.Bind(new(which.RegistryInstaller), new(*registry.Installer)),
+			wire.Bind(new(run.RegistryInstaller), new(*registry.Installer)),
 		),
 		wire.NewSet(
 			download.NewGitHubContentFileDownloader,
 			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
-			wire.Bind(new(domain.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
-			wire.Bind(new(updatechecksum.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
 		),
-		download.NewHTTPDownloader,
 		wire.NewSet(
-			download.NewDownloader,
-			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+			reader.New,
+			wire.Bind(new(which.ConfigReader), new(*reader.ConfigReader)),
+			wire.Bind(new(run.ConfigReader), new(*reader.ConfigReader)),
 		),
 		wire.NewSet(
-			cosign.NewVerifier,
-			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
-			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+			which.New,
+			wire.Bind(new(cexec.WhichController), new(*which.Controller)),
 		),
 		wire.NewSet(
 			osexec.New,
+			wire.Bind(new(installpackage.Executor), new(*osexec.Executor)),
+			wire.Bind(new(cexec.Executor), new(*osexec.Executor)),
 			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
 			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
-			wire.Bind(new(installpackage.Executor), new(*osexec.Executor)),
-			wire.Bind(new(minisign.CommandExecutor), new(*osexec.Executor)),
 			wire.Bind(new(ghattestation.CommandExecutor), new(*osexec.Executor)),
 			wire.Bind(new(unarchive.Executor), new(*osexec.Executor)),
 		),
 		wire.NewSet(
-			slsa.New,
-			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
-			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
-		),
-		wire.NewSet(
-			slsa.NewExecutor,
-			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
-		),
-		wire.NewSet(
-			installpackage.New,
-			wire.Bind(new(updatechecksum.ChecksumFileVerifier), new(*installpackage.Installer)),
+			download.NewChecksumDownloader,
+			wire.Bind(new(download.ChecksumDownloader), new(*download.ChecksumDownloaderImpl)),
 		),
+		osenv.New,
 		wire.NewSet(
 			link.New,
 			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
+			wire.Bind(new(which.Linker), new(*link.Linker)),
 		),
+		download.NewHTTPDownloader,
 		wire.NewSet(
 			checksum.NewCalculator,
 			wire.Bind(new(installpackage.ChecksumCalculator), new(*checksum.Calculator)),
@@ -844,13 +955,43 @@ func InitializeUpdateChecksumCommandController(ctx context.Context, logger *slog
 			unarchive.New,
 			wire.Bind(new(installpackage.Unarchiver), new(*unarchive.Unarchiver)),
 		),
+		wire.NewSet(
+			policy.NewConfigReader,
+			wire.Bind(new(policy.ConfigReader), new(*policy.ConfigReaderImpl)),
+		),
+		wire.NewSet(
+			policy.NewConfigFinder,
+			wire.Bind(new(policy.ConfigFinder), new(*policy.ConfigFinderImpl)),
+		),
+		wire.NewSet(
+			policy.NewValidator,
+			wire.Bind(new(policy.Validator), new(*policy.ValidatorImpl)),
+		),
+		wire.NewSet(
+			policy.NewReader,
+			wire.Bind(new(cexec.PolicyReader), new(*policy.Reader)),
+		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
 		wire.NewSet(
 			minisign.New,
 			wire.Bind(new(installpackage.MinisignVerifier), new(*minisign.Verifier)),
 		),
 		wire.NewSet(
-			minisign.NewExecutor,
-			wire.Bind(new(minisign.Executor), new(*minisign.ExecutorImpl)),
+			pgp.New,
+			wire.Bind(new(installpackage.PGPVerifier), new(*pgp.Verifier)),
 		),
 		wire.NewSet(
 			ghattestation.New,
@@ -875,73 +1016,469 @@ func InitializeUpdateChecksumCommandController(ctx context.Context, logger *slog
 		wire.NewSet(
 			vacuum.New,
 			wire.Bind(new(installpackage.Vacuum), new(*vacuum.Client)),
+			wire.Bind(new(cexec.Vacuum), new(*vacuum.Client)),
 		),
-	)
-	return &updatechecksum.Controller{}, nil
-}
-
-func InitializeUpdateCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*update.Controller, error) {
-	wire.Build(
-		update.New,
 		wire.NewSet(
-			finder.NewConfigFinder,
-			wire.Bind(new(update.ConfigFinder), new(*finder.ConfigFinder)),
-			wire.Bind(new(which.ConfigFinder), new(*finder.ConfigFinder)),
+			manifest.New,
+			wire.Bind(new(installpackage.Manifest), new(*manifest.Client)),
 		),
 		wire.NewSet(
-			reader.New,
-			wire.Bind(new(update.ConfigReader), new(*reader.ConfigReader)),
-			wire.Bind(new(which.ConfigReader), new(*reader.ConfigReader)),
+			versiongetter.NewFuzzy,
+			wire.Bind(new(run.FuzzyGetter), new(*versiongetter.FuzzyGetter)),
 		),
 		wire.NewSet(
-			registry.New,
-			wire.Bind(new(update.RegistryInstaller), new(*registry.Installer)),
-			wire.Bind(new(which.RegistryInstaller), new(*registry.Installer)),
+			fuzzyfinder.New,
+			wire.Bind(new(versiongetter.FuzzyFinder), new(*fuzzyfinder.Finder)),
+		),
+		wire.NewSet(
+			versiongetter.NewGeneralVersionGetter,
+			wire.Bind(new(versiongetter.VersionGetter), new(*versiongetter.GeneralVersionGetter)),
+		),
+		versiongetter.NewCargo,
+		versiongetter.NewGitHubRelease,
+		versiongetter.NewGitHubTag,
+		versiongetter.NewGoGetter,
+		wire.NewSet(
+			cargo.NewClient,
+			wire.Bind(new(versiongetter.CargoClient), new(*cargo.Client)),
+		),
+		wire.NewSet(
+			goproxy.New,
+			wire.Bind(new(versiongetter.GoProxyClient), new(*goproxy.Client)),
 		),
+	)
+	return &run.Controller{}, nil
+}
+
+func InitializeUpdateAquaCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*updateaqua.Controller, error) {
+	wire.Build(
+		updateaqua.New,
 		wire.NewSet(
 			github.New,
 			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
-			wire.Bind(new(update.RepositoriesService), new(*github.RepositoriesService)),
-			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
-			wire.Bind(new(versiongetter.GitHubTagClient), new(*github.RepositoriesService)),
-			wire.Bind(new(versiongetter.GitHubReleaseClient), new(*github.RepositoriesService)),
+			wire.Bind(new(updateaqua.RepositoriesService), new(*github.RepositoriesService)),
 		),
 		wire.NewSet(
-			download.NewGitHubContentFileDownloader,
-			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+			installpackage.New,
+			wire.Bind(new(updateaqua.AquaInstaller), new(*installpackage.Installer)),
 		),
 		download.NewHTTPDownloader,
 		wire.NewSet(
 			download.NewDownloader,
 			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
 		),
-		wire.NewSet(
-			cosign.NewVerifier,
-			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
-			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
-		),
 		wire.NewSet(
 			osexec.New,
+			wire.Bind(new(installpackage.Executor), new(*osexec.Executor)),
 			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
 			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(ghattestation.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(unarchive.Executor), new(*osexec.Executor)),
 		),
 		wire.NewSet(
-			slsa.New,
-			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
-			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+			unarchive.New,
+			wire.Bind(new(installpackage.Unarchiver), new(*unarchive.Unarchiver)),
 		),
 		wire.NewSet(
-			slsa.NewExecutor,
-			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+			checksum.NewCalculator,
+			wire.Bind(new(installpackage.ChecksumCalculator), new(*checksum.Calculator)),
 		),
 		wire.NewSet(
-			versiongetter.NewFuzzy,
-			wire.Bind(new(update.FuzzyGetter), new(*versiongetter.FuzzyGetter)),
+			download.NewChecksumDownloader,
+			wire.Bind(new(download.ChecksumDownloader), new(*download.ChecksumDownloaderImpl)),
 		),
 		wire.NewSet(
-			fuzzyfinder.New,
-			wire.Bind(new(update.FuzzyFinder), new(*fuzzyfinder.Finder)),
-			wire.Bind(new(versiongetter.FuzzyFinder), new(*fuzzyfinder.Finder)),
+			link.New,
+			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
+		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
+		wire.NewSet(
+			minisign.New,
+			wire.Bind(new(installpackage.MinisignVerifier), new(*minisign.Verifier)),
+		),
+		wire.NewSet(
+			pgp.New,
+			wire.Bind(new(installpackage.PGPVerifier), new(*pgp.Verifier)),
+		),
+		wire.NewSet(
+			ghattestation.New,
+			wire.Bind(new(installpackage.GitHubArtifactAttestationsVerifier), new(*ghattestation.Verifier)),
+		),
+		wire.NewSet(
+			ghattestation.NewExecutor,
+			wire.Bind(new(ghattestation.Executor), new(*ghattestation.ExecutorImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewGoInstallInstallerImpl,
+			wire.Bind(new(installpackage.GoInstallInstaller), new(*installpackage.GoInstallInstallerImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewGoBuildInstallerImpl,
+			wire.Bind(new(installpackage.GoBuildInstaller), new(*installpackage.GoBuildInstallerImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewCargoPackageInstallerImpl,
+			wire.Bind(new(installpackage.CargoPackageInstaller), new(*installpackage.CargoPackageInstallerImpl)),
+		),
+		wire.NewSet(
+			vacuum.New,
+			wire.Bind(new(installpackage.Vacuum), new(*vacuum.Client)),
+		),
+		wire.NewSet(
+			manifest.New,
+			wire.Bind(new(installpackage.Manifest), new(*manifest.Client)),
+		),
+	)
+	return &updateaqua.Controller{}, nil
+}
+
+func InitializeCopyCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*cp.Controller, error) {
+	wire.Build(
+		cp.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(which.ConfigFinder), new(*finder.ConfigFinder)),
+			wire.Bind(new(install.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			install.New,
+			wire.Bind(new(cp.Installer), new(*install.Controller)),
+		),
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		wire.NewSet(
+			installpackage.New,
+			wire.Bind(new(install.Installer), new(*installpackage.Installer)),
+			wire.Bind(new(cp.PackageInstaller), new(*installpackage.Installer)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			registry.New,
+			wire.Bind(new(install.RegistryInstaller), new(*registry.Installer)),
+			wire.Bind(new(which.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(which.ConfigReader), new(*reader.ConfigReader)),
+			wire.Bind(new(install.ConfigReader), new(*reader.ConfigReader)),
+		),
+		wire.NewSet(
+			which.New,
+			wire.Bind(new(cp.WhichController), new(*which.Controller)),
+		),
+		wire.NewSet(
+			osexec.New,
+			wire.Bind(new(installpackage.Executor), new(*osexec.Executor)),
+			wire.Bind(new(cexec.Executor), new(*osexec.Executor)),
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(unarchive.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(ghattestation.CommandExecutor), new(*osexec.Executor)),
+		),
+		wire.NewSet(
+			download.NewChecksumDownloader,
+			wire.Bind(new(download.ChecksumDownloader), new(*download.ChecksumDownloaderImpl)),
+		),
+		osenv.New,
+		wire.NewSet(
+			link.New,
+			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
+			wire.Bind(new(which.Linker), new(*link.Linker)),
+		),
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			checksum.NewCalculator,
+			wire.Bind(new(installpackage.ChecksumCalculator), new(*checksum.Calculator)),
+		),
+		wire.NewSet(
+			unarchive.New,
+			wire.Bind(new(installpackage.Unarchiver), new(*unarchive.Unarchiver)),
+		),
+		wire.NewSet(
+			policy.NewConfigReader,
+			wire.Bind(new(policy.ConfigReader), new(*policy.ConfigReaderImpl)),
+		),
+		wire.NewSet(
+			policy.NewConfigFinder,
+			wire.Bind(new(policy.ConfigFinder), new(*policy.ConfigFinderImpl)),
+		),
+		wire.NewSet(
+			policy.NewValidator,
+			wire.Bind(new(policy.Validator), new(*policy.ValidatorImpl)),
+		),
+		wire.NewSet(
+			policy.NewReader,
+			wire.Bind(new(cp.PolicyReader), new(*policy.Reader)),
+			wire.Bind(new(install.PolicyReader), new(*policy.Reader)),
+		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
+		wire.NewSet(
+			minisign.New,
+			wire.Bind(new(installpackage.MinisignVerifier), new(*minisign.Verifier)),
+		),
+		wire.NewSet(
+			pgp.New,
+			wire.Bind(new(installpackage.PGPVerifier), new(*pgp.Verifier)),
+		),
+		wire.NewSet(
+			ghattestation.New,
+			wire.Bind(new(installpackage.GitHubArtifactAttestationsVerifier), new(*ghattestation.Verifier)),
+		),
+		wire.NewSet(
+			ghattestation.NewExecutor,
+			wire.Bind(new(ghattestation.Executor), new(*ghattestation.ExecutorImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewGoInstallInstallerImpl,
+			wire.Bind(new(installpackage.GoInstallInstaller), new(*installpackage.GoInstallInstallerImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewGoBuildInstallerImpl,
+			wire.Bind(new(installpackage.GoBuildInstaller), new(*installpackage.GoBuildInstallerImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewCargoPackageInstallerImpl,
+			wire.Bind(new(installpackage.CargoPackageInstaller), new(*installpackage.CargoPackageInstallerImpl)),
+		),
+		wire.NewSet(
+			vacuum.New,
+			wire.Bind(new(installpackage.Vacuum), new(*vacuum.Client)),
+		),
+		wire.NewSet(
+			manifest.New,
+			wire.Bind(new(installpackage.Manifest), new(*manifest.Client)),
+		),
+	)
+	return &cp.Controller{}, nil
+}
+
+func InitializeUpdateChecksumCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*updatechecksum.Controller, error) {
+	wire.Build(
+		updatechecksum.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(updatechecksum.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(updatechecksum.ConfigReader), new(*reader.ConfigReader)),
+		),
+		wire.NewSet(
+			download.NewChecksumDownloader,
+			wire.Bind(new(download.ChecksumDownloader), new(*download.ChecksumDownloaderImpl)),
+		),
+		wire.NewSet(
+			registry.New,
+			wire.Bind(new(updatechecksum.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+			wire.Bind(new(domain.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+			wire.Bind(new(updatechecksum.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+		),
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			osexec.New,
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(installpackage.Executor), new(*osexec.Executor)),
+			wire.Bind(new(ghattestation.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(unarchive.Executor), new(*osexec.Executor)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
+		wire.NewSet(
+			installpackage.New,
+			wire.Bind(new(updatechecksum.ChecksumFileVerifier), new(*installpackage.Installer)),
+		),
+		wire.NewSet(
+			link.New,
+			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
+		),
+		wire.NewSet(
+			checksum.NewCalculator,
+			wire.Bind(new(installpackage.ChecksumCalculator), new(*checksum.Calculator)),
+		),
+		wire.NewSet(
+			unarchive.New,
+			wire.Bind(new(installpackage.Unarchiver), new(*unarchive.Unarchiver)),
+		),
+		wire.NewSet(
+			minisign.New,
+			wire.Bind(new(installpackage.MinisignVerifier), new(*minisign.Verifier)),
+		),
+		wire.NewSet(
+			pgp.New,
+			wire.Bind(new(installpackage.PGPVerifier), new(*pgp.Verifier)),
+		),
+		wire.NewSet(
+			ghattestation.New,
+			wire.Bind(new(installpackage.GitHubArtifactAttestationsVerifier), new(*ghattestation.Verifier)),
+		),
+		wire.NewSet(
+			ghattestation.NewExecutor,
+			wire.Bind(new(ghattestation.Executor), new(*ghattestation.ExecutorImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewGoInstallInstallerImpl,
+			wire.Bind(new(installpackage.GoInstallInstaller), new(*installpackage.GoInstallInstallerImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewGoBuildInstallerImpl,
+			wire.Bind(new(installpackage.GoBuildInstaller), new(*installpackage.GoBuildInstallerImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewCargoPackageInstallerImpl,
+			wire.Bind(new(installpackage.CargoPackageInstaller), new(*installpackage.CargoPackageInstallerImpl)),
+		),
+		wire.NewSet(
+			vacuum.New,
+			wire.Bind(new(installpackage.Vacuum), new(*vacuum.Client)),
+		),
+		wire.NewSet(
+			manifest.New,
+			wire.Bind(new(installpackage.Manifest), new(*manifest.Client)),
+		),
+		wire.NewSet(
+			policy.NewConfigReader,
+			wire.Bind(new(policy.ConfigReader), new(*policy.ConfigReaderImpl)),
+		),
+		wire.NewSet(
+			policy.NewConfigFinder,
+			wire.Bind(new(policy.ConfigFinder), new(*policy.ConfigFinderImpl)),
+		),
+		wire.NewSet(
+			policy.NewValidator,
+			wire.Bind(new(policy.Validator), new(*policy.ValidatorImpl)),
+		),
+		wire.NewSet(
+			policy.NewReader,
+			wire.Bind(new(updatechecksum.PolicyReader), new(*policy.Reader)),
+		),
+	)
+	return &updatechecksum.Controller{}, nil
+}
+
+func InitializeUpdateCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*update.Controller, error) {
+	wire.Build(
+		update.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(update.ConfigFinder), new(*finder.ConfigFinder)),
+			wire.Bind(new(which.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(update.ConfigReader), new(*reader.ConfigReader)),
+			wire.Bind(new(which.ConfigReader), new(*reader.ConfigReader)),
+		),
+		wire.NewSet(
+			registry.New,
+			wire.Bind(new(update.RegistryInstaller), new(*registry.Installer)),
+			wire.Bind(new(which.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+			wire.Bind(new(update.RepositoriesService), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+			wire.Bind(new(versiongetter.GitHubTagClient), new(*github.RepositoriesService)),
+			wire.Bind(new(versiongetter.GitHubReleaseClient), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+		),
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			osexec.New,
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
+		wire.NewSet(
+			versiongetter.NewFuzzy,
+			wire.Bind(new(update.FuzzyGetter), new(*versiongetter.FuzzyGetter)),
+		),
+		wire.NewSet(
+			fuzzyfinder.New,
+			wire.Bind(new(update.FuzzyFinder), new(*fuzzyfinder.Finder)),
+			wire.Bind(new(versiongetter.FuzzyFinder), new(*fuzzyfinder.Finder)),
 		),
 		wire.NewSet(
 			versiongetter.NewGeneralVersionGetter,
@@ -952,30 +1489,284 @@ func InitializeUpdateCommandController(ctx context.Context, logger *slog.Logger,
 		versiongetter.NewGitHubTag,
 		versiongetter.NewGoGetter,
 		wire.NewSet(
-			cargo.NewClient,
-			wire.Bind(new(versiongetter.CargoClient), new(*cargo.Client)),
+			cargo.NewClient,
+			wire.Bind(new(versiongetter.CargoClient), new(*cargo.Client)),
+		),
+		wire.NewSet(
+			goproxy.New,
+			wire.Bind(new(versiongetter.GoProxyClient), new(*goproxy.Client)),
+		),
+		wire.NewSet(
+			which.New,
+			wire.Bind(new(update.WhichController), new(*which.Controller)),
+		),
+		wire.NewSet(
+			link.New,
+			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
+			wire.Bind(new(which.Linker), new(*link.Linker)),
+		),
+		osenv.New,
+	)
+	return &update.Controller{}, nil
+}
+
+func InitializeAllowPolicyCommandController(ctx context.Context, param *config.Param) *allowpolicy.Controller {
+	wire.Build(
+		allowpolicy.New,
+		wire.NewSet(
+			policy.NewConfigFinder,
+			wire.Bind(new(policy.ConfigFinder), new(*policy.ConfigFinderImpl)),
+		),
+		wire.NewSet(
+			policy.NewValidator,
+			wire.Bind(new(policy.Validator), new(*policy.ValidatorImpl)),
+		),
+	)
+	return &allowpolicy.Controller{}
+}
+
+func InitializeDenyPolicyCommandController(ctx context.Context, param *config.Param) *denypolicy.Controller {
+	wire.Build(
+		denypolicy.New,
+		wire.NewSet(
+			policy.NewConfigFinder,
+			wire.Bind(new(policy.ConfigFinder), new(*policy.ConfigFinderImpl)),
+		),
+		wire.NewSet(
+			policy.NewValidator,
+			wire.Bind(new(policy.Validator), new(*policy.ValidatorImpl)),
+		),
+	)
+	return &denypolicy.Controller{}
+}
+
+func InitializeInfoCommandController(ctx context.Context, param *config.Param, rt *runtime.Runtime) *info.Controller {
+	wire.Build(
+		info.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(info.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+	)
+	return &info.Controller{}
+}
+
+func InitializeRemoveCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime, target *config.RemoveMode) (*remove.Controller, error) {
+	wire.Build(
+		remove.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(remove.ConfigFinder), new(*finder.ConfigFinder)),
+			wire.Bind(new(which.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(remove.ConfigReader), new(*reader.ConfigReader)),
+			wire.Bind(new(which.ConfigReader), new(*reader.ConfigReader)),
+		),
+		wire.NewSet(
+			registry.New,
+			wire.Bind(new(remove.RegistryInstaller), new(*registry.Installer)),
+			wire.Bind(new(which.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+		),
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			osexec.New,
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+		),
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
+		wire.NewSet(
+			fuzzyfinder.New,
+			wire.Bind(new(remove.FuzzyFinder), new(*fuzzyfinder.Finder)),
+		),
+		wire.NewSet(
+			which.New,
+			wire.Bind(new(remove.WhichController), new(*which.Controller)),
+		),
+		osenv.New,
+		wire.NewSet(
+			link.New,
+			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
+			wire.Bind(new(which.Linker), new(*link.Linker)),
+		),
+		wire.NewSet(
+			vacuum.New,
+			wire.Bind(new(remove.Vacuum), new(*vacuum.Client)),
+		),
+	)
+	return &remove.Controller{}, nil
+}
+
+func InitializeVacuumCommandController(ctx context.Context, param *config.Param, rt *runtime.Runtime) *cvacuum.Controller {
+	wire.Build(
+		cvacuum.New,
+		wire.NewSet(
+			vacuum.New,
+			wire.Bind(new(cvacuum.Vacuum), new(*vacuum.Client)),
+		),
+	)
+	return &cvacuum.Controller{}
+}
+
+func InitializeStatsCommandController(ctx context.Context, param *config.Param) *stats.Controller {
+	wire.Build(
+		stats.New,
+		wire.NewSet(
+			vacuum.New,
+			wire.Bind(new(stats.Vacuum), new(*vacuum.Client)),
+		),
+		wire.NewSet(
+			manifest.New,
+			wire.Bind(new(stats.Manifest), new(*manifest.Client)),
+		),
+	)
+	return &stats.Controller{}
+}
+
+func InitializeVacuumInitCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, rt *runtime.Runtime, httpClient *http.Client) (*initialize.Controller, error) {
+	wire.Build(
+		initialize.New,
+		wire.NewSet(
+			vacuum.New,
+			wire.Bind(new(initialize.Vacuum), new(*vacuum.Client)),
+		),
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(initialize.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(initialize.ConfigReader), new(*reader.ConfigReader)),
+		),
+		wire.NewSet(
+			registry.New,
+			wire.Bind(new(initialize.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+		),
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			osexec.New,
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
+	)
+	return &initialize.Controller{}, nil
+}
+
+func InitializeVerifyCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*verify.Controller, error) {
+	wire.Build(
+		verify.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(verify.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			registry.New,
+			wire.Bind(new(verify.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(verify.ConfigReader), new(*reader.ConfigReader)),
+		),
+		wire.NewSet(
+			installpackage.New,
+			wire.Bind(new(verify.Installer), new(*installpackage.Installer)),
+		),
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		wire.NewSet(
+			link.New,
+			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
+		),
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			osexec.New,
+			wire.Bind(new(installpackage.Executor), new(*osexec.Executor)),
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(ghattestation.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(unarchive.Executor), new(*osexec.Executor)),
+		),
+		wire.NewSet(
+			download.NewChecksumDownloader,
+			wire.Bind(new(download.ChecksumDownloader), new(*download.ChecksumDownloaderImpl)),
 		),
 		wire.NewSet(
-			goproxy.New,
-			wire.Bind(new(versiongetter.GoProxyClient), new(*goproxy.Client)),
+			checksum.NewCalculator,
+			wire.Bind(new(installpackage.ChecksumCalculator), new(*checksum.Calculator)),
 		),
 		wire.NewSet(
-			which.New,
-			wire.Bind(new(update.WhichController), new(*which.Controller)),
+			unarchive.New,
+			wire.Bind(new(installpackage.Unarchiver), new(*unarchive.Unarchiver)),
 		),
 		wire.NewSet(
-			link.New,
-			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
-			wire.Bind(new(which.Linker), new(*link.Linker)),
+			policy.NewConfigReader,
+			wire.Bind(new(policy.ConfigReader), new(*policy.ConfigReaderImpl)),
 		),
-		osenv.New,
-	)
-	return &update.Controller{}, nil
-}
-
-func InitializeAllowPolicyCommandController(ctx context.Context, param *config.Param) *allowpolicy.Controller {
-	wire.Build(
-		allowpolicy.New,
 		wire.NewSet(
 			policy.NewConfigFinder,
 			wire.Bind(new(policy.ConfigFinder), new(*policy.ConfigFinderImpl)),
@@ -984,77 +1775,126 @@ func InitializeAllowPolicyCommandController(ctx context.Context, param *config.P
 			policy.NewValidator,
 			wire.Bind(new(policy.Validator), new(*policy.ValidatorImpl)),
 		),
-	)
-	return &allowpolicy.Controller{}
-}
-
-func InitializeDenyPolicyCommandController(ctx context.Context, param *config.Param) *denypolicy.Controller {
-	wire.Build(
-		denypolicy.New,
 		wire.NewSet(
-			policy.NewConfigFinder,
-			wire.Bind(new(policy.ConfigFinder), new(*policy.ConfigFinderImpl)),
+			policy.NewReader,
+			wire.Bind(new(verify.PolicyReader), new(*policy.Reader)),
 		),
 		wire.NewSet(
-			policy.NewValidator,
-			wire.Bind(new(policy.Validator), new(*policy.ValidatorImpl)),
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
 		),
-	)
-	return &denypolicy.Controller{}
-}
-
-func InitializeInfoCommandController(ctx context.Context, param *config.Param, rt *runtime.Runtime) *info.Controller {
-	wire.Build(
-		info.New,
 		wire.NewSet(
-			finder.NewConfigFinder,
-			wire.Bind(new(info.ConfigFinder), new(*finder.ConfigFinder)),
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
+		wire.NewSet(
+			minisign.New,
+			wire.Bind(new(installpackage.MinisignVerifier), new(*minisign.Verifier)),
+		),
+		wire.NewSet(
+			pgp.New,
+			wire.Bind(new(installpackage.PGPVerifier), new(*pgp.Verifier)),
+		),
+		wire.NewSet(
+			ghattestation.New,
+			wire.Bind(new(installpackage.GitHubArtifactAttestationsVerifier), new(*ghattestation.Verifier)),
+		),
+		wire.NewSet(
+			ghattestation.NewExecutor,
+			wire.Bind(new(ghattestation.Executor), new(*ghattestation.ExecutorImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewGoInstallInstallerImpl,
+			wire.Bind(new(installpackage.GoInstallInstaller), new(*installpackage.GoInstallInstallerImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewGoBuildInstallerImpl,
+			wire.Bind(new(installpackage.GoBuildInstaller), new(*installpackage.GoBuildInstallerImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewCargoPackageInstallerImpl,
+			wire.Bind(new(installpackage.CargoPackageInstaller), new(*installpackage.CargoPackageInstallerImpl)),
+		),
+		wire.NewSet(
+			vacuum.New,
+			wire.Bind(new(installpackage.Vacuum), new(*vacuum.Client)),
+		),
+		wire.NewSet(
+			manifest.New,
+			wire.Bind(new(installpackage.Manifest), new(*manifest.Client)),
+			wire.Bind(new(verify.Manifest), new(*manifest.Client)),
 		),
 	)
-	return &info.Controller{}
+	return &verify.Controller{}, nil
 }
 
-func InitializeRemoveCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime, target *config.RemoveMode) (*remove.Controller, error) {
+func InitializeSBOMCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*sbom.Controller, error) {
 	wire.Build(
-		remove.New,
+		sbom.New,
 		wire.NewSet(
 			finder.NewConfigFinder,
-			wire.Bind(new(remove.ConfigFinder), new(*finder.ConfigFinder)),
-			wire.Bind(new(which.ConfigFinder), new(*finder.ConfigFinder)),
+			wire.Bind(new(sbom.ConfigFinder), new(*finder.ConfigFinder)),
 		),
 		wire.NewSet(
-			reader.New,
-			wire.Bind(new(remove.ConfigReader), new(*reader.ConfigReader)),
-			wire.Bind(new(which.ConfigReader), new(*reader.ConfigReader)),
+			github.New,
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
 		),
 		wire.NewSet(
 			registry.New,
-			wire.Bind(new(remove.RegistryInstaller), new(*registry.Installer)),
-			wire.Bind(new(which.RegistryInstaller), new(*registry.Installer)),
+			wire.Bind(new(sbom.RegistryInstaller), new(*registry.Installer)),
 		),
 		wire.NewSet(
 			download.NewGitHubContentFileDownloader,
 			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
 		),
 		wire.NewSet(
-			github.New,
-			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
-			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+			reader.New,
+			wire.Bind(new(sbom.ConfigReader), new(*reader.ConfigReader)),
 		),
 		wire.NewSet(
-			cosign.NewVerifier,
-			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
-			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+			installpackage.New,
+			wire.Bind(new(sbom.Installer), new(*installpackage.Installer)),
+		),
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		wire.NewSet(
+			link.New,
+			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
 		),
 		download.NewHTTPDownloader,
 		wire.NewSet(
 			osexec.New,
+			wire.Bind(new(installpackage.Executor), new(*osexec.Executor)),
 			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
 			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(ghattestation.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(unarchive.Executor), new(*osexec.Executor)),
 		),
 		wire.NewSet(
-			download.NewDownloader,
-			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+			download.NewChecksumDownloader,
+			wire.Bind(new(download.ChecksumDownloader), new(*download.ChecksumDownloaderImpl)),
+		),
+		wire.NewSet(
+			checksum.NewCalculator,
+			wire.Bind(new(installpackage.ChecksumCalculator), new(*checksum.Calculator)),
+		),
+		wire.NewSet(
+			unarchive.New,
+			wire.Bind(new(installpackage.Unarchiver), new(*unarchive.Unarchiver)),
+		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
 		),
 		wire.NewSet(
 			slsa.New,
@@ -1066,71 +1906,122 @@ func InitializeRemoveCommandController(ctx context.Context, logger *slog.Logger,
 			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
 		),
 		wire.NewSet(
-			fuzzyfinder.New,
-			wire.Bind(new(remove.FuzzyFinder), new(*fuzzyfinder.Finder)),
+			minisign.New,
+			wire.Bind(new(installpackage.MinisignVerifier), new(*minisign.Verifier)),
 		),
 		wire.NewSet(
-			which.New,
-			wire.Bind(new(remove.WhichController), new(*which.Controller)),
+			pgp.New,
+			wire.Bind(new(installpackage.PGPVerifier), new(*pgp.Verifier)),
 		),
-		osenv.New,
 		wire.NewSet(
-			link.New,
-			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
-			wire.Bind(new(which.Linker), new(*link.Linker)),
+			ghattestation.New,
+			wire.Bind(new(installpackage.GitHubArtifactAttestationsVerifier), new(*ghattestation.Verifier)),
 		),
 		wire.NewSet(
-			vacuum.New,
-			wire.Bind(new(remove.Vacuum), new(*vacuum.Client)),
+			ghattestation.NewExecutor,
+			wire.Bind(new(ghattestation.Executor), new(*ghattestation.ExecutorImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewGoInstallInstallerImpl,
+			wire.Bind(new(installpackage.GoInstallInstaller), new(*installpackage.GoInstallInstallerImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewGoBuildInstallerImpl,
+			wire.Bind(new(installpackage.GoBuildInstaller), new(*installpackage.GoBuildInstallerImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewCargoPackageInstallerImpl,
+			wire.Bind(new(installpackage.CargoPackageInstaller), new(*installpackage.CargoPackageInstallerImpl)),
 		),
-	)
-	return &remove.Controller{}, nil
-}
-
-func InitializeVacuumCommandController(ctx context.Context, param *config.Param, rt *runtime.Runtime) *cvacuum.Controller {
-	wire.Build(
-		cvacuum.New,
 		wire.NewSet(
 			vacuum.New,
-			wire.Bind(new(cvacuum.Vacuum), new(*vacuum.Client)),
+			wire.Bind(new(installpackage.Vacuum), new(*vacuum.Client)),
+		),
+		wire.NewSet(
+			manifest.New,
+			wire.Bind(new(installpackage.Manifest), new(*manifest.Client)),
 		),
 	)
-	return &cvacuum.Controller{}
+	return &sbom.Controller{}, nil
 }
 
-func InitializeVacuumInitCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, rt *runtime.Runtime, httpClient *http.Client) (*initialize.Controller, error) {
+func InitializeAuditCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*audit.Controller, error) {
 	wire.Build(
-		initialize.New,
+		audit.New,
 		wire.NewSet(
-			vacuum.New,
-			wire.Bind(new(initialize.Vacuum), new(*vacuum.Client)),
+			finder.NewConfigFinder,
+			wire.Bind(new(audit.ConfigFinder), new(*finder.ConfigFinder)),
 		),
 		wire.NewSet(
-			finder.NewConfigFinder,
-			wire.Bind(new(initialize.ConfigFinder), new(*finder.ConfigFinder)),
+			github.New,
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			registry.New,
+			wire.Bind(new(audit.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
 		),
 		wire.NewSet(
 			reader.New,
-			wire.Bind(new(initialize.ConfigReader), new(*reader.ConfigReader)),
+			wire.Bind(new(audit.ConfigReader), new(*reader.ConfigReader)),
 		),
+		download.NewHTTPDownloader,
 		wire.NewSet(
-			registry.New,
-			wire.Bind(new(initialize.RegistryInstaller), new(*registry.Installer)),
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			osexec.New,
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+		),
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
+	)
+	return &audit.Controller{}, nil
+}
+
+func InitializeTestPolicyCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*testpolicy.Controller, error) {
+	wire.Build(
+		testpolicy.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(testpolicy.ConfigFinder), new(*finder.ConfigFinder)),
 		),
 		wire.NewSet(
 			github.New,
 			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
 			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
 		),
+		wire.NewSet(
+			registry.New,
+			wire.Bind(new(testpolicy.RegistryInstaller), new(*registry.Installer)),
+		),
 		wire.NewSet(
 			download.NewGitHubContentFileDownloader,
 			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
 		),
-		download.NewHTTPDownloader,
 		wire.NewSet(
-			download.NewDownloader,
-			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+			reader.New,
+			wire.Bind(new(testpolicy.ConfigReader), new(*reader.ConfigReader)),
 		),
+		download.NewHTTPDownloader,
 		wire.NewSet(
 			cosign.NewVerifier,
 			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
@@ -1141,6 +2032,10 @@ func InitializeVacuumInitCommandController(ctx context.Context, logger *slog.Log
 			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
 			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
 		),
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
 		wire.NewSet(
 			slsa.New,
 			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
@@ -1150,6 +2045,22 @@ func InitializeVacuumInitCommandController(ctx context.Context, logger *slog.Log
 			slsa.NewExecutor,
 			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
 		),
+		wire.NewSet(
+			policy.NewConfigReader,
+			wire.Bind(new(policy.ConfigReader), new(*policy.ConfigReaderImpl)),
+		),
+		wire.NewSet(
+			policy.NewConfigFinder,
+			wire.Bind(new(policy.ConfigFinder), new(*policy.ConfigFinderImpl)),
+		),
+		wire.NewSet(
+			policy.NewValidator,
+			wire.Bind(new(policy.Validator), new(*policy.ValidatorImpl)),
+		),
+		wire.NewSet(
+			policy.NewReader,
+			wire.Bind(new(testpolicy.PolicyReader), new(*policy.Reader)),
+		),
 	)
-	return &initialize.Controller{}, nil
+	return &testpolicy.Controller{}, nil
 }
@@ -14,8 +14,11 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/config-finder"
 	"github.com/aquaproj/aqua/v2/pkg/config-reader"
 	"github.com/aquaproj/aqua/v2/pkg/controller/allowpolicy"
+	"github.com/aquaproj/aqua/v2/pkg/controller/audit"
 	"github.com/aquaproj/aqua/v2/pkg/controller/cp"
 	"github.com/aquaproj/aqua/v2/pkg/controller/denypolicy"
+	"github.com/aquaproj/aqua/v2/pkg/controller/doctor"
+	"github.com/aquaproj/aqua/v2/pkg/controller/env"
 	"github.com/aquaproj/aqua/v2/pkg/controller/exec"
 	"github.com/aquaproj/aqua/v2/pkg/controller/generate"
 	"github.com/aquaproj/aqua/v2/pkg/controller/generate-registry"
@@ -26,11 +29,18 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/controller/install"
 	"github.com/aquaproj/aqua/v2/pkg/controller/list"
 	"github.com/aquaproj/aqua/v2/pkg/controller/remove"
+	"github.com/aquaproj/aqua/v2/pkg/controller/run"
+	"github.com/aquaproj/aqua/v2/pkg/controller/sbom"
+	"github.com/aquaproj/aqua/v2/pkg/controller/shellinit"
+	"github.com/aquaproj/aqua/v2/pkg/controller/stats"
+	"github.com/aquaproj/aqua/v2/pkg/controller/suggest"
+	"github.com/aquaproj/aqua/v2/pkg/controller/testpolicy"
 	"github.com/aquaproj/aqua/v2/pkg/controller/update"
 	"github.com/aquaproj/aqua/v2/pkg/controller/updateaqua"
 	"github.com/aquaproj/aqua/v2/pkg/controller/updatechecksum"
 	vacuum2 "github.com/aquaproj/aqua/v2/pkg/controller/vacuum"
 	"github.com/aquaproj/aqua/v2/pkg/controller/vacuum/initialize"
+	"github.com/aquaproj/aqua/v2/pkg/controller/verify"
 	"github.com/aquaproj/aqua/v2/pkg/controller/which"
 	"github.com/aquaproj/aqua/v2/pkg/cosign"
 	"github.com/aquaproj/aqua/v2/pkg/download"
@@ -40,8 +50,10 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/install-registry"
 	"github.com/aquaproj/aqua/v2/pkg/installpackage"
 	"github.com/aquaproj/aqua/v2/pkg/link"
+	"github.com/aquaproj/aqua/v2/pkg/manifest"
 	"github.com/aquaproj/aqua/v2/pkg/minisign"
 	"github.com/aquaproj/aqua/v2/pkg/osexec"
+	"github.com/aquaproj/aqua/v2/pkg/pgp"
 	"github.com/aquaproj/aqua/v2/pkg/policy"
 	"github.com/aquaproj/aqua/v2/pkg/runtime"
 	"github.com/aquaproj/aqua/v2/pkg/slsa"
@@ -76,6 +88,25 @@ func InitializeListCommandController(ctx context.Context, logger *slog.Logger, p
 	return controller, nil
 }
 
+func InitializeShellInitCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*shellinit.Controller, error) {
+	configFinder := finder.NewConfigFinder()
+	configReader := reader.New(param)
+	repositoriesService, err := github.New(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	httpDownloader := download.NewHTTPDownloader(logger, httpClient)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
+	executor := osexec.New()
+	downloader := download.NewDownloader(repositoriesService, httpDownloader)
+	verifier := cosign.NewVerifier(executor, downloader, param)
+	executorImpl := slsa.NewExecutor(executor, param)
+	slsaVerifier := slsa.New(downloader, executorImpl)
+	installer := registry.New(param, gitHubContentFileDownloader, rt, verifier, slsaVerifier)
+	controller := shellinit.NewController(param, configFinder, configReader, installer, rt)
+	return controller, nil
+}
+
 func InitializeGenerateRegistryCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, stdout io.Writer) (*genrgst.Controller, error) {
 	repositoriesService, err := github.New(ctx, logger)
 	if err != nil {
@@ -129,6 +160,35 @@ func InitializeGenerateCommandController(ctx context.Context, logger *slog.Logge
 	return controller, nil
 }
 
+func InitializeSuggestCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*suggest.Controller, error) {
+	configFinder := finder.NewConfigFinder()
+	configReader := reader.New(param)
+	repositoriesService, err := github.New(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	httpDownloader := download.NewHTTPDownloader(logger, httpClient)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
+	executor := osexec.New()
+	downloader := download.NewDownloader(repositoriesService, httpDownloader)
+	verifier := cosign.NewVerifier(executor, downloader, param)
+	executorImpl := slsa.NewExecutor(executor, param)
+	slsaVerifier := slsa.New(downloader, executorImpl)
+	installer := registry.New(param, gitHubContentFileDownloader, rt, verifier, slsaVerifier)
+	fuzzyfinderFinder := fuzzyfinder.New()
+	client := cargo.NewClient(httpClient)
+	cargoVersionGetter := versiongetter.NewCargo(client)
+	gitHubTagVersionGetter := versiongetter.NewGitHubTag(repositoriesService)
+	gitHubReleaseVersionGetter := versiongetter.NewGitHubRelease(repositoriesService)
+	goproxyClient := goproxy.New(httpClient)
+	goGetter := versiongetter.NewGoGetter(goproxyClient)
+	generalVersionGetter := versiongetter.NewGeneralVersionGetter(cargoVersionGetter, gitHubTagVersionGetter, gitHubReleaseVersionGetter, goGetter)
+	fuzzyGetter := versiongetter.NewFuzzy(fuzzyfinderFinder, generalVersionGetter)
+	controller := generate.New(configFinder, configReader, installer, repositoriesService, fuzzyfinderFinder, fuzzyGetter)
+	suggestController := suggest.New(configFinder, configReader, installer, controller)
+	return suggestController, nil
+}
+
 func InitializeInstallCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*install.Controller, error) {
 	configFinder := finder.NewConfigFinder()
 	configReader := reader.New(param)
@@ -148,11 +208,48 @@ func InitializeInstallCommandController(ctx context.Context, logger *slog.Logger
 	checksumDownloaderImpl := download.NewChecksumDownloader(repositoriesService, rt, httpDownloader)
 	calculator := checksum.NewCalculator()
 	unarchiver := unarchive.New(executor)
-	minisignExecutorImpl, err := minisign.NewExecutor(logger, executor, param)
+	minisignVerifier := minisign.New(downloader)
+	pgpVerifier := pgp.New(downloader)
+	ghattestationExecutorImpl, err := ghattestation.NewExecutor(executor, param)
+	if err != nil {
+		return nil, err
+	}
+	ghattestationVerifier := ghattestation.New(ghattestationExecutorImpl)
+	goInstallInstallerImpl := installpackage.NewGoInstallInstallerImpl(executor)
+	goBuildInstallerImpl := installpackage.NewGoBuildInstallerImpl(executor)
+	cargoPackageInstallerImpl := installpackage.NewCargoPackageInstallerImpl(executor)
+	client := vacuum.New(param)
+	manifestClient := manifest.New(param)
+	installpackageInstaller := installpackage.New(param, downloader, rt, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, pgpVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, client, manifestClient)
+	validatorImpl := policy.NewValidator(param)
+	configFinderImpl := policy.NewConfigFinder()
+	configReaderImpl := policy.NewConfigReader()
+	policyReader := policy.NewReader(validatorImpl, configFinderImpl, configReaderImpl)
+	controller := install.New(param, configFinder, configReader, installer, installpackageInstaller, rt, policyReader)
+	return controller, nil
+}
+
+func InitializeEnvCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*env.Controller, error) {
+	configFinder := finder.NewConfigFinder()
+	configReader := reader.New(param)
+	repositoriesService, err := github.New(ctx, logger)
 	if err != nil {
 		return nil, err
 	}
-	minisignVerifier := minisign.New(downloader, minisignExecutorImpl)
+	httpDownloader := download.NewHTTPDownloader(logger, httpClient)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
+	executor := osexec.New()
+	downloader := download.NewDownloader(repositoriesService, httpDownloader)
+	verifier := cosign.NewVerifier(executor, downloader, param)
+	executorImpl := slsa.NewExecutor(executor, param)
+	slsaVerifier := slsa.New(downloader, executorImpl)
+	installer := registry.New(param, gitHubContentFileDownloader, rt, verifier, slsaVerifier)
+	linker := link.New()
+	checksumDownloaderImpl := download.NewChecksumDownloader(repositoriesService, rt, httpDownloader)
+	calculator := checksum.NewCalculator()
+	unarchiver := unarchive.New(executor)
+	minisignVerifier := minisign.New(downloader)
+	pgpVerifier := pgp.New(downloader)
 	ghattestationExecutorImpl, err := ghattestation.NewExecutor(executor, param)
 	if err != nil {
 		return nil, err
@@ -162,12 +259,14 @@ func InitializeInstallCommandController(ctx context.Context, logger *slog.Logger
 	goBuildInstallerImpl := installpackage.NewGoBuildInstallerImpl(executor)
 	cargoPackageInstallerImpl := installpackage.NewCargoPackageInstallerImpl(executor)
 	client := vacuum.New(param)
-	installpackageInstaller := installpackage.New(param, downloader, rt, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, client)
+	manifestClient := manifest.New(param)
+	installpackageInstaller := installpackage.New(param, downloader, rt, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, pgpVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, client, manifestClient)
 	validatorImpl := policy.NewValidator(param)
 	configFinderImpl := policy.NewConfigFinder()
 	configReaderImpl := policy.NewConfigReader()
 	policyReader := policy.NewReader(validatorImpl, configFinderImpl, configReaderImpl)
-	controller := install.New(param, configFinder, configReader, installer, installpackageInstaller, rt, policyReader)
+	osEnv := osenv.New()
+	controller := env.New(param, configFinder, configReader, installer, installpackageInstaller, rt, policyReader, osEnv)
 	return controller, nil
 }
 
@@ -192,6 +291,27 @@ func InitializeWhichCommandController(ctx context.Context, logger *slog.Logger,
 	return controller, nil
 }
 
+func InitializeDoctorCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*doctor.Controller, error) {
+	configFinder := finder.NewConfigFinder()
+	configReader := reader.New(param)
+	repositoriesService, err := github.New(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	httpDownloader := download.NewHTTPDownloader(logger, httpClient)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
+	executor := osexec.New()
+	downloader := download.NewDownloader(repositoriesService, httpDownloader)
+	verifier := cosign.NewVerifier(executor, downloader, param)
+	executorImpl := slsa.NewExecutor(executor, param)
+	slsaVerifier := slsa.New(downloader, executorImpl)
+	installer := registry.New(param, gitHubContentFileDownloader, rt, verifier, slsaVerifier)
+	osEnv := osenv.New()
+	linker := link.New()
+	controller := doctor.New(param, configFinder, configReader, installer, rt, osEnv, linker)
+	return controller, nil
+}
+
 func InitializeExecCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*exec.Controller, error) {
 	repositoriesService, err := github.New(ctx, logger)
 	if err != nil {
@@ -207,11 +327,8 @@ func InitializeExecCommandController(ctx context.Context, logger *slog.Logger, p
 	verifier := cosign.NewVerifier(executor, downloader, param)
 	executorImpl := slsa.NewExecutor(executor, param)
 	slsaVerifier := slsa.New(downloader, executorImpl)
-	minisignExecutorImpl, err := minisign.NewExecutor(logger, executor, param)
-	if err != nil {
-		return nil, err
-	}
-	minisignVerifier := minisign.New(downloader, minisignExecutorImpl)
+	minisignVerifier := minisign.New(downloader)
+	pgpVerifier := pgp.New(downloader)
 	ghattestationExecutorImpl, err := ghattestation.NewExecutor(executor, param)
 	if err != nil {
 		return nil, err
@@ -221,7 +338,8 @@ func InitializeExecCommandController(ctx context.Context, logger *slog.Logger, p
 	goBuildInstallerImpl := installpackage.NewGoBuildInstallerImpl(executor)
 	cargoPackageInstallerImpl := installpackage.NewCargoPackageInstallerImpl(executor)
 	client := vacuum.New(param)
-	installer := installpackage.New(param, downloader, rt, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, client)
+	manifestClient := manifest.New(param)
+	installer := installpackage.New(param, downloader, rt, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, pgpVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, client, manifestClient)
 	configFinder := finder.NewConfigFinder()
 	configReader := reader.New(param)
 	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
@@ -236,7 +354,7 @@ func InitializeExecCommandController(ctx context.Context, logger *slog.Logger, p
 	return execController, nil
 }
 
-func InitializeUpdateAquaCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*updateaqua.Controller, error) {
+func InitializeRunCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*run.Controller, error) {
 	repositoriesService, err := github.New(ctx, logger)
 	if err != nil {
 		return nil, err
@@ -251,11 +369,60 @@ func InitializeUpdateAquaCommandController(ctx context.Context, logger *slog.Log
 	verifier := cosign.NewVerifier(executor, downloader, param)
 	executorImpl := slsa.NewExecutor(executor, param)
 	slsaVerifier := slsa.New(downloader, executorImpl)
-	minisignExecutorImpl, err := minisign.NewExecutor(logger, executor, param)
+	minisignVerifier := minisign.New(downloader)
+	pgpVerifier := pgp.New(downloader)
+	ghattestationExecutorImpl, err := ghattestation.NewExecutor(executor, param)
 	if err != nil {
 		return nil, err
 	}
-	minisignVerifier := minisign.New(downloader, minisignExecutorImpl)
+	ghattestationVerifier := ghattestation.New(ghattestationExecutorImpl)
+	goInstallInstallerImpl := installpackage.NewGoInstallInstallerImpl(executor)
+	goBuildInstallerImpl := installpackage.NewGoBuildInstallerImpl(executor)
+	cargoPackageInstallerImpl := installpackage.NewCargoPackageInstallerImpl(executor)
+	client := vacuum.New(param)
+	manifestClient := manifest.New(param)
+	installer := installpackage.New(param, downloader, rt, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, pgpVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, client, manifestClient)
+	configFinder := finder.NewConfigFinder()
+	configReader := reader.New(param)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
+	registryInstaller := registry.New(param, gitHubContentFileDownloader, rt, verifier, slsaVerifier)
+	osEnv := osenv.New()
+	controller := which.New(param, configFinder, configReader, registryInstaller, rt, osEnv, linker)
+	validatorImpl := policy.NewValidator(param)
+	configFinderImpl := policy.NewConfigFinder()
+	configReaderImpl := policy.NewConfigReader()
+	policyReader := policy.NewReader(validatorImpl, configFinderImpl, configReaderImpl)
+	execController := exec.New(installer, controller, executor, osEnv, policyReader, client)
+	fuzzyfinderFinder := fuzzyfinder.New()
+	cargoClient := cargo.NewClient(httpClient)
+	cargoVersionGetter := versiongetter.NewCargo(cargoClient)
+	gitHubTagVersionGetter := versiongetter.NewGitHubTag(repositoriesService)
+	gitHubReleaseVersionGetter := versiongetter.NewGitHubRelease(repositoriesService)
+	goproxyClient := goproxy.New(httpClient)
+	goGetter := versiongetter.NewGoGetter(goproxyClient)
+	generalVersionGetter := versiongetter.NewGeneralVersionGetter(cargoVersionGetter, gitHubTagVersionGetter, gitHubReleaseVersionGetter, goGetter)
+	fuzzyGetter := versiongetter.NewFuzzy(fuzzyfinderFinder, generalVersionGetter)
+	runController := run.New(param, configFinder, configReader, registryInstaller, fuzzyGetter, execController, rt)
+	return runController, nil
+}
+
+func InitializeUpdateAquaCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*updateaqua.Controller, error) {
+	repositoriesService, err := github.New(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	httpDownloader := download.NewHTTPDownloader(logger, httpClient)
+	downloader := download.NewDownloader(repositoriesService, httpDownloader)
+	linker := link.New()
+	checksumDownloaderImpl := download.NewChecksumDownloader(repositoriesService, rt, httpDownloader)
+	calculator := checksum.NewCalculator()
+	executor := osexec.New()
+	unarchiver := unarchive.New(executor)
+	verifier := cosign.NewVerifier(executor, downloader, param)
+	executorImpl := slsa.NewExecutor(executor, param)
+	slsaVerifier := slsa.New(downloader, executorImpl)
+	minisignVerifier := minisign.New(downloader)
+	pgpVerifier := pgp.New(downloader)
 	ghattestationExecutorImpl, err := ghattestation.NewExecutor(executor, param)
 	if err != nil {
 		return nil, err
@@ -265,7 +432,8 @@ func InitializeUpdateAquaCommandController(ctx context.Context, logger *slog.Log
 	goBuildInstallerImpl := installpackage.NewGoBuildInstallerImpl(executor)
 	cargoPackageInstallerImpl := installpackage.NewCargoPackageInstallerImpl(executor)
 	client := vacuum.New(param)
-	installer := installpackage.New(param, downloader, rt, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, client)
+	manifestClient := manifest.New(param)
+	installer := installpackage.New(param, downloader, rt, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, pgpVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, client, manifestClient)
 	controller := updateaqua.New(param, rt, repositoriesService, installer)
 	return controller, nil
 }
@@ -285,11 +453,8 @@ func InitializeCopyCommandController(ctx context.Context, logger *slog.Logger, p
 	verifier := cosign.NewVerifier(executor, downloader, param)
 	executorImpl := slsa.NewExecutor(executor, param)
 	slsaVerifier := slsa.New(downloader, executorImpl)
-	minisignExecutorImpl, err := minisign.NewExecutor(logger, executor, param)
-	if err != nil {
-		return nil, err
-	}
-	minisignVerifier := minisign.New(downloader, minisignExecutorImpl)
+	minisignVerifier := minisign.New(downloader)
+	pgpVerifier := pgp.New(downloader)
 	ghattestationExecutorImpl, err := ghattestation.NewExecutor(executor, param)
 	if err != nil {
 		return nil, err
@@ -299,7 +464,8 @@ func InitializeCopyCommandController(ctx context.Context, logger *slog.Logger, p
 	goBuildInstallerImpl := installpackage.NewGoBuildInstallerImpl(executor)
 	cargoPackageInstallerImpl := installpackage.NewCargoPackageInstallerImpl(executor)
 	client := vacuum.New(param)
-	installer := installpackage.New(param, downloader, rt, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, client)
+	manifestClient := manifest.New(param)
+	installer := installpackage.New(param, downloader, rt, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, pgpVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, client, manifestClient)
 	configFinder := finder.NewConfigFinder()
 	configReader := reader.New(param)
 	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
@@ -334,11 +500,8 @@ func InitializeUpdateChecksumCommandController(ctx context.Context, logger *slog
 	linker := link.New()
 	calculator := checksum.NewCalculator()
 	unarchiver := unarchive.New(executor)
-	minisignExecutorImpl, err := minisign.NewExecutor(logger, executor, param)
-	if err != nil {
-		return nil, err
-	}
-	minisignVerifier := minisign.New(downloader, minisignExecutorImpl)
+	minisignVerifier := minisign.New(downloader)
+	pgpVerifier := pgp.New(downloader)
 	ghattestationExecutorImpl, err := ghattestation.NewExecutor(executor, param)
 	if err != nil {
 		return nil, err
@@ -348,8 +511,13 @@ func InitializeUpdateChecksumCommandController(ctx context.Context, logger *slog
 	goBuildInstallerImpl := installpackage.NewGoBuildInstallerImpl(executor)
 	cargoPackageInstallerImpl := installpackage.NewCargoPackageInstallerImpl(executor)
 	client := vacuum.New(param)
-	installpackageInstaller := installpackage.New(param, downloader, rt, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, client)
-	controller := updatechecksum.New(param, configFinder, configReader, installer, rt, checksumDownloaderImpl, downloader, gitHubContentFileDownloader, installpackageInstaller)
+	manifestClient := manifest.New(param)
+	installpackageInstaller := installpackage.New(param, downloader, rt, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, pgpVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, client, manifestClient)
+	validatorImpl := policy.NewValidator(param)
+	configFinderImpl := policy.NewConfigFinder()
+	configReaderImpl := policy.NewConfigReader()
+	policyReader := policy.NewReader(validatorImpl, configFinderImpl, configReaderImpl)
+	controller := updatechecksum.New(param, configFinder, configReader, installer, rt, checksumDownloaderImpl, downloader, gitHubContentFileDownloader, installpackageInstaller, policyReader)
 	return controller, nil
 }
 
@@ -434,6 +602,13 @@ func InitializeVacuumCommandController(ctx context.Context, param *config.Param,
 	return controller
 }
 
+func InitializeStatsCommandController(ctx context.Context, param *config.Param) *stats.Controller {
+	client := vacuum.New(param)
+	manifestClient := manifest.New(param)
+	controller := stats.New(client, manifestClient)
+	return controller
+}
+
 func InitializeVacuumInitCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, rt *runtime.Runtime, httpClient *http.Client) (*initialize.Controller, error) {
 	client := vacuum.New(param)
 	configFinder := finder.NewConfigFinder()
@@ -453,3 +628,121 @@ func InitializeVacuumInitCommandController(ctx context.Context, logger *slog.Log
 	controller := initialize.New(param, rt, client, configFinder, configReader, installer)
 	return controller, nil
 }
+
+func InitializeVerifyCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*verify.Controller, error) {
+	configFinder := finder.NewConfigFinder()
+	configReader := reader.New(param)
+	repositoriesService, err := github.New(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	httpDownloader := download.NewHTTPDownloader(logger, httpClient)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
+	executor := osexec.New()
+	downloader := download.NewDownloader(repositoriesService, httpDownloader)
+	verifier := cosign.NewVerifier(executor, downloader, param)
+	executorImpl := slsa.NewExecutor(executor, param)
+	slsaVerifier := slsa.New(downloader, executorImpl)
+	installer := registry.New(param, gitHubContentFileDownloader, rt, verifier, slsaVerifier)
+	linker := link.New()
+	checksumDownloaderImpl := download.NewChecksumDownloader(repositoriesService, rt, httpDownloader)
+	calculator := checksum.NewCalculator()
+	unarchiver := unarchive.New(executor)
+	minisignVerifier := minisign.New(downloader)
+	pgpVerifier := pgp.New(downloader)
+	ghattestationExecutorImpl, err := ghattestation.NewExecutor(executor, param)
+	if err != nil {
+		return nil, err
+	}
+	ghattestationVerifier := ghattestation.New(ghattestationExecutorImpl)
+	goInstallInstallerImpl := installpackage.NewGoInstallInstallerImpl(executor)
+	goBuildInstallerImpl := installpackage.NewGoBuildInstallerImpl(executor)
+	cargoPackageInstallerImpl := installpackage.NewCargoPackageInstallerImpl(executor)
+	client := vacuum.New(param)
+	manifestClient := manifest.New(param)
+	installpackageInstaller := installpackage.New(param, downloader, rt, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, pgpVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, client, manifestClient)
+	validatorImpl := policy.NewValidator(param)
+	configFinderImpl := policy.NewConfigFinder()
+	configReaderImpl := policy.NewConfigReader()
+	policyReader := policy.NewReader(validatorImpl, configFinderImpl, configReaderImpl)
+	controller := verify.New(param, configFinder, configReader, installer, installpackageInstaller, rt, policyReader, manifestClient)
+	return controller, nil
+}
+
+func InitializeSBOMCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*sbom.Controller, error) {
+	configFinder := finder.NewConfigFinder()
+	configReader := reader.New(param)
+	repositoriesService, err := github.New(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	httpDownloader := download.NewHTTPDownloader(logger, httpClient)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
+	executor := osexec.New()
+	downloader := download.NewDownloader(repositoriesService, httpDownloader)
+	verifier := cosign.NewVerifier(executor, downloader, param)
+	executorImpl := slsa.NewExecutor(executor, param)
+	slsaVerifier := slsa.New(downloader, executorImpl)
+	installer := registry.New(param, gitHubContentFileDownloader, rt, verifier, slsaVerifier)
+	linker := link.New()
+	checksumDownloaderImpl := download.NewChecksumDownloader(repositoriesService, rt, httpDownloader)
+	calculator := checksum.NewCalculator()
+	unarchiver := unarchive.New(executor)
+	minisignVerifier := minisign.New(downloader)
+	pgpVerifier := pgp.New(downloader)
+	ghattestationExecutorImpl, err := ghattestation.NewExecutor(executor, param)
+	if err != nil {
+		return nil, err
+	}
+	ghattestationVerifier := ghattestation.New(ghattestationExecutorImpl)
+	goInstallInstallerImpl := installpackage.NewGoInstallInstallerImpl(executor)
+	goBuildInstallerImpl := installpackage.NewGoBuildInstallerImpl(executor)
+	cargoPackageInstallerImpl := installpackage.NewCargoPackageInstallerImpl(executor)
+	client := vacuum.New(param)
+	manifestClient := manifest.New(param)
+	installpackageInstaller := installpackage.New(param, downloader, rt, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, pgpVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, client, manifestClient)
+	controller := sbom.New(configFinder, configReader, installer, installpackageInstaller)
+	return controller, nil
+}
+
+func InitializeAuditCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*audit.Controller, error) {
+	configFinder := finder.NewConfigFinder()
+	configReader := reader.New(param)
+	repositoriesService, err := github.New(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	httpDownloader := download.NewHTTPDownloader(logger, httpClient)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
+	executor := osexec.New()
+	downloader := download.NewDownloader(repositoriesService, httpDownloader)
+	verifier := cosign.NewVerifier(executor, downloader, param)
+	executorImpl := slsa.NewExecutor(executor, param)
+	slsaVerifier := slsa.New(downloader, executorImpl)
+	installer := registry.New(param, gitHubContentFileDownloader, rt, verifier, slsaVerifier)
+	controller := audit.New(configFinder, configReader, installer, rt)
+	return controller, nil
+}
+
+func InitializeTestPolicyCommandController(ctx context.Context, logger *slog.Logger, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*testpolicy.Controller, error) {
+	configFinder := finder.NewConfigFinder()
+	configReader := reader.New(param)
+	repositoriesService, err := github.New(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	httpDownloader := download.NewHTTPDownloader(logger, httpClient)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
+	executor := osexec.New()
+	downloader := download.NewDownloader(repositoriesService, httpDownloader)
+	verifier := cosign.NewVerifier(executor, downloader, param)
+	executorImpl := slsa.NewExecutor(executor, param)
+	slsaVerifier := slsa.New(downloader, executorImpl)
+	installer := registry.New(param, gitHubContentFileDownloader, rt, verifier, slsaVerifier)
+	validatorImpl := policy.NewValidator(param)
+	configFinderImpl := policy.NewConfigFinder()
+	configReaderImpl := policy.NewConfigReader()
+	policyReader := policy.NewReader(validatorImpl, configFinderImpl, configReaderImpl)
+	controller := testpolicy.New(configFinder, configReader, installer, policyReader, configReaderImpl)
+	return controller, nil
+}
@@ -0,0 +1,226 @@
+package cosign
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// material is a signature and materials to verify it, which come from options or a bundle.
+type material struct {
+	signature   []byte
+	certs       []*x509.Certificate
+	tlogEntries []*tlogEntry
+}
+
+// tlogEntry is an entry of the transparency log Rekor with its signed entry timestamp.
+type tlogEntry struct {
+	body           []byte
+	integratedTime int64
+	logIndex       int64
+	logID          string
+	set            []byte
+}
+
+// legacyBundleJSON is a bundle created by `cosign sign-blob --bundle`.
+type legacyBundleJSON struct {
+	Base64Signature string `json:"base64Signature"`
+	Cert            string `json:"cert"`
+	RekorBundle     *struct {
+		SignedEntryTimestamp []byte `json:"SignedEntryTimestamp"`
+		Payload              struct {
+			Body           string `json:"body"`
+			IntegratedTime int64  `json:"integratedTime"`
+			LogIndex       int64  `json:"logIndex"`
+			LogID          string `json:"logID"`
+		} `json:"Payload"`
+	} `json:"rekorBundle"`
+}
+
+// sigstoreBundleJSON is a bundle in the format of Sigstore's protobuf-specs.
+// https://github.com/sigstore/protobuf-specs/blob/main/protos/sigstore_bundle.proto
+type sigstoreBundleJSON struct {
+	MediaType            string `json:"mediaType"`
+	VerificationMaterial struct {
+		Certificate *struct {
+			RawBytes []byte `json:"rawBytes"`
+		} `json:"certificate"`
+		X509CertificateChain *struct {
+			Certificates []struct {
+				RawBytes []byte `json:"rawBytes"`
+			} `json:"certificates"`
+		} `json:"x509CertificateChain"`
+		TlogEntries []struct {
+			LogIndex jsonInt64 `json:"logIndex"`
+			LogID    struct {
+				KeyID []byte `json:"keyId"`
+			} `json:"logId"`
+			IntegratedTime   jsonInt64 `json:"integratedTime"`
+			InclusionPromise *struct {
+				SignedEntryTimestamp []byte `json:"signedEntryTimestamp"`
+			} `json:"inclusionPromise"`
+			CanonicalizedBody []byte `json:"canonicalizedBody"`
+		} `json:"tlogEntries"`
+	} `json:"verificationMaterial"`
+	MessageSignature *struct {
+		Signature []byte `json:"signature"`
+	} `json:"messageSignature"`
+}
+
+// jsonInt64 accepts both a number and a string because protojson encodes int64 as a string.
+type jsonInt64 int64
+
+func (i *jsonInt64) UnmarshalJSON(b []byte) error {
+	v, err := strconv.ParseInt(strings.Trim(string(b), `"`), 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse an integer: %w", err)
+	}
+	*i = jsonInt64(v)
+	return nil
+}
+
+var errUnsupportedBundle = errors.New("the bundle has no message signature. DSSE envelopes aren't supported")
+
+func parseBundle(b []byte) (*material, error) {
+	probe := &struct {
+		MediaType string `json:"mediaType"`
+	}{}
+	if err := json.Unmarshal(b, probe); err != nil {
+		return nil, fmt.Errorf("parse a bundle as JSON: %w", err)
+	}
+	if probe.MediaType != "" {
+		return parseSigstoreBundle(b)
+	}
+	return parseLegacyBundle(b)
+}
+
+func parseLegacyBundle(b []byte) (*material, error) {
+	raw := &legacyBundleJSON{}
+	if err := json.Unmarshal(b, raw); err != nil {
+		return nil, fmt.Errorf("parse a bundle as JSON: %w", err)
+	}
+	sig, err := decodeSignature([]byte(raw.Base64Signature))
+	if err != nil {
+		return nil, err
+	}
+	m := &material{
+		signature: sig,
+	}
+	if raw.Cert != "" {
+		certs, err := parseCertificates([]byte(raw.Cert))
+		if err != nil {
+			return nil, err
+		}
+		m.certs = certs
+	}
+	if rb := raw.RekorBundle; rb != nil {
+		body, err := base64.StdEncoding.DecodeString(rb.Payload.Body)
+		if err != nil {
+			return nil, fmt.Errorf("decode the body of a transparency log entry: %w", err)
+		}
+		m.tlogEntries = []*tlogEntry{
+			{
+				body:           body,
+				integratedTime: rb.Payload.IntegratedTime,
+				logIndex:       rb.Payload.LogIndex,
+				logID:          rb.Payload.LogID,
+				set:            rb.SignedEntryTimestamp,
+			},
+		}
+	}
+	return m, nil
+}
+
+func parseSigstoreBundle(b []byte) (*material, error) {
+	raw := &sigstoreBundleJSON{}
+	if err := json.Unmarshal(b, raw); err != nil {
+		return nil, fmt.Errorf("parse a bundle as JSON: %w", err)
+	}
+	if raw.MessageSignature == nil {
+		return nil, errUnsupportedBundle
+	}
+	m := &material{
+		signature: raw.MessageSignature.Signature,
+	}
+	vm := raw.VerificationMaterial
+	var ders [][]byte
+	if vm.Certificate != nil {
+		ders = append(ders, vm.Certificate.RawBytes)
+	}
+	if vm.X509CertificateChain != nil {
+		for _, c := range vm.X509CertificateChain.Certificates {
+			ders = append(ders, c.RawBytes)
+		}
+	}
+	for _, der := range ders {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("parse a certificate in the bundle: %w", err)
+		}
+		m.certs = append(m.certs, cert)
+	}
+	for _, e := range vm.TlogEntries {
+		entry := &tlogEntry{
+			body:           e.CanonicalizedBody,
+			integratedTime: int64(e.IntegratedTime),
+			logIndex:       int64(e.LogIndex),
+			logID:          hex.EncodeToString(e.LogID.KeyID),
+		}
+		if e.InclusionPromise != nil {
+			entry.set = e.InclusionPromise.SignedEntryTimestamp
+		}
+		m.tlogEntries = append(m.tlogEntries, entry)
+	}
+	return m, nil
+}
+
+// decodeSignature decodes a base64-encoded signature file.
+func decodeSignature(b []byte) ([]byte, error) {
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(b)))
+	if err != nil {
+		return nil, fmt.Errorf("decode a signature as base64: %w", err)
+	}
+	return sig, nil
+}
+
+var errNoCertificate = errors.New("no certificate is found")
+
+// parseCertificates parses PEM-encoded certificates.
+// Like cosign, it also accepts base64-encoded PEM because many projects release certificates in that form.
+func parseCertificates(b []byte) ([]*x509.Certificate, error) {
+	b = bytes.TrimSpace(b)
+	if !bytes.HasPrefix(b, []byte("-----")) {
+		decoded, err := base64.StdEncoding.DecodeString(string(b))
+		if err != nil {
+			return nil, fmt.Errorf("decode a certificate as base64: %w", err)
+		}
+		b = decoded
+	}
+	var certs []*x509.Certificate
+	for {
+		block, rest := pem.Decode(b)
+		if block == nil {
+			break
+		}
+		b = rest
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse a certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errNoCertificate
+	}
+	return certs, nil
+}
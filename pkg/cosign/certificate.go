@@ -0,0 +1,146 @@
+package cosign
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+)
+
+//nolint:gochecknoglobals
+var (
+	oidIssuerV1 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+var (
+	errUntrustedCertificate = errors.New("the certificate isn't issued by a trusted certificate authority")
+	errIdentityRequired     = errors.New("keyless verification requires --certificate-identity or --certificate-identity-regexp, and --certificate-oidc-issuer or --certificate-oidc-issuer-regexp")
+	errIdentityMismatch     = errors.New("the certificate identity doesn't match")
+	errIssuerMismatch       = errors.New("the certificate OIDC issuer doesn't match")
+	errExtensionMismatch    = errors.New("the certificate extension doesn't match")
+)
+
+// hasIssuer returns true if the certificate chain ends with a certificate authority in the trusted root.
+// Only the signature of the top of the chain is checked. The chain is verified by verifyCertificate.
+func (r *TrustedRoot) hasIssuer(certs []*x509.Certificate) bool {
+	top := certs[len(certs)-1]
+	for _, ca := range r.cas {
+		for _, c := range append([]*x509.Certificate{ca.root}, ca.intermediates...) {
+			if bytes.Equal(top.Raw, c.Raw) || top.CheckSignatureFrom(c) == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verifyCertificate verifies that the leaf certificate was issued by a trusted certificate authority at t.
+// It returns the certificate which issued the leaf certificate.
+func (r *TrustedRoot) verifyCertificate(certs []*x509.Certificate, t time.Time) (*x509.Certificate, error) {
+	leaf := certs[0]
+	for _, ca := range r.cas {
+		if !ca.validFor.contains(t) {
+			continue
+		}
+		roots := x509.NewCertPool()
+		roots.AddCert(ca.root)
+		intermediates := x509.NewCertPool()
+		for _, c := range ca.intermediates {
+			intermediates.AddCert(c)
+		}
+		for _, c := range certs[1:] {
+			intermediates.AddCert(c)
+		}
+		chains, err := leaf.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			CurrentTime:   t,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		})
+		if err != nil || len(chains) == 0 || len(chains[0]) < 2 { //nolint:mnd
+			continue
+		}
+		return chains[0][1], nil
+	}
+	return nil, errUntrustedCertificate
+}
+
+// checkIdentity checks the identity and the OIDC issuer in the certificate like `cosign verify-blob`.
+func checkIdentity(cert *x509.Certificate, opts *verifyOpts) error {
+	if (opts.Identity == "" && opts.IdentityRegexp == "") || (opts.Issuer == "" && opts.IssuerRegexp == "") {
+		return errIdentityRequired
+	}
+	sans := make([]string, 0, len(cert.URIs)+len(cert.EmailAddresses))
+	for _, u := range cert.URIs {
+		sans = append(sans, u.String())
+	}
+	sans = append(sans, cert.EmailAddresses...)
+	if err := match(sans, opts.Identity, opts.IdentityRegexp); err != nil {
+		return fmt.Errorf("%w: %w", errIdentityMismatch, err)
+	}
+	issuer, err := certificateIssuer(cert)
+	if err != nil {
+		return err
+	}
+	if err := match([]string{issuer}, opts.Issuer, opts.IssuerRegexp); err != nil {
+		return fmt.Errorf("%w: %w", errIssuerMismatch, err)
+	}
+	for oid, expected := range opts.GitHubWorkflowFields {
+		if v := extensionValue(cert, oid); v != expected {
+			return fmt.Errorf("%w: %s", errExtensionMismatch, oid)
+		}
+	}
+	return nil
+}
+
+var errNoMatch = errors.New("no value matches")
+
+// match returns nil if one of values is equal to expected or matches pattern.
+// Like cosign, the pattern isn't anchored.
+func match(values []string, expected, pattern string) error {
+	if expected != "" {
+		if slices.Contains(values, expected) {
+			return nil
+		}
+		return errNoMatch
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("compile a regular expression: %w", err)
+	}
+	if slices.ContainsFunc(values, re.MatchString) {
+		return nil
+	}
+	return errNoMatch
+}
+
+// certificateIssuer returns the OIDC issuer recorded by Fulcio.
+func certificateIssuer(cert *x509.Certificate) (string, error) {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidIssuerV2) {
+			continue
+		}
+		var s string
+		if _, err := asn1.UnmarshalWithParams(ext.Value, &s, "utf8"); err != nil {
+			return "", fmt.Errorf("parse the OIDC issuer in the certificate: %w", err)
+		}
+		return s, nil
+	}
+	return extensionValue(cert, oidIssuerV1.String()), nil
+}
+
+// extensionValue returns the raw value of a certificate extension.
+// Deprecated Fulcio extensions store strings without DER encoding.
+func extensionValue(cert *x509.Certificate, oid string) string {
+	for _, ext := range cert.Extensions {
+		if ext.Id.String() == oid {
+			return string(ext.Value)
+		}
+	}
+	return ""
+}
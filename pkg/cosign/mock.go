@@ -17,3 +17,7 @@ type MockVerifier struct {
 func (v *MockVerifier) Verify(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, file *download.File, cos *registry.Cosign, art *template.Artifact, verifiedFilePath string) error {
 	return v.err
 }
+
+func (v *MockVerifier) VerifyWithInstaller(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, file *download.File, cos *registry.Cosign, art *template.Artifact, verifiedFilePath string, install ExecutableInstaller) error {
+	return v.err
+}
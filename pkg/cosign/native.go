@@ -0,0 +1,355 @@
+package cosign
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/download"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/template"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+const (
+	// VerifierAuto verifies signatures natively if possible, and otherwise runs the cosign executable.
+	// This is the default.
+	VerifierAuto = "auto"
+	// VerifierNative always verifies signatures natively and fails if it isn't possible.
+	VerifierNative = "native"
+	// VerifierExternal always runs the cosign executable.
+	VerifierExternal = "external"
+)
+
+var (
+	errUnknownVerifier  = errors.New("unknown cosign verifier. auto, native, and external are supported")
+	errUnsupportedKey   = errors.New("keys in KMS aren't supported by the native cosign verifier")
+	errNoTrustedRoot    = errors.New("the trusted root isn't set")
+	errNoBundle         = errors.New("the native cosign verifier requires a bundle to verify the transparency log offline")
+	errNoSignature      = errors.New("no signature is found")
+	errNoSignatureOwner = errors.New("neither a public key nor a certificate is found")
+	errNoSupportedTlog  = errors.New("no transparency log entry has a signed entry timestamp of a transparency log in the trusted root")
+	errUnknownCA        = errors.New("the certificate isn't issued by a certificate authority in the trusted root")
+)
+
+// ValidateVerifier validates the value of AQUA_COSIGN_VERIFIER.
+func ValidateVerifier(s string) error {
+	switch s {
+	case "", VerifierAuto, VerifierNative, VerifierExternal:
+		return nil
+	default:
+		return slogerr.With(errUnknownVerifier, "cosign_verifier", s) //nolint:wrapcheck
+	}
+}
+
+// prepareNative reads the files passed to the native verifier.
+// It returns nil if the signature is verified with the cosign executable.
+//
+// In the auto mode, the bundle is parsed before the verifier is chosen,
+// so a signature whose format the native verifier doesn't support falls back to the cosign executable.
+func (v *Verifier) prepareNative(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, file *download.File, cos *registry.Cosign, art *template.Artifact, opts []string) (*nativeInput, error) {
+	if v.mode == VerifierExternal {
+		return nil, nil //nolint:nilnil
+	}
+	root, err := v.getTrustedRoot()
+	if err != nil {
+		return nil, err
+	}
+	reason := checkNative(cos, opts, root)
+	if reason == nil {
+		in, err := v.readNativeInput(ctx, logger, rt, file, cos, art, opts)
+		if err != nil {
+			return nil, err
+		}
+		if v.mode == VerifierNative {
+			return in, nil
+		}
+		reason = checkMaterial(root, opts, in)
+		if reason == nil {
+			return in, nil
+		}
+	}
+	if v.mode == VerifierNative {
+		return nil, fmt.Errorf("verify the signature natively: %w", reason)
+	}
+	slogerr.WithError(logger, reason).Warn("the signature can't be verified natively, so the cosign executable is installed and used. Please set AQUA_COSIGN_VERIFIER=external to suppress this warning")
+	return nil, nil //nolint:nilnil
+}
+
+// getTrustedRoot returns the trusted root set by AQUA_COSIGN_TRUSTED_ROOT.
+// If it isn't set, the embedded trusted root of Sigstore's public-good instance is used.
+func (v *Verifier) getTrustedRoot() (*TrustedRoot, error) {
+	v.trustedRootOnce.Do(func() {
+		if v.trustedRootPath == "" {
+			v.trustedRoot, v.trustedRootErr = parseTrustedRoot(publicGoodTrustedRoot)
+			return
+		}
+		v.trustedRoot, v.trustedRootErr = ReadTrustedRoot(v.trustedRootPath)
+	})
+	if v.trustedRootErr != nil {
+		return nil, slogerr.With(v.trustedRootErr, "cosign_trusted_root", v.trustedRootPath) //nolint:wrapcheck
+	}
+	return v.trustedRoot, nil
+}
+
+// checkNative returns an error if the native verifier can't verify the signature with the given config.
+func checkNative(cos *registry.Cosign, opts []string, root *TrustedRoot) error {
+	o, err := parseOpts(opts)
+	if err != nil {
+		return err
+	}
+	if isKMSKey(o.Key) {
+		return errUnsupportedKey
+	}
+	keyless := cos.Key == nil && o.Key == ""
+	if (keyless || !o.IgnoreTlog) && root == nil {
+		return errNoTrustedRoot
+	}
+	if !o.IgnoreTlog && cos.Bundle == nil && o.Bundle == "" {
+		return errNoBundle
+	}
+	return nil
+}
+
+// isKMSKey returns true if the key is a reference to a key in KMS such as awskms:// and k8s://.
+func isKMSKey(key string) bool {
+	if strings.HasPrefix(key, "pkcs11:") {
+		return true
+	}
+	u, err := url.Parse(key)
+	if err != nil {
+		return false
+	}
+	// A scheme with a single letter is a Windows drive letter.
+	return len(u.Scheme) > 1 && u.Scheme != "http" && u.Scheme != "https"
+}
+
+// checkMaterial returns an error if the native verifier doesn't support the signature.
+// The native verifier verifies the transparency log offline with the signed entry timestamp,
+// and it doesn't support DSSE envelopes.
+// The embedded trusted root is updated only when aqua is updated,
+// so a log or a certificate authority which isn't in the trusted root is also unsupported.
+// Other errors are returned by the native verifier.
+func checkMaterial(root *TrustedRoot, opts []string, in *nativeInput) error {
+	o, err := parseOpts(opts)
+	if err != nil {
+		return err
+	}
+	m, err := loadMaterial(in)
+	if err != nil {
+		if errors.Is(err, errUnsupportedBundle) {
+			return err
+		}
+		return nil
+	}
+	if !o.IgnoreTlog && !root.hasTlogEntry(m.tlogEntries) {
+		return errNoSupportedTlog
+	}
+	if in.key == nil && len(m.certs) != 0 && !root.hasIssuer(m.certs) {
+		return errUnknownCA
+	}
+	return nil
+}
+
+// nativeInput holds the contents of the files passed to the native verifier.
+type nativeInput struct {
+	artifact         []byte
+	signature        []byte
+	key              []byte
+	certificate      []byte
+	certificateChain []byte
+	bundle           []byte
+}
+
+func (v *Verifier) verifyNative(opts []string, in *nativeInput, verifiedFilePath string) error {
+	o, err := parseOpts(opts)
+	if err != nil {
+		return err
+	}
+	root, err := v.getTrustedRoot()
+	if err != nil {
+		return err
+	}
+	artifact, err := os.ReadFile(verifiedFilePath)
+	if err != nil {
+		return fmt.Errorf("read a verified file: %w", err)
+	}
+	in.artifact = artifact
+	if err := verifyNative(root, o, in); err != nil {
+		return fmt.Errorf("verify a signature file natively: %w", slogerr.With(err,
+			"cosign_opts", strings.Join(opts, ", "),
+			"target", verifiedFilePath,
+		))
+	}
+	return nil
+}
+
+// readNativeInput reads the files passed to the native verifier except for the verified file.
+func (v *Verifier) readNativeInput(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, file *download.File, cos *registry.Cosign, art *template.Artifact, opts []string) (*nativeInput, error) {
+	o, err := parseOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+	in := &nativeInput{}
+	for _, f := range []struct {
+		df     *registry.DownloadedFile
+		opt    string
+		target *[]byte
+	}{
+		{cos.Signature, o.Signature, &in.signature},
+		{cos.Key, o.Key, &in.key},
+		{cos.Certificate, o.Certificate, &in.certificate},
+		{nil, o.CertificateChain, &in.certificateChain},
+		{cos.Bundle, o.Bundle, &in.bundle},
+	} {
+		b, err := v.readFile(ctx, logger, rt, file, art, f.df, f.opt)
+		if err != nil {
+			return nil, err
+		}
+		*f.target = b
+	}
+	return in, nil
+}
+
+// readFile reads a file passed to the native verifier.
+// A file in the registry takes precedence over the option like `cosign verify-blob`,
+// where aqua appends options for files in the registry.
+// It returns nil if neither is set.
+func (v *Verifier) readFile(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, file *download.File, art *template.Artifact, df *registry.DownloadedFile, opt string) ([]byte, error) {
+	var f *download.File
+	switch {
+	case df != nil:
+		a, err := download.ConvertDownloadedFileToFile(df, file, rt, art)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		f = a
+	case strings.HasPrefix(opt, "https://") || strings.HasPrefix(opt, "http://"):
+		f = &download.File{
+			Type: "http",
+			URL:  opt,
+		}
+	case opt != "":
+		b, err := os.ReadFile(opt)
+		if err != nil {
+			return nil, fmt.Errorf("read a file: %w", slogerr.With(err, "path", opt))
+		}
+		return b, nil
+	default:
+		return nil, nil
+	}
+	rc, _, err := v.downloader.ReadCloser(ctx, logger, f)
+	if err != nil {
+		return nil, fmt.Errorf("get a readcloser: %w", err)
+	}
+	defer rc.Close()
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("download a file: %w", err)
+	}
+	return b, nil
+}
+
+// verifyNative verifies the signature of the artifact like `cosign verify-blob`.
+// Like cosign, the whole artifact is read because Ed25519 signs the message itself.
+func verifyNative(root *TrustedRoot, opts *verifyOpts, in *nativeInput) error { //nolint:cyclop
+	m, err := loadMaterial(in)
+	if err != nil {
+		return err
+	}
+	keyless := in.key == nil
+	var pub crypto.PublicKey
+	var signer []byte
+	if keyless {
+		if len(m.certs) == 0 {
+			return errNoSignatureOwner
+		}
+		pub = m.certs[0].PublicKey
+		signer = m.certs[0].Raw
+	} else {
+		pub, err = parsePublicKey(in.key)
+		if err != nil {
+			return err
+		}
+		signer, err = marshalPublicKey(pub)
+		if err != nil {
+			return err
+		}
+	}
+	if err := verifySignature(pub, in.artifact, m.signature); err != nil {
+		return fmt.Errorf("verify the signature: %w", err)
+	}
+	if (keyless || !opts.IgnoreTlog) && root == nil {
+		return errNoTrustedRoot
+	}
+	t := time.Now()
+	if !opts.IgnoreTlog {
+		digest := sha256.Sum256(in.artifact)
+		t, err = root.verifyTlog(m.tlogEntries, digest[:], m.signature, signer)
+		if err != nil {
+			return err
+		}
+	}
+	if !keyless {
+		return nil
+	}
+	// The certificate is short-lived, so it is verified at the time when the signature was logged.
+	issuer, err := root.verifyCertificate(m.certs, t)
+	if err != nil {
+		return err
+	}
+	if !opts.IgnoreSCT {
+		if err := root.verifySCT(m.certs[0], issuer); err != nil {
+			return err
+		}
+	}
+	return checkIdentity(m.certs[0], opts)
+}
+
+// loadMaterial merges the bundle and the files.
+// Files take precedence over the bundle.
+func loadMaterial(in *nativeInput) (*material, error) {
+	m := &material{}
+	if in.bundle != nil {
+		a, err := parseBundle(in.bundle)
+		if err != nil {
+			return nil, err
+		}
+		m = a
+	}
+	if in.signature != nil {
+		sig, err := decodeSignature(in.signature)
+		if err != nil {
+			return nil, err
+		}
+		m.signature = sig
+	}
+	if in.certificate != nil {
+		certs, err := parseCertificates(in.certificate)
+		if err != nil {
+			return nil, err
+		}
+		m.certs = certs
+	}
+	if in.certificateChain != nil {
+		chain, err := parseCertificates(in.certificateChain)
+		if err != nil {
+			return nil, err
+		}
+		m.certs = append(append([]*x509.Certificate{}, m.certs[:min(1, len(m.certs))]...), chain...)
+	}
+	if len(m.signature) == 0 {
+		return nil, errNoSignature
+	}
+	return m, nil
+}
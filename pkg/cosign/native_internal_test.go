@@ -0,0 +1,648 @@
+package cosign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+)
+
+const (
+	testIdentity = "https://github.com/aquaproj/example/.github/workflows/release.yaml@refs/tags/v1.0.0"
+	testIssuer   = "https://token.actions.githubusercontent.com"
+)
+
+// testSigstore is a Sigstore instance created in tests.
+// It signs artifacts offline like Fulcio, the certificate transparency log, and Rekor.
+type testSigstore struct {
+	t            *testing.T
+	rootCert     *x509.Certificate
+	intermediate *x509.Certificate
+	caKey        *ecdsa.PrivateKey
+	ctlogKey     *ecdsa.PrivateKey
+	tlogKey      *ecdsa.PrivateKey
+	now          time.Time
+}
+
+func newTestSigstore(t *testing.T) *testSigstore {
+	t.Helper()
+	s := &testSigstore{
+		t:        t,
+		caKey:    newKey(t),
+		ctlogKey: newKey(t),
+		tlogKey:  newKey(t),
+		now:      time.Now().Add(-time.Hour).Truncate(time.Second),
+	}
+	rootKey := newKey(t)
+	rootTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test root"},
+		NotBefore:             s.now.Add(-24 * time.Hour),
+		NotAfter:              s.now.Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	s.rootCert = createCert(t, rootTmpl, rootTmpl, &rootKey.PublicKey, rootKey)
+	s.intermediate = createCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(2), //nolint:mnd
+		Subject:               pkix.Name{CommonName: "test intermediate"},
+		NotBefore:             s.now.Add(-24 * time.Hour),
+		NotAfter:              s.now.Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}, s.rootCert, &s.caKey.PublicKey, rootKey)
+	return s
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func createCert(t *testing.T, tmpl, parent *x509.Certificate, pub crypto.PublicKey, priv crypto.Signer) *x509.Certificate {
+	t.Helper()
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, pub, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func pkixKey(t *testing.T, pub crypto.PublicKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, msg []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(msg)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+// trustedRoot returns trusted_root.json trusting the instance.
+func (s *testSigstore) trustedRoot() []byte {
+	s.t.Helper()
+	type rawBytes struct {
+		RawBytes []byte `json:"rawBytes"`
+	}
+	type log struct {
+		PublicKey rawBytes `json:"publicKey"`
+	}
+	b, err := json.Marshal(map[string]any{
+		"tlogs":  []log{{PublicKey: rawBytes{RawBytes: pkixKey(s.t, &s.tlogKey.PublicKey)}}},
+		"ctlogs": []log{{PublicKey: rawBytes{RawBytes: pkixKey(s.t, &s.ctlogKey.PublicKey)}}},
+		"certificateAuthorities": []map[string]any{
+			{
+				"certChain": map[string]any{
+					"certificates": []rawBytes{{RawBytes: s.intermediate.Raw}, {RawBytes: s.rootCert.Raw}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return b
+}
+
+// issueCertificate issues a short-lived certificate like Fulcio with an embedded SCT.
+func (s *testSigstore) issueCertificate(pub crypto.PublicKey) *x509.Certificate {
+	s.t.Helper()
+	u, err := url.Parse(testIdentity)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	issuer, err := asn1.MarshalWithParams(testIssuer, "utf8")
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(3), //nolint:mnd
+		NotBefore:    s.now,
+		NotAfter:     s.now.Add(10 * time.Minute), //nolint:mnd
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:         []*url.URL{u},
+		ExtraExtensions: []pkix.Extension{
+			{Id: oidIssuerV2, Value: issuer},
+		},
+	}
+	// The certificate transparency log signs the certificate without the SCT.
+	precert := createCert(s.t, tmpl, s.intermediate, pub, s.caKey)
+	ctlogID := sha256.Sum256(pkixKey(s.t, &s.ctlogKey.PublicKey))
+	ts := &sct{
+		logID:     ctlogID[:],
+		timestamp: uint64(s.now.UnixMilli()), //nolint:gosec
+	}
+	issuerKeyHash := sha256.Sum256(s.intermediate.RawSubjectPublicKeyInfo)
+	ts.signature = sign(s.t, s.ctlogKey, ts.signedData(issuerKeyHash[:], precert.RawTBSCertificate))
+
+	entry := []byte{ts.version}
+	entry = append(entry, ts.logID...)
+	entry = binary.BigEndian.AppendUint64(entry, ts.timestamp)
+	entry = binary.BigEndian.AppendUint16(entry, 0)                         // extensions
+	entry = append(entry, 4, 3)                                             // sha256, ecdsa
+	entry = binary.BigEndian.AppendUint16(entry, uint16(len(ts.signature))) //nolint:gosec
+	entry = append(entry, ts.signature...)
+	list := binary.BigEndian.AppendUint16(nil, uint16(len(entry)+2)) //nolint:gosec,mnd
+	list = binary.BigEndian.AppendUint16(list, uint16(len(entry)))   //nolint:gosec
+	list = append(list, entry...)
+	value, err := asn1.Marshal(list)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	tmpl.ExtraExtensions = append(tmpl.ExtraExtensions, pkix.Extension{Id: oidSCTList, Value: value})
+	return createCert(s.t, tmpl, s.intermediate, pub, s.caKey)
+}
+
+// logEntry records the signature in the transparency log like Rekor.
+// signer is the PEM-encoded certificate or public key.
+func (s *testSigstore) logEntry(artifact, sig, signer []byte) *tlogEntry {
+	s.t.Helper()
+	digest := sha256.Sum256(artifact)
+	body, err := json.Marshal(map[string]any{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]any{
+			"data": map[string]any{
+				"hash": map[string]string{"algorithm": "sha256", "value": hex.EncodeToString(digest[:])},
+			},
+			"signature": map[string]any{
+				"content":   sig,
+				"publicKey": map[string]any{"content": signer},
+			},
+		},
+	})
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	entry := &tlogEntry{
+		body:           body,
+		integratedTime: s.now.Add(time.Minute).Unix(),
+		logIndex:       100, //nolint:mnd
+		logID:          logID(pkixKey(s.t, &s.tlogKey.PublicKey)),
+	}
+	payload, err := json.Marshal(&setPayload{
+		Body:           base64.StdEncoding.EncodeToString(entry.body),
+		IntegratedTime: entry.integratedTime,
+		LogID:          entry.logID,
+		LogIndex:       entry.logIndex,
+	})
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	entry.set = sign(s.t, s.tlogKey, payload)
+	return entry
+}
+
+func legacyBundle(t *testing.T, sig, cert []byte, entry *tlogEntry) []byte {
+	t.Helper()
+	b, err := json.Marshal(map[string]any{
+		"base64Signature": base64.StdEncoding.EncodeToString(sig),
+		"cert":            base64.StdEncoding.EncodeToString(cert),
+		"rekorBundle": map[string]any{
+			"SignedEntryTimestamp": entry.set,
+			"Payload": map[string]any{
+				"body":           base64.StdEncoding.EncodeToString(entry.body),
+				"integratedTime": entry.integratedTime,
+				"logIndex":       entry.logIndex,
+				"logID":          entry.logID,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func sigstoreBundle(t *testing.T, sig []byte, cert *x509.Certificate, entry *tlogEntry) []byte {
+	t.Helper()
+	keyID, err := hex.DecodeString(entry.logID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(map[string]any{
+		"mediaType": "application/vnd.dev.sigstore.bundle.v0.3+json",
+		"verificationMaterial": map[string]any{
+			"certificate": map[string]any{"rawBytes": cert.Raw},
+			"tlogEntries": []map[string]any{
+				{
+					"logIndex":          strconv.FormatInt(entry.logIndex, 10),
+					"logId":             map[string]any{"keyId": keyID},
+					"integratedTime":    strconv.FormatInt(entry.integratedTime, 10),
+					"inclusionPromise":  map[string]any{"signedEntryTimestamp": entry.set},
+					"canonicalizedBody": entry.body,
+				},
+			},
+		},
+		"messageSignature": map[string]any{"signature": sig},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func Test_verifyNative(t *testing.T) { //nolint:funlen,maintidx
+	t.Parallel()
+	s := newTestSigstore(t)
+	root, err := parseTrustedRoot(s.trustedRoot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherRoot, err := parseTrustedRoot(newTestSigstore(t).trustedRoot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	artifact := []byte("hello")
+
+	signerKey := newKey(t)
+	cert := s.issueCertificate(&signerKey.PublicKey)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	sig := sign(t, signerKey, artifact)
+	entry := s.logEntry(artifact, sig, certPEM)
+
+	key := newKey(t)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkixKey(t, &key.PublicKey)})
+	keySig := sign(t, key, artifact)
+	keyEntry := s.logEntry(artifact, keySig, keyPEM)
+
+	identity := &verifyOpts{
+		Identity: testIdentity,
+		Issuer:   testIssuer,
+	}
+
+	data := []struct {
+		name  string
+		root  *TrustedRoot
+		opts  *verifyOpts
+		in    *nativeInput
+		isErr bool
+	}{
+		{
+			name: "keyless with a legacy bundle",
+			root: root,
+			opts: identity,
+			in: &nativeInput{
+				artifact: artifact,
+				bundle:   legacyBundle(t, sig, certPEM, entry),
+			},
+		},
+		{
+			name: "keyless with a sigstore bundle",
+			root: root,
+			opts: &verifyOpts{
+				IdentityRegexp: `^https://github\.com/aquaproj/example/`,
+				IssuerRegexp:   `githubusercontent`,
+			},
+			in: &nativeInput{
+				artifact: artifact,
+				bundle:   sigstoreBundle(t, sig, cert, entry),
+			},
+		},
+		{
+			name: "the signature and the certificate take precedence over the bundle",
+			root: root,
+			opts: identity,
+			in: &nativeInput{
+				artifact:    artifact,
+				signature:   []byte(base64.StdEncoding.EncodeToString(sig)),
+				certificate: []byte(base64.StdEncoding.EncodeToString(certPEM)),
+				bundle:      legacyBundle(t, []byte("invalid"), certPEM, entry),
+			},
+		},
+		{
+			name: "tampered artifact",
+			root: root,
+			opts: identity,
+			in: &nativeInput{
+				artifact: []byte("tampered"),
+				bundle:   legacyBundle(t, sig, certPEM, entry),
+			},
+			isErr: true,
+		},
+		{
+			name: "identity mismatch",
+			root: root,
+			opts: &verifyOpts{
+				Identity: "https://github.com/aquaproj/other/.github/workflows/release.yaml@refs/tags/v1.0.0",
+				Issuer:   testIssuer,
+			},
+			in: &nativeInput{
+				artifact: artifact,
+				bundle:   legacyBundle(t, sig, certPEM, entry),
+			},
+			isErr: true,
+		},
+		{
+			name: "github workflow extension mismatch",
+			root: root,
+			opts: &verifyOpts{
+				Identity:             testIdentity,
+				Issuer:               testIssuer,
+				GitHubWorkflowFields: map[string]string{"1.3.6.1.4.1.57264.1.5": "aquaproj/other"},
+			},
+			in: &nativeInput{
+				artifact: artifact,
+				bundle:   legacyBundle(t, sig, certPEM, entry),
+			},
+			isErr: true,
+		},
+		{
+			name: "identity is required",
+			root: root,
+			opts: &verifyOpts{},
+			in: &nativeInput{
+				artifact: artifact,
+				bundle:   legacyBundle(t, sig, certPEM, entry),
+			},
+			isErr: true,
+		},
+		{
+			name: "untrusted sigstore",
+			root: otherRoot,
+			opts: identity,
+			in: &nativeInput{
+				artifact: artifact,
+				bundle:   legacyBundle(t, sig, certPEM, entry),
+			},
+			isErr: true,
+		},
+		{
+			name: "the certificate expired and the transparency log is ignored",
+			root: root,
+			opts: &verifyOpts{
+				Identity:   testIdentity,
+				Issuer:     testIssuer,
+				IgnoreTlog: true,
+			},
+			in: &nativeInput{
+				artifact:    artifact,
+				signature:   []byte(base64.StdEncoding.EncodeToString(sig)),
+				certificate: certPEM,
+			},
+			isErr: true,
+		},
+		{
+			name: "key with the transparency log",
+			root: root,
+			opts: &verifyOpts{},
+			in: &nativeInput{
+				artifact: artifact,
+				key:      keyPEM,
+				bundle:   legacyBundle(t, keySig, nil, keyEntry),
+			},
+		},
+		{
+			name: "key without the transparency log and the trusted root",
+			opts: &verifyOpts{
+				IgnoreTlog: true,
+			},
+			in: &nativeInput{
+				artifact:  artifact,
+				key:       keyPEM,
+				signature: []byte(base64.StdEncoding.EncodeToString(keySig)),
+			},
+		},
+		{
+			name: "wrong key",
+			opts: &verifyOpts{
+				IgnoreTlog: true,
+			},
+			in: &nativeInput{
+				artifact:  artifact,
+				key:       keyPEM,
+				signature: []byte(base64.StdEncoding.EncodeToString(sig)),
+			},
+			isErr: true,
+		},
+		{
+			name: "the transparency log entry is required",
+			root: root,
+			opts: &verifyOpts{},
+			in: &nativeInput{
+				artifact:  artifact,
+				key:       keyPEM,
+				signature: []byte(base64.StdEncoding.EncodeToString(keySig)),
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			err := verifyNative(d.root, d.opts, d.in)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+		})
+	}
+}
+
+func Test_parseTrustedRoot(t *testing.T) {
+	t.Parallel()
+	root, err := parseTrustedRoot(publicGoodTrustedRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(root.tlogs) == 0 || len(root.ctlogs) == 0 || len(root.cas) == 0 {
+		t.Fatal("the embedded trusted root must have transparency logs, certificate transparency logs, and certificate authorities")
+	}
+}
+
+func Test_checkNative(t *testing.T) {
+	t.Parallel()
+	root := &TrustedRoot{}
+	data := []struct {
+		name  string
+		cos   *registry.Cosign
+		opts  []string
+		root  *TrustedRoot
+		isErr bool
+	}{
+		{
+			name: "keyless with a bundle",
+			cos:  &registry.Cosign{},
+			opts: []string{"--bundle", "https://example.com/foo.bundle", "--certificate-identity", "foo", "--certificate-oidc-issuer", "bar"},
+			root: root,
+		},
+		{
+			name: "key without the transparency log",
+			cos: &registry.Cosign{
+				Key:       &registry.DownloadedFile{Type: "github_release"},
+				Signature: &registry.DownloadedFile{Type: "github_release"},
+			},
+			opts: []string{"--insecure-ignore-tlog"},
+		},
+		{
+			name:  "keyless without the trusted root",
+			cos:   &registry.Cosign{Bundle: &registry.DownloadedFile{Type: "github_release"}},
+			isErr: true,
+		},
+		{
+			name:  "no bundle",
+			cos:   &registry.Cosign{},
+			opts:  []string{"--signature", "foo.sig", "--certificate", "foo.pem"},
+			root:  root,
+			isErr: true,
+		},
+		{
+			name:  "KMS",
+			cos:   &registry.Cosign{},
+			opts:  []string{"--key", "awskms:///arn:aws:kms:us-east-1:000000000000:key/foo", "--insecure-ignore-tlog"},
+			isErr: true,
+		},
+		{
+			name:  "unsupported option",
+			cos:   &registry.Cosign{Bundle: &registry.DownloadedFile{Type: "github_release"}},
+			opts:  []string{"--use-signed-timestamps"},
+			root:  root,
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkNative(d.cos, d.opts, d.root)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+		})
+	}
+}
+
+func Test_checkMaterial(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	s := newTestSigstore(t)
+	root, err := parseTrustedRoot(s.trustedRoot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherRoot, err := parseTrustedRoot(newTestSigstore(t).trustedRoot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	artifact := []byte("hello")
+	signerKey := newKey(t)
+	cert := s.issueCertificate(&signerKey.PublicKey)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	sig := sign(t, signerKey, artifact)
+	entry := s.logEntry(artifact, sig, certPEM)
+	noSET := *entry
+	noSET.set = nil
+
+	opts := []string{"--certificate-identity", testIdentity, "--certificate-oidc-issuer", testIssuer}
+	data := []struct {
+		name  string
+		root  *TrustedRoot
+		opts  []string
+		in    *nativeInput
+		isErr bool
+	}{
+		{
+			name: "supported",
+			root: root,
+			opts: opts,
+			in:   &nativeInput{bundle: sigstoreBundle(t, sig, cert, entry)},
+		},
+		{
+			name: "DSSE envelope",
+			root: root,
+			opts: opts,
+			in: &nativeInput{
+				bundle: []byte(`{"mediaType": "application/vnd.dev.sigstore.bundle.v0.3+json", "verificationMaterial": {}, "dsseEnvelope": {}}`),
+			},
+			isErr: true,
+		},
+		{
+			name:  "no signed entry timestamp",
+			root:  root,
+			opts:  opts,
+			in:    &nativeInput{bundle: legacyBundle(t, sig, certPEM, &noSET)},
+			isErr: true,
+		},
+		{
+			name:  "the transparency log isn't in the trusted root",
+			root:  otherRoot,
+			opts:  opts,
+			in:    &nativeInput{bundle: legacyBundle(t, sig, certPEM, entry)},
+			isErr: true,
+		},
+		{
+			name:  "the certificate authority isn't in the trusted root",
+			root:  otherRoot,
+			opts:  append([]string{"--insecure-ignore-tlog"}, opts...),
+			in:    &nativeInput{bundle: legacyBundle(t, sig, certPEM, entry)},
+			isErr: true,
+		},
+		{
+			name: "an invalid bundle is reported by the native verifier",
+			root: root,
+			opts: opts,
+			in:   &nativeInput{bundle: []byte(`{}`)},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkMaterial(d.root, d.opts, d.in)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+		})
+	}
+}
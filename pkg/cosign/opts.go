@@ -0,0 +1,118 @@
+package cosign
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// verifyOpts is the subset of `cosign verify-blob` options the native verifier supports.
+type verifyOpts struct {
+	Key                  string
+	Signature            string
+	Certificate          string
+	CertificateChain     string
+	Bundle               string
+	Identity             string
+	IdentityRegexp       string
+	Issuer               string
+	IssuerRegexp         string
+	GitHubWorkflowFields map[string]string
+	IgnoreTlog           bool
+	IgnoreSCT            bool
+}
+
+var errUnsupportedOpt = errors.New("the option isn't supported by the native cosign verifier")
+
+// githubWorkflowOIDs maps options of `cosign verify-blob` to the certificate extensions they check.
+// https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md
+//
+//nolint:gochecknoglobals
+var githubWorkflowOIDs = map[string]string{
+	"certificate-github-workflow-trigger":    "1.3.6.1.4.1.57264.1.2",
+	"certificate-github-workflow-sha":        "1.3.6.1.4.1.57264.1.3",
+	"certificate-github-workflow-name":       "1.3.6.1.4.1.57264.1.4",
+	"certificate-github-workflow-repository": "1.3.6.1.4.1.57264.1.5",
+	"certificate-github-workflow-ref":        "1.3.6.1.4.1.57264.1.6",
+}
+
+// ignoredOpts and ignoredBoolOpts are options which don't affect the result of the native verification.
+// The native verifier never connects to Rekor.
+//
+//nolint:gochecknoglobals
+var (
+	ignoredOpts = map[string]struct{}{
+		"rekor-url": {},
+	}
+	ignoredBoolOpts = map[string]struct{}{
+		"offline":           {},
+		"verbose":           {},
+		"new-bundle-format": {},
+	}
+)
+
+// parseOpts parses options of `cosign verify-blob`.
+// It returns errUnsupportedOpt if opts contain an option the native verifier can't honor.
+func parseOpts(opts []string) (*verifyOpts, error) { //nolint:cyclop,funlen
+	v := &verifyOpts{
+		GitHubWorkflowFields: map[string]string{},
+	}
+	strOpts := map[string]*string{
+		"key":                            &v.Key,
+		"signature":                      &v.Signature,
+		"certificate":                    &v.Certificate,
+		"certificate-chain":              &v.CertificateChain,
+		"bundle":                         &v.Bundle,
+		"certificate-identity":           &v.Identity,
+		"certificate-identity-regexp":    &v.IdentityRegexp,
+		"certificate-oidc-issuer":        &v.Issuer,
+		"certificate-oidc-issuer-regexp": &v.IssuerRegexp,
+	}
+	boolOpts := map[string]*bool{
+		"insecure-ignore-tlog": &v.IgnoreTlog,
+		"insecure-ignore-sct":  &v.IgnoreSCT,
+	}
+	for i := 0; i < len(opts); i++ {
+		opt := opts[i]
+		if !strings.HasPrefix(opt, "-") {
+			return nil, fmt.Errorf("%w: %s", errUnsupportedOpt, opt)
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(opt, "-"), "=")
+		if p, ok := boolOpts[name]; ok {
+			if !hasValue {
+				*p = true
+				continue
+			}
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("parse the option %s as bool: %w", name, err)
+			}
+			*p = b
+			continue
+		}
+		if _, ok := ignoredBoolOpts[name]; ok {
+			continue
+		}
+		if !hasValue {
+			if i+1 >= len(opts) {
+				return nil, fmt.Errorf("the option %s requires a value", name)
+			}
+			i++
+			value = opts[i]
+		}
+		if p, ok := strOpts[name]; ok {
+			*p = value
+			continue
+		}
+		if oid, ok := githubWorkflowOIDs[name]; ok {
+			v.GitHubWorkflowFields[oid] = value
+			continue
+		}
+		if _, ok := ignoredOpts[name]; ok {
+			continue
+		}
+		return nil, fmt.Errorf("%w: %s", errUnsupportedOpt, name)
+	}
+	return v, nil
+}
@@ -0,0 +1,72 @@
+package cosign
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseOpts(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name  string
+		opts  []string
+		exp   *verifyOpts
+		isErr bool
+	}{
+		{
+			name: "normal",
+			opts: []string{
+				"--certificate-identity-regexp", "^https://github.com/aquaproj/",
+				"--certificate-oidc-issuer=https://token.actions.githubusercontent.com",
+				"--certificate-github-workflow-repository", "aquaproj/aqua",
+				"--insecure-ignore-sct",
+				"--rekor-url", "https://rekor.example.com",
+				"--new-bundle-format",
+			},
+			exp: &verifyOpts{
+				IdentityRegexp: "^https://github.com/aquaproj/",
+				Issuer:         "https://token.actions.githubusercontent.com",
+				GitHubWorkflowFields: map[string]string{
+					"1.3.6.1.4.1.57264.1.5": "aquaproj/aqua",
+				},
+				IgnoreSCT: true,
+			},
+		},
+		{
+			name: "bool option with a value",
+			opts: []string{"--insecure-ignore-tlog=false"},
+			exp: &verifyOpts{
+				GitHubWorkflowFields: map[string]string{},
+			},
+		},
+		{
+			name:  "unsupported option",
+			opts:  []string{"--use-signed-timestamps"},
+			isErr: true,
+		},
+		{
+			name:  "no value",
+			opts:  []string{"--key"},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			opts, err := parseOpts(d.opts)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.exp, opts, cmp.AllowUnexported(verifyOpts{})); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
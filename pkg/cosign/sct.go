@@ -0,0 +1,209 @@
+package cosign
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+//nolint:gochecknoglobals
+var oidSCTList = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+
+var (
+	errNoSCT        = errors.New("the certificate has no signed certificate timestamp. Please ignore it explicitly by --insecure-ignore-sct if it's expected")
+	errUntrustedSCT = errors.New("no signed certificate timestamp is signed by a trusted certificate transparency log")
+	errMalformedSCT = errors.New("the signed certificate timestamp is malformed")
+)
+
+// sct is a signed certificate timestamp embedded in a certificate.
+// https://datatracker.ietf.org/doc/html/rfc6962#section-3.2
+type sct struct {
+	version    uint8
+	logID      []byte
+	timestamp  uint64
+	extensions []byte
+	signature  []byte
+}
+
+// verifySCT verifies that a trusted certificate transparency log has logged the leaf certificate.
+func (r *TrustedRoot) verifySCT(leaf, issuer *x509.Certificate) error {
+	var raw []byte
+	for _, ext := range leaf.Extensions {
+		if ext.Id.Equal(oidSCTList) {
+			raw = ext.Value
+			break
+		}
+	}
+	if raw == nil {
+		return errNoSCT
+	}
+	var list []byte
+	if _, err := asn1.Unmarshal(raw, &list); err != nil {
+		return fmt.Errorf("parse signed certificate timestamps: %w", err)
+	}
+	scts, err := parseSCTList(list)
+	if err != nil {
+		return err
+	}
+	tbs, err := removeSCTList(leaf.RawTBSCertificate)
+	if err != nil {
+		return err
+	}
+	issuerKeyHash := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+	for _, s := range scts {
+		key, ok := r.ctlogs[hex.EncodeToString(s.logID)]
+		if !ok || !key.validFor.contains(time.UnixMilli(int64(s.timestamp))) { //nolint:gosec
+			continue
+		}
+		if err := verifySignature(key.publicKey, s.signedData(issuerKeyHash[:], tbs), s.signature); err == nil {
+			return nil
+		}
+	}
+	return errUntrustedSCT
+}
+
+// signedData returns the data signed by the certificate transparency log for a precertificate.
+func (s *sct) signedData(issuerKeyHash, tbs []byte) []byte {
+	b := make([]byte, 0, 1+1+8+2+len(issuerKeyHash)+3+len(tbs)+2+len(s.extensions)) //nolint:mnd
+	b = append(b, s.version, 0)                                                     // signature_type: certificate_timestamp
+	b = binary.BigEndian.AppendUint64(b, s.timestamp)
+	b = binary.BigEndian.AppendUint16(b, 1) // entry_type: precert_entry
+	b = append(b, issuerKeyHash...)
+	b = append(b, byte(len(tbs)>>16), byte(len(tbs)>>8), byte(len(tbs))) //nolint:mnd
+	b = append(b, tbs...)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s.extensions))) //nolint:gosec
+	return append(b, s.extensions...)
+}
+
+// parseSCTList parses a TLS-encoded SignedCertificateTimestampList.
+func parseSCTList(b []byte) ([]*sct, error) {
+	list, rest, err := readOpaque16(b)
+	if err != nil || len(rest) != 0 {
+		return nil, errMalformedSCT
+	}
+	var scts []*sct
+	for len(list) != 0 {
+		var raw []byte
+		raw, list, err = readOpaque16(list)
+		if err != nil {
+			return nil, err
+		}
+		s, err := parseSCT(raw)
+		if err != nil {
+			return nil, err
+		}
+		scts = append(scts, s)
+	}
+	return scts, nil
+}
+
+func parseSCT(b []byte) (*sct, error) {
+	const headerSize = 1 + 32 + 8 // version, log id, timestamp
+	if len(b) < headerSize {
+		return nil, errMalformedSCT
+	}
+	s := &sct{
+		version:   b[0],
+		logID:     b[1:33],
+		timestamp: binary.BigEndian.Uint64(b[33:41]),
+	}
+	exts, rest, err := readOpaque16(b[headerSize:])
+	if err != nil {
+		return nil, err
+	}
+	s.extensions = exts
+	if len(rest) < 2 { //nolint:mnd
+		return nil, errMalformedSCT
+	}
+	// skip the hash algorithm and the signature algorithm
+	sig, rest, err := readOpaque16(rest[2:])
+	if err != nil || len(rest) != 0 {
+		return nil, errMalformedSCT
+	}
+	s.signature = sig
+	return s, nil
+}
+
+func readOpaque16(b []byte) ([]byte, []byte, error) {
+	if len(b) < 2 { //nolint:mnd
+		return nil, nil, errMalformedSCT
+	}
+	n := int(binary.BigEndian.Uint16(b))
+	b = b[2:]
+	if len(b) < n {
+		return nil, nil, errMalformedSCT
+	}
+	return b[:n], b[n:], nil
+}
+
+// removeSCTList returns the DER-encoded TBSCertificate without the SCT list extension,
+// which is what the certificate transparency log signed.
+func removeSCTList(rawTBS []byte) ([]byte, error) {
+	var tbs asn1.RawValue
+	if _, err := asn1.Unmarshal(rawTBS, &tbs); err != nil {
+		return nil, fmt.Errorf("parse a TBSCertificate: %w", err)
+	}
+	var fields []byte
+	for rest := tbs.Bytes; len(rest) != 0; {
+		var field asn1.RawValue
+		var err error
+		rest, err = asn1.Unmarshal(rest, &field)
+		if err != nil {
+			return nil, fmt.Errorf("parse a TBSCertificate: %w", err)
+		}
+		if field.Class != asn1.ClassContextSpecific || field.Tag != 3 { //nolint:mnd
+			fields = append(fields, field.FullBytes...)
+			continue
+		}
+		exts, err := removeExtension(field.Bytes, oidSCTList)
+		if err != nil {
+			return nil, err
+		}
+		b, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 3, IsCompound: true, Bytes: exts}) //nolint:mnd
+		if err != nil {
+			return nil, fmt.Errorf("marshal extensions: %w", err)
+		}
+		fields = append(fields, b...)
+	}
+	b, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSequence, IsCompound: true, Bytes: fields})
+	if err != nil {
+		return nil, fmt.Errorf("marshal a TBSCertificate: %w", err)
+	}
+	return b, nil
+}
+
+// removeExtension returns the DER-encoded Extensions without the extension oid.
+func removeExtension(rawExts []byte, oid asn1.ObjectIdentifier) ([]byte, error) {
+	var seq asn1.RawValue
+	if _, err := asn1.Unmarshal(rawExts, &seq); err != nil {
+		return nil, fmt.Errorf("parse extensions: %w", err)
+	}
+	var exts []byte
+	for rest := seq.Bytes; len(rest) != 0; {
+		var ext asn1.RawValue
+		var err error
+		rest, err = asn1.Unmarshal(rest, &ext)
+		if err != nil {
+			return nil, fmt.Errorf("parse an extension: %w", err)
+		}
+		var e pkix.Extension
+		if _, err := asn1.Unmarshal(ext.FullBytes, &e); err != nil {
+			return nil, fmt.Errorf("parse an extension: %w", err)
+		}
+		if e.Id.Equal(oid) {
+			continue
+		}
+		exts = append(exts, ext.FullBytes...)
+	}
+	b, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSequence, IsCompound: true, Bytes: exts})
+	if err != nil {
+		return nil, fmt.Errorf("marshal extensions: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,57 @@
+package cosign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+var (
+	errInvalidSignature     = errors.New("the signature is invalid")
+	errUnsupportedPublicKey = errors.New("the type of the public key isn't supported")
+	errNoPublicKey          = errors.New("no public key is found")
+)
+
+// verifySignature verifies sig of msg in the same way as cosign.
+// cosign signs SHA256 digests with ECDSA and RSA PKCS #1 v1.5 keys, and messages themselves with Ed25519 keys.
+func verifySignature(pub crypto.PublicKey, msg, sig []byte) error {
+	digest := sha256.Sum256(msg)
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, digest[:], sig) {
+			return errInvalidSignature
+		}
+		return nil
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig); err != nil {
+			return errInvalidSignature
+		}
+		return nil
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, msg, sig) {
+			return errInvalidSignature
+		}
+		return nil
+	default:
+		return errUnsupportedPublicKey
+	}
+}
+
+// parsePublicKey parses a PEM-encoded public key.
+func parsePublicKey(b []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errNoPublicKey
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse a public key: %w", err)
+	}
+	return pub, nil
+}
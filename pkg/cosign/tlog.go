@@ -0,0 +1,132 @@
+package cosign
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// rekorBody is the body of a hashedrekord or rekord entry of Rekor.
+type rekorBody struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+		Signature struct {
+			Content   []byte `json:"content"`
+			PublicKey struct {
+				Content []byte `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+	} `json:"spec"`
+}
+
+// setPayload is the payload of a signed entry timestamp.
+// The fields must be in the lexicographical order because Rekor signs the canonicalized JSON.
+type setPayload struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+var (
+	errNoTlogEntry          = errors.New("no transparency log entry is found. Please set the bundle or ignore the transparency log explicitly by --insecure-ignore-tlog")
+	errUntrustedTlog        = errors.New("the transparency log entry isn't signed by a trusted transparency log")
+	errTlogEntryMismatch    = errors.New("the transparency log entry doesn't match the artifact and the signature")
+	errUnsupportedTlogEntry = errors.New("the kind of the transparency log entry isn't supported")
+)
+
+// hasTlogEntry returns true if an entry has a signed entry timestamp of a transparency log in the trusted root.
+// An entry with only an inclusion proof can't be verified offline.
+func (r *TrustedRoot) hasTlogEntry(entries []*tlogEntry) bool {
+	for _, entry := range entries {
+		if _, ok := r.tlogs[entry.logID]; ok && len(entry.set) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyTlog verifies that a trusted Rekor has recorded the signature of the artifact.
+// It returns the time when the entry was integrated into the log.
+// signer is the DER-encoded leaf certificate or public key.
+func (r *TrustedRoot) verifyTlog(entries []*tlogEntry, digest, sig, signer []byte) (time.Time, error) {
+	if len(entries) == 0 {
+		return time.Time{}, errNoTlogEntry
+	}
+	var lastErr error
+	for _, entry := range entries {
+		t, err := r.verifyTlogEntry(entry, digest, sig, signer)
+		if err == nil {
+			return t, nil
+		}
+		lastErr = err
+	}
+	return time.Time{}, lastErr
+}
+
+func (r *TrustedRoot) verifyTlogEntry(entry *tlogEntry, digest, sig, signer []byte) (time.Time, error) {
+	integratedTime := time.Unix(entry.integratedTime, 0)
+	key, ok := r.tlogs[entry.logID]
+	if !ok || !key.validFor.contains(integratedTime) {
+		return time.Time{}, errUntrustedTlog
+	}
+	payload, err := json.Marshal(&setPayload{
+		Body:           base64.StdEncoding.EncodeToString(entry.body),
+		IntegratedTime: entry.integratedTime,
+		LogID:          entry.logID,
+		LogIndex:       entry.logIndex,
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("marshal the payload of the signed entry timestamp: %w", err)
+	}
+	if err := verifySignature(key.publicKey, payload, entry.set); err != nil {
+		return time.Time{}, fmt.Errorf("verify the signed entry timestamp: %w", err)
+	}
+	if err := verifyRekorBody(entry.body, digest, sig, signer); err != nil {
+		return time.Time{}, err
+	}
+	return integratedTime, nil
+}
+
+func verifyRekorBody(b, digest, sig, signer []byte) error {
+	body := &rekorBody{}
+	if err := json.Unmarshal(b, body); err != nil {
+		return fmt.Errorf("parse the body of the transparency log entry: %w", err)
+	}
+	if body.Kind != "hashedrekord" && body.Kind != "rekord" {
+		return fmt.Errorf("%w: %s", errUnsupportedTlogEntry, body.Kind)
+	}
+	if body.Spec.Data.Hash.Algorithm != "sha256" || body.Spec.Data.Hash.Value != hex.EncodeToString(digest) {
+		return errTlogEntryMismatch
+	}
+	if !bytes.Equal(body.Spec.Signature.Content, sig) {
+		return errTlogEntryMismatch
+	}
+	block, _ := pem.Decode(body.Spec.Signature.PublicKey.Content)
+	if block == nil || !bytes.Equal(block.Bytes, signer) {
+		return errTlogEntryMismatch
+	}
+	return nil
+}
+
+// marshalPublicKey returns the DER encoding of a public key, which Rekor records for key-based signatures.
+func marshalPublicKey(pub crypto.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, fmt.Errorf("marshal a public key: %w", err)
+	}
+	return der, nil
+}
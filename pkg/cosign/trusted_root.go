@@ -0,0 +1,155 @@
+package cosign
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// publicGoodTrustedRoot is the trusted root of Sigstore's public-good instance.
+// It's used if AQUA_COSIGN_TRUSTED_ROOT isn't set.
+// https://github.com/sigstore/root-signing/blob/main/targets/trusted_root.json
+//
+//go:embed trusted_root.json
+var publicGoodTrustedRoot []byte //nolint:gochecknoglobals
+
+// trustedRootJSON is a trust root in the format of Sigstore's trusted_root.json.
+// https://github.com/sigstore/protobuf-specs/blob/main/protos/sigstore_trustroot.proto
+type trustedRootJSON struct {
+	Tlogs                  []*transparencyLogJSON      `json:"tlogs"`
+	CertificateAuthorities []*certificateAuthorityJSON `json:"certificateAuthorities"`
+	Ctlogs                 []*transparencyLogJSON      `json:"ctlogs"`
+}
+
+type transparencyLogJSON struct {
+	PublicKey struct {
+		RawBytes []byte        `json:"rawBytes"`
+		ValidFor *validForJSON `json:"validFor"`
+	} `json:"publicKey"`
+}
+
+type certificateAuthorityJSON struct {
+	CertChain struct {
+		Certificates []struct {
+			RawBytes []byte `json:"rawBytes"`
+		} `json:"certificates"`
+	} `json:"certChain"`
+	ValidFor *validForJSON `json:"validFor"`
+}
+
+type validForJSON struct {
+	Start *time.Time `json:"start"`
+	End   *time.Time `json:"end"`
+}
+
+// TrustedRoot holds keys and certificates trusted by the native verifier.
+type TrustedRoot struct {
+	tlogs  map[string]*trustedKey
+	ctlogs map[string]*trustedKey
+	cas    []*certificateAuthority
+}
+
+// trustedKey is a public key of a transparency log or a certificate transparency log.
+type trustedKey struct {
+	publicKey crypto.PublicKey
+	validFor  *validForJSON
+}
+
+type certificateAuthority struct {
+	root          *x509.Certificate
+	intermediates []*x509.Certificate
+	validFor      *validForJSON
+}
+
+func (v *validForJSON) contains(t time.Time) bool {
+	if v == nil {
+		return true
+	}
+	if v.Start != nil && t.Before(*v.Start) {
+		return false
+	}
+	if v.End != nil && t.After(*v.End) {
+		return false
+	}
+	return true
+}
+
+var errEmptyTrustedRoot = errors.New("the trusted root has neither transparency logs nor certificate authorities")
+
+// ReadTrustedRoot reads a trust root in the format of Sigstore's trusted_root.json.
+func ReadTrustedRoot(p string) (*TrustedRoot, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("read a trusted root: %w", err)
+	}
+	return parseTrustedRoot(b)
+}
+
+func parseTrustedRoot(b []byte) (*TrustedRoot, error) {
+	raw := &trustedRootJSON{}
+	if err := json.Unmarshal(b, raw); err != nil {
+		return nil, fmt.Errorf("parse a trusted root as JSON: %w", err)
+	}
+	root := &TrustedRoot{}
+	tlogs, err := parseTransparencyLogs(raw.Tlogs)
+	if err != nil {
+		return nil, fmt.Errorf("parse transparency logs: %w", err)
+	}
+	root.tlogs = tlogs
+	ctlogs, err := parseTransparencyLogs(raw.Ctlogs)
+	if err != nil {
+		return nil, fmt.Errorf("parse certificate transparency logs: %w", err)
+	}
+	root.ctlogs = ctlogs
+	for _, ca := range raw.CertificateAuthorities {
+		certs := make([]*x509.Certificate, len(ca.CertChain.Certificates))
+		for i, c := range ca.CertChain.Certificates {
+			cert, err := x509.ParseCertificate(c.RawBytes)
+			if err != nil {
+				return nil, fmt.Errorf("parse a certificate of a certificate authority: %w", err)
+			}
+			certs[i] = cert
+		}
+		if len(certs) == 0 {
+			continue
+		}
+		// The chain is ordered from the leaf-most certificate to the root.
+		root.cas = append(root.cas, &certificateAuthority{
+			root:          certs[len(certs)-1],
+			intermediates: certs[:len(certs)-1],
+			validFor:      ca.ValidFor,
+		})
+	}
+	if len(root.tlogs) == 0 && len(root.cas) == 0 {
+		return nil, errEmptyTrustedRoot
+	}
+	return root, nil
+}
+
+func parseTransparencyLogs(logs []*transparencyLogJSON) (map[string]*trustedKey, error) {
+	m := make(map[string]*trustedKey, len(logs))
+	for _, l := range logs {
+		pub, err := x509.ParsePKIXPublicKey(l.PublicKey.RawBytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse a public key: %w", err)
+		}
+		m[logID(l.PublicKey.RawBytes)] = &trustedKey{
+			publicKey: pub,
+			validFor:  l.PublicKey.ValidFor,
+		}
+	}
+	return m, nil
+}
+
+// logID returns the ID of a log, which is the hex-encoded SHA256 of the DER-encoded public key.
+func logID(der []byte) string {
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
@@ -29,10 +29,15 @@ func GetMutex() *sync.Mutex {
 }
 
 type Verifier struct {
-	executor      Executor
-	downloader    download.ClientAPI
-	cosignExePath string
-	disabled      bool
+	executor        Executor
+	downloader      download.ClientAPI
+	cosignExePath   string
+	disabled        bool
+	mode            string
+	trustedRootPath string
+	trustedRoot     *TrustedRoot
+	trustedRootErr  error
+	trustedRootOnce sync.Once
 }
 
 func NewVerifier(executor Executor, downloader download.ClientAPI, param *config.Param) *Verifier {
@@ -44,21 +49,46 @@ func NewVerifier(executor Executor, downloader download.ClientAPI, param *config
 			RootDir: param.RootDir,
 			Runtime: rt,
 		}),
-		// assets for windows/arm64 aren't released.
-		disabled: rt.GOOS == "windows" && rt.GOARCH == "arm64",
+		// assets for windows/arm64 aren't released, so only the native verifier is available.
+		disabled:        rt.GOOS == "windows" && rt.GOARCH == "arm64",
+		mode:            param.CosignVerifier,
+		trustedRootPath: param.CosignTrustedRoot,
 	}
 }
 
+// ExecutableInstaller installs the cosign executable.
+type ExecutableInstaller func(ctx context.Context, logger *slog.Logger) error
+
 func (v *Verifier) Verify(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, file *download.File, cos *registry.Cosign, art *template.Artifact, verifiedFilePath string) error {
+	return v.VerifyWithInstaller(ctx, logger, rt, file, cos, art, verifiedFilePath, nil)
+}
+
+// VerifyWithInstaller is like Verify, but it calls install before running the cosign executable.
+// The cosign executable isn't installed if the signature is verified natively.
+func (v *Verifier) VerifyWithInstaller(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, file *download.File, cos *registry.Cosign, art *template.Artifact, verifiedFilePath string, install ExecutableInstaller) error { //nolint:cyclop
 	// art is used to render the template.
+	opts, err := cos.RenderOpts(rt, art)
+	if err != nil {
+		return fmt.Errorf("render cosign options: %w", err)
+	}
+
+	in, err := v.prepareNative(ctx, logger, rt, file, cos, art, opts)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		return v.verifyNative(opts, in, verifiedFilePath)
+	}
+
 	if v.disabled {
 		logger.Debug("verification with cosign is disabled")
 		return nil
 	}
 
-	opts, err := cos.RenderOpts(rt, art)
-	if err != nil {
-		return fmt.Errorf("render cosign options: %w", err)
+	if install != nil {
+		if err := install(ctx, logger); err != nil {
+			return fmt.Errorf("install sigstore/cosign: %w", err)
+		}
 	}
 
 	files := map[string]*registry.DownloadedFile{
@@ -1,6 +1,7 @@
 package cosign_test
 
 import (
+	"context"
 	"io"
 	"log/slog"
 	"strings"
@@ -129,3 +130,63 @@ func TestVerifier_Verify(t *testing.T) { //nolint:funlen
 		})
 	}
 }
+
+func TestVerifier_VerifyWithInstaller(t *testing.T) {
+	t.Parallel()
+	// A bundle with a DSSE envelope, which the native verifier doesn't support.
+	const dsseBundle = `{"mediaType": "application/vnd.dev.sigstore.bundle.v0.3+json", "verificationMaterial": {}, "dsseEnvelope": {"payload": "e30=", "payloadType": "application/vnd.in-toto+json"}}`
+	data := []struct {
+		name      string
+		verifier  string
+		installed bool
+		isErr     bool
+	}{
+		{
+			name:      "auto falls back to the cosign executable",
+			installed: true,
+		},
+		{
+			name:     "native",
+			verifier: cosign.VerifierNative,
+			isErr:    true,
+		},
+	}
+	logger := slog.New(slog.DiscardHandler)
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			verifier := cosign.NewVerifier(&osexec.Mock{}, &download.Mock{
+				RC: io.NopCloser(strings.NewReader(dsseBundle)),
+			}, &config.Param{
+				RootDir:        "/home/foo/.local/share/aquaproj-aqua",
+				CosignVerifier: d.verifier,
+			})
+			installed := false
+			err := verifier.VerifyWithInstaller(t.Context(), logger, &runtime.Runtime{
+				GOOS:   osDarwin,
+				GOARCH: archArm64,
+			}, &download.File{}, &registry.Cosign{
+				Opts: []string{
+					"--bundle", "https://github.com/aquaproj/aqua-installer/releases/download/{{.Version}}/aqua-installer.bundle",
+					"--certificate-identity", "foo",
+					"--certificate-oidc-issuer", "bar",
+				},
+			}, &template.Artifact{
+				Version: versionV113,
+			}, "", func(_ context.Context, _ *slog.Logger) error {
+				installed = true
+				return nil
+			})
+			if err != nil {
+				if !d.isErr {
+					t.Fatal(err)
+				}
+			} else if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if installed != d.installed {
+				t.Fatalf("installed: wanted %v, got %v", d.installed, installed)
+			}
+		})
+	}
+}
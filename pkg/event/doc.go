@@ -0,0 +1,5 @@
+// Package event emits machine-readable events of package installation.
+// Events are written as newline-delimited JSON to a file or a file descriptor
+// so that IDE plugins and CI dashboards can track the progress of installation
+// without parsing logs or progress bars.
+package event
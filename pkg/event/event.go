@@ -0,0 +1,143 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+)
+
+// Event types.
+const (
+	TypeStart    = "start"
+	TypeProgress = "progress"
+	TypeVerify   = "verify"
+	TypeExtract  = "extract"
+	TypeLink     = "link"
+	TypeDone     = "done"
+	TypeError    = "error"
+)
+
+// Event is a line of the event stream.
+type Event struct {
+	Time       time.Time `json:"time"`
+	Type       string    `json:"type"`
+	Package    string    `json:"package,omitempty"`
+	Version    string    `json:"version,omitempty"`
+	Registry   string    `json:"registry,omitempty"`
+	Bytes      int64     `json:"bytes,omitempty"`
+	TotalBytes int64     `json:"total_bytes,omitempty"`
+	DurationMS int64     `json:"duration_ms,omitempty"`
+	Verifier   string    `json:"verifier,omitempty"`
+	Command    string    `json:"command,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Package identifies the package which events are about.
+type Package struct {
+	Name     string
+	Version  string
+	Registry string
+}
+
+// Emitter writes events.
+// A nil Emitter discards events, so callers don't need to check if events are enabled.
+type Emitter struct {
+	dest  string
+	mutex sync.Mutex
+	w     io.Writer
+	now   func() time.Time
+}
+
+const fdPrefix = "fd:"
+
+// New returns an Emitter writing events to dest.
+// dest is either a file path or "fd:<file descriptor>" such as "fd:3".
+// If dest is empty, New returns nil.
+// The file is opened lazily, so New never fails because of the destination.
+func New(dest string) *Emitter {
+	if dest == "" {
+		return nil
+	}
+	return &Emitter{
+		dest: dest,
+		now:  time.Now,
+	}
+}
+
+// NewWriter returns an Emitter writing events to w.
+func NewWriter(w io.Writer, now func() time.Time) *Emitter {
+	if now == nil {
+		now = time.Now
+	}
+	return &Emitter{
+		w:   w,
+		now: now,
+	}
+}
+
+// Emit writes an event about pkg.
+// Time and package fields are set by Emit.
+// Errors are returned but callers can ignore them because events must not break installation.
+func (e *Emitter) Emit(pkg *Package, ev *Event) error {
+	if e == nil {
+		return nil
+	}
+	ev.Time = e.now()
+	if pkg != nil {
+		ev.Package = pkg.Name
+		ev.Version = pkg.Version
+		ev.Registry = pkg.Registry
+	}
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("marshal an event as JSON: %w", err)
+	}
+	b = append(b, '\n')
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.w != nil {
+		if _, err := e.w.Write(b); err != nil {
+			return fmt.Errorf("write an event: %w", err)
+		}
+		return nil
+	}
+	return e.write(b)
+}
+
+func (e *Emitter) write(b []byte) error {
+	if fd, ok := strings.CutPrefix(e.dest, fdPrefix); ok {
+		n, err := strconv.ParseUint(fd, 10, 0)
+		if err != nil {
+			return fmt.Errorf("parse a file descriptor of the event stream: %w", err)
+		}
+		f := os.NewFile(uintptr(n), e.dest)
+		if f == nil {
+			return errors.New("the file descriptor of the event stream is invalid")
+		}
+		// Keep the file descriptor open because it's owned by the parent process.
+		e.w = f
+		if _, err := e.w.Write(b); err != nil {
+			return fmt.Errorf("write an event: %w", err)
+		}
+		return nil
+	}
+	// The file is opened for each event in append mode,
+	// so that aqua processes running in parallel (e.g. lazy installs by aqua exec) can share the same file.
+	f, err := os.OpenFile(e.dest, os.O_WRONLY|os.O_APPEND|os.O_CREATE, osfile.FilePermission)
+	if err != nil {
+		return fmt.Errorf("open the event file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(b); err != nil {
+		return fmt.Errorf("write an event: %w", err)
+	}
+	return nil
+}
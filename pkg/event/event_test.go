@@ -0,0 +1,79 @@
+package event_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/event"
+)
+
+func TestEmitter_Emit(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	buf := &bytes.Buffer{}
+	emitter := event.NewWriter(buf, func() time.Time { return now })
+	pkg := &event.Package{
+		Name:     "cli/cli",
+		Version:  "v2.0.0",
+		Registry: "standard",
+	}
+	if err := emitter.Emit(pkg, &event.Event{Type: event.TypeStart}); err != nil {
+		t.Fatal(err)
+	}
+	if err := emitter.Emit(pkg, &event.Event{Type: event.TypeVerify, Verifier: "cosign", DurationMS: 10}); err != nil {
+		t.Fatal(err)
+	}
+	exp := `{"time":"2026-01-02T03:04:05Z","type":"start","package":"cli/cli","version":"v2.0.0","registry":"standard"}
+{"time":"2026-01-02T03:04:05Z","type":"verify","package":"cli/cli","version":"v2.0.0","registry":"standard","duration_ms":10,"verifier":"cosign"}
+`
+	if buf.String() != exp {
+		t.Fatalf("wanted %s, got %s", exp, buf.String())
+	}
+}
+
+func TestEmitter_Emit_nil(t *testing.T) {
+	t.Parallel()
+	var emitter *event.Emitter
+	if err := emitter.Emit(nil, &event.Event{Type: event.TypeStart}); err != nil {
+		t.Fatal(err)
+	}
+	if event.New("") != nil {
+		t.Fatal("New must return nil if the destination is empty")
+	}
+}
+
+func TestEmitter_Emit_file(t *testing.T) {
+	t.Parallel()
+	p := filepath.Join(t.TempDir(), "events.jsonl")
+	emitter := event.New(p)
+	for range 2 {
+		if err := emitter.Emit(nil, &event.Event{Type: event.TypeDone}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(b), "\n"); n != 2 {
+		t.Fatalf("wanted 2 events, got %d", n)
+	}
+}
+
+func TestEmitter_ProgressReader(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	emitter := event.NewWriter(buf, nil)
+	body := emitter.ProgressReader(&event.Package{Name: "foo"}, io.NopCloser(strings.NewReader("hello")), 5)
+	if _, err := io.ReadAll(body); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"type":"progress","package":"foo","bytes":5,"total_bytes":5`) {
+		t.Fatalf("the final progress event isn't emitted: %s", buf.String())
+	}
+}
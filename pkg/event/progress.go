@@ -0,0 +1,65 @@
+package event
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// progressInterval is the minimum interval between progress events of a download.
+const progressInterval = 500 * time.Millisecond
+
+type progressReader struct {
+	body       io.ReadCloser
+	emitter    *Emitter
+	pkg        *Package
+	total      int64
+	read       int64
+	lastEmit   time.Time
+	eofEmitted bool
+}
+
+// ProgressReader wraps body and emits progress events while body is read.
+// total is the content length and may be zero if it's unknown.
+// If the Emitter is nil, body is returned as is.
+func (e *Emitter) ProgressReader(pkg *Package, body io.ReadCloser, total int64) io.ReadCloser {
+	if e == nil || body == nil {
+		return body
+	}
+	return &progressReader{
+		body:     body,
+		emitter:  e,
+		pkg:      pkg,
+		total:    total,
+		lastEmit: e.now(),
+	}
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	r.read += int64(n)
+	if errors.Is(err, io.EOF) {
+		if !r.eofEmitted {
+			r.eofEmitted = true
+			r.emit()
+		}
+		return n, err //nolint:wrapcheck
+	}
+	if now := r.emitter.now(); now.Sub(r.lastEmit) >= progressInterval {
+		r.lastEmit = now
+		r.emit()
+	}
+	return n, err //nolint:wrapcheck
+}
+
+func (r *progressReader) emit() {
+	_ = r.emitter.Emit(r.pkg, &Event{
+		Type:       TypeProgress,
+		Bytes:      r.read,
+		TotalBytes: r.total,
+	})
+}
+
+func (r *progressReader) Close() error {
+	return r.body.Close() //nolint:wrapcheck
+}
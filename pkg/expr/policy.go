@@ -0,0 +1,65 @@
+package expr
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+)
+
+// PolicyPackage is attributes of a package which can be referred in expressions of Policy.
+type PolicyPackage struct {
+	Name      string
+	Version   string
+	SemVer    string
+	RepoOwner string
+	RepoName  string
+	Type      string
+	Registry  string
+	Tags      []string
+}
+
+// CompilePolicyCondition compiles the if condition of a package policy.
+func CompilePolicyCondition(condition string) (*vm.Program, error) {
+	return expr.Compile(condition, expr.AsBool(), expr.Env(map[string]any{ //nolint:wrapcheck
+		"Name":               "",
+		keyVersion:           "",
+		"SemVer":             "",
+		"RepoOwner":          "",
+		"RepoName":           "",
+		"Type":               "",
+		"Registry":           "",
+		"Tags":               []string{},
+		keySemver:            emptySemver,
+		keySemverWithVersion: emptySemverWithVersion,
+	}))
+}
+
+func EvaluatePolicyCondition(logger *slog.Logger, condition string, pkg *PolicyPackage) (bool, error) {
+	prog, err := CompilePolicyCondition(condition)
+	if err != nil {
+		return false, fmt.Errorf("parse the expression: %w", err)
+	}
+	return EvaluatePolicyConditionProg(logger, prog, pkg)
+}
+
+// EvaluatePolicyConditionProg evaluates the if condition compiled by CompilePolicyCondition.
+func EvaluatePolicyConditionProg(logger *slog.Logger, prog *vm.Program, pkg *PolicyPackage) (bool, error) {
+	tags := pkg.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return evaluateBoolProg(prog, map[string]any{
+		"Name":               pkg.Name,
+		keyVersion:           pkg.Version,
+		"SemVer":             pkg.SemVer,
+		"RepoOwner":          pkg.RepoOwner,
+		"RepoName":           pkg.RepoName,
+		"Type":               pkg.Type,
+		"Registry":           pkg.Registry,
+		"Tags":               tags,
+		keySemver:            getCompareFunc(logger, pkg.SemVer),
+		keySemverWithVersion: compareFunc(logger),
+	})
+}
@@ -0,0 +1,98 @@
+package expr_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/expr"
+)
+
+func TestEvaluatePolicyCondition(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	pkg := &expr.PolicyPackage{
+		Name:      "suzuki-shunsuke/tfcmt",
+		Version:   "v4.0.0",
+		SemVer:    "v4.0.0",
+		RepoOwner: "suzuki-shunsuke",
+		RepoName:  "tfcmt",
+		Type:      "github_release",
+		Registry:  "standard",
+		Tags:      []string{"ci"},
+	}
+	data := []struct {
+		title     string
+		condition string
+		pkg       *expr.PolicyPackage
+		exp       bool
+		isErr     bool
+	}{
+		{
+			title:     "repo owner and type",
+			condition: `RepoOwner == "suzuki-shunsuke" && Type == "github_release"`,
+			pkg:       pkg,
+			exp:       true,
+		},
+		{
+			title:     "deny go_install outside the organization",
+			condition: `Type != "go_install" || RepoOwner == "aquaproj"`,
+			pkg: &expr.PolicyPackage{
+				Name:      "golang.org/x/tools/gopls",
+				Version:   "v0.16.0",
+				SemVer:    "v0.16.0",
+				RepoOwner: "golang",
+				RepoName:  "tools",
+				Type:      "go_install",
+				Registry:  "standard",
+			},
+			exp: false,
+		},
+		{
+			title:     "tags",
+			condition: `"ci" in Tags && Registry == "standard"`,
+			pkg:       pkg,
+			exp:       true,
+		},
+		{
+			title:     "no tags",
+			condition: `"ci" in Tags`,
+			pkg:       &expr.PolicyPackage{},
+			exp:       false,
+		},
+		{
+			title:     "semver",
+			condition: `semver(">= 4.0.0") && Name startsWith "suzuki-shunsuke/"`,
+			pkg:       pkg,
+			exp:       true,
+		},
+		{
+			title:     "not boolean",
+			condition: `Name`,
+			pkg:       pkg,
+			isErr:     true,
+		},
+		{
+			title:     "unknown variable",
+			condition: `Owner == "suzuki-shunsuke"`,
+			pkg:       pkg,
+			isErr:     true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			b, err := expr.EvaluatePolicyCondition(slog.Default(), d.condition, d.pkg)
+			if d.isErr {
+				if err == nil {
+					t.Fatal("err should be returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b != d.exp {
+				t.Fatalf("wanted %v, got %v", d.exp, b)
+			}
+		})
+	}
+}
@@ -21,6 +21,12 @@ const safeVersionPattern = `^v?\d+\.\d+(\.\d+)*[.-]?((alpha|beta|dev|rc)[.-]?)?\
 
 var safeVersionRegexp = regexp.MustCompile(safeVersionPattern)
 
+// IsSafeVersion returns true if the version matches with a pattern of versions.
+// Versions read from files must be checked to prevent secrets from being exposed.
+func IsSafeVersion(s string) bool {
+	return safeVersionRegexp.MatchString(s)
+}
+
 func EvalVersionExpr(pwd string, expression string) (string, error) {
 	r := Reader{pwd: pwd}
 	compiled, err := expr.Compile(expression, expr.Env(map[string]any{
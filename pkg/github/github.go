@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 
+	"github.com/aquaproj/aqua/v2/pkg/hostlimit"
 	"github.com/aquaproj/aqua/v2/pkg/keyring"
 	"github.com/google/go-github/v90/github"
 	"github.com/suzuki-shunsuke/ghtkn-go-sdk/ghtkn"
@@ -51,7 +52,7 @@ func getGitHubToken() string {
 
 func MakeRetryable(client *http.Client, logger *slog.Logger) *http.Client {
 	c := retryablehttp.NewClient()
-	c.HTTPClient = client
+	c.HTTPClient = hostlimit.Default().Client(client)
 	c.Logger = logger
 	return c.StandardClient()
 }
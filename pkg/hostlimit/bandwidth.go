@@ -0,0 +1,80 @@
+package hostlimit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const maxChunkSize = 32 * 1024
+
+// bandwidth is shared by all response bodies to cap the aggregate bandwidth.
+type bandwidth struct {
+	bytesPerSecond int64
+	mutex          sync.Mutex
+	next           time.Time
+}
+
+// chunkSize returns the maximum size of a read so that a single read doesn't exceed the bandwidth much.
+func (b *bandwidth) chunkSize() int {
+	return int(min(b.bytesPerSecond, maxChunkSize))
+}
+
+// reserve accounts n bytes read at now and returns how long the reader should wait.
+func (b *bandwidth) reserve(now time.Time, n int) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.next.Before(now) {
+		b.next = now
+	}
+	b.next = b.next.Add(time.Duration(int64(n) * int64(time.Second) / b.bytesPerSecond))
+	return b.next.Sub(now)
+}
+
+var errInvalidBandwidth = errors.New("bandwidth must be a positive number of bytes with an optional unit such as 500KB, 10MB, or 1GiB")
+
+//nolint:gochecknoglobals
+var bandwidthUnits = []struct {
+	suffix string
+	size   int64
+}{
+	// longer suffixes must come first
+	{"kib", 1 << 10},
+	{"mib", 1 << 20},
+	{"gib", 1 << 30},
+	{"kb", 1000},
+	{"mb", 1000 * 1000},
+	{"gb", 1000 * 1000 * 1000},
+	{"k", 1000},
+	{"m", 1000 * 1000},
+	{"g", 1000 * 1000 * 1000},
+	{"b", 1},
+}
+
+// ParseBandwidth parses a bandwidth per second such as 500KB, 10MB, and 1GiB.
+// A number without unit is bytes. An empty string means unlimited and returns 0.
+func ParseBandwidth(s string) (int64, error) {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "/s"))
+	if s == "" {
+		return 0, nil
+	}
+	size := int64(1)
+	for _, unit := range bandwidthUnits {
+		if v, ok := strings.CutSuffix(s, unit.suffix); ok {
+			s = strings.TrimSpace(v)
+			size = unit.size
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", errInvalidBandwidth, err)
+	}
+	if v <= 0 {
+		return 0, errInvalidBandwidth
+	}
+	return int64(v * float64(size)), nil
+}
@@ -0,0 +1,44 @@
+package hostlimit_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/hostlimit"
+)
+
+func TestParseBandwidth(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name  string
+		s     string
+		exp   int64
+		isErr bool
+	}{
+		{name: "empty", s: "", exp: 0},
+		{name: "bytes", s: "1024", exp: 1024},
+		{name: "KB", s: "500KB", exp: 500_000},
+		{name: "MiB", s: "1MiB", exp: 1 << 20},
+		{name: "per second", s: "10 MB/s", exp: 10_000_000},
+		{name: "decimal", s: "1.5g", exp: 1_500_000_000},
+		{name: "zero", s: "0", isErr: true},
+		{name: "invalid", s: "fast", isErr: true},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			v, err := hostlimit.ParseBandwidth(d.s)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if v != d.exp {
+				t.Fatalf("wanted %d, got %d", d.exp, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Package hostlimit throttles HTTP requests of aqua.
+// It limits the number of concurrent requests per host, caps the aggregate
+// download bandwidth, and holds requests to a host back while the host asks
+// clients to wait by a 429 or 503 response with a Retry-After header.
+// The limits are process-wide so that they apply to every HTTP client aqua creates.
+package hostlimit
@@ -0,0 +1,238 @@
+package hostlimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxRetryAfter caps how long requests are held back by a Retry-After header
+// so that a broken server can't block aqua for hours.
+const maxRetryAfter = time.Minute
+
+// Limiter limits HTTP requests.
+// The zero limits mean unlimited, but requests are still held back by Retry-After headers.
+type Limiter struct {
+	maxConnsPerHost int
+	bandwidth       *bandwidth
+	mutex           sync.Mutex
+	hosts           map[string]*host
+	now             func() time.Time
+	sleep           func(ctx context.Context, d time.Duration) error
+}
+
+//nolint:gochecknoglobals
+var (
+	defaultLimiter = New(0, 0)
+	defaultMutex   sync.RWMutex
+)
+
+// New creates a Limiter.
+// maxConnsPerHost is the maximum number of concurrent requests per host.
+// bytesPerSecond is the aggregate bandwidth of response bodies.
+func New(maxConnsPerHost int, bytesPerSecond int64) *Limiter {
+	l := &Limiter{
+		maxConnsPerHost: maxConnsPerHost,
+		hosts:           map[string]*host{},
+		now:             time.Now,
+		sleep:           sleep,
+	}
+	if bytesPerSecond > 0 {
+		l.bandwidth = &bandwidth{
+			bytesPerSecond: bytesPerSecond,
+		}
+	}
+	return l
+}
+
+// Default returns the process-wide Limiter.
+func Default() *Limiter {
+	defaultMutex.RLock()
+	defer defaultMutex.RUnlock()
+	return defaultLimiter
+}
+
+// SetDefault replaces the process-wide Limiter.
+// It affects HTTP clients created after it's called.
+func SetDefault(l *Limiter) {
+	defaultMutex.Lock()
+	defer defaultMutex.Unlock()
+	defaultLimiter = l
+}
+
+// Client returns a shallow copy of client whose requests are limited by l.
+func (l *Limiter) Client(client *http.Client) *http.Client {
+	c := *client
+	base := c.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	c.Transport = &transport{
+		base:    base,
+		limiter: l,
+	}
+	return &c
+}
+
+type host struct {
+	sem          chan struct{}
+	mutex        sync.Mutex
+	blockedUntil time.Time
+}
+
+func (l *Limiter) host(name string) *host {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	h, ok := l.hosts[name]
+	if ok {
+		return h
+	}
+	h = &host{}
+	if l.maxConnsPerHost > 0 {
+		h.sem = make(chan struct{}, l.maxConnsPerHost)
+	}
+	l.hosts[name] = h
+	return h
+}
+
+func (h *host) acquire(ctx context.Context) error {
+	if h.sem == nil {
+		return nil
+	}
+	select {
+	case h.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	}
+}
+
+func (h *host) release() {
+	if h.sem == nil {
+		return
+	}
+	<-h.sem
+}
+
+func (h *host) block(until time.Time) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if until.After(h.blockedUntil) {
+		h.blockedUntil = until
+	}
+}
+
+func (h *host) getBlockedUntil() time.Time {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.blockedUntil
+}
+
+type transport struct {
+	base    http.RoundTripper
+	limiter *Limiter
+}
+
+// RoundTrip sends a request after a connection slot of the host becomes free and the host's Retry-After has passed.
+// The slot is released when the response body is read to the end or closed.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	l := t.limiter
+	h := l.host(req.URL.Host)
+	if err := h.acquire(ctx); err != nil {
+		return nil, err
+	}
+	if d := h.getBlockedUntil().Sub(l.now()); d > 0 {
+		if err := l.sleep(ctx, d); err != nil {
+			h.release()
+			return nil, err
+		}
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		h.release()
+		return nil, err //nolint:wrapcheck
+	}
+	if d, ok := retryAfter(resp, l.now()); ok {
+		h.block(l.now().Add(d))
+	}
+	resp.Body = &body{
+		ReadCloser: resp.Body,
+		ctx:        ctx,
+		release:    sync.OnceFunc(h.release),
+		bandwidth:  l.bandwidth,
+		now:        l.now,
+		sleep:      l.sleep,
+	}
+	return resp, nil
+}
+
+// retryAfter returns how long the server asks clients to wait.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	var d time.Duration
+	if sec, err := strconv.ParseInt(v, 10, 64); err == nil {
+		d = time.Duration(sec) * time.Second
+	} else if t, err := http.ParseTime(v); err == nil {
+		d = t.Sub(now)
+	} else {
+		return 0, false
+	}
+	if d <= 0 {
+		return 0, false
+	}
+	return min(d, maxRetryAfter), true
+}
+
+type body struct {
+	io.ReadCloser
+
+	ctx       context.Context //nolint:containedctx
+	release   func()
+	bandwidth *bandwidth
+	now       func() time.Time
+	sleep     func(ctx context.Context, d time.Duration) error
+}
+
+func (b *body) Read(p []byte) (int, error) {
+	if b.bandwidth != nil {
+		p = p[:min(len(p), b.bandwidth.chunkSize())]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.release()
+	}
+	if b.bandwidth != nil && n > 0 {
+		if d := b.bandwidth.reserve(b.now(), n); d > 0 {
+			if e := b.sleep(b.ctx, d); e != nil {
+				return n, e
+			}
+		}
+	}
+	return n, err //nolint:wrapcheck
+}
+
+func (b *body) Close() error {
+	b.release()
+	return b.ReadCloser.Close() //nolint:wrapcheck
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	}
+}
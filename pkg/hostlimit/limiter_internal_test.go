@@ -0,0 +1,157 @@
+package hostlimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiter_maxConnsPerHost(t *testing.T) {
+	t.Parallel()
+	var inFlight, maxInFlight atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	client := New(2, 0).Client(srv.Client())
+	wg := &sync.WaitGroup{}
+	for range 6 {
+		wg.Go(func() {
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer resp.Body.Close()
+			if _, err := io.ReadAll(resp.Body); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+	if m := maxInFlight.Load(); m > 2 {
+		t.Fatalf("the number of concurrent requests must be at most 2, got %d", m)
+	}
+}
+
+func TestLimiter_retryAfter(t *testing.T) {
+	t.Parallel()
+	var count atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if count.Add(1) == 1 {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var slept []time.Duration
+	l := New(0, 0)
+	l.now = func() time.Time { return now }
+	l.sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+	client := l.Client(srv.Client())
+	for range 2 {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	if len(slept) != 1 || slept[0] != 30*time.Second {
+		t.Fatalf("the second request must wait for 30 seconds, got %v", slept)
+	}
+}
+
+func TestLimiter_bandwidth(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var slept time.Duration
+	l := New(0, 1000)
+	l.now = func() time.Time { return now }
+	l.sleep = func(_ context.Context, d time.Duration) error {
+		slept += d
+		now = now.Add(d)
+		return nil
+	}
+	b := &body{
+		ReadCloser: io.NopCloser(strings.NewReader(strings.Repeat("a", 3000))),
+		ctx:        t.Context(),
+		release:    func() {},
+		bandwidth:  l.bandwidth,
+		now:        l.now,
+		sleep:      l.sleep,
+	}
+	s, err := io.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s) != 3000 {
+		t.Fatalf("wanted 3000 bytes, got %d", len(s))
+	}
+	if slept != 3*time.Second {
+		t.Fatalf("reading 3000 bytes at 1000 bytes per second must take 3 seconds, got %v", slept)
+	}
+}
+
+func Test_retryAfter(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := []struct {
+		name   string
+		status int
+		header string
+		exp    time.Duration
+		ok     bool
+	}{
+		{name: "seconds", status: http.StatusTooManyRequests, header: "10", exp: 10 * time.Second, ok: true},
+		{name: "date", status: http.StatusServiceUnavailable, header: now.Add(20 * time.Second).Format(http.TimeFormat), exp: 20 * time.Second, ok: true},
+		{name: "capped", status: http.StatusTooManyRequests, header: "86400", exp: maxRetryAfter, ok: true},
+		{name: "ok", status: http.StatusOK, header: "10"},
+		{name: "no header", status: http.StatusTooManyRequests},
+		{name: "invalid", status: http.StatusTooManyRequests, header: "soon"},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			resp := &http.Response{StatusCode: d.status, Header: http.Header{}}
+			if d.header != "" {
+				resp.Header.Set("Retry-After", d.header)
+			}
+			v, ok := retryAfter(resp, now)
+			if ok != d.ok || v != d.exp {
+				t.Fatalf("wanted (%v, %v), got (%v, %v)", d.exp, d.ok, v, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package installpackage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/download"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// artifactIndex records which packages use each file name in the artifact directory.
+// Files are looked up by the base name of the asset, so packages whose assets have the same base name
+// can't be distinguished. Such files are ambiguous and aren't used.
+type artifactIndex struct {
+	mutex sync.Mutex
+	// names maps file names to package paths of packages using them.
+	names map[string]map[string]struct{}
+}
+
+// add records the file name of the package and returns package paths of all packages using the file name.
+func (a *artifactIndex) add(name, pkgPath string) []string {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.names == nil {
+		a.names = map[string]map[string]struct{}{}
+	}
+	pkgPaths, ok := a.names[name]
+	if !ok {
+		pkgPaths = map[string]struct{}{}
+		a.names[name] = pkgPaths
+	}
+	pkgPaths[pkgPath] = struct{}{}
+	return slices.Sorted(maps.Keys(pkgPaths))
+}
+
+// registerArtifacts records file names of packages in the artifact directory before they're installed,
+// so that ambiguous files are detected regardless of the order of installation.
+// Packages which are filtered out by tags aren't recorded.
+func (is *Installer) registerArtifacts(pkgs []*config.Package, tags, excludedTags map[string]struct{}) {
+	if is.artifactDir == "" {
+		return
+	}
+	for _, pkg := range pkgs {
+		if !aqua.FilterPackageByTag(pkg.Package, tags, excludedTags) {
+			continue
+		}
+		switch pkg.PackageInfo.Type {
+		case config.PkgInfoTypeGoInstall, config.PkgInfoTypeCargo:
+			continue
+		}
+		assetName, err := pkg.RenderAsset(is.runtime)
+		if err != nil || assetName == "" {
+			// The error is reported when the package is installed.
+			continue
+		}
+		pkgPath, err := pkg.PkgPath(is.runtime)
+		if err != nil {
+			continue
+		}
+		is.artifacts.add(filepath.Base(assetName), pkgPath)
+	}
+}
+
+// artifactPath returns the path of the local file of the package in the artifact directory.
+// It returns an error if other packages have assets with the same base name.
+func (is *Installer) artifactPath(pkg *config.Package, assetName string) (string, error) {
+	name := filepath.Base(assetName)
+	p := filepath.Join(is.artifactDir, name)
+	pkgPath, err := pkg.PkgPath(is.runtime)
+	if err != nil {
+		return "", fmt.Errorf("get a package path: %w", err)
+	}
+	if pkgPaths := is.artifacts.add(name, pkgPath); len(pkgPaths) > 1 {
+		return "", slogerr.With(errAmbiguousArtifact, //nolint:wrapcheck
+			"artifact_path", p,
+			"package_paths", pkgPaths,
+		)
+	}
+	return p, nil
+}
+
+// installFromArtifactDir installs the package from a local file in the artifact directory
+// instead of downloading it.
+// A file matches the package if its name is equal to the base name of the rendered asset name.
+// If packages have assets with the same base name, the file is ambiguous and an error is returned.
+// It returns false if no file matches the package, then the package should be downloaded as usual.
+// Local files must be verified with aqua-checksums.json because they can't be verified with the upstream.
+func (is *Installer) installFromArtifactDir(ctx context.Context, logger *slog.Logger, param *DownloadParam) (bool, error) {
+	if param.Asset == "" {
+		return false, nil
+	}
+	p := filepath.Join(is.artifactDir, filepath.Base(param.Asset))
+	logger = logger.With("artifact_path", p)
+	if _, err := os.Stat(p); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			logger.Debug("the asset isn't found in the artifact directory")
+			return false, nil
+		}
+		return false, fmt.Errorf("get a local artifact stat: %w", slogerr.With(err, "artifact_path", p))
+	}
+	if _, err := is.artifactPath(param.Package, param.Asset); err != nil {
+		return false, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			logger.Debug("the asset isn't found in the artifact directory")
+			return false, nil
+		}
+		return false, fmt.Errorf("open a local artifact: %w", slogerr.With(err, "artifact_path", p))
+	}
+	defer f.Close()
+	logger.Info("install the package from a local artifact")
+
+	if param.Checksum == nil && param.Checksums == nil {
+		return false, slogerr.With(errArtifactDirRequiresChecksum, "artifact_path", p) //nolint:wrapcheck
+	}
+
+	bodyFile := download.NewDownloadedFile(f, nil)
+	defer func() {
+		if err := bodyFile.Remove(); err != nil {
+			slogerr.WithError(logger, err).Warn("remove a temporary file")
+		}
+	}()
+
+	// Don't fall back to the checksum file in the upstream.
+	// The checksum must be pinned in aqua-checksums.json.
+	chkParam := *param
+	chkParam.RequireChecksum = true
+	if err := is.verifyChecksumWrap(ctx, logger, &chkParam, bodyFile); err != nil {
+		return false, err
+	}
+
+	if err := is.unarchive(ctx, logger, param, bodyFile, param.Package.PackageInfo.GetFormat()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
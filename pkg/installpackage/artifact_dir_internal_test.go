@@ -0,0 +1,151 @@
+package installpackage
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+)
+
+func TestInstaller_installFromArtifactDir(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	const (
+		asset      = "gh_2.96.0_linux_amd64.tar.gz"
+		checksumID = "github_release/github.com/cli/cli/v2.96.0/gh_2.96.0_linux_amd64.tar.gz"
+		sum        = "3516A4D84F7B69EA5752CA2416895A2705910AF3ED6815502AF789000FC7E963"
+	)
+	data := []struct {
+		name      string
+		files     []string
+		checksums map[string]string
+		noChksum  bool
+		ambiguous bool
+		installed bool
+		isErr     bool
+	}{
+		{
+			name:  "normal",
+			files: []string{asset},
+			checksums: map[string]string{
+				checksumID: sum,
+			},
+			installed: true,
+		},
+		{
+			name: "not found",
+		},
+		{
+			name:  "another package has the asset with the same name",
+			files: []string{asset},
+			checksums: map[string]string{
+				checksumID: sum,
+			},
+			ambiguous: true,
+			isErr:     true,
+		},
+		{
+			name:  "checksum isn't pinned",
+			files: []string{asset},
+			isErr: true,
+		},
+		{
+			name:     "checksum is disabled",
+			files:    []string{asset},
+			noChksum: true,
+			isErr:    true,
+		},
+		{
+			name:  "invalid checksum",
+			files: []string{asset},
+			checksums: map[string]string{
+				checksumID: "invalid",
+			},
+			isErr: true,
+		},
+	}
+	logger := slog.New(slog.DiscardHandler)
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			inst, rootDir, dest := newUnarchiveTestInstaller(t, nil)
+			inst.artifactDir = filepath.Join(rootDir, "artifacts")
+			inst.runtime = &runtime.Runtime{GOOS: "linux", GOARCH: "amd64"}
+			inst.checksumCalculator = &MockChecksumCalculator{Checksum: sum}
+			if err := os.MkdirAll(inst.artifactDir, dirPerm); err != nil {
+				t.Fatal(err)
+			}
+			for _, f := range d.files {
+				if err := os.WriteFile(filepath.Join(inst.artifactDir, f), []byte("gh"), dirPerm); err != nil {
+					t.Fatal(err)
+				}
+			}
+			param := &DownloadParam{
+				Package: &config.Package{
+					Package: &aqua.Package{
+						Name:    "cli/cli",
+						Version: "v2.96.0",
+					},
+					PackageInfo: &registry.PackageInfo{
+						Type:      pkgTypeGitHubRelease,
+						RepoOwner: "cli",
+						RepoName:  "cli",
+						Asset:     "gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz",
+					},
+				},
+				Asset: asset,
+				Dest:  dest,
+			}
+			if !d.noChksum {
+				param.Checksums = checksum.New()
+				for id, c := range d.checksums {
+					param.Checksums.Set(id, &checksum.Checksum{
+						ID:        id,
+						Checksum:  c,
+						Algorithm: algoSHA256,
+					})
+				}
+			}
+			if d.ambiguous {
+				inst.registerArtifacts([]*config.Package{
+					{
+						Package: &aqua.Package{
+							Name:    "suzuki-shunsuke/cli",
+							Version: "v2.96.0",
+						},
+						PackageInfo: &registry.PackageInfo{
+							Type:      pkgTypeGitHubRelease,
+							RepoOwner: "suzuki-shunsuke",
+							RepoName:  "cli",
+							Asset:     "gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz",
+						},
+					},
+				}, nil, nil)
+			}
+			installed, err := inst.installFromArtifactDir(t.Context(), logger, param)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if installed != d.installed {
+				t.Fatalf("installed: wanted %v, got %v", d.installed, installed)
+			}
+			if !installed {
+				return
+			}
+			if _, err := os.Stat(filepath.Join(dest, "bin", "gh")); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -63,7 +63,7 @@ func (is *Installer) checkAndCopyFile(ctx context.Context, logger *slog.Logger,
 	}
 	logger.Info("copying an executable file")
 	exeNames := map[string]struct{}{
-		file.Name: {},
+		pkg.Package.CommandName(file.Name): {},
 	}
 	for _, alias := range pkg.Package.CommandAliases {
 		if alias.Command == file.Name {
@@ -17,12 +17,12 @@ import (
 )
 
 // hasChecksumSignatureVerification returns true if the checksum has signature verification configured
-// (Cosign, Minisign, or GitHubArtifactAttestations).
+// (Cosign, Minisign, PGP, or GitHubArtifactAttestations).
 func hasChecksumSignatureVerification(chksum *registry.Checksum) bool {
 	if chksum == nil {
 		return false
 	}
-	return chksum.GetCosign() != nil || chksum.GetMinisign() != nil || chksum.GetGitHubArtifactAttestations() != nil
+	return chksum.GetCosign() != nil || chksum.GetMinisign() != nil || chksum.GetPGP() != nil || chksum.GetGitHubArtifactAttestations() != nil
 }
 
 func (is *Installer) newChecksumVerifiers(pkg *config.Package, assetName string) []FileVerifier {
@@ -45,12 +45,18 @@ func (is *Installer) newChecksumVerifiers(pkg *config.Package, assetName string)
 			asset:     assetName,
 		},
 		&minisignVerifier{
-			pkg:       pkg,
-			minisign:  pkgInfo.Checksum.GetMinisign(),
-			installer: is.minisignInstaller,
-			verifier:  is.minisignVerifier,
-			runtime:   is.runtime,
-			asset:     assetName,
+			pkg:      pkg,
+			minisign: pkgInfo.Checksum.GetMinisign(),
+			verifier: is.minisignVerifier,
+			runtime:  is.runtime,
+			asset:    assetName,
+		},
+		&pgpVerifier{
+			pkg:      pkg,
+			pgp:      pkgInfo.Checksum.GetPGP(),
+			verifier: is.pgpVerifier,
+			runtime:  is.runtime,
+			asset:    assetName,
 		},
 	}
 }
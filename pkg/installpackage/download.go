@@ -9,45 +9,58 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aquaproj/aqua/v2/pkg/config"
 	"github.com/aquaproj/aqua/v2/pkg/download"
+	"github.com/aquaproj/aqua/v2/pkg/event"
 	"github.com/aquaproj/aqua/v2/pkg/osfile"
 	"github.com/aquaproj/aqua/v2/pkg/unarchive"
 	"github.com/schollz/progressbar/v3"
 	"github.com/suzuki-shunsuke/slog-error/slogerr"
 )
 
-func (is *Installer) downloadWithRetry(ctx context.Context, logger *slog.Logger, param *DownloadParam) error {
+// downloadWithRetry installs the package if it isn't installed yet.
+// It returns true if it has started installing the package, even if the installation fails.
+// Then the start event has been emitted, and the caller must emit the final done or error event
+// after the whole installation including links.
+func (is *Installer) downloadWithRetry(ctx context.Context, logger *slog.Logger, param *DownloadParam) (bool, error) {
 	retryCount := 0
+	started := false
 	for {
 		logger.Debug("check if the package is already installed")
 		finfo, err := os.Stat(param.Dest)
 		if err != nil { //nolint:nestif
 			// file doesn't exist
+			if !started {
+				started = true
+				is.emitEvent(logger, param.Package, &event.Event{
+					Type: event.TypeStart,
+				})
+			}
 			if err := is.download(ctx, logger, param); err != nil {
 				if strings.Contains(err.Error(), "file already exists") {
 					if retryCount >= maxRetryDownload {
-						return err
+						return true, err
 					}
 					retryCount++
 					slogerr.WithError(logger, err).Info("retry installing the package",
 						"retry_count", retryCount)
 					continue
 				}
-				return err
+				return true, err
 			}
 			pkgPath, err := param.Package.PkgPath(is.runtime)
 			if err != nil {
-				return fmt.Errorf("get a package path: %w", err)
+				return true, fmt.Errorf("get a package path: %w", err)
 			}
 			if err := is.vacuum.Update(pkgPath, time.Now()); err != nil {
 				slogerr.WithError(logger, err).Warn("update the last used datetime")
 			}
-			return nil
+			return true, nil
 		}
 		if !finfo.IsDir() {
-			return fmt.Errorf("%s isn't a directory", param.Dest)
+			return started, fmt.Errorf("%s isn't a directory", param.Dest)
 		}
-		return nil
+		return started, nil
 	}
 }
 
@@ -64,6 +77,12 @@ func (is *Installer) download(ctx context.Context, logger *slog.Logger, param *D
 		return is.downloadCargo(ctx, logger, ppkg, param.Dest)
 	}
 
+	if is.artifactDir != "" {
+		if installed, err := is.installFromArtifactDir(ctx, logger, param); err != nil || installed {
+			return err
+		}
+	}
+
 	logger.Info("download and unarchive the package")
 
 	file, err := download.ConvertPackageToFile(ppkg, param.Asset, is.runtime)
@@ -85,14 +104,65 @@ func (is *Installer) download(ctx context.Context, logger *slog.Logger, param *D
 			fmt.Sprintf("Downloading %s %s", pkg.Name, pkg.Version),
 		)
 	}
-	bodyFile := download.NewDownloadedFile(body, pb)
+	bodyFile := download.NewDownloadedFile(is.events.ProgressReader(eventPackage(ppkg), body, cl), pb)
 	defer func() {
 		if err := bodyFile.Remove(); err != nil {
 			slogerr.WithError(logger, err).Warn("remove a temporary file")
 		}
 	}()
 
-	verifiers := []FileVerifier{
+	verifiers := is.newAssetVerifiers(ppkg, param.Asset)
+
+	var tempFilePath string
+	for _, verifier := range verifiers {
+		a, err := verifier.Enabled(logger)
+		if err != nil {
+			return fmt.Errorf("check if the verifier is enabled: %w", err)
+		}
+		if !a {
+			continue
+		}
+		if tempFilePath == "" {
+			a, err := bodyFile.Path()
+			if err != nil {
+				return fmt.Errorf("get a temporary file path: %w", err)
+			}
+			tempFilePath = a
+		}
+		start := time.Now()
+		err = verifier.Verify(ctx, logger, tempFilePath)
+		is.emitTimedEvent(logger, ppkg, &event.Event{
+			Type:     event.TypeVerify,
+			Verifier: verifier.Name(),
+		}, start, err)
+		if err != nil {
+			return fmt.Errorf("verify the asset: %w", err)
+		}
+	}
+
+	start := time.Now()
+	err = is.verifyChecksumWrap(ctx, logger, param, bodyFile)
+	if param.Checksum != nil || param.Checksums != nil {
+		is.emitTimedEvent(logger, ppkg, &event.Event{
+			Type:     event.TypeVerify,
+			Verifier: "checksum",
+		}, start, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	start = time.Now()
+	err = is.unarchive(ctx, logger, param, bodyFile, pkgInfo.GetFormat())
+	is.emitTimedEvent(logger, ppkg, &event.Event{
+		Type: event.TypeExtract,
+	}, start, err)
+	return err
+}
+
+func (is *Installer) newAssetVerifiers(ppkg *config.Package, assetName string) []FileVerifier {
+	pkgInfo := ppkg.PackageInfo
+	return []FileVerifier{
 		&gitHubArtifactAttestationsVerifier{
 			disabled:    is.gaaDisabled,
 			gaa:         pkgInfo.GitHubArtifactAttestations,
@@ -107,7 +177,7 @@ func (is *Installer) download(ctx context.Context, logger *slog.Logger, param *D
 			installer: is.cosignInstaller,
 			verifier:  is.cosign,
 			runtime:   is.runtime,
-			asset:     param.Asset,
+			asset:     assetName,
 		},
 		&slsaVerifier{
 			disabled:  is.slsaDisabled,
@@ -115,44 +185,23 @@ func (is *Installer) download(ctx context.Context, logger *slog.Logger, param *D
 			installer: is.slsaVerifierInstaller,
 			verifier:  is.slsaVerifier,
 			runtime:   is.runtime,
-			asset:     param.Asset,
+			asset:     assetName,
 		},
 		&minisignVerifier{
-			pkg:       ppkg,
-			installer: is.minisignInstaller,
-			verifier:  is.minisignVerifier,
-			runtime:   is.runtime,
-			asset:     param.Asset,
-			minisign:  pkgInfo.Minisign,
+			pkg:      ppkg,
+			verifier: is.minisignVerifier,
+			runtime:  is.runtime,
+			asset:    assetName,
+			minisign: pkgInfo.Minisign,
+		},
+		&pgpVerifier{
+			pkg:      ppkg,
+			verifier: is.pgpVerifier,
+			runtime:  is.runtime,
+			asset:    assetName,
+			pgp:      pkgInfo.PGP,
 		},
 	}
-
-	var tempFilePath string
-	for _, verifier := range verifiers {
-		a, err := verifier.Enabled(logger)
-		if err != nil {
-			return fmt.Errorf("check if the verifier is enabled: %w", err)
-		}
-		if !a {
-			continue
-		}
-		if tempFilePath == "" {
-			a, err := bodyFile.Path()
-			if err != nil {
-				return fmt.Errorf("get a temporary file path: %w", err)
-			}
-			tempFilePath = a
-		}
-		if err := verifier.Verify(ctx, logger, tempFilePath); err != nil {
-			return fmt.Errorf("verify the asset: %w", err)
-		}
-	}
-
-	if err := is.verifyChecksumWrap(ctx, logger, param, bodyFile); err != nil {
-		return err
-	}
-
-	return is.unarchive(ctx, logger, param, bodyFile, pkgInfo.GetFormat())
 }
 
 // unarchive extracts the asset into a temporary directory and moves it to
@@ -3,11 +3,13 @@ package installpackage
 import "errors"
 
 var (
-	errExePathIsDirectory    = errors.New("exe_path is directory")
-	errChmod                 = errors.New("add the permission to execute the command")
-	errInstallFailure        = errors.New("it failed to install some packages")
-	errGoInstallForbidLatest = errors.New(`the version "latest" is forbidden. Please specify Git tag or commit sha`)
-	errInvalidChecksum       = errors.New("checksum is invalid")
-	errChecksumIsRequired    = errors.New("checksum is required")
-	errNoAsset               = errors.New("no asset is released for this version")
+	errExePathIsDirectory          = errors.New("exe_path is directory")
+	errChmod                       = errors.New("add the permission to execute the command")
+	errInstallFailure              = errors.New("it failed to install some packages")
+	errGoInstallForbidLatest       = errors.New(`the version "latest" is forbidden. Please specify Git tag or commit sha`)
+	errInvalidChecksum             = errors.New("checksum is invalid")
+	errChecksumIsRequired          = errors.New("checksum is required")
+	errNoAsset                     = errors.New("no asset is released for this version")
+	errArtifactDirRequiresChecksum = errors.New("checksum verification must be enabled to install packages from local artifacts")
+	errAmbiguousArtifact           = errors.New("the local artifact is ambiguous because assets of multiple packages have the same file name")
 )
@@ -0,0 +1,57 @@
+package installpackage
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/event"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+func eventPackage(pkg *config.Package) *event.Package {
+	return &event.Package{
+		Name:     pkg.Package.Name,
+		Version:  pkg.Package.Version,
+		Registry: pkg.Package.Registry,
+	}
+}
+
+// emitEvent emits an event of the package.
+// Events are best-effort, so a failure is logged but doesn't fail the installation.
+func (is *Installer) emitEvent(logger *slog.Logger, pkg *config.Package, ev *event.Event) {
+	if err := is.events.Emit(eventPackage(pkg), ev); err != nil {
+		slogerr.WithError(logger, err).Warn("emit an event")
+	}
+}
+
+// emitTimedEvent emits an event with the duration since start.
+// If err isn't nil, an error event is emitted instead.
+func (is *Installer) emitTimedEvent(logger *slog.Logger, pkg *config.Package, ev *event.Event, start time.Time, err error) {
+	ev.DurationMS = time.Since(start).Milliseconds()
+	if err != nil {
+		ev.Type = event.TypeError
+		ev.Error = err.Error()
+	}
+	is.emitEvent(logger, pkg, ev)
+}
+
+// emitFinalEvents emits link events and then the final done or error event of the installation,
+// so that the final event is the last event of the package.
+// A failure of links is reported by InstallPackages, but it makes the final event an error.
+func (is *Installer) emitFinalEvents(logger *slog.Logger, pkg *config.Package, links *linkResult, start time.Time, err error) {
+	if links != nil {
+		for _, cmd := range links.commands {
+			is.emitEvent(logger, pkg, &event.Event{
+				Type:    event.TypeLink,
+				Command: cmd,
+			})
+		}
+		if err == nil {
+			err = links.err
+		}
+	}
+	is.emitTimedEvent(logger, pkg, &event.Event{
+		Type: event.TypeDone,
+	}, start, err)
+}
@@ -0,0 +1,152 @@
+package installpackage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/event"
+	"github.com/google/go-cmp/cmp"
+)
+
+func readEvents(t *testing.T, buf *bytes.Buffer) []string {
+	t.Helper()
+	var types []string
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		ev := &event.Event{}
+		if err := dec.Decode(ev); err != nil {
+			t.Fatal(err)
+		}
+		s := ev.Type
+		if ev.Command != "" {
+			s += " " + ev.Command
+		}
+		types = append(types, s)
+	}
+	return types
+}
+
+func TestInstaller_emitFinalEvents(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name  string
+		links *linkResult
+		err   error
+		exp   []string
+	}{
+		{
+			name: "links are emitted before done",
+			links: &linkResult{
+				commands: []string{"gh", "gh2"},
+			},
+			exp: []string{"link gh", "link gh2", "done"},
+		},
+		{
+			name: "no link",
+			exp:  []string{"done"},
+		},
+		{
+			name: "failure of links",
+			links: &linkResult{
+				commands: []string{"gh"},
+				err:      errors.New("create a link"),
+			},
+			exp: []string{"link gh", "error"},
+		},
+		{
+			name: "failure of installation",
+			links: &linkResult{
+				commands: []string{"gh"},
+			},
+			err: errors.New("download a file"),
+			exp: []string{"link gh", "error"},
+		},
+	}
+	logger := slog.New(slog.DiscardHandler)
+	pkg := &config.Package{
+		Package: &aqua.Package{
+			Name:    "cli/cli",
+			Version: "v2.96.0",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			buf := &bytes.Buffer{}
+			is := &Installer{
+				events: event.NewWriter(buf, nil),
+			}
+			is.emitFinalEvents(logger, pkg, d.links, time.Now(), d.err)
+			if diff := cmp.Diff(d.exp, readEvents(t, buf)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestInstaller_downloadWithRetry_installed(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.DiscardHandler)
+	buf := &bytes.Buffer{}
+	is := &Installer{
+		events: event.NewWriter(buf, nil),
+	}
+	// Packages which are already installed don't emit events.
+	started, err := is.downloadWithRetry(t.Context(), logger, &DownloadParam{
+		Package: &config.Package{
+			Package: &aqua.Package{
+				Name:    "cli/cli",
+				Version: "v2.96.0",
+			},
+		},
+		Dest: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if started {
+		t.Fatal("the installation must not start")
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("events must not be emitted: %s", buf.String())
+	}
+}
+
+func TestInstaller_InstallPackage_errorEvent(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.DiscardHandler)
+	buf := &bytes.Buffer{}
+	is := &Installer{
+		events: event.NewWriter(buf, nil),
+	}
+	// The installation fails before it starts, but the error event is emitted.
+	err := is.InstallPackage(t.Context(), logger, &ParamInstallPackage{
+		DisablePolicy: true,
+		Pkg: &config.Package{
+			Package: &aqua.Package{
+				Name:    "cli/cli",
+				Version: "v2.96.0",
+			},
+			PackageInfo: &registry.PackageInfo{
+				Type:      pkgTypeGitHubRelease,
+				RepoOwner: "cli",
+				RepoName:  "cli",
+				Asset:     "gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz",
+				NoAsset:   true,
+			},
+		},
+	})
+	if err == nil {
+		t.Fatal("error must be returned")
+	}
+	if diff := cmp.Diff([]string{"error"}, readEvents(t, buf)); diff != "" {
+		t.Fatal(diff)
+	}
+}
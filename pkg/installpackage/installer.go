@@ -13,8 +13,10 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/aquaproj/aqua/v2/pkg/cosign"
 	"github.com/aquaproj/aqua/v2/pkg/download"
+	"github.com/aquaproj/aqua/v2/pkg/event"
 	"github.com/aquaproj/aqua/v2/pkg/ghattestation"
 	"github.com/aquaproj/aqua/v2/pkg/minisign"
+	"github.com/aquaproj/aqua/v2/pkg/pgp"
 	"github.com/aquaproj/aqua/v2/pkg/policy"
 	"github.com/aquaproj/aqua/v2/pkg/runtime"
 	"github.com/aquaproj/aqua/v2/pkg/slsa"
@@ -38,10 +40,10 @@ type Installer struct {
 	cosign                CosignVerifier
 	slsaVerifier          SLSAVerifier
 	minisignVerifier      MinisignVerifier
+	pgpVerifier           PGPVerifier
 	ghVerifier            GitHubArtifactAttestationsVerifier
 	cosignInstaller       *DedicatedInstaller
 	slsaVerifierInstaller *DedicatedInstaller
-	minisignInstaller     *DedicatedInstaller
 	ghInstaller           *DedicatedInstaller
 	goInstallInstaller    GoInstallInstaller
 	goBuildInstaller      GoBuildInstaller
@@ -50,27 +52,39 @@ type Installer struct {
 	realRuntime           *runtime.Runtime
 	rootDir               string
 	copyDir               string
+	artifactDir           string
+	artifacts             artifactIndex
 	maxParallelism        int
 	progressBar           bool
 	onlyLink              bool
 	cosignDisabled        bool
 	slsaDisabled          bool
 	gaaDisabled           bool
+	// disabledVerifications is passed to policies so that required verifications disabled at runtime aren't regarded as met.
+	// checksum is added per package in validatePackage, because it depends on the configuration file.
+	disabledVerifications []string
 	vacuum                Vacuum
+	manifest              Manifest
+	events                *event.Emitter
+	policyAuditLog        *policy.AuditLog
 }
 
 type Vacuum interface {
 	Update(pkgPath string, timestamp time.Time) error
 }
 
-func New(param *config.Param, downloader download.ClientAPI, rt *runtime.Runtime, linker Linker, chkDL download.ChecksumDownloader, chkCalc ChecksumCalculator, unarchiver Unarchiver, cosignVerifier CosignVerifier, slsaVerifier SLSAVerifier, minisignVerifier MinisignVerifier, ghVerifier GitHubArtifactAttestationsVerifier, goInstallInstaller GoInstallInstaller, goBuildInstaller GoBuildInstaller, cargoPackageInstaller CargoPackageInstaller, vacuum Vacuum) *Installer {
+type Manifest interface {
+	Record(pkgPath, dir string, pkg *config.Package) error
+}
+
+func New(param *config.Param, downloader download.ClientAPI, rt *runtime.Runtime, linker Linker, chkDL download.ChecksumDownloader, chkCalc ChecksumCalculator, unarchiver Unarchiver, cosignVerifier CosignVerifier, slsaVerifier SLSAVerifier, minisignVerifier MinisignVerifier, pgpVerifier PGPVerifier, ghVerifier GitHubArtifactAttestationsVerifier, goInstallInstaller GoInstallInstaller, goBuildInstaller GoBuildInstaller, cargoPackageInstaller CargoPackageInstaller, vacuum Vacuum, mf Manifest) *Installer {
 	// realRT is the actual host runtime, shared across the main installer and
 	// the four dedicated verifier installers below. Computing it once avoids
 	// repeating libc detection (file stats and a possible `ldd --version`
 	// invocation) for every dedicated installer.
 	realRT := runtime.NewR(context.Background())
 	ni := func(rt *runtime.Runtime) *Installer {
-		return newInstaller(param, downloader, rt, realRT, linker, chkDL, chkCalc, unarchiver, cosignVerifier, slsaVerifier, minisignVerifier, ghVerifier, goInstallInstaller, goBuildInstaller, cargoPackageInstaller, vacuum)
+		return newInstaller(param, downloader, rt, realRT, linker, chkDL, chkCalc, unarchiver, cosignVerifier, slsaVerifier, minisignVerifier, pgpVerifier, ghVerifier, goInstallInstaller, goBuildInstaller, cargoPackageInstaller, vacuum, mf)
 	}
 	installer := ni(rt)
 	installer.cosignInstaller = newDedicatedInstaller(
@@ -83,11 +97,6 @@ func New(param *config.Param, downloader download.ClientAPI, rt *runtime.Runtime
 		slsa.Package,
 		slsa.Checksums(),
 	)
-	installer.minisignInstaller = newDedicatedInstaller(
-		ni(realRT),
-		minisign.Package,
-		minisign.Checksums(),
-	)
 	installer.ghInstaller = newDedicatedInstaller(
 		ni(realRT),
 		ghattestation.Package,
@@ -96,7 +105,7 @@ func New(param *config.Param, downloader download.ClientAPI, rt *runtime.Runtime
 	return installer
 }
 
-func newInstaller(param *config.Param, downloader download.ClientAPI, rt, realRT *runtime.Runtime, linker Linker, chkDL download.ChecksumDownloader, chkCalc ChecksumCalculator, unarchiver Unarchiver, cosignVerifier CosignVerifier, slsaVerifier SLSAVerifier, minisignVerifier MinisignVerifier, ghVerifier GitHubArtifactAttestationsVerifier, goInstallInstaller GoInstallInstaller, goBuildInstaller GoBuildInstaller, cargoPackageInstaller CargoPackageInstaller, vacuum Vacuum) *Installer {
+func newInstaller(param *config.Param, downloader download.ClientAPI, rt, realRT *runtime.Runtime, linker Linker, chkDL download.ChecksumDownloader, chkCalc ChecksumCalculator, unarchiver Unarchiver, cosignVerifier CosignVerifier, slsaVerifier SLSAVerifier, minisignVerifier MinisignVerifier, pgpVerifier PGPVerifier, ghVerifier GitHubArtifactAttestationsVerifier, goInstallInstaller GoInstallInstaller, goBuildInstaller GoBuildInstaller, cargoPackageInstaller CargoPackageInstaller, vacuum Vacuum, mf Manifest) *Installer {
 	return &Installer{
 		rootDir:               param.RootDir,
 		maxParallelism:        param.MaxParallelism,
@@ -111,16 +120,22 @@ func newInstaller(param *config.Param, downloader download.ClientAPI, rt, realRT
 		cosignDisabled:        param.CosignDisabled,
 		slsaDisabled:          param.SLSADisabled,
 		gaaDisabled:           param.GitHubArtifactAttestationDisabled,
+		disabledVerifications: policy.DisabledVerifications(param, true),
 		copyDir:               param.Dest,
+		artifactDir:           param.ArtifactDir,
 		unarchiver:            unarchiver,
 		cosign:                cosignVerifier,
 		slsaVerifier:          slsaVerifier,
 		minisignVerifier:      minisignVerifier,
+		pgpVerifier:           pgpVerifier,
 		ghVerifier:            ghVerifier,
 		goInstallInstaller:    goInstallInstaller,
 		goBuildInstaller:      goBuildInstaller,
 		cargoPackageInstaller: cargoPackageInstaller,
 		vacuum:                vacuum,
+		manifest:              mf,
+		events:                event.New(param.EventLog),
+		policyAuditLog:        policy.NewAuditLog(param.PolicyAuditLog, param.SubCommand),
 	}
 }
 
@@ -139,12 +154,16 @@ type MinisignVerifier interface {
 	Verify(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, m *registry.Minisign, art *template.Artifact, file *download.File, param *minisign.ParamVerify) error
 }
 
+type PGPVerifier interface {
+	Verify(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, p *registry.PGP, art *template.Artifact, file *download.File, param *pgp.ParamVerify) error
+}
+
 type GitHubArtifactAttestationsVerifier interface {
 	Verify(ctx context.Context, logger *slog.Logger, param *ghattestation.ParamVerify) error
 }
 
 type CosignVerifier interface {
-	Verify(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, file *download.File, cos *registry.Cosign, art *template.Artifact, verifiedFilePath string) error
+	VerifyWithInstaller(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, file *download.File, cos *registry.Cosign, art *template.Artifact, verifiedFilePath string, install cosign.ExecutableInstaller) error
 }
 
 type Unarchiver interface {
@@ -173,6 +192,8 @@ type ParamInstallPackage struct {
 	ConfigFileDir   string
 	CosignExePath   string
 	Checksum        *checksum.Checksum
+	// links is the result of creating links of the package by InstallPackages.
+	links *linkResult
 }
 
 type ChecksumCalculator interface {
@@ -194,10 +215,13 @@ type DownloadParam struct {
 
 func (is *Installer) InstallPackages(ctx context.Context, logger *slog.Logger, param *ParamInstallPackages) error { //nolint:cyclop
 	pkgs, failed := config.ListPackages(logger, param.Config, is.runtime, param.Registries)
+	var links map[*config.Package]*linkResult
 	if !param.SkipLink {
-		if failedCreateLinks := is.createLinks(logger, pkgs); failedCreateLinks {
+		failedCreateLinks, results := is.createLinks(logger, pkgs)
+		if failedCreateLinks {
 			failed = failedCreateLinks
 		}
+		links = results
 	}
 
 	if is.onlyLink {
@@ -216,6 +240,8 @@ func (is *Installer) InstallPackages(ctx context.Context, logger *slog.Logger, p
 		return nil
 	}
 
+	is.registerArtifacts(pkgs, param.Tags, param.ExcludedTags)
+
 	eg := &errgroup.Group{}
 	eg.SetLimit(is.maxParallelism)
 
@@ -236,6 +262,7 @@ func (is *Installer) InstallPackages(ctx context.Context, logger *slog.Logger, p
 				RequireChecksum: param.RequireChecksum,
 				PolicyConfigs:   param.PolicyConfigs,
 				DisablePolicy:   param.DisablePolicy,
+				links:           links[pkg],
 			}); err != nil {
 				slogerr.WithError(logger, err).Error("install the package")
 				return err
@@ -249,34 +276,80 @@ func (is *Installer) InstallPackages(ctx context.Context, logger *slog.Logger, p
 	return nil
 }
 
+// recordManifest records hashes of files in the package so that "aqua verify" can detect their modification.
+// The failure doesn't make the installation fail.
+func (is *Installer) recordManifest(logger *slog.Logger, pkg *config.Package, dir string) {
+	pkgPath, err := pkg.PkgPath(is.runtime)
+	if err != nil {
+		slogerr.WithError(logger, err).Warn("get a package path to record the manifest")
+		return
+	}
+	if err := is.manifest.Record(pkgPath, dir, pkg); err != nil {
+		slogerr.WithError(logger, err).Warn("record the manifest of the package")
+	}
+}
+
 func (is *Installer) InstallPackage(ctx context.Context, logger *slog.Logger, param *ParamInstallPackage) error {
 	pkg := param.Pkg
 	logger.Debug("installing the package")
 
-	if err := is.validatePackage(logger, param); err != nil {
+	start := time.Now()
+	assetName, pkgPath, err := is.preparePackage(logger, param)
+	if err != nil {
+		// The installation doesn't start, but the error event tells consumers why the package isn't installed.
+		is.emitTimedEvent(logger, pkg, &event.Event{
+			Type: event.TypeDone,
+		}, start, err)
 		return err
 	}
 
+	started, err := is.downloadWithRetry(ctx, logger, &DownloadParam{
+		Package:         pkg,
+		Dest:            pkgPath,
+		Asset:           assetName,
+		Checksums:       param.Checksums,
+		RequireChecksum: param.RequireChecksum,
+		Checksum:        param.Checksum,
+	})
+	if err == nil {
+		err = is.finishInstall(ctx, logger, param, pkgPath, started)
+	}
+	if started {
+		// Links of packages which are already installed don't emit events because their installation doesn't start.
+		is.emitFinalEvents(logger, pkg, param.links, start, err)
+	}
+	return err
+}
+
+// preparePackage validates the package and returns the asset name and the install path.
+func (is *Installer) preparePackage(logger *slog.Logger, param *ParamInstallPackage) (string, string, error) {
+	pkg := param.Pkg
+	if err := is.validatePackage(logger, param); err != nil {
+		return "", "", err
+	}
+
 	assetName, err := pkg.RenderAsset(is.runtime)
 	if err != nil {
-		return fmt.Errorf("render the asset name: %w", err)
+		return "", "", fmt.Errorf("render the asset name: %w", err)
 	}
 
 	pkgPath, err := pkg.AbsPkgPath(is.rootDir, is.runtime)
 	if err != nil {
-		return fmt.Errorf("get the package install path: %w", err)
+		return "", "", fmt.Errorf("get the package install path: %w", err)
 	}
+	return assetName, pkgPath, nil
+}
 
-	if err := is.downloadWithRetry(ctx, logger, &DownloadParam{
-		Package:         pkg,
-		Dest:            pkgPath,
-		Asset:           assetName,
-		Checksums:       param.Checksums,
-		RequireChecksum: param.RequireChecksum,
-		Checksum:        param.Checksum,
-	}); err != nil {
+// finishInstall checks files of the downloaded package and links share files.
+func (is *Installer) finishInstall(ctx context.Context, logger *slog.Logger, param *ParamInstallPackage, pkgPath string, downloaded bool) error {
+	pkg := param.Pkg
+	if err := is.checkFilesWrap(ctx, logger, param, pkgPath); err != nil {
 		return err
 	}
-
-	return is.checkFilesWrap(ctx, logger, param, pkgPath)
+	if downloaded {
+		// The manifest is recorded after checkFilesWrap because files may be renamed on Windows.
+		is.recordManifest(logger, pkg, pkgPath)
+	}
+	is.linkShareFiles(logger, pkg)
+	return nil
 }
@@ -13,8 +13,18 @@ import (
 	"github.com/suzuki-shunsuke/slog-error/slogerr"
 )
 
-func (is *Installer) createLinks(logger *slog.Logger, pkgs []*config.Package) bool {
+// linkResult is the result of creating links of a package.
+// Link events are emitted by the installation of the package so that they're emitted between the start event and the final event.
+type linkResult struct {
+	commands []string
+	err      error
+}
+
+// createLinks creates links to aqua-proxy of packages.
+// It returns true if it fails to create some links, and results by package.
+func (is *Installer) createLinks(logger *slog.Logger, pkgs []*config.Package) (bool, map[*config.Package]*linkResult) {
 	failed := false
+	results := make(map[*config.Package]*linkResult, len(pkgs))
 
 	var aquaProxyPathOnWindows string
 	if is.runtime.IsWindows() {
@@ -32,46 +42,49 @@ func (is *Installer) createLinks(logger *slog.Logger, pkgs []*config.Package) bo
 			"package_name", pkg.Package.Name,
 			"package_version", pkg.Package.Version,
 		)
-		if is.createPackageLinks(logger, pkg, aquaProxyPathOnWindows) {
+		result := is.createPackageLinks(logger, pkg, aquaProxyPathOnWindows)
+		if result.err != nil {
 			failed = true
 		}
+		results[pkg] = result
 	}
-	return failed
+	return failed, results
 }
 
-func (is *Installer) createPackageLinks(logger *slog.Logger, pkg *config.Package, aquaProxyPathOnWindows string) bool {
-	failed := false
+func (is *Installer) createPackageLinks(logger *slog.Logger, pkg *config.Package, aquaProxyPathOnWindows string) *linkResult {
+	result := &linkResult{}
 	pkgInfo := pkg.PackageInfo
 	for _, file := range pkgInfo.GetFiles() {
 		logger := logger.With("command", file.Name)
-		if is.createFileLinks(logger, pkg, file, aquaProxyPathOnWindows) {
-			failed = true
-		}
+		is.createFileLinks(logger, pkg, file, aquaProxyPathOnWindows, result)
 	}
-	return failed
+	return result
 }
 
-func (is *Installer) createFileLinks(logger *slog.Logger, pkg *config.Package, file *registry.File, aquaProxyPathOnWindows string) bool {
-	failed := false
-	cmds := map[string]struct{}{
-		file.Name: {},
-	}
-	for _, alias := range pkg.Package.CommandAliases {
-		if file.Name != alias.Command {
-			continue
-		}
-		if alias.NoLink {
+func (is *Installer) createFileLinks(logger *slog.Logger, pkg *config.Package, file *registry.File, aquaProxyPathOnWindows string, result *linkResult) {
+	for _, cmd := range linkCommands(pkg, file) {
+		if err := is.createCmdLink(logger, file, cmd, aquaProxyPathOnWindows); err != nil {
+			slogerr.WithError(logger, err).Error("create a link to aqua-proxy")
+			if result.err == nil {
+				result.err = fmt.Errorf("create a link to aqua-proxy: %w", slogerr.With(err, "command", cmd))
+			}
 			continue
 		}
-		cmds[alias.Alias] = struct{}{}
+		result.commands = append(result.commands, cmd)
 	}
-	for cmd := range cmds {
-		if err := is.createCmdLink(logger, file, cmd, aquaProxyPathOnWindows); err != nil {
-			slogerr.WithError(logger, err).Error("create a link to aqua-proxy")
-			failed = true
+}
+
+// linkCommands returns the command names linked to the file, including command aliases.
+// If the package has a command suffix, the suffix is appended to the file name.
+func linkCommands(pkg *config.Package, file *registry.File) []string {
+	cmds := []string{pkg.Package.CommandName(file.Name)}
+	for _, alias := range pkg.Package.CommandAliases {
+		if file.Name != alias.Command || alias.NoLink {
+			continue
 		}
+		cmds = append(cmds, alias.Alias)
 	}
-	return failed
+	return cmds
 }
 
 func (is *Installer) createCmdLink(logger *slog.Logger, file *registry.File, cmd string, aquaProxyPathOnWindows string) error {
@@ -0,0 +1,249 @@
+package installpackage
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+)
+
+// PackagePlan describes what "aqua install" would do for a package.
+// It is built without downloading the package.
+type PackagePlan struct {
+	Name                  string             `json:"name"`
+	Version               string             `json:"version"`
+	Registry              string             `json:"registry"`
+	Type                  string             `json:"type"`
+	ConfigFilePath        string             `json:"config_file_path"`
+	RepoOwner             string             `json:"repo_owner,omitempty"`
+	RepoName              string             `json:"repo_name,omitempty"`
+	PURL                  string             `json:"purl,omitempty"`
+	Asset                 string             `json:"asset,omitempty"`
+	URL                   string             `json:"url,omitempty"`
+	ArtifactPath          string             `json:"artifact_path,omitempty"`
+	PkgPath               string             `json:"pkg_path,omitempty"`
+	Installed             bool               `json:"installed"`
+	Checksum              *checksum.Checksum `json:"checksum,omitempty"`
+	ChecksumSource        string             `json:"checksum_source,omitempty"`
+	Verifications         []string           `json:"verifications,omitempty"`
+	ChecksumVerifications []string           `json:"checksum_verifications,omitempty"`
+	Links                 []*LinkPlan        `json:"links,omitempty"`
+	Error                 string             `json:"error,omitempty"`
+}
+
+// LinkPlan is a link which would be created in the bin directory.
+type LinkPlan struct {
+	Command string `json:"command"`
+	Path    string `json:"path"`
+	Target  string `json:"target"`
+	ExePath string `json:"exe_path,omitempty"`
+}
+
+const (
+	checksumSourceFile     = "aqua-checksums.json"
+	checksumSourceDownload = "checksum_file"
+	checksumSourceCalc     = "calculated"
+)
+
+// PlanPackages resolves packages in the same way as InstallPackages but doesn't download nor link anything.
+func (is *Installer) PlanPackages(logger *slog.Logger, param *ParamInstallPackages) ([]*PackagePlan, error) {
+	pkgs, failed := config.ListPackages(logger, param.Config, is.runtime, param.Registries)
+	is.registerArtifacts(pkgs, param.Tags, param.ExcludedTags)
+	plans := make([]*PackagePlan, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		if !aqua.FilterPackageByTag(pkg.Package, param.Tags, param.ExcludedTags) {
+			continue
+		}
+		logger := logger.With(
+			"package_name", pkg.Package.Name,
+			"package_version", pkg.Package.Version,
+			"registry", pkg.Package.Registry,
+		)
+		plan := is.planPackage(logger, pkg, param)
+		plan.ConfigFilePath = param.ConfigFilePath
+		plans = append(plans, plan)
+	}
+	if failed {
+		return plans, errInstallFailure
+	}
+	return plans, nil
+}
+
+func (is *Installer) planPackage(logger *slog.Logger, pkg *config.Package, param *ParamInstallPackages) *PackagePlan {
+	pkgInfo := pkg.PackageInfo
+	plan := &PackagePlan{
+		Name:      pkg.Package.Name,
+		Version:   pkg.Package.Version,
+		Registry:  pkg.Package.Registry,
+		Type:      pkgInfo.Type,
+		RepoOwner: pkgInfo.RepoOwner,
+		RepoName:  pkgInfo.RepoName,
+	}
+	if err := is.validatePackage(logger, &ParamInstallPackage{
+		Pkg:           pkg,
+		Checksums:     param.Checksums,
+		PolicyConfigs: param.PolicyConfigs,
+		DisablePolicy: param.DisablePolicy,
+	}); err != nil {
+		plan.Error = err.Error()
+		return plan
+	}
+	if err := is.setPlanSource(pkg, plan); err != nil {
+		plan.Error = err.Error()
+		return plan
+	}
+	if err := is.setPlanChecksum(pkg, plan, param); err != nil {
+		plan.Error = err.Error()
+		return plan
+	}
+	verifiers, err := is.filterVerifiers(logger, is.newAssetVerifiers(pkg, plan.Asset))
+	if err != nil {
+		plan.Error = err.Error()
+		return plan
+	}
+	plan.Verifications = verifierNames(verifiers)
+	if plan.ChecksumSource == checksumSourceDownload {
+		verifiers, err := is.filterVerifiers(logger, is.newChecksumVerifiers(pkg, plan.Asset))
+		if err != nil {
+			plan.Error = err.Error()
+			return plan
+		}
+		plan.ChecksumVerifications = verifierNames(verifiers)
+	}
+	if !param.SkipLink {
+		plan.Links = is.planLinks(pkg)
+	}
+	return plan
+}
+
+func verifierNames(verifiers []FileVerifier) []string {
+	if len(verifiers) == 0 {
+		return nil
+	}
+	names := make([]string, len(verifiers))
+	for i, verifier := range verifiers {
+		names[i] = verifier.Name()
+	}
+	return names
+}
+
+func (is *Installer) setPlanSource(pkg *config.Package, plan *PackagePlan) error {
+	pkgInfo := pkg.PackageInfo
+	assetName, err := pkg.RenderAsset(is.runtime)
+	if err != nil {
+		return fmt.Errorf("render the asset name: %w", err)
+	}
+	plan.Asset = assetName
+
+	purl, err := pkg.PURL()
+	if err != nil {
+		return fmt.Errorf("get the package URL: %w", err)
+	}
+	plan.PURL = purl
+
+	pkgPath, err := pkg.AbsPkgPath(is.rootDir, is.runtime)
+	if err != nil {
+		return fmt.Errorf("get the package install path: %w", err)
+	}
+	plan.PkgPath = pkgPath
+	if finfo, err := os.Stat(pkgPath); err == nil && finfo.IsDir() {
+		plan.Installed = true
+	}
+
+	switch pkgInfo.Type {
+	case config.PkgInfoTypeGitHubRelease:
+		plan.URL = fmt.Sprintf("https://github.com/%s/%s/releases/download/%s/%s", pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Package.Version, assetName)
+	case config.PkgInfoTypeGitHubContent:
+		plan.URL = fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Package.Version, assetName)
+	case config.PkgInfoTypeGitHubArchive, config.PkgInfoTypeGoBuild:
+		plan.URL = fmt.Sprintf("https://github.com/%s/%s/archive/%s.tar.gz", pkgInfo.RepoOwner, pkgInfo.RepoName, pkg.Package.Version)
+	case config.PkgInfoTypeHTTP:
+		u, err := pkg.RenderURL(is.runtime)
+		if err != nil {
+			return fmt.Errorf("render URL: %w", err)
+		}
+		plan.URL = u
+	case config.PkgInfoTypeGoInstall:
+		p, err := pkg.RenderPath()
+		if err != nil {
+			return fmt.Errorf("render Go Module Path: %w", err)
+		}
+		plan.URL = p + "@" + pkg.Package.Version
+	case config.PkgInfoTypeCargo:
+		plan.URL = fmt.Sprintf("https://crates.io/crates/%s/%s", pkgInfo.Crate, pkg.Package.Version)
+	}
+	if is.artifactDir != "" && assetName != "" && pkgInfo.Type != config.PkgInfoTypeGoInstall && pkgInfo.Type != config.PkgInfoTypeCargo {
+		p := filepath.Join(is.artifactDir, filepath.Base(assetName))
+		if _, err := os.Stat(p); err == nil {
+			if _, err := is.artifactPath(pkg, assetName); err != nil {
+				return err
+			}
+			plan.ArtifactPath = p
+		}
+	}
+	return nil
+}
+
+func (is *Installer) setPlanChecksum(pkg *config.Package, plan *PackagePlan, param *ParamInstallPackages) error {
+	if param.Checksums == nil {
+		return nil
+	}
+	switch pkg.PackageInfo.Type {
+	case config.PkgInfoTypeGoInstall, config.PkgInfoTypeCargo:
+		return nil
+	}
+	cid, err := pkg.ChecksumID(is.runtime)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if chk := param.Checksums.Get(cid); chk != nil {
+		plan.Checksum = chk
+		plan.ChecksumSource = checksumSourceFile
+		return nil
+	}
+	if param.RequireChecksum {
+		return errChecksumIsRequired
+	}
+	if pkg.PackageInfo.Checksum.GetEnabled() {
+		plan.ChecksumSource = checksumSourceDownload
+		return nil
+	}
+	plan.ChecksumSource = checksumSourceCalc
+	return nil
+}
+
+func (is *Installer) planLinks(pkg *config.Package) []*LinkPlan {
+	var links []*LinkPlan
+	for _, file := range pkg.PackageInfo.GetFiles() {
+		exePath, err := pkg.ExePath(is.rootDir, file, is.runtime)
+		if err != nil {
+			exePath = ""
+		}
+		for _, cmd := range linkCommands(pkg, file) {
+			links = append(links, is.planLink(cmd, exePath))
+		}
+	}
+	return links
+}
+
+func (is *Installer) planLink(cmd, exePath string) *LinkPlan {
+	if is.realRuntime.IsWindows() {
+		pkgPath, _ := proxyPkg().AbsPkgPath(is.rootDir, is.runtime)
+		return &LinkPlan{
+			Command: cmd,
+			Path:    filepath.Join(is.rootDir, "bin", cmd+exeExt),
+			Target:  filepath.Join(pkgPath, "aqua-proxy.exe"),
+			ExePath: exePath,
+		}
+	}
+	return &LinkPlan{
+		Command: cmd,
+		Path:    filepath.Join(is.rootDir, "bin", cmd),
+		Target:  filepath.Join("..", proxyName),
+		ExePath: exePath,
+	}
+}
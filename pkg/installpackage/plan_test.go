@@ -0,0 +1,180 @@
+package installpackage_test
+
+import (
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/cosign"
+	"github.com/aquaproj/aqua/v2/pkg/download"
+	"github.com/aquaproj/aqua/v2/pkg/ghattestation"
+	"github.com/aquaproj/aqua/v2/pkg/installpackage"
+	"github.com/aquaproj/aqua/v2/pkg/link"
+	"github.com/aquaproj/aqua/v2/pkg/manifest"
+	"github.com/aquaproj/aqua/v2/pkg/minisign"
+	"github.com/aquaproj/aqua/v2/pkg/pgp"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/slsa"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/aquaproj/aqua/v2/pkg/unarchive"
+	"github.com/aquaproj/aqua/v2/pkg/vacuum"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInstaller_PlanPackages(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	cfg := &aqua.Config{
+		Packages: []*aqua.Package{
+			{
+				Name:     repoSuzukiShunsukeCiInfo,
+				Registry: regTypeStandard,
+				Version:  versionV203,
+			},
+		},
+	}
+	registries := map[string]*registry.Config{
+		regTypeStandard: {
+			PackageInfos: registry.PackageInfos{
+				{
+					Type:      pkgTypeGitHubRelease,
+					RepoOwner: repoSuzukiShunsuke,
+					RepoName:  repoNameCiInfo,
+					Asset:     tmplCiInfoAsset,
+					Cosign: &registry.Cosign{
+						Opts: []string{"--key", "cosign.pub"},
+					},
+				},
+			},
+		},
+	}
+	checksumID := "github_release/github.com/suzuki-shunsuke/ci-info/v2.0.3/ci-info_2.0.3_linux_amd64.tar.gz"
+	data := []struct {
+		name      string
+		files     map[string]string
+		checksums map[string]*checksum.Checksum
+		exp       []*installpackage.PackagePlan
+	}{
+		{
+			name: "not installed",
+			checksums: map[string]*checksum.Checksum{
+				checksumID: {
+					ID:        checksumID,
+					Checksum:  "FOO",
+					Algorithm: "sha256",
+				},
+			},
+			exp: []*installpackage.PackagePlan{
+				{
+					Name:           repoSuzukiShunsukeCiInfo,
+					Version:        versionV203,
+					Registry:       regTypeStandard,
+					Type:           pkgTypeGitHubRelease,
+					ConfigFilePath: fileAquaYaml,
+					RepoOwner:      repoSuzukiShunsuke,
+					RepoName:       repoNameCiInfo,
+					PURL:           "pkg:github/suzuki-shunsuke/ci-info@v2.0.3",
+					Asset:          "ci-info_2.0.3_linux_amd64.tar.gz",
+					URL:            "https://github.com/suzuki-shunsuke/ci-info/releases/download/v2.0.3/ci-info_2.0.3_linux_amd64.tar.gz",
+					PkgPath:        "/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/suzuki-shunsuke/ci-info/v2.0.3/ci-info_2.0.3_linux_amd64.tar.gz",
+					Checksum: &checksum.Checksum{
+						ID:        checksumID,
+						Checksum:  "FOO",
+						Algorithm: "sha256",
+					},
+					ChecksumSource: "aqua-checksums.json",
+					Verifications:  []string{"cosign"},
+					Links: []*installpackage.LinkPlan{
+						{
+							Command: repoNameCiInfo,
+							Path:    "/home/foo/.local/share/aquaproj-aqua/bin/ci-info",
+							Target:  "../aqua-proxy",
+							ExePath: pathCiInfoBinary,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "installed",
+			files: map[string]string{
+				pathCiInfoBinary: ``,
+			},
+			exp: []*installpackage.PackagePlan{
+				{
+					Name:           repoSuzukiShunsukeCiInfo,
+					Version:        versionV203,
+					Registry:       regTypeStandard,
+					Type:           pkgTypeGitHubRelease,
+					ConfigFilePath: fileAquaYaml,
+					RepoOwner:      repoSuzukiShunsuke,
+					RepoName:       repoNameCiInfo,
+					PURL:           "pkg:github/suzuki-shunsuke/ci-info@v2.0.3",
+					Asset:          "ci-info_2.0.3_linux_amd64.tar.gz",
+					URL:            "https://github.com/suzuki-shunsuke/ci-info/releases/download/v2.0.3/ci-info_2.0.3_linux_amd64.tar.gz",
+					PkgPath:        "/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/suzuki-shunsuke/ci-info/v2.0.3/ci-info_2.0.3_linux_amd64.tar.gz",
+					Installed:      true,
+					ChecksumSource: "calculated",
+					Verifications:  []string{"cosign"},
+					Links: []*installpackage.LinkPlan{
+						{
+							Command: repoNameCiInfo,
+							Path:    "/home/foo/.local/share/aquaproj-aqua/bin/ci-info",
+							Target:  "../aqua-proxy",
+							ExePath: pathCiInfoBinary,
+						},
+					},
+				},
+			},
+		},
+	}
+	logger := slog.New(slog.DiscardHandler)
+	rt := &runtime.Runtime{
+		GOOS:   osLinux,
+		GOARCH: archAmd64,
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			testutil.WriteFiles(t, dir, d.files)
+			param := &config.Param{
+				CWD:            pathWorkspace,
+				ConfigFilePath: fileAquaYaml,
+				RootDir:        pathRoot,
+				MaxParallelism: 5,
+			}
+			testutil.RootParam(dir, param)
+			checksums := checksum.New()
+			for id, chk := range d.checksums {
+				checksums.Set(id, chk)
+			}
+			downloader := download.NewDownloader(nil, download.NewHTTPDownloader(logger, http.DefaultClient))
+			vacuumMock := vacuum.NewMock(param.RootDir, nil, nil)
+			ctrl := installpackage.New(param, downloader, rt, link.New(), nil, &checksum.Calculator{}, unarchive.New(nil), &cosign.MockVerifier{}, &slsa.MockVerifier{}, &minisign.MockVerifier{}, &pgp.MockVerifier{}, &ghattestation.MockVerifier{}, &installpackage.MockGoInstallInstaller{}, &installpackage.MockGoBuildInstaller{}, &installpackage.MockCargoPackageInstaller{}, vacuumMock, manifest.NewMock(nil, nil))
+			plans, err := ctrl.PlanPackages(logger, &installpackage.ParamInstallPackages{
+				Config:         cfg,
+				Registries:     registries,
+				ConfigFilePath: fileAquaYaml,
+				Checksums:      checksums,
+				DisablePolicy:  true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, plan := range d.exp {
+				plan.PkgPath = testutil.Abs(dir, plan.PkgPath)
+				for _, l := range plan.Links {
+					l.Path = testutil.Abs(dir, l.Path)
+					l.ExePath = testutil.Abs(dir, l.ExePath)
+				}
+			}
+			if diff := cmp.Diff(d.exp, plans); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -6,11 +6,14 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/aquaproj/aqua/v2/pkg/checksum"
 	"github.com/aquaproj/aqua/v2/pkg/config"
 	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
 	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/event"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
 )
 
 const ProxyVersion = "v1.2.13" // renovate: depName=aquaproj/aqua-proxy
@@ -46,6 +49,18 @@ func proxyPkg() *config.Package {
 	}
 }
 
+// ProxyPath returns the path of aqua-proxy which this version of aqua requires.
+func ProxyPath(rootDir string, rt *runtime.Runtime) (string, error) {
+	pkgPath, err := proxyPkg().AbsPkgPath(rootDir, rt)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+	if rt.IsWindows() {
+		return filepath.Join(pkgPath, proxyName+exeExt), nil
+	}
+	return filepath.Join(pkgPath, proxyName), nil
+}
+
 func (is *Installer) InstallProxy(ctx context.Context, logger *slog.Logger) error {
 	pkg := proxyPkg()
 	logger = logger.With(
@@ -77,7 +92,8 @@ func (is *Installer) InstallProxy(ctx context.Context, logger *slog.Logger) erro
 	if err != nil {
 		// file doesn't exist
 		chksum := ProxyChecksums()[is.runtime.Env()]
-		if err := is.downloadWithRetry(ctx, logger, &DownloadParam{
+		start := time.Now()
+		started, err := is.downloadWithRetry(ctx, logger, &DownloadParam{
 			Package: pkg,
 			Dest:    pkgPath,
 			Asset:   assetName,
@@ -85,7 +101,13 @@ func (is *Installer) InstallProxy(ctx context.Context, logger *slog.Logger) erro
 				Algorithm: algoSHA256,
 				Checksum:  chksum,
 			},
-		}); err != nil {
+		})
+		if started {
+			is.emitTimedEvent(logger, pkg, &event.Event{
+				Type: event.TypeDone,
+			}, start, err)
+		}
+		if err != nil {
 			return err
 		}
 		if is.realRuntime.IsWindows() {
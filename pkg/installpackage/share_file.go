@@ -0,0 +1,45 @@
+package installpackage
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+var errShareFileNotFound = errors.New("the share file isn't found in the package")
+
+// linkShareFiles links shell completions and man pages of the package into the share directory of the package version.
+// Failing to link them doesn't fail the installation because the package's commands still work.
+func (is *Installer) linkShareFiles(logger *slog.Logger, pkg *config.Package) {
+	if is.copyDir != "" || is.realRuntime.IsWindows() {
+		return
+	}
+	for _, file := range pkg.PackageInfo.ShareFiles {
+		logger := logger.With("share_file_kind", file.Kind, "share_file_src", file.Src)
+		if err := is.linkShareFile(logger, pkg, file); err != nil {
+			slogerr.WithError(logger, err).Warn("link a share file")
+		}
+	}
+}
+
+func (is *Installer) linkShareFile(logger *slog.Logger, pkg *config.Package, file *registry.ShareFile) error {
+	src, linkPath, err := pkg.ShareFilePath(is.rootDir, file, is.runtime)
+	if err != nil {
+		return fmt.Errorf("get the path of the share file: %w", err)
+	}
+	if f, err := osfile.Exists(src); err != nil {
+		return err //nolint:wrapcheck
+	} else if !f {
+		return slogerr.With(errShareFileNotFound, "share_file", src) //nolint:wrapcheck
+	}
+	if err := osfile.MkdirAll(filepath.Dir(linkPath)); err != nil {
+		return fmt.Errorf("create a directory: %w", err)
+	}
+	return is.createLink(logger.With("link_file", linkPath), linkPath, src)
+}
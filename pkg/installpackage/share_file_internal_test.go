@@ -0,0 +1,87 @@
+package installpackage
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/link"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+)
+
+func TestInstaller_linkShareFiles(t *testing.T) {
+	t.Parallel()
+	if goruntime.GOOS == "windows" {
+		t.Skip("share files aren't linked on Windows")
+	}
+	logger := slog.New(slog.DiscardHandler)
+	rt := &runtime.Runtime{GOOS: "linux", GOARCH: "amd64"}
+	inst, rootDir, dest := newUnarchiveTestInstaller(t, nil)
+	inst.runtime = rt
+	inst.realRuntime = rt
+	inst.linker = link.New()
+	for _, p := range []string{"completions/gh.bash", "share/man/man1/gh.1"} {
+		p = filepath.Join(dest, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(p), dirPerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("gh"), dirPerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pkg := &config.Package{
+		Package: &aqua.Package{
+			Name:    "cli/cli",
+			Version: "v2.96.0",
+		},
+		PackageInfo: &registry.PackageInfo{
+			Type:      pkgTypeGitHubRelease,
+			RepoOwner: "cli",
+			RepoName:  "cli",
+			Asset:     "gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz",
+			ShareFiles: []*registry.ShareFile{
+				{
+					Kind: registry.ShareFileKindBashCompletion,
+					Src:  "completions/gh.bash",
+					Name: "gh",
+				},
+				{
+					Kind: registry.ShareFileKindMan,
+					Src:  "share/man/man1/gh.1",
+				},
+				{
+					// missing files are ignored
+					Kind: registry.ShareFileKindZshCompletion,
+					Src:  "completions/_gh",
+				},
+			},
+		},
+	}
+	inst.linkShareFiles(logger, pkg)
+	// Links are created per package version.
+	pkgPath, err := filepath.Rel(rootDir, dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shareDir := filepath.Join(rootDir, "share", pkgPath)
+	for link, target := range map[string]string{
+		"bash-completion/completions/gh": "completions/gh.bash",
+		"man/man1/gh.1":                  "share/man/man1/gh.1",
+	} {
+		s, err := os.Readlink(filepath.Join(shareDir, filepath.FromSlash(link)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exp := filepath.Join(dest, filepath.FromSlash(target)); s != exp {
+			t.Fatalf("wanted %s, got %s", exp, s)
+		}
+	}
+	if _, err := os.Lstat(filepath.Join(shareDir, "zsh", "site-functions", "_gh")); err == nil {
+		t.Fatal("a link to a missing file must not be created")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	"github.com/aquaproj/aqua/v2/pkg/policy"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
 )
 
 func (is *Installer) validatePackage(logger *slog.Logger, param *ParamInstallPackage) error {
@@ -25,7 +26,16 @@ func (is *Installer) validatePackage(logger *slog.Logger, param *ParamInstallPac
 	}
 
 	if !param.DisablePolicy {
-		if err := policy.ValidatePackage(logger, pkg, param.PolicyConfigs); err != nil {
+		disabled := is.disabledVerifications
+		if param.Checksums == nil {
+			// Checksums are nil if the checksum verification is disabled in the configuration file.
+			disabled = append([]string{policy.VerificationChecksum}, disabled...)
+		}
+		decision, err := policy.EvaluatePackage(logger, pkg, param.PolicyConfigs, disabled)
+		if err := is.policyAuditLog.Record(decision); err != nil {
+			slogerr.WithError(logger, err).Warn("record the policy decision")
+		}
+		if err != nil {
 			return err //nolint:wrapcheck
 		}
 	}
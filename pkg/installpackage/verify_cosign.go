@@ -21,6 +21,10 @@ type cosignVerifier struct {
 	asset     string
 }
 
+func (c *cosignVerifier) Name() string {
+	return "cosign"
+}
+
 func (c *cosignVerifier) Enabled(logger *slog.Logger) (bool, error) {
 	if c.disabled {
 		logger.Debug("cosign is disabled")
@@ -32,20 +36,16 @@ func (c *cosignVerifier) Enabled(logger *slog.Logger) (bool, error) {
 
 func (c *cosignVerifier) Verify(ctx context.Context, logger *slog.Logger, file string) error {
 	logger.Info("verifying a file with Cosign")
-	if err := c.installer.install(ctx, logger); err != nil {
-		return fmt.Errorf("install sigstore/cosign: %w", err)
-	}
-
 	pkg := c.pkg
 	cos := c.cosign
 
 	art := pkg.TemplateArtifact(c.runtime, c.asset)
 
-	if err := c.verifier.Verify(ctx, logger, c.runtime, &download.File{
+	if err := c.verifier.VerifyWithInstaller(ctx, logger, c.runtime, &download.File{
 		RepoOwner: pkg.PackageInfo.RepoOwner,
 		RepoName:  pkg.PackageInfo.RepoName,
 		Version:   pkg.Package.Version,
-	}, cos, art, file); err != nil {
+	}, cos, art, file, c.installer.install); err != nil {
 		return fmt.Errorf("verify a file with Cosign: %w", err)
 	}
 	return nil
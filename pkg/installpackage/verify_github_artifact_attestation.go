@@ -11,6 +11,7 @@ import (
 )
 
 type FileVerifier interface {
+	Name() string
 	Enabled(logger *slog.Logger) (bool, error)
 	Verify(ctx context.Context, logger *slog.Logger, file string) error
 }
@@ -23,6 +24,10 @@ type gitHubArtifactAttestationsVerifier struct {
 	ghVerifier  GitHubArtifactAttestationsVerifier
 }
 
+func (g *gitHubArtifactAttestationsVerifier) Name() string {
+	return "github_artifact_attestations"
+}
+
 func (g *gitHubArtifactAttestationsVerifier) Enabled(logger *slog.Logger) (bool, error) {
 	if g.disabled {
 		logger.Debug("GitHub Artifact Attestation is disabled")
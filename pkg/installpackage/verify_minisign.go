@@ -13,34 +13,23 @@ import (
 )
 
 type minisignVerifier struct {
-	pkg       *config.Package
-	installer *DedicatedInstaller
-	verifier  MinisignVerifier
-	runtime   *runtime.Runtime
-	asset     string
-	minisign  *registry.Minisign
+	pkg      *config.Package
+	verifier MinisignVerifier
+	runtime  *runtime.Runtime
+	asset    string
+	minisign *registry.Minisign
 }
 
-func (s *minisignVerifier) Enabled(logger *slog.Logger) (bool, error) {
-	if !s.minisign.GetEnabled() {
-		return false, nil
-	}
+func (s *minisignVerifier) Name() string {
+	return "minisign"
+}
 
-	mPkg := minisign.Package()
-	if f, err := mPkg.PackageInfo.CheckSupported(s.runtime, s.runtime.Env()); err != nil {
-		return false, fmt.Errorf("check if minisign supports this environment: %w", err)
-	} else if !f {
-		logger.Warn("minisign doesn't support this environment")
-		return false, nil
-	}
-	return true, nil
+func (s *minisignVerifier) Enabled(logger *slog.Logger) (bool, error) {
+	return s.minisign.GetEnabled(), nil
 }
 
 func (s *minisignVerifier) Verify(ctx context.Context, logger *slog.Logger, file string) error {
 	logger.Info("verify a package with minisign")
-	if err := s.installer.install(ctx, logger); err != nil {
-		return fmt.Errorf("install minisign: %w", err)
-	}
 
 	pkg := s.pkg
 	pkgInfo := s.pkg.PackageInfo
@@ -0,0 +1,50 @@
+package installpackage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/download"
+	"github.com/aquaproj/aqua/v2/pkg/pgp"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+)
+
+type pgpVerifier struct {
+	pkg      *config.Package
+	verifier PGPVerifier
+	runtime  *runtime.Runtime
+	asset    string
+	pgp      *registry.PGP
+}
+
+func (s *pgpVerifier) Name() string {
+	return "pgp"
+}
+
+func (s *pgpVerifier) Enabled(logger *slog.Logger) (bool, error) {
+	return s.pgp.GetEnabled(), nil
+}
+
+func (s *pgpVerifier) Verify(ctx context.Context, logger *slog.Logger, file string) error {
+	logger.Info("verify a package with OpenPGP")
+
+	pkg := s.pkg
+	pkgInfo := s.pkg.PackageInfo
+
+	art := pkg.TemplateArtifact(s.runtime, s.asset)
+
+	if err := s.verifier.Verify(ctx, logger, s.runtime, s.pgp, art, &download.File{
+		RepoOwner: pkgInfo.RepoOwner,
+		RepoName:  pkgInfo.RepoName,
+		Version:   pkg.Package.Version,
+	}, &pgp.ParamVerify{
+		ArtifactPath: file,
+	}); err != nil {
+		return fmt.Errorf("verify a package with OpenPGP: %w", err)
+	}
+
+	return nil
+}
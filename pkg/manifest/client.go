@@ -0,0 +1,129 @@
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+)
+
+const (
+	// filePermission is group writable like timestamp files of vacuum,
+	// so that several users can share $AQUA_ROOT_DIR.
+	filePermission = 0o664
+	baseDir        = "metadata"
+	// FileName is the name of a manifest in the package metadata directory.
+	FileName = "manifest.json"
+)
+
+// ErrNotFound is returned if the manifest of a package isn't recorded.
+// Packages installed by old aqua don't have manifests.
+var ErrNotFound = errors.New("the manifest of the package isn't found")
+
+// Client reads and writes manifests in $AQUA_ROOT_DIR/metadata.
+// Manifests are stored outside package directories so that they aren't reported as extra files.
+type Client struct {
+	rootDir string
+}
+
+func New(param *config.Param) *Client {
+	return &Client{
+		rootDir: filepath.Join(param.RootDir, baseDir),
+	}
+}
+
+// Record hashes files in the package directory and writes the manifest.
+func (c *Client) Record(pkgPath, dir string, pkg *config.Package) error {
+	files, err := Hash(dir)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(&Manifest{
+		Package: pkg.Package.Name,
+		Version: pkg.Package.Version,
+		Files:   files,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode a manifest as JSON: %w", err)
+	}
+	if err := osfile.MkdirAll(c.dir(pkgPath)); err != nil {
+		return fmt.Errorf("create a package metadata directory: %w", err)
+	}
+	if err := os.WriteFile(c.file(pkgPath), append(b, '\n'), filePermission); err != nil {
+		return fmt.Errorf("write a manifest: %w", err)
+	}
+	return nil
+}
+
+// Read reads the manifest of a package.
+// If the manifest doesn't exist, ErrNotFound is returned.
+func (c *Client) Read(pkgPath string) (*Manifest, error) {
+	return c.read(c.file(pkgPath))
+}
+
+func (c *Client) read(file string) (*Manifest, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("read a manifest: %w", err)
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("parse a manifest as JSON: %w", err)
+	}
+	return m, nil
+}
+
+// FindAll returns manifests of all installed packages.
+// The key is a package path relative to $AQUA_ROOT_DIR.
+func (c *Client) FindAll() (map[string]*Manifest, error) {
+	manifests := map[string]*Manifest{}
+	if err := filepath.WalkDir(filepath.Join(c.rootDir, "pkgs"), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return fmt.Errorf("walk directory to find manifests: %w", err)
+		}
+		if entry.Name() != FileName {
+			return nil
+		}
+		m, err := c.read(path)
+		if err != nil {
+			return fmt.Errorf("read a manifest: %w", err)
+		}
+		rel, err := filepath.Rel(c.rootDir, filepath.Dir(path))
+		if err != nil {
+			return fmt.Errorf("get a relative file path: %w", err)
+		}
+		manifests[rel] = m
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("find manifests: %w", err)
+	}
+	return manifests, nil
+}
+
+// Remove removes the manifest of a package.
+// It doesn't fail if the manifest doesn't exist.
+func (c *Client) Remove(pkgPath string) error {
+	if err := os.Remove(c.file(pkgPath)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove a manifest: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) dir(pkgPath string) string {
+	return filepath.Join(c.rootDir, pkgPath)
+}
+
+func (c *Client) file(pkgPath string) string {
+	return filepath.Join(c.dir(pkgPath), FileName)
+}
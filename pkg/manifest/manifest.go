@@ -0,0 +1,114 @@
+// Package manifest records hashes of files of installed packages and detects their modification.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// Manifest is a list of files of an installed package.
+type Manifest struct {
+	Package string `json:"package"`
+	Version string `json:"version"`
+	// Files is a map of slash-separated relative file paths to files.
+	Files map[string]*File `json:"files"`
+}
+
+// File is a regular file or a symbolic link in a package.
+type File struct {
+	SHA256  string `json:"sha256,omitempty"`
+	Symlink string `json:"symlink,omitempty"`
+}
+
+// Diff is the difference between a manifest and files in a package directory.
+type Diff struct {
+	Modified []string
+	Missing  []string
+	Extra    []string
+}
+
+// OK returns true if the package directory matches the manifest.
+func (d *Diff) OK() bool {
+	return len(d.Modified) == 0 && len(d.Missing) == 0 && len(d.Extra) == 0
+}
+
+// Hash walks a package directory and returns its files.
+// Directories aren't recorded, and symbolic links aren't followed.
+func Hash(dir string) (map[string]*File, error) {
+	files := map[string]*File{}
+	if err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return fmt.Errorf("get a relative file path: %w", err)
+		}
+		f, err := hashFile(path, entry)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = f
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("walk a package directory: %w", err)
+	}
+	return files, nil
+}
+
+func hashFile(path string, entry fs.DirEntry) (*File, error) {
+	if entry.Type()&fs.ModeSymlink != 0 {
+		dest, err := os.Readlink(path)
+		if err != nil {
+			return nil, fmt.Errorf("read a symbolic link: %w", err)
+		}
+		return &File{Symlink: filepath.ToSlash(dest)}, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open a file: %w", err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("read a file: %w", err)
+	}
+	return &File{SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// Compare hashes files in a package directory again and compares them with the manifest.
+func (m *Manifest) Compare(dir string) (*Diff, error) {
+	files, err := Hash(dir)
+	if err != nil {
+		return nil, err
+	}
+	diff := &Diff{}
+	for path, expected := range m.Files {
+		actual, ok := files[path]
+		if !ok {
+			diff.Missing = append(diff.Missing, path)
+			continue
+		}
+		if *actual != *expected {
+			diff.Modified = append(diff.Modified, path)
+		}
+	}
+	for path := range files {
+		if _, ok := m.Files[path]; !ok {
+			diff.Extra = append(diff.Extra, path)
+		}
+	}
+	slices.Sort(diff.Modified)
+	slices.Sort(diff.Missing)
+	slices.Sort(diff.Extra)
+	return diff, nil
+}
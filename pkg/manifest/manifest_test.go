@@ -0,0 +1,154 @@
+package manifest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/manifest"
+	"github.com/google/go-cmp/cmp"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:mnd
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil { //nolint:gosec,mnd
+		t.Fatal(err)
+	}
+}
+
+func TestManifest_Compare(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name   string
+		modify func(t *testing.T, dir string)
+		exp    *manifest.Diff
+		ok     bool
+	}{
+		{
+			name:   "not modified",
+			modify: func(*testing.T, string) {},
+			exp:    &manifest.Diff{},
+			ok:     true,
+		},
+		{
+			name: "modified",
+			modify: func(t *testing.T, dir string) {
+				t.Helper()
+				writeFile(t, filepath.Join(dir, "bin", "gh"), "tampered")
+			},
+			exp: &manifest.Diff{
+				Modified: []string{"bin/gh"},
+			},
+		},
+		{
+			name: "missing and extra",
+			modify: func(t *testing.T, dir string) {
+				t.Helper()
+				if err := os.Remove(filepath.Join(dir, "LICENSE")); err != nil {
+					t.Fatal(err)
+				}
+				writeFile(t, filepath.Join(dir, "bin", "evil"), "evil")
+			},
+			exp: &manifest.Diff{
+				Missing: []string{"LICENSE"},
+				Extra:   []string{"bin/evil"},
+			},
+		},
+		{
+			name: "symlink is changed",
+			modify: func(t *testing.T, dir string) {
+				t.Helper()
+				p := filepath.Join(dir, "gh")
+				if err := os.Remove(p); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Symlink("bin/evil", p); err != nil {
+					t.Fatal(err)
+				}
+			},
+			exp: &manifest.Diff{
+				Modified: []string{"gh"},
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			writeFile(t, filepath.Join(dir, "bin", "gh"), "gh")
+			writeFile(t, filepath.Join(dir, "LICENSE"), "MIT")
+			if err := os.Symlink("bin/gh", filepath.Join(dir, "gh")); err != nil {
+				t.Fatal(err)
+			}
+			files, err := manifest.Hash(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m := &manifest.Manifest{Files: files}
+			d.modify(t, dir)
+			diff, err := m.Compare(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := cmp.Diff(d.exp, diff); s != "" {
+				t.Fatal(s)
+			}
+			if diff.OK() != d.ok {
+				t.Fatalf("OK() must return %v", d.ok)
+			}
+		})
+	}
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+	rootDir := t.TempDir()
+	pkgPath := filepath.Join("pkgs", "github_release", "github.com", "cli", "cli", "v2.40.0", "gh.tar.gz")
+	dir := filepath.Join(rootDir, pkgPath)
+	writeFile(t, filepath.Join(dir, "gh"), "gh")
+	client := manifest.New(&config.Param{RootDir: rootDir})
+
+	if _, err := client.Read(pkgPath); err != manifest.ErrNotFound { //nolint:errorlint
+		t.Fatalf("ErrNotFound must be returned: %v", err)
+	}
+	if err := client.Record(pkgPath, dir, &config.Package{
+		Package: &aqua.Package{
+			Name:    "cli/cli",
+			Version: "v2.40.0",
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	m, err := client.Read(pkgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := &manifest.Manifest{
+		Package: "cli/cli",
+		Version: "v2.40.0",
+		Files: map[string]*manifest.File{
+			"gh": {SHA256: "fb2b7fce0940161406a6aa3e4d8b4aa6104014774ffa665743f8d9704f0eb0ec"},
+		},
+	}
+	if diff := cmp.Diff(exp, m); diff != "" {
+		t.Fatal(diff)
+	}
+	manifests, err := client.FindAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]*manifest.Manifest{pkgPath: exp}, manifests); diff != "" {
+		t.Fatal(diff)
+	}
+	if err := client.Remove(pkgPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Remove(pkgPath); err != nil {
+		t.Fatal("Remove must not fail if the manifest doesn't exist")
+	}
+}
@@ -0,0 +1,39 @@
+package manifest
+
+import (
+	"github.com/aquaproj/aqua/v2/pkg/config"
+)
+
+type Mock struct {
+	manifests map[string]*Manifest
+	err       error
+}
+
+func NewMock(manifests map[string]*Manifest, err error) *Mock {
+	return &Mock{
+		manifests: manifests,
+		err:       err,
+	}
+}
+
+func (m *Mock) Record(pkgPath, dir string, pkg *config.Package) error {
+	return m.err
+}
+
+func (m *Mock) Read(pkgPath string) (*Manifest, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if mf, ok := m.manifests[pkgPath]; ok {
+		return mf, nil
+	}
+	return nil, ErrNotFound
+}
+
+func (m *Mock) FindAll() (map[string]*Manifest, error) {
+	return m.manifests, m.err
+}
+
+func (m *Mock) Remove(pkgPath string) error {
+	return m.err
+}
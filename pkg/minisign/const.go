@@ -1,9 +0,0 @@
-package minisign
-
-const (
-	pkgName = "minisign"
-
-	osDarwin  = "darwin"
-	osWindows = "windows"
-	archAmd64 = "amd64"
-)
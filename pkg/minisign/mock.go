@@ -17,11 +17,3 @@ type MockVerifier struct {
 func (m *MockVerifier) Verify(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, ms *registry.Minisign, art *template.Artifact, file *download.File, param *ParamVerify) error {
 	return m.err
 }
-
-type MockExecutor struct {
-	Err error
-}
-
-func (m *MockExecutor) Verify(ctx context.Context, logger *slog.Logger, param *ParamVerify, signature string) error {
-	return m.Err
-}
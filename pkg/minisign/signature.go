@@ -0,0 +1,153 @@
+package minisign
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Signature algorithms of minisign.
+// https://jedisct1.github.io/minisign/#signature-format
+const (
+	// algEd25519 signs the file itself. It's the legacy format.
+	algEd25519 = "Ed"
+	// algEd25519Prehashed signs the BLAKE2b-512 hash of the file.
+	algEd25519Prehashed = "ED"
+
+	keyIDSize            = 8
+	untrustedCommentHead = "untrusted comment:"
+	trustedCommentHead   = "trusted comment: "
+)
+
+var (
+	errInvalidPublicKey      = errors.New("the minisign public key is invalid")
+	errInvalidSignature      = errors.New("the minisign signature is invalid")
+	errUnsupportedAlgorithm  = errors.New("the signature algorithm isn't supported")
+	errKeyIDMismatch         = errors.New("the signature was created with a different key")
+	errSignatureMismatch     = errors.New("the signature verification failed")
+	errTrustedCommentInvalid = errors.New("the signature of the trusted comment is invalid")
+)
+
+// publicKey is a minisign public key.
+type publicKey struct {
+	keyID [keyIDSize]byte
+	key   ed25519.PublicKey
+}
+
+// signature is a minisign signature with its trusted comment.
+type signature struct {
+	algorithm       string
+	keyID           [keyIDSize]byte
+	signature       []byte
+	trustedComment  string
+	globalSignature []byte
+}
+
+// parsePublicKey parses a base64-encoded minisign public key.
+// It also accepts the content of a public key file, whose first line is an untrusted comment.
+func parsePublicKey(s string) (*publicKey, error) {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[len(lines)-1]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: decode the public key as base64: %w", errInvalidPublicKey, err)
+	}
+	if len(b) != 2+keyIDSize+ed25519.PublicKeySize || string(b[:2]) != algEd25519 {
+		return nil, errInvalidPublicKey
+	}
+	pub := &publicKey{
+		key: ed25519.PublicKey(b[2+keyIDSize:]),
+	}
+	copy(pub.keyID[:], b[2:2+keyIDSize])
+	return pub, nil
+}
+
+// parseSignature parses a minisign signature file.
+func parseSignature(b []byte) (*signature, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read a signature: %w", err)
+	}
+	if len(lines) < 4 || !strings.HasPrefix(lines[0], untrustedCommentHead) || !strings.HasPrefix(lines[2], trustedCommentHead) { //nolint:mnd
+		return nil, fmt.Errorf("%w: the format is wrong", errInvalidSignature)
+	}
+	sig, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: decode the signature as base64: %w", errInvalidSignature, err)
+	}
+	if len(sig) != 2+keyIDSize+ed25519.SignatureSize {
+		return nil, fmt.Errorf("%w: the length of the signature is wrong", errInvalidSignature)
+	}
+	globalSig, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil {
+		return nil, fmt.Errorf("%w: decode the global signature as base64: %w", errInvalidSignature, err)
+	}
+	if len(globalSig) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("%w: the length of the global signature is wrong", errInvalidSignature)
+	}
+	s := &signature{
+		algorithm:       string(sig[:2]),
+		signature:       sig[2+keyIDSize:],
+		trustedComment:  strings.TrimPrefix(lines[2], trustedCommentHead),
+		globalSignature: globalSig,
+	}
+	copy(s.keyID[:], sig[2:2+keyIDSize])
+	return s, nil
+}
+
+// verify verifies the signature of the message read from r and the trusted comment like `minisign -V`.
+func (p *publicKey) verify(r io.Reader, sig *signature) error {
+	if sig.keyID != p.keyID {
+		return fmt.Errorf("%w: signature key id %s, public key id %s", errKeyIDMismatch, keyIDString(sig.keyID), keyIDString(p.keyID))
+	}
+	var msg []byte
+	switch sig.algorithm {
+	case algEd25519:
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("read a file: %w", err)
+		}
+		msg = b
+	case algEd25519Prehashed:
+		h, err := blake2b.New512(nil)
+		if err != nil {
+			return fmt.Errorf("initialize BLAKE2b: %w", err)
+		}
+		if _, err := io.Copy(h, r); err != nil {
+			return fmt.Errorf("read a file: %w", err)
+		}
+		msg = h.Sum(nil)
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedAlgorithm, sig.algorithm)
+	}
+	if !ed25519.Verify(p.key, msg, sig.signature) {
+		return errSignatureMismatch
+	}
+	// The global signature covers the signature and the trusted comment,
+	// so the trusted comment can't be tampered.
+	if !ed25519.Verify(p.key, append(append([]byte{}, sig.signature...), sig.trustedComment...), sig.globalSignature) {
+		return errTrustedCommentInvalid
+	}
+	return nil
+}
+
+// keyIDString formats a key id in the same way as minisign.
+func keyIDString(id [keyIDSize]byte) string {
+	// minisign prints key ids as little-endian hex numbers.
+	b := make([]byte, keyIDSize)
+	for i := range keyIDSize {
+		b[i] = id[keyIDSize-1-i]
+	}
+	return strings.ToUpper(hex.EncodeToString(b))
+}
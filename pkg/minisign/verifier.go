@@ -15,13 +15,11 @@ import (
 
 type Verifier struct {
 	downloader download.ClientAPI
-	exe        Executor
 }
 
-func New(downloader download.ClientAPI, exe Executor) *Verifier {
+func New(downloader download.ClientAPI) *Verifier {
 	return &Verifier{
 		downloader: downloader,
-		exe:        exe,
 	}
 }
 
@@ -30,34 +28,47 @@ type ParamVerify struct {
 	PublicKey    string
 }
 
+// Verify verifies a file with minisign's signature natively, so the minisign executable isn't needed.
 func (v *Verifier) Verify(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, m *registry.Minisign, art *template.Artifact, file *download.File, param *ParamVerify) error {
-	sigFile, err := v.downloadSignature(ctx, logger, rt, m, art, file)
+	pub, err := parsePublicKey(param.PublicKey)
 	if err != nil {
 		return err
 	}
-	defer os.Remove(sigFile)
-	return v.exe.Verify(ctx, logger, param, sigFile) //nolint:wrapcheck
+	b, err := v.downloadSignature(ctx, logger, rt, m, art, file)
+	if err != nil {
+		return err
+	}
+	sig, err := parseSignature(b)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(param.ArtifactPath)
+	if err != nil {
+		return fmt.Errorf("open a verified file: %w", err)
+	}
+	defer f.Close()
+	if err := pub.verify(f, sig); err != nil {
+		return err
+	}
+	logger.Debug("verified a file with minisign", "trusted_comment", sig.trustedComment)
+	return nil
 }
 
-func (v *Verifier) downloadSignature(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, m *registry.Minisign, art *template.Artifact, file *download.File) (string, error) {
+func (v *Verifier) downloadSignature(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, m *registry.Minisign, art *template.Artifact, file *download.File) ([]byte, error) {
 	f, err := download.ConvertDownloadedFileToFile(m.ToDownloadedFile(), file, rt, art)
 	if err != nil {
-		return "", err //nolint:wrapcheck
+		return nil, err //nolint:wrapcheck
 	}
 
 	rc, _, err := v.downloader.ReadCloser(ctx, logger, f)
 	if err != nil {
-		return "", fmt.Errorf("download a Minisign signature: %w", err)
+		return nil, fmt.Errorf("download a Minisign signature: %w", err)
 	}
 	defer rc.Close()
 
-	signatureFile, err := os.CreateTemp("", "")
+	b, err := io.ReadAll(rc)
 	if err != nil {
-		return "", fmt.Errorf("create a temporary file: %w", err)
-	}
-	defer signatureFile.Close()
-	if _, err := io.Copy(signatureFile, rc); err != nil {
-		return signatureFile.Name(), fmt.Errorf("copy a signature to a temporary file: %w", err)
+		return nil, fmt.Errorf("read a Minisign signature: %w", err)
 	}
-	return signatureFile.Name(), nil
+	return b, nil
 }
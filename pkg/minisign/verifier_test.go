@@ -1,8 +1,13 @@
 package minisign_test
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
 	"io"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -11,64 +16,145 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/minisign"
 	"github.com/aquaproj/aqua/v2/pkg/runtime"
 	"github.com/aquaproj/aqua/v2/pkg/template"
+	"golang.org/x/crypto/blake2b"
 )
 
+type testKey struct {
+	t     *testing.T
+	keyID []byte
+	priv  ed25519.PrivateKey
+}
+
+func newTestKey(t *testing.T, keyID string) *testKey {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testKey{
+		t:     t,
+		keyID: []byte(keyID),
+		priv:  priv,
+	}
+}
+
+// publicKey returns the base64-encoded public key like `minisign -G`.
+func (k *testKey) publicKey() string {
+	b := append([]byte("Ed"), k.keyID...)
+	b = append(b, k.priv.Public().(ed25519.PublicKey)...)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// sign returns a signature file like `minisign -S`.
+func (k *testKey) sign(alg string, msg []byte, trustedComment string) string {
+	if alg == "ED" {
+		sum := blake2b.Sum512(msg)
+		msg = sum[:]
+	}
+	sig := ed25519.Sign(k.priv, msg)
+	globalSig := ed25519.Sign(k.priv, append(append([]byte{}, sig...), trustedComment...))
+	b := append([]byte(alg), k.keyID...)
+	b = append(b, sig...)
+	return "untrusted comment: signature from minisign secret key\n" +
+		base64.StdEncoding.EncodeToString(b) + "\n" +
+		"trusted comment: " + trustedComment + "\n" +
+		base64.StdEncoding.EncodeToString(globalSig) + "\n"
+}
+
 func TestVerifier_Verify(t *testing.T) { //nolint:funlen
 	t.Parallel()
+	artifact := []byte("hello")
+	artifactPath := filepath.Join(t.TempDir(), "aqua_darwin_arm64.tar.gz")
+	if err := os.WriteFile(artifactPath, artifact, 0o644); err != nil { //nolint:gosec,mnd
+		t.Fatal(err)
+	}
+	key := newTestKey(t, "12345678")
+	otherKey := newTestKey(t, "87654321")
+	comment := "timestamp:1700000000\tfile:aqua_darwin_arm64.tar.gz\thashed"
+	tamperedComment := strings.Replace(key.sign("ED", artifact, comment), "timestamp:1700000000", "timestamp:1800000000", 1)
+
 	data := []struct {
-		name             string
-		isErr            bool
-		rt               *runtime.Runtime
-		downloader       download.ClientAPI
-		file             *download.File
-		m                *registry.Minisign
-		art              *template.Artifact
-		param            *minisign.ParamVerify
-		exe              minisign.Executor
-		verifiedFilePath string
+		name      string
+		isErr     bool
+		signature string
+		publicKey string
 	}{
 		{
-			name: "normal",
-			rt: &runtime.Runtime{
-				GOOS:   "darwin",
-				GOARCH: "arm64",
-			},
-			downloader: &download.Mock{
-				RC: io.NopCloser(strings.NewReader("hello")),
-			},
-			file: &download.File{
-				Type:      "github_release",
-				RepoOwner: "aquaproj",
-				RepoName:  "aqua",
-				Version:   "v1.6.0",
-				Asset:     "aqua_darwin_arm64.tar.gz",
-			},
-			m: &registry.Minisign{
-				Type:      "github_release",
-				RepoOwner: "aquaproj",
-				RepoName:  "aqua",
-				Asset:     new("multiple.intoto.jsonl"),
-			},
-			art: &template.Artifact{
-				Version: "v1.6.0",
-				OS:      "darwin",
-				Arch:    "arm64",
-				Format:  "tar.gz",
-				Asset:   "aqua_darwin_arm64.tar.gz",
-			},
-			param: &minisign.ParamVerify{
-				ArtifactPath: "/temp/foo",
-			},
-			exe: &minisign.MockExecutor{},
+			name:      "prehashed",
+			signature: key.sign("ED", artifact, comment),
+			publicKey: key.publicKey(),
+		},
+		{
+			name:      "legacy",
+			signature: key.sign("Ed", artifact, comment),
+			publicKey: key.publicKey(),
+		},
+		{
+			name:      "public key file",
+			signature: key.sign("ED", artifact, comment),
+			publicKey: "untrusted comment: minisign public key 3837363534333231\n" + key.publicKey() + "\n",
+		},
+		{
+			name:      "tampered file",
+			signature: key.sign("ED", []byte("tampered"), comment),
+			publicKey: key.publicKey(),
+			isErr:     true,
+		},
+		{
+			name:      "tampered trusted comment",
+			signature: tamperedComment,
+			publicKey: key.publicKey(),
+			isErr:     true,
+		},
+		{
+			name:      "other key",
+			signature: otherKey.sign("ED", artifact, comment),
+			publicKey: key.publicKey(),
+			isErr:     true,
+		},
+		{
+			name:      "invalid signature",
+			signature: "hello",
+			publicKey: key.publicKey(),
+			isErr:     true,
 		},
 	}
 	logger := slog.New(slog.DiscardHandler)
+	rt := &runtime.Runtime{
+		GOOS:   "darwin",
+		GOARCH: "arm64",
+	}
+	file := &download.File{
+		Type:      "github_release",
+		RepoOwner: "aquaproj",
+		RepoName:  "aqua",
+		Version:   "v1.6.0",
+		Asset:     "aqua_darwin_arm64.tar.gz",
+	}
+	m := &registry.Minisign{
+		Type:      "github_release",
+		RepoOwner: "aquaproj",
+		RepoName:  "aqua",
+		Asset:     new("aqua_darwin_arm64.tar.gz.minisig"),
+	}
+	art := &template.Artifact{
+		Version: "v1.6.0",
+		OS:      "darwin",
+		Arch:    "arm64",
+		Format:  "tar.gz",
+		Asset:   "aqua_darwin_arm64.tar.gz",
+	}
 	for _, d := range data {
 		t.Run(d.name, func(t *testing.T) {
 			t.Parallel()
 			ctx := t.Context()
-			verifier := minisign.New(d.downloader, d.exe)
-			if err := verifier.Verify(ctx, logger, d.rt, d.m, d.art, d.file, d.param); err != nil {
+			verifier := minisign.New(&download.Mock{
+				RC: io.NopCloser(strings.NewReader(d.signature)),
+			})
+			if err := verifier.Verify(ctx, logger, rt, m, art, file, &minisign.ParamVerify{
+				ArtifactPath: artifactPath,
+				PublicKey:    d.publicKey,
+			}); err != nil {
 				if d.isErr {
 					return
 				}
@@ -0,0 +1,28 @@
+package osexec
+
+import (
+	"os"
+	"strings"
+)
+
+// Environ returns the environment variables of the current process with envs applied.
+// Each element of envs is formatted as NAME=VALUE and overrides the variable with the same name.
+// Unlike exec.Cmd, execve(2) doesn't remove duplicate variables, so they are removed here.
+func Environ(envs []string) []string {
+	return mergeEnv(os.Environ(), envs)
+}
+
+func mergeEnv(base, envs []string) []string {
+	ret := make([]string, 0, len(base)+len(envs))
+	indexes := make(map[string]int, len(base)+len(envs))
+	for _, kv := range append(append([]string{}, base...), envs...) {
+		name, _, _ := strings.Cut(kv, "=")
+		if i, ok := indexes[name]; ok {
+			ret[i] = kv
+			continue
+		}
+		indexes[name] = len(ret)
+		ret = append(ret, kv)
+	}
+	return ret
+}
@@ -0,0 +1,38 @@
+package osexec
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_mergeEnv(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name string
+		base []string
+		envs []string
+		exp  []string
+	}{
+		{
+			name: "add",
+			base: []string{"PATH=/usr/bin", "HOME=/home/foo"},
+			envs: []string{"JAVA_HOME=/opt/java"},
+			exp:  []string{"PATH=/usr/bin", "HOME=/home/foo", "JAVA_HOME=/opt/java"},
+		},
+		{
+			name: "override",
+			base: []string{"JAVA_HOME=/usr/lib/jvm", "PATH=/usr/bin"},
+			envs: []string{"JAVA_HOME=/opt/java"},
+			exp:  []string{"JAVA_HOME=/opt/java", "PATH=/usr/bin"},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(d.exp, mergeEnv(d.base, d.envs)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -8,6 +8,11 @@ import (
 	"golang.org/x/sys/unix"
 )
 
-func (e *Executor) ExecXSys(exePath, name string, args ...string) error {
-	return unix.Exec(exePath, append([]string{name}, args...), os.Environ()) //nolint:wrapcheck
+// ExecXSys replaces the current process with the command by execve(2).
+// If env is nil, the environment variables of the current process are inherited.
+func (e *Executor) ExecXSys(exePath, name string, env []string, args ...string) error {
+	if env == nil {
+		env = os.Environ()
+	}
+	return unix.Exec(exePath, append([]string{name}, args...), env) //nolint:wrapcheck
 }
@@ -0,0 +1,152 @@
+package osv
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Contains returns true if the version is affected.
+// The second returned value is fixed versions.
+// SEMVER and ECOSYSTEM ranges are evaluated as semantic versions, and GIT ranges are ignored because commit hashes can't be compared.
+// Ranges including versions which can't be parsed are ignored.
+func (a *Affected) Contains(v string) (bool, []string) {
+	for _, s := range a.Versions {
+		if trimV(s) == trimV(v) {
+			return true, a.fixed()
+		}
+	}
+	sv, err := version.NewVersion(v)
+	if err != nil {
+		return false, nil
+	}
+	for _, rng := range a.Ranges {
+		if !rng.comparable() {
+			continue
+		}
+		if rng.contains(sv) {
+			return true, rng.fixed()
+		}
+	}
+	return false, nil
+}
+
+func trimV(v string) string {
+	return strings.TrimPrefix(v, "v")
+}
+
+func (a *Affected) fixed() []string {
+	var fixed []string
+	for _, rng := range a.Ranges {
+		if !rng.comparable() {
+			continue
+		}
+		fixed = append(fixed, rng.fixed()...)
+	}
+	return fixed
+}
+
+func (r *Range) comparable() bool {
+	return r.Type == "SEMVER" || r.Type == "ECOSYSTEM"
+}
+
+func (r *Range) fixed() []string {
+	var fixed []string
+	for _, ev := range r.Events {
+		if ev.Fixed != "" {
+			fixed = append(fixed, ev.Fixed)
+		}
+	}
+	return fixed
+}
+
+type event struct {
+	typ     string
+	version *version.Version
+}
+
+const (
+	eventIntroduced   = "introduced"
+	eventFixed        = "fixed"
+	eventLastAffected = "last_affected"
+	eventLimit        = "limit"
+)
+
+// contains evaluates events in the order of versions.
+// https://ossf.github.io/osv-schema/#evaluation
+func (r *Range) contains(v *version.Version) bool {
+	events, ok := r.sortedEvents()
+	if !ok {
+		return false
+	}
+	affected := false
+	for _, ev := range events {
+		switch ev.typ {
+		case eventIntroduced:
+			if ev.version == nil || v.GreaterThanOrEqual(ev.version) {
+				affected = true
+			}
+		case eventFixed:
+			if v.GreaterThanOrEqual(ev.version) {
+				affected = false
+			}
+		case eventLastAffected:
+			if v.GreaterThan(ev.version) {
+				affected = false
+			}
+		case eventLimit:
+			if v.GreaterThanOrEqual(ev.version) {
+				return false
+			}
+		}
+	}
+	return affected
+}
+
+// sortedEvents parses versions of events and sorts them.
+// The version of the introduced event "0" is nil, which means all versions.
+func (r *Range) sortedEvents() ([]*event, bool) {
+	events := make([]*event, 0, len(r.Events))
+	for _, ev := range r.Events {
+		typ, s := ev.get()
+		if typ == "" {
+			continue
+		}
+		if typ == eventIntroduced && s == "0" {
+			events = append(events, &event{typ: typ})
+			continue
+		}
+		sv, err := version.NewVersion(s)
+		if err != nil {
+			return nil, false
+		}
+		events = append(events, &event{typ: typ, version: sv})
+	}
+	slices.SortStableFunc(events, func(a, b *event) int {
+		switch {
+		case a.version == nil && b.version == nil:
+			return 0
+		case a.version == nil:
+			return -1
+		case b.version == nil:
+			return 1
+		}
+		return a.version.Compare(b.version)
+	})
+	return events, true
+}
+
+func (e *Event) get() (string, string) {
+	switch {
+	case e.Introduced != "":
+		return eventIntroduced, e.Introduced
+	case e.Fixed != "":
+		return eventFixed, e.Fixed
+	case e.LastAffected != "":
+		return eventLastAffected, e.LastAffected
+	case e.Limit != "":
+		return eventLimit, e.Limit
+	}
+	return "", ""
+}
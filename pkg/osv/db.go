@@ -0,0 +1,227 @@
+package osv
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// DB is an in-memory index of an OSV database.
+type DB struct {
+	index map[string][]*Vulnerability
+}
+
+// Read reads an OSV database from a directory or a zip file.
+// JSON files in the directory are read recursively.
+// Zip files distributed by osv.dev such as https://osv-vulnerabilities.storage.googleapis.com/Go/all.zip are supported.
+// Withdrawn vulnerabilities are ignored.
+func Read(p string) (*DB, error) {
+	finfo, err := os.Stat(p)
+	if err != nil {
+		return nil, fmt.Errorf("get the OSV database: %w", err)
+	}
+	db := &DB{
+		index: map[string][]*Vulnerability{},
+	}
+	if finfo.IsDir() {
+		if err := db.readDir(p); err != nil {
+			return nil, err
+		}
+		return db, nil
+	}
+	if err := db.readZip(p); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+func (db *DB) readDir(dir string) error {
+	if err := filepath.WalkDir(dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(p) != ".json" {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return fmt.Errorf("open a file: %w", err)
+		}
+		defer f.Close()
+		return db.add(f, p)
+	}); err != nil {
+		return fmt.Errorf("read the OSV database directory: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) readZip(p string) error {
+	r, err := zip.OpenReader(p)
+	if err != nil {
+		return fmt.Errorf("open the OSV database as zip: %w", err)
+	}
+	defer r.Close()
+	for _, file := range r.File {
+		if file.FileInfo().IsDir() || path.Ext(file.Name) != ".json" {
+			continue
+		}
+		if err := db.addZipFile(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *DB) addZipFile(file *zip.File) error {
+	f, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("open a file in zip: %w", slogerr.With(err, "file", file.Name))
+	}
+	defer f.Close()
+	return db.add(f, file.Name)
+}
+
+func (db *DB) add(r io.Reader, name string) error {
+	vuln := &Vulnerability{}
+	if err := json.NewDecoder(r).Decode(vuln); err != nil {
+		return fmt.Errorf("parse a vulnerability as JSON: %w", slogerr.With(err, "file", name))
+	}
+	if vuln.Withdrawn != "" {
+		return nil
+	}
+	added := map[string]struct{}{}
+	for _, affected := range vuln.Affected {
+		if affected.Package == nil {
+			continue
+		}
+		for _, key := range affected.Package.keys() {
+			if _, ok := added[key]; ok {
+				continue
+			}
+			added[key] = struct{}{}
+			db.index[key] = append(db.index[key], vuln)
+		}
+	}
+	return nil
+}
+
+// keys returns index keys of the package.
+// Package URLs are normalized by removing the version, qualifiers, and subpath.
+func (p *Package) keys() []string {
+	var keys []string
+	if p.Ecosystem != "" && p.Name != "" {
+		keys = append(keys, p.Ecosystem+":"+p.name())
+	}
+	if purl := normalizePURL(p.PURL); purl != "" {
+		keys = append(keys, purl)
+	}
+	return keys
+}
+
+func (p *Package) name() string {
+	// Names of crates are case insensitive.
+	if p.Ecosystem == EcosystemCrates {
+		return strings.ToLower(p.Name)
+	}
+	return p.Name
+}
+
+func normalizePURL(purl string) string {
+	if purl == "" {
+		return ""
+	}
+	purl, _, _ = strings.Cut(purl, "#")
+	purl, _, _ = strings.Cut(purl, "?")
+	if i := strings.LastIndex(purl, "@"); i != -1 {
+		purl = purl[:i]
+	}
+	if strings.HasPrefix(purl, "pkg:github/") {
+		return strings.ToLower(purl)
+	}
+	return purl
+}
+
+// Finding is a vulnerability affecting a package.
+type Finding struct {
+	Vulnerability *Vulnerability
+	Severity      string
+	Fixed         []string
+}
+
+// Find returns vulnerabilities affecting the given version of packages.
+// pkgs are identifiers of the same package, for instance, a Package URL and a Go module.
+// Vulnerabilities of parent modules of Go packages are also returned.
+func (db *DB) Find(pkgs []*Package, version string) []*Finding {
+	var findings []*Finding
+	found := map[string]struct{}{}
+	for _, pkg := range pkgs {
+		for _, key := range pkg.lookupKeys() {
+			for _, vuln := range db.index[key] {
+				if _, ok := found[vuln.ID]; ok {
+					continue
+				}
+				finding := vuln.find(pkg, version)
+				if finding == nil {
+					continue
+				}
+				found[vuln.ID] = struct{}{}
+				findings = append(findings, finding)
+			}
+		}
+	}
+	return findings
+}
+
+func (p *Package) lookupKeys() []string {
+	keys := p.keys()
+	if p.Ecosystem != EcosystemGo {
+		return keys
+	}
+	// A Go package path such as github.com/foo/bar/cmd/bar belongs to the module github.com/foo/bar.
+	for name := path.Dir(p.Name); strings.Contains(name, "/"); name = path.Dir(name) {
+		keys = append(keys, EcosystemGo+":"+name)
+	}
+	return keys
+}
+
+func (v *Vulnerability) find(pkg *Package, version string) *Finding {
+	var finding *Finding
+	for _, affected := range v.Affected {
+		if affected.Package == nil || !affected.Package.match(pkg) {
+			continue
+		}
+		ok, fixed := affected.Contains(version)
+		if !ok {
+			continue
+		}
+		if finding == nil {
+			finding = &Finding{
+				Vulnerability: v,
+				Severity:      v.severity(affected),
+			}
+		}
+		finding.Fixed = append(finding.Fixed, fixed...)
+	}
+	return finding
+}
+
+func (p *Package) match(pkg *Package) bool {
+	if purl := normalizePURL(p.PURL); purl != "" && purl == normalizePURL(pkg.PURL) {
+		return true
+	}
+	if p.Ecosystem == "" || p.Ecosystem != pkg.Ecosystem {
+		return false
+	}
+	if p.Ecosystem == EcosystemGo {
+		return pkg.Name == p.Name || strings.HasPrefix(pkg.Name, p.Name+"/")
+	}
+	return p.name() == pkg.name()
+}
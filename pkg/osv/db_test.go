@@ -0,0 +1,143 @@
+package osv_test
+
+import (
+	"archive/zip"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/osv"
+	"github.com/google/go-cmp/cmp"
+)
+
+type result struct {
+	ID       string
+	Severity string
+	Fixed    []string
+}
+
+// zipDB archives the test database into a zip file like osv.dev's all.zip.
+func zipDB(t *testing.T) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "all.zip")
+	f, err := os.Create(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+	if err := w.AddFS(os.DirFS(filepath.Join("testdata", "db"))); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestDB_Find(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name    string
+		pkgs    []*osv.Package
+		version string
+		exp     []*result
+	}{
+		{
+			name: "go module of a GitHub repository",
+			pkgs: []*osv.Package{
+				{PURL: "pkg:github/cli/cli"},
+				{Ecosystem: "Go", Name: "github.com/cli/cli/v2"},
+			},
+			version: "v2.40.0",
+			exp: []*result{
+				{ID: "GHSA-0001", Severity: "MEDIUM", Fixed: []string{"2.41.0"}},
+			},
+		},
+		{
+			name: "fixed",
+			pkgs: []*osv.Package{
+				{Ecosystem: "Go", Name: "github.com/cli/cli/v2"},
+			},
+			version: "v2.41.0",
+		},
+		{
+			name: "go package in a module",
+			pkgs: []*osv.Package{
+				{Ecosystem: "Go", Name: "golang.org/x/tools/gopls"},
+			},
+			version: "v0.5.1",
+			exp: []*result{
+				{ID: "GO-0001", Severity: "CRITICAL", Fixed: []string{"0.5.2"}},
+			},
+		},
+		{
+			name: "last_affected",
+			pkgs: []*osv.Package{
+				{Ecosystem: "Go", Name: "golang.org/x/tools/gopls"},
+			},
+			version: "v0.4.0",
+		},
+		{
+			name: "explicit version",
+			pkgs: []*osv.Package{
+				{PURL: "pkg:cargo/ripgrep@13.0.0"},
+			},
+			version: "13.0.0",
+			exp: []*result{
+				{ID: "RUSTSEC-0001", Severity: "UNKNOWN"},
+			},
+		},
+		{
+			name: "crate",
+			pkgs: []*osv.Package{
+				{Ecosystem: "crates.io", Name: "ripgrep"},
+			},
+			version: "14.1.0",
+			exp: []*result{
+				{ID: "RUSTSEC-0001", Severity: "UNKNOWN", Fixed: []string{"14.1.1"}},
+			},
+		},
+	}
+	dbs := map[string]string{
+		"dir": filepath.Join("testdata", "db"),
+		"zip": zipDB(t),
+	}
+	for dbType, dbPath := range dbs {
+		db, err := osv.Read(dbPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range data {
+			t.Run(dbType+"/"+d.name, func(t *testing.T) {
+				t.Parallel()
+				var results []*result
+				for _, finding := range db.Find(d.pkgs, d.version) {
+					results = append(results, &result{
+						ID:       finding.Vulnerability.ID,
+						Severity: finding.Severity,
+						Fixed:    finding.Fixed,
+					})
+				}
+				if diff := cmp.Diff(d.exp, results); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+	if _, err := osv.Read(filepath.Join("testdata", "not-found")); err == nil {
+		t.Fatal("error must be returned if the database doesn't exist")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), fs.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := osv.Read(dir); err == nil {
+		t.Fatal("error must be returned if the database is broken")
+	}
+}
@@ -0,0 +1,55 @@
+// Package osv reads vulnerability databases in the OSV format and finds vulnerabilities of packages.
+// https://ossf.github.io/osv-schema/
+package osv
+
+// Vulnerability is an entry of OSV databases.
+type Vulnerability struct {
+	ID               string         `json:"id"`
+	Summary          string         `json:"summary,omitempty"`
+	Aliases          []string       `json:"aliases,omitempty"`
+	Withdrawn        string         `json:"withdrawn,omitempty"`
+	Severity         []*Severity    `json:"severity,omitempty"`
+	Affected         []*Affected    `json:"affected,omitempty"`
+	DatabaseSpecific map[string]any `json:"database_specific,omitempty"`
+}
+
+// Severity is a severity score such as a CVSS vector.
+type Severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// Affected is a package affected by the vulnerability.
+type Affected struct {
+	Package          *Package       `json:"package"`
+	Severity         []*Severity    `json:"severity,omitempty"`
+	Ranges           []*Range       `json:"ranges,omitempty"`
+	Versions         []string       `json:"versions,omitempty"`
+	DatabaseSpecific map[string]any `json:"database_specific,omitempty"`
+}
+
+// Package identifies a package by the ecosystem and the name, or by the Package URL.
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	PURL      string `json:"purl,omitempty"`
+}
+
+// Range is a range of affected versions.
+type Range struct {
+	Type   string   `json:"type"`
+	Events []*Event `json:"events"`
+}
+
+// Event is an event in the timeline of a range.
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+const (
+	EcosystemGo     = "Go"
+	EcosystemCrates = "crates.io"
+)
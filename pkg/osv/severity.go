@@ -0,0 +1,155 @@
+package osv
+
+import (
+	"math"
+	"strings"
+)
+
+// Severities in ascending order.
+const (
+	SeverityUnknown  = "UNKNOWN"
+	SeverityLow      = "LOW"
+	SeverityMedium   = "MEDIUM"
+	SeverityHigh     = "HIGH"
+	SeverityCritical = "CRITICAL"
+)
+
+// SeverityLevel returns the level of the severity to compare severities.
+// Unknown severities are 0.
+func SeverityLevel(severity string) int {
+	switch strings.ToUpper(severity) {
+	case SeverityLow:
+		return 1
+	case SeverityMedium, "MODERATE":
+		return 2 //nolint:mnd
+	case SeverityHigh:
+		return 3 //nolint:mnd
+	case SeverityCritical:
+		return 4 //nolint:mnd
+	}
+	return 0
+}
+
+// severity returns the severity of the vulnerability.
+// The severity in database_specific such as GitHub Advisory Database is preferred.
+// Otherwise, the severity is calculated from the CVSS v3 vector.
+func (v *Vulnerability) severity(affected *Affected) string {
+	for _, m := range []map[string]any{affected.DatabaseSpecific, v.DatabaseSpecific} {
+		if s, ok := m["severity"].(string); ok {
+			if level := SeverityLevel(s); level != 0 {
+				return severityNames[level]
+			}
+		}
+	}
+	for _, severities := range [][]*Severity{affected.Severity, v.Severity} {
+		for _, s := range severities {
+			if s.Type != "CVSS_V3" {
+				continue
+			}
+			if score, ok := cvss3BaseScore(s.Score); ok {
+				return severityFromScore(score)
+			}
+		}
+	}
+	return SeverityUnknown
+}
+
+var severityNames = []string{SeverityUnknown, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical} //nolint:gochecknoglobals
+
+func severityFromScore(score float64) string {
+	switch {
+	case score >= 9: //nolint:mnd
+		return SeverityCritical
+	case score >= 7: //nolint:mnd
+		return SeverityHigh
+	case score >= 4: //nolint:mnd
+		return SeverityMedium
+	case score > 0:
+		return SeverityLow
+	}
+	return SeverityUnknown
+}
+
+var cvss3Weights = map[string]map[string]float64{ //nolint:gochecknoglobals
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"UI": {"N": 0.85, "R": 0.62},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// cvss3BaseScore calculates the base score of a CVSS v3 vector such as "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H".
+// https://www.first.org/cvss/v3.1/specification-document#7-4-Metric-Values
+func cvss3BaseScore(vector string) (float64, bool) { //nolint:cyclop
+	metrics := map[string]string{}
+	for i, s := range strings.Split(vector, "/") {
+		k, v, ok := strings.Cut(s, ":")
+		if !ok {
+			return 0, false
+		}
+		if i == 0 {
+			if k != "CVSS" || !strings.HasPrefix(v, "3.") {
+				return 0, false
+			}
+			continue
+		}
+		metrics[k] = v
+	}
+	w := map[string]float64{}
+	for k, weights := range cvss3Weights {
+		weight, ok := weights[metrics[k]]
+		if !ok {
+			return 0, false
+		}
+		w[k] = weight
+	}
+	changed := false
+	switch metrics["S"] {
+	case "U":
+	case "C":
+		changed = true
+	default:
+		return 0, false
+	}
+	var pr float64
+	switch metrics["PR"] {
+	case "N":
+		pr = 0.85
+	case "L":
+		pr = 0.62
+		if changed {
+			pr = 0.68
+		}
+	case "H":
+		pr = 0.27
+		if changed {
+			pr = 0.5
+		}
+	default:
+		return 0, false
+	}
+
+	iss := 1 - (1-w["C"])*(1-w["I"])*(1-w["A"])
+	impact := 6.42 * iss
+	if changed {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15) //nolint:mnd
+	}
+	if impact <= 0 {
+		return 0, true
+	}
+	exploitability := 8.22 * w["AV"] * w["AC"] * pr * w["UI"]
+	if changed {
+		return roundUp(math.Min(1.08*(impact+exploitability), 10)), true //nolint:mnd
+	}
+	return roundUp(math.Min(impact+exploitability, 10)), true //nolint:mnd
+}
+
+// roundUp returns the smallest number, specified to 1 decimal place, that is equal to or higher than its input.
+func roundUp(f float64) float64 {
+	i := int(math.Round(f * 100000)) //nolint:mnd
+	if i%10000 == 0 {
+		return float64(i) / 100000 //nolint:mnd
+	}
+	return float64(i/10000+1) / 10 //nolint:mnd
+}
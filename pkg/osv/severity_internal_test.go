@@ -0,0 +1,38 @@
+package osv
+
+import "testing"
+
+func TestCVSS3BaseScore(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		vector string
+		exp    float64
+		isErr  bool
+	}{
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", exp: 9.8},
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N", exp: 6.1},
+		{vector: "CVSS:3.0/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:N/A:N", exp: 5.5},
+		{vector: "CVSS:3.1/AV:N/AC:H/PR:H/UI:R/S:C/C:H/I:H/A:H", exp: 7.6},
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N", exp: 0},
+		{vector: "CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N", isErr: true},
+		{vector: "CVSS:3.1/AV:X/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", isErr: true},
+	}
+	for _, d := range data {
+		t.Run(d.vector, func(t *testing.T) {
+			t.Parallel()
+			score, ok := cvss3BaseScore(d.vector)
+			if !ok {
+				if d.isErr {
+					return
+				}
+				t.Fatal("the vector must be parsed")
+			}
+			if d.isErr {
+				t.Fatal("the vector must not be parsed")
+			}
+			if score != d.exp {
+				t.Fatalf("wanted %v, got %v", d.exp, score)
+			}
+		})
+	}
+}
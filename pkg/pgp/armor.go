@@ -0,0 +1,111 @@
+package pgp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	errInvalidArmor  = errors.New("the ASCII armor is invalid")
+	errArmorChecksum = errors.New("the checksum of the ASCII armor doesn't match")
+)
+
+// decode returns the binary packets of an ASCII-armored or binary OpenPGP message.
+// Concatenated ASCII-armored blocks such as KEYS files are also accepted.
+// https://www.rfc-editor.org/rfc/rfc4880#section-6.2
+func decode(b []byte) ([]byte, error) {
+	const beginPrefix = "-----BEGIN PGP "
+	if !bytes.HasPrefix(bytes.TrimSpace(b), []byte(beginPrefix)) {
+		return b, nil
+	}
+	var packets []byte
+	for {
+		idx := bytes.Index(b, []byte(beginPrefix))
+		if idx == -1 {
+			return packets, nil
+		}
+		p, rest, err := decodeArmor(b[idx:])
+		if err != nil {
+			return nil, err
+		}
+		packets = append(packets, p...)
+		b = rest
+	}
+}
+
+// decodeArmor decodes the first ASCII-armored block and returns the data after the block.
+func decodeArmor(b []byte) ([]byte, []byte, error) {
+	var body strings.Builder
+	var checksum string
+	inHeaders := true
+	// skip the header line
+	_, b, _ = bytes.Cut(b, []byte("\n"))
+	for len(b) != 0 {
+		var l []byte
+		l, b, _ = bytes.Cut(b, []byte("\n"))
+		line := strings.TrimSpace(string(l))
+		if inHeaders {
+			// Armor headers such as "Version: ..." continue until an empty line.
+			if line == "" {
+				inHeaders = false
+				continue
+			}
+			if strings.Contains(line, ": ") {
+				continue
+			}
+			// Some tools omit the empty line if there is no header.
+			inHeaders = false
+		}
+		if strings.HasPrefix(line, "-----END PGP ") {
+			p, err := decodeArmorBody(body.String(), checksum)
+			return p, b, err
+		}
+		if strings.HasPrefix(line, "=") && len(line) == 5 { //nolint:mnd
+			checksum = line[1:]
+			continue
+		}
+		body.WriteString(line)
+	}
+	return nil, nil, errInvalidArmor
+}
+
+func decodeArmorBody(body, checksum string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidArmor, err)
+	}
+	if checksum == "" {
+		return b, nil
+	}
+	expected, err := base64.StdEncoding.DecodeString(checksum)
+	if err != nil {
+		return nil, fmt.Errorf("%w: decode the checksum: %w", errInvalidArmor, err)
+	}
+	sum := crc24(b)
+	if !bytes.Equal(expected, []byte{byte(sum >> 16), byte(sum >> 8), byte(sum)}) { //nolint:mnd
+		return nil, errArmorChecksum
+	}
+	return b, nil
+}
+
+// crc24 computes the checksum of the ASCII armor.
+func crc24(b []byte) uint32 {
+	const (
+		init = 0xb704ce
+		poly = 0x1864cfb
+	)
+	crc := uint32(init)
+	for _, c := range b {
+		crc ^= uint32(c) << 16 //nolint:mnd
+		for range 8 {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= poly
+			}
+		}
+	}
+	return crc & 0xffffff //nolint:mnd
+}
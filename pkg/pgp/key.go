@@ -0,0 +1,193 @@
+package pgp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Public key algorithms.
+// https://www.rfc-editor.org/rfc/rfc9580#section-9.1
+const (
+	algoRSA         = 1
+	algoRSASignOnly = 3
+	algoECDSA       = 19
+	algoEdDSALegacy = 22
+	algoEd25519     = 27
+)
+
+//nolint:gochecknoglobals
+var (
+	oidP256           = []byte{0x2a, 0x86, 0x48, 0xce, 0x3d, 0x03, 0x01, 0x07}
+	oidP384           = []byte{0x2b, 0x81, 0x04, 0x00, 0x22}
+	oidP521           = []byte{0x2b, 0x81, 0x04, 0x00, 0x23}
+	oidEd25519        = []byte{0x2b, 0x06, 0x01, 0x04, 0x01, 0xda, 0x47, 0x0f, 0x01}
+	errUnsupportedKey = errors.New("the public key algorithm or version isn't supported")
+)
+
+// key is a primary key or a subkey.
+type key struct {
+	fingerprint []byte
+	body        []byte
+	algo        byte
+	publicKey   crypto.PublicKey
+	created     time.Time
+	// expires is zero if the key doesn't expire.
+	expires time.Time
+	// selfSigned is the creation time of the self-signature from which expires comes.
+	selfSigned time.Time
+	// err is set if the key can't be used to verify signatures.
+	err     error
+	revoked bool
+}
+
+// validAt returns true if the key was valid at t.
+func (k *key) validAt(t time.Time) bool {
+	return !t.Before(k.created) && (k.expires.IsZero() || t.Before(k.expires))
+}
+
+// applyExpiration sets the expiration time by the self-signature.
+// The latest self-signature takes precedence.
+func (k *key) applyExpiration(sig *signature) {
+	if sig.created.Before(k.selfSigned) {
+		return
+	}
+	k.selfSigned = sig.created
+	k.expires = time.Time{}
+	if sig.keyExpiration != nil && *sig.keyExpiration != 0 {
+		k.expires = k.created.Add(time.Duration(*sig.keyExpiration) * time.Second)
+	}
+}
+
+// Fingerprint returns the fingerprint in upper-case hex.
+func (k *key) Fingerprint() string {
+	return strings.ToUpper(hex.EncodeToString(k.fingerprint))
+}
+
+// keyID returns the last 8 bytes of the fingerprint.
+func (k *key) keyID() []byte {
+	return k.fingerprint[len(k.fingerprint)-8:]
+}
+
+// hashPrefix returns the data hashed for signatures over the key.
+func (k *key) hashPrefix() []byte {
+	b := []byte{0x99}                                         //nolint:mnd
+	b = binary.BigEndian.AppendUint16(b, uint16(len(k.body))) //nolint:gosec
+	return append(b, k.body...)
+}
+
+// parseKey parses a v4 public key packet.
+// https://www.rfc-editor.org/rfc/rfc4880#section-5.5.2
+func parseKey(body []byte) (*key, error) {
+	if len(body) < 6 || body[0] != 4 { //nolint:mnd
+		return nil, errUnsupportedKey
+	}
+	k := &key{
+		body:    body,
+		algo:    body[5],
+		created: time.Unix(int64(binary.BigEndian.Uint32(body[1:5])), 0),
+	}
+	sum := sha1.Sum(k.hashPrefix()) //nolint:gosec
+	k.fingerprint = sum[:]
+	pub, err := parseKeyMaterial(k.algo, body[6:])
+	if err != nil {
+		// Keys for encryption such as ECDH subkeys are kept to compute fingerprints.
+		k.err = err
+		return k, nil
+	}
+	k.publicKey = pub
+	return k, nil
+}
+
+func parseKeyMaterial(algo byte, b []byte) (crypto.PublicKey, error) {
+	switch algo {
+	case algoRSA, algoRSASignOnly:
+		n, rest, err := readMPI(b)
+		if err != nil {
+			return nil, err
+		}
+		e, _, err := readMPI(rest)
+		if err != nil {
+			return nil, err
+		}
+		if len(e) > 4 { //nolint:mnd
+			return nil, fmt.Errorf("%w: the RSA exponent is too large", errUnsupportedKey)
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case algoECDSA:
+		return parseECDSAKey(b)
+	case algoEdDSALegacy:
+		oid, rest, err := readOID(b)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(oid, oidEd25519) {
+			return nil, fmt.Errorf("%w: the curve isn't supported", errUnsupportedKey)
+		}
+		point, _, err := readMPI(rest)
+		if err != nil {
+			return nil, err
+		}
+		// The point is prefixed with 0x40.
+		if len(point) != 1+ed25519.PublicKeySize || point[0] != 0x40 {
+			return nil, fmt.Errorf("%w: the Ed25519 point is invalid", errUnsupportedKey)
+		}
+		return ed25519.PublicKey(point[1:]), nil
+	case algoEd25519:
+		if len(b) < ed25519.PublicKeySize {
+			return nil, errInvalidPacket
+		}
+		return ed25519.PublicKey(b[:ed25519.PublicKeySize]), nil
+	default:
+		return nil, fmt.Errorf("%w: algorithm %d", errUnsupportedKey, algo)
+	}
+}
+
+func parseECDSAKey(b []byte) (crypto.PublicKey, error) {
+	oid, rest, err := readOID(b)
+	if err != nil {
+		return nil, err
+	}
+	var curve elliptic.Curve
+	switch {
+	case bytes.Equal(oid, oidP256):
+		curve = elliptic.P256()
+	case bytes.Equal(oid, oidP384):
+		curve = elliptic.P384()
+	case bytes.Equal(oid, oidP521):
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("%w: the curve isn't supported", errUnsupportedKey)
+	}
+	point, _, err := readMPI(rest)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := ecdsa.ParseUncompressedPublicKey(curve, point)
+	if err != nil {
+		return nil, fmt.Errorf("%w: the ECDSA point is invalid: %w", errUnsupportedKey, err)
+	}
+	return pub, nil
+}
+
+func readOID(b []byte) ([]byte, []byte, error) {
+	if len(b) < 1 || len(b) < 1+int(b[0]) {
+		return nil, nil, errInvalidPacket
+	}
+	n := int(b[0])
+	return b[1 : 1+n], b[1+n:], nil
+}
@@ -0,0 +1,334 @@
+package pgp
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+var (
+	errNoPublicKey         = errors.New("no public key is found")
+	errFingerprintMismatch = errors.New("no public key matches the pinned fingerprints")
+	errInvalidFingerprint  = errors.New("the fingerprint must be 40 hex characters")
+	errNoSignature         = errors.New("no signature is found")
+	errUnknownSigner       = errors.New("the signature was created by a key which isn't trusted")
+	errNoBindingSignature  = errors.New("the subkey isn't bound to the primary key for signing")
+	errKeyExpired          = errors.New("the key had expired or wasn't valid yet when the signature was created")
+	errNoCreationTime      = errors.New("the signature has no creation time")
+)
+
+// entity is a primary key and its subkeys.
+type entity struct {
+	primary *key
+	subkeys []*key
+}
+
+// Keyring is a set of public keys.
+type Keyring struct {
+	entities []*entity
+}
+
+// ReadKeyring reads ASCII-armored or binary public keys.
+// Subkeys are used only if they are bound to the primary key for signing
+// and the binding signature has a back signature by the subkey.
+func ReadKeyring(b []byte) (*Keyring, error) {
+	b, err := decode(b)
+	if err != nil {
+		return nil, err
+	}
+	packets, err := readPackets(b)
+	if err != nil {
+		return nil, err
+	}
+	kr := &Keyring{}
+	var cur *entity
+	var subkey *key
+	var userID []byte
+	for _, p := range packets {
+		switch p.tag {
+		case tagPublicKey:
+			k, err := parseKey(p.body)
+			if err != nil {
+				return nil, err
+			}
+			cur = &entity{primary: k}
+			subkey = nil
+			userID = nil
+			kr.entities = append(kr.entities, cur)
+		case tagUserID:
+			userID = p.body
+		case tagUserAttribute:
+			userID = nil
+		case tagPublicSubkey:
+			if cur == nil {
+				return nil, fmt.Errorf("%w: a subkey without a primary key", errInvalidPacket)
+			}
+			k, err := parseKey(p.body)
+			if err != nil {
+				// Skip subkeys of unsupported versions.
+				subkey = nil
+				continue
+			}
+			// A subkey is used only after the binding signature is verified.
+			k.err = errors.Join(k.err, errNoBindingSignature)
+			subkey = k
+			userID = nil
+			cur.subkeys = append(cur.subkeys, k)
+		case tagSignature:
+			if cur != nil {
+				cur.applySignature(p.body, subkey, userID)
+			}
+		}
+	}
+	if len(kr.entities) == 0 {
+		return nil, errNoPublicKey
+	}
+	return kr, nil
+}
+
+// applySignature applies a self-signature, a revocation, or a binding signature by the primary key.
+// subkey is the last subkey before the signature, or nil if the signature is for the primary key.
+// userID is the last user ID before the signature.
+// Invalid signatures are ignored.
+func (e *entity) applySignature(body []byte, subkey *key, userID []byte) { //nolint:cyclop
+	sig, err := parseSignature(body)
+	if err != nil || e.primary.err != nil {
+		return
+	}
+	switch {
+	case subkey == nil && sig.sigType == sigTypeKeyRevocation:
+		h := sig.hash.New()
+		h.Write(e.primary.hashPrefix())
+		if sig.verify(e.primary, h) == nil {
+			e.primary.revoked = true
+		}
+	case subkey == nil && sig.sigType == sigTypeDirectKey:
+		h := sig.hash.New()
+		h.Write(e.primary.hashPrefix())
+		if sig.verify(e.primary, h) == nil {
+			e.primary.applyExpiration(sig)
+		}
+	case subkey == nil && userID != nil && sig.sigType >= sigTypeGenericCert && sig.sigType <= sigTypePositiveCert:
+		// Certifications by other keys fail to be verified and are ignored.
+		h := sig.hash.New()
+		h.Write(e.primary.hashPrefix())
+		h.Write(userIDPrefix(userID))
+		if sig.verify(e.primary, h) == nil {
+			e.primary.applyExpiration(sig)
+		}
+	case subkey != nil && (sig.sigType == sigTypeSubkeyBinding || sig.sigType == sigTypeSubkeyRevocation):
+		h := sig.hash.New()
+		h.Write(e.primary.hashPrefix())
+		h.Write(subkey.hashPrefix())
+		if sig.verify(e.primary, h) != nil {
+			return
+		}
+		if sig.sigType == sigTypeSubkeyRevocation {
+			subkey.revoked = true
+			return
+		}
+		if sig.keyFlags != nil && *sig.keyFlags&keyFlagSign == 0 {
+			return
+		}
+		if !e.verifyBackSignature(subkey, sig) {
+			return
+		}
+		subkey.applyExpiration(sig)
+		subkey.err = nil
+	}
+}
+
+// verifyBackSignature verifies the primary key binding signature embedded in the subkey binding signature.
+// Without it, anyone could bind someone else's signing key to their primary key.
+// https://www.rfc-editor.org/rfc/rfc4880#section-5.2.1
+func (e *entity) verifyBackSignature(subkey *key, sig *signature) bool {
+	if sig.embedded == nil || subkey.publicKey == nil {
+		return false
+	}
+	back, err := parseSignature(sig.embedded)
+	if err != nil || back.sigType != sigTypePrimaryKeyBinding {
+		return false
+	}
+	h := back.hash.New()
+	h.Write(e.primary.hashPrefix())
+	h.Write(subkey.hashPrefix())
+	return back.verify(subkey, h) == nil
+}
+
+// userIDPrefix returns the data hashed for certifications over the user ID.
+func userIDPrefix(userID []byte) []byte {
+	b := []byte{0xb4}                                         //nolint:mnd
+	b = binary.BigEndian.AppendUint32(b, uint32(len(userID))) //nolint:gosec
+	return append(b, userID...)
+}
+
+// Pin returns a keyring with only entities whose primary key fingerprints are in fingerprints.
+// Spaces in fingerprints are ignored.
+func (kr *Keyring) Pin(fingerprints []string) (*Keyring, error) {
+	pinned := make([]string, len(fingerprints))
+	for i, fp := range fingerprints {
+		fp = strings.ToUpper(strings.ReplaceAll(strings.TrimPrefix(fp, "0x"), " ", ""))
+		if b, err := hex.DecodeString(fp); err != nil || len(b) != 20 { //nolint:mnd
+			return nil, slogerr.With(errInvalidFingerprint, "fingerprint", fingerprints[i]) //nolint:wrapcheck
+		}
+		pinned[i] = fp
+	}
+	filtered := &Keyring{}
+	for _, e := range kr.entities {
+		if slices.Contains(pinned, e.primary.Fingerprint()) {
+			filtered.entities = append(filtered.entities, e)
+		}
+	}
+	if len(filtered.entities) == 0 {
+		return nil, errFingerprintMismatch
+	}
+	return filtered, nil
+}
+
+// signingKeys returns keys which can verify the signature.
+// Keys must be valid when the signature was created, and so must the primary key of subkeys.
+// expired is true if a key matches the issuer but isn't valid then.
+func (kr *Keyring) signingKeys(sig *signature) ([]*key, bool) {
+	var keys []*key
+	expired := false
+	for _, e := range kr.entities {
+		if e.primary.revoked {
+			continue
+		}
+		for _, k := range append([]*key{e.primary}, e.subkeys...) {
+			if k.err != nil || k.revoked {
+				continue
+			}
+			if !matchIssuer(sig, k) {
+				continue
+			}
+			if !k.validAt(sig.created) || !e.primary.validAt(sig.created) {
+				expired = true
+				continue
+			}
+			keys = append(keys, k)
+		}
+	}
+	return keys, expired
+}
+
+func matchIssuer(sig *signature, k *key) bool {
+	if sig.issuerFingerprint != nil {
+		return bytes.Equal(sig.issuerFingerprint, k.fingerprint)
+	}
+	// Signatures without an issuer are tried with all keys.
+	return sig.issuerKeyID == nil || bytes.Equal(sig.issuerKeyID, k.keyID())
+}
+
+// candidate is a pair of a signature and a key which may verify it.
+type candidate struct {
+	sig  *signature
+	key  *key
+	hash hash.Hash
+}
+
+// Verify verifies the detached signature of data read from r.
+// It returns the fingerprint of the key which created the signature.
+// If the signature file has multiple signatures, one valid signature is enough.
+func (kr *Keyring) Verify(r io.Reader, sigFile []byte) (string, error) {
+	b, err := decode(sigFile)
+	if err != nil {
+		return "", err
+	}
+	packets, err := readPackets(b)
+	if err != nil {
+		return "", err
+	}
+	var candidates []*candidate
+	var lastErr error
+	for _, p := range packets {
+		if p.tag != tagSignature {
+			continue
+		}
+		sig, err := parseSignature(p.body)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if sig.sigType != sigTypeBinary && sig.sigType != sigTypeText {
+			continue
+		}
+		if sig.hash == crypto.SHA1 {
+			lastErr = errWeakHash
+			continue
+		}
+		if sig.created.IsZero() {
+			lastErr = errNoCreationTime
+			continue
+		}
+		keys, expired := kr.signingKeys(sig)
+		for _, k := range keys {
+			candidates = append(candidates, &candidate{sig: sig, key: k, hash: sig.hash.New()})
+		}
+		if len(candidates) == 0 {
+			err := errUnknownSigner
+			if expired {
+				err = errKeyExpired
+			}
+			lastErr = slogerr.With(err,
+				"issuer_key_id", strings.ToUpper(hex.EncodeToString(sig.issuerKeyID)),
+				"signature_created_at", sig.created.UTC().Format(time.RFC3339))
+		}
+	}
+	if len(candidates) == 0 {
+		if lastErr != nil {
+			return "", lastErr
+		}
+		return "", errNoSignature
+	}
+	writers := make([]io.Writer, len(candidates))
+	for i, c := range candidates {
+		writers[i] = c.hash
+		if c.sig.sigType == sigTypeText {
+			writers[i] = &textWriter{w: c.hash}
+		}
+	}
+	if _, err := io.Copy(io.MultiWriter(writers...), r); err != nil {
+		return "", fmt.Errorf("read a signed file: %w", err)
+	}
+	for _, c := range candidates {
+		if err := c.sig.verify(c.key, c.hash); err != nil {
+			lastErr = err
+			continue
+		}
+		return c.key.Fingerprint(), nil
+	}
+	return "", lastErr
+}
+
+// textWriter converts line endings to CRLF for text signatures.
+// https://www.rfc-editor.org/rfc/rfc4880#section-5.2.4
+type textWriter struct {
+	w    io.Writer
+	last byte
+}
+
+func (t *textWriter) Write(p []byte) (int, error) {
+	var buf bytes.Buffer
+	for _, c := range p {
+		if c == '\n' && t.last != '\r' {
+			buf.WriteByte('\r')
+		}
+		buf.WriteByte(c)
+		t.last = c
+	}
+	if _, err := t.w.Write(buf.Bytes()); err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+	return len(p), nil
+}
@@ -0,0 +1,236 @@
+package pgp_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/pgp"
+)
+
+// The test data was generated by GnuPG.
+// rsa.asc has a certification-only primary key and a signing subkey.
+// rsa-revoked-subkey.asc is the same key whose signing subkey is revoked.
+// rsa-no-backsig.gpg is the same key whose subkey binding signature has no back signature.
+// ed25519-expired.asc is a key which expired a day after it was created,
+// and ed25519-expired-subkey.asc has a signing subkey which expired a day after it was created.
+// hello.txt.expired-*-before.asc were signed before the keys expired, and hello.txt.expired-*-after.asc after.
+const (
+	fingerprintEd25519 = "B5E4BBA02CC4801F184C6A1137BAEEBF07A584FA"
+	fingerprintRSA     = "5182D94839A72D32CEFBC8118C274A045F1171F0"
+	fingerprintP256    = "777EF24BB811F4A0B71FEF67B3EF61B94906A0F7"
+	fingerprintRSASub  = "2CB1CE7213E0CB51C26E021481DBE4F92C35DDD6"
+	fingerprintExpired = "4E514F34F37C9D0302022F4E24685104A2BB77BF"
+	fingerprintExpSub  = "16765859E2C01F53D40DF5A2E6A064B26343DBB8"
+)
+
+func readTestData(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func breakArmorChecksum(b []byte) []byte {
+	i := bytes.LastIndex(b, []byte("\n=")) + 2 //nolint:mnd
+	copy(b[i:i+4], "AAAA")
+	return b
+}
+
+func TestKeyring_Verify(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name         string
+		keys         []string
+		fingerprints []string
+		signature    string
+		file         string
+		exp          string
+		isErr        bool
+	}{
+		{
+			name:      "ed25519",
+			keys:      []string{"ed25519.asc"},
+			signature: "hello.txt.ed25519.asc",
+			exp:       fingerprintEd25519,
+		},
+		{
+			name:      "rsa subkey with a binary signature",
+			keys:      []string{"rsa.asc"},
+			signature: "hello.txt.rsa.sig",
+			exp:       fingerprintRSASub,
+		},
+		{
+			name:      "p256 binary key",
+			keys:      []string{"p256.gpg"},
+			signature: "hello.txt.p256.asc",
+			exp:       fingerprintP256,
+		},
+		{
+			name:      "text signature",
+			keys:      []string{"ed25519.asc"},
+			signature: "hello.txt.text.asc",
+			exp:       fingerprintEd25519,
+		},
+		{
+			name:         "multiple keys with a pinned fingerprint",
+			keys:         []string{"ed25519.asc", "rsa.asc"},
+			fingerprints: []string{"5182 D948 39A7 2D32 CEFB  C811 8C27 4A04 5F11 71F0"},
+			signature:    "hello.txt.rsa.sig",
+			exp:          fingerprintRSASub,
+		},
+		{
+			name:         "the signer isn't pinned",
+			keys:         []string{"ed25519.asc", "rsa.asc"},
+			fingerprints: []string{fingerprintRSA},
+			signature:    "hello.txt.ed25519.asc",
+			isErr:        true,
+		},
+		{
+			name:         "no key matches the fingerprint",
+			keys:         []string{"ed25519.asc"},
+			fingerprints: []string{fingerprintP256},
+			signature:    "hello.txt.ed25519.asc",
+			isErr:        true,
+		},
+		{
+			name:      "other key",
+			keys:      []string{"p256.gpg"},
+			signature: "hello.txt.ed25519.asc",
+			isErr:     true,
+		},
+		{
+			name:      "revoked subkey",
+			keys:      []string{"rsa-revoked-subkey.asc"},
+			signature: "hello.txt.rsa.sig",
+			isErr:     true,
+		},
+		{
+			name:      "subkey without a back signature",
+			keys:      []string{"rsa-no-backsig.gpg"},
+			signature: "hello.txt.rsa.sig",
+			isErr:     true,
+		},
+		{
+			name:      "signed before the key expired",
+			keys:      []string{"ed25519-expired.asc"},
+			signature: "hello.txt.expired-before.asc",
+			exp:       fingerprintExpired,
+		},
+		{
+			name:      "signed after the key expired",
+			keys:      []string{"ed25519-expired.asc"},
+			signature: "hello.txt.expired-after.asc",
+			isErr:     true,
+		},
+		{
+			name:      "signed before the subkey expired",
+			keys:      []string{"ed25519-expired-subkey.asc"},
+			signature: "hello.txt.expired-subkey-before.asc",
+			exp:       fingerprintExpSub,
+		},
+		{
+			name:      "signed after the subkey expired",
+			keys:      []string{"ed25519-expired-subkey.asc"},
+			signature: "hello.txt.expired-subkey-after.asc",
+			isErr:     true,
+		},
+		{
+			name:      "sha1",
+			keys:      []string{"ed25519.asc"},
+			signature: "hello.txt.sha1.asc",
+			isErr:     true,
+		},
+		{
+			name:      "tampered file",
+			keys:      []string{"ed25519.asc"},
+			signature: "hello.txt.ed25519.asc",
+			file:      "hello\nworld!\n",
+			isErr:     true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			var keys []byte
+			for _, k := range d.keys {
+				keys = append(keys, readTestData(t, k)...)
+			}
+			kr, err := pgp.ReadKeyring(keys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.fingerprints != nil {
+				kr, err = kr.Pin(d.fingerprints)
+				if err != nil {
+					if d.isErr {
+						return
+					}
+					t.Fatal(err)
+				}
+			}
+			file := readTestData(t, "hello.txt")
+			if d.file != "" {
+				file = []byte(d.file)
+			}
+			fingerprint, err := kr.Verify(bytes.NewReader(file), readTestData(t, d.signature))
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if fingerprint != d.exp {
+				t.Fatalf("wanted %s, got %s", d.exp, fingerprint)
+			}
+		})
+	}
+}
+
+func TestReadKeyring(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name  string
+		key   []byte
+		isErr bool
+	}{
+		{
+			name: "armored",
+			key:  readTestData(t, "rsa.asc"),
+		},
+		{
+			name: "binary",
+			key:  readTestData(t, "p256.gpg"),
+		},
+		{
+			name:  "broken checksum",
+			key:   breakArmorChecksum(readTestData(t, "ed25519.asc")),
+			isErr: true,
+		},
+		{
+			name:  "signature",
+			key:   readTestData(t, "hello.txt.ed25519.asc"),
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := pgp.ReadKeyring(d.key); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+		})
+	}
+}
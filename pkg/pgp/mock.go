@@ -0,0 +1,19 @@
+package pgp
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/download"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/template"
+)
+
+type MockVerifier struct {
+	err error
+}
+
+func (m *MockVerifier) Verify(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, p *registry.PGP, art *template.Artifact, file *download.File, param *ParamVerify) error {
+	return m.err
+}
@@ -0,0 +1,121 @@
+package pgp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Packet tags.
+// https://www.rfc-editor.org/rfc/rfc4880#section-4.3
+const (
+	tagSignature     = 2
+	tagPublicKey     = 6
+	tagUserID        = 13
+	tagPublicSubkey  = 14
+	tagUserAttribute = 17
+)
+
+var errInvalidPacket = errors.New("the OpenPGP packet is invalid")
+
+type packet struct {
+	tag  byte
+	body []byte
+}
+
+// readPackets splits binary OpenPGP data into packets.
+// https://www.rfc-editor.org/rfc/rfc4880#section-4.2
+func readPackets(b []byte) ([]*packet, error) {
+	var packets []*packet
+	for len(b) != 0 {
+		p, rest, err := readPacket(b)
+		if err != nil {
+			return nil, err
+		}
+		packets = append(packets, p)
+		b = rest
+	}
+	return packets, nil
+}
+
+func readPacket(b []byte) (*packet, []byte, error) {
+	header := b[0]
+	if header&0x80 == 0 {
+		return nil, nil, fmt.Errorf("%w: the packet header is invalid", errInvalidPacket)
+	}
+	if header&0x40 != 0 {
+		return readNewFormatPacket(header&0x3f, b[1:]) //nolint:mnd
+	}
+	return readOldFormatPacket((header>>2)&0x0f, header&0x03, b[1:]) //nolint:mnd
+}
+
+func readOldFormatPacket(tag, lengthType byte, b []byte) (*packet, []byte, error) {
+	var n int
+	switch lengthType {
+	case 0:
+		if len(b) < 1 {
+			return nil, nil, errInvalidPacket
+		}
+		n, b = int(b[0]), b[1:]
+	case 1:
+		if len(b) < 2 { //nolint:mnd
+			return nil, nil, errInvalidPacket
+		}
+		n, b = int(binary.BigEndian.Uint16(b)), b[2:]
+	case 2: //nolint:mnd
+		if len(b) < 4 { //nolint:mnd
+			return nil, nil, errInvalidPacket
+		}
+		n, b = int(binary.BigEndian.Uint32(b)), b[4:]
+	default:
+		// The packet extends to the end of the data.
+		n = len(b)
+	}
+	return splitBody(tag, n, b)
+}
+
+func readNewFormatPacket(tag byte, b []byte) (*packet, []byte, error) {
+	if len(b) < 1 {
+		return nil, nil, errInvalidPacket
+	}
+	var n int
+	switch first := int(b[0]); {
+	case first < 192: //nolint:mnd
+		n, b = first, b[1:]
+	case first < 224: //nolint:mnd
+		if len(b) < 2 { //nolint:mnd
+			return nil, nil, errInvalidPacket
+		}
+		n, b = (first-192)<<8+int(b[1])+192, b[2:] //nolint:mnd
+	case first == 255: //nolint:mnd
+		if len(b) < 5 { //nolint:mnd
+			return nil, nil, errInvalidPacket
+		}
+		n, b = int(binary.BigEndian.Uint32(b[1:])), b[5:]
+	default:
+		// Partial body lengths are used only for streamed data such as literal data.
+		return nil, nil, fmt.Errorf("%w: partial body lengths aren't supported", errInvalidPacket)
+	}
+	return splitBody(tag, n, b)
+}
+
+func splitBody(tag byte, n int, b []byte) (*packet, []byte, error) {
+	if n < 0 || len(b) < n {
+		return nil, nil, fmt.Errorf("%w: the packet is truncated", errInvalidPacket)
+	}
+	return &packet{tag: tag, body: b[:n]}, b[n:], nil
+}
+
+// readMPI reads a multiprecision integer.
+// https://www.rfc-editor.org/rfc/rfc4880#section-3.2
+func readMPI(b []byte) ([]byte, []byte, error) {
+	if len(b) < 2 { //nolint:mnd
+		return nil, nil, errInvalidPacket
+	}
+	n := (int(binary.BigEndian.Uint16(b)) + 7) / 8 //nolint:mnd
+	b = b[2:]
+	if len(b) < n {
+		return nil, nil, errInvalidPacket
+	}
+	return b[:n], b[n:], nil
+}
@@ -0,0 +1,249 @@
+package pgp
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	_ "crypto/sha1" //nolint:gosec
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"time"
+)
+
+// Signature types.
+// https://www.rfc-editor.org/rfc/rfc4880#section-5.2.1
+const (
+	sigTypeBinary            = 0x00
+	sigTypeText              = 0x01
+	sigTypeGenericCert       = 0x10
+	sigTypePositiveCert      = 0x13
+	sigTypeSubkeyBinding     = 0x18
+	sigTypePrimaryKeyBinding = 0x19
+	sigTypeDirectKey         = 0x1f
+	sigTypeKeyRevocation     = 0x20
+	sigTypeSubkeyRevocation  = 0x28
+	subpacketCreationTime    = 2
+	subpacketKeyExpiration   = 9
+	subpacketIssuer          = 16
+	subpacketKeyFlags        = 27
+	subpacketIssuerFprint    = 33
+	subpacketEmbeddedSig     = 32
+	keyFlagSign              = 0x02
+	ed25519ComponentSize     = 32
+	signatureVersion         = 4
+	signatureHashedPartStart = 6
+)
+
+var (
+	errUnsupportedSignature = errors.New("the signature version or algorithm isn't supported")
+	errInvalidSignature     = errors.New("the signature is invalid")
+	errWeakHash             = errors.New("SHA-1 isn't accepted for signatures over files")
+)
+
+// signature is a v4 signature packet.
+// https://www.rfc-editor.org/rfc/rfc4880#section-5.2.3
+type signature struct {
+	sigType           byte
+	algo              byte
+	hash              crypto.Hash
+	hashedPart        []byte
+	left16            []byte
+	issuerKeyID       []byte
+	issuerFingerprint []byte
+	keyFlags          *byte
+	created           time.Time
+	keyExpiration     *uint32
+	embedded          []byte
+	mpis              [][]byte
+	raw               []byte
+}
+
+func parseSignature(body []byte) (*signature, error) {
+	if len(body) < signatureHashedPartStart || body[0] != signatureVersion {
+		return nil, errUnsupportedSignature
+	}
+	sig := &signature{
+		sigType: body[1],
+		algo:    body[2],
+	}
+	h, err := hashAlgorithm(body[3])
+	if err != nil {
+		return nil, err
+	}
+	sig.hash = h
+	hashedLen := int(binary.BigEndian.Uint16(body[4:]))
+	if len(body) < signatureHashedPartStart+hashedLen+2 { //nolint:mnd
+		return nil, errInvalidPacket
+	}
+	sig.hashedPart = body[:signatureHashedPartStart+hashedLen]
+	if err := sig.parseSubpackets(body[signatureHashedPartStart:signatureHashedPartStart+hashedLen], true); err != nil {
+		return nil, err
+	}
+	rest := body[signatureHashedPartStart+hashedLen:]
+	unhashedLen := int(binary.BigEndian.Uint16(rest))
+	rest = rest[2:]
+	if len(rest) < unhashedLen+2 { //nolint:mnd
+		return nil, errInvalidPacket
+	}
+	// The issuer is often in the unhashed area. It's only a hint to find the key.
+	if err := sig.parseSubpackets(rest[:unhashedLen], false); err != nil {
+		return nil, err
+	}
+	rest = rest[unhashedLen:]
+	sig.left16 = rest[:2]
+	rest = rest[2:]
+	if sig.algo == algoEd25519 {
+		if len(rest) < ed25519.SignatureSize {
+			return nil, errInvalidPacket
+		}
+		sig.raw = rest[:ed25519.SignatureSize]
+		return sig, nil
+	}
+	for len(rest) != 0 {
+		mpi, r, err := readMPI(rest)
+		if err != nil {
+			return nil, err
+		}
+		sig.mpis = append(sig.mpis, mpi)
+		rest = r
+	}
+	return sig, nil
+}
+
+func hashAlgorithm(id byte) (crypto.Hash, error) {
+	switch id {
+	case 2: //nolint:mnd
+		return crypto.SHA1, nil
+	case 8: //nolint:mnd
+		return crypto.SHA256, nil
+	case 9: //nolint:mnd
+		return crypto.SHA384, nil
+	case 10: //nolint:mnd
+		return crypto.SHA512, nil
+	case 11: //nolint:mnd
+		return crypto.SHA224, nil
+	default:
+		return 0, fmt.Errorf("%w: hash algorithm %d", errUnsupportedSignature, id)
+	}
+}
+
+// parseSubpackets reads subpackets the verifier uses.
+// https://www.rfc-editor.org/rfc/rfc4880#section-5.2.3.1
+func (s *signature) parseSubpackets(b []byte, hashed bool) error {
+	for len(b) != 0 {
+		var n int
+		switch first := int(b[0]); {
+		case first < 192: //nolint:mnd
+			n, b = first, b[1:]
+		case first < 255: //nolint:mnd
+			if len(b) < 2 { //nolint:mnd
+				return errInvalidPacket
+			}
+			n, b = (first-192)<<8+int(b[1])+192, b[2:] //nolint:mnd
+		default:
+			if len(b) < 5 { //nolint:mnd
+				return errInvalidPacket
+			}
+			n, b = int(binary.BigEndian.Uint32(b[1:])), b[5:]
+		}
+		if n < 1 || len(b) < n {
+			return errInvalidPacket
+		}
+		typ, data := b[0]&0x7f, b[1:n] //nolint:mnd
+		b = b[n:]
+		switch typ {
+		case subpacketIssuer:
+			if len(data) == 8 { //nolint:mnd
+				s.issuerKeyID = data
+			}
+		case subpacketIssuerFprint:
+			if len(data) == 21 && data[0] == 4 { //nolint:mnd
+				s.issuerFingerprint = data[1:]
+			}
+		case subpacketKeyFlags:
+			// Key flags are trusted only in the hashed area.
+			if hashed && len(data) != 0 {
+				s.keyFlags = &data[0]
+			}
+		case subpacketCreationTime:
+			if hashed && len(data) == 4 { //nolint:mnd
+				s.created = time.Unix(int64(binary.BigEndian.Uint32(data)), 0)
+			}
+		case subpacketKeyExpiration:
+			if hashed && len(data) == 4 { //nolint:mnd
+				exp := binary.BigEndian.Uint32(data)
+				s.keyExpiration = &exp
+			}
+		case subpacketEmbeddedSig:
+			// The embedded signature is verified by itself, so it may be in the unhashed area.
+			s.embedded = data
+		}
+	}
+	return nil
+}
+
+// verify verifies the signature with the hash to which the signed data has been written.
+func (s *signature) verify(k *key, h hash.Hash) error {
+	if k.algo != s.algo && !(k.algo == algoRSASignOnly && s.algo == algoRSA) {
+		return errInvalidSignature
+	}
+	h.Write(s.hashedPart)
+	trailer := []byte{signatureVersion, 0xff}                                   //nolint:mnd
+	trailer = binary.BigEndian.AppendUint32(trailer, uint32(len(s.hashedPart))) //nolint:gosec
+	h.Write(trailer)
+	digest := h.Sum(nil)
+	if digest[0] != s.left16[0] || digest[1] != s.left16[1] {
+		return errInvalidSignature
+	}
+	return verifyDigest(k.publicKey, s, digest)
+}
+
+func verifyDigest(pub crypto.PublicKey, s *signature, digest []byte) error {
+	switch pk := pub.(type) {
+	case *rsa.PublicKey:
+		if len(s.mpis) != 1 {
+			return errInvalidSignature
+		}
+		sig := make([]byte, pk.Size())
+		if len(s.mpis[0]) > len(sig) {
+			return errInvalidSignature
+		}
+		copy(sig[len(sig)-len(s.mpis[0]):], s.mpis[0])
+		if err := rsa.VerifyPKCS1v15(pk, s.hash, digest, sig); err != nil {
+			return errInvalidSignature
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if len(s.mpis) != 2 { //nolint:mnd
+			return errInvalidSignature
+		}
+		if !ecdsa.Verify(pk, digest, new(big.Int).SetBytes(s.mpis[0]), new(big.Int).SetBytes(s.mpis[1])) {
+			return errInvalidSignature
+		}
+		return nil
+	case ed25519.PublicKey:
+		sig := s.raw
+		if sig == nil {
+			// EdDSA (legacy) stores R and S as MPIs, which drop leading zeros.
+			if len(s.mpis) != 2 || len(s.mpis[0]) > ed25519ComponentSize || len(s.mpis[1]) > ed25519ComponentSize { //nolint:mnd
+				return errInvalidSignature
+			}
+			sig = make([]byte, ed25519.SignatureSize)
+			copy(sig[ed25519ComponentSize-len(s.mpis[0]):ed25519ComponentSize], s.mpis[0])
+			copy(sig[ed25519.SignatureSize-len(s.mpis[1]):], s.mpis[1])
+		}
+		// OpenPGP signs the digest with Ed25519.
+		if !ed25519.Verify(pk, digest, sig) {
+			return errInvalidSignature
+		}
+		return nil
+	default:
+		return errUnsupportedKey
+	}
+}
@@ -0,0 +1,101 @@
+package pgp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/download"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/template"
+)
+
+var (
+	errPublicKeyRequired    = errors.New("either public_key or public_key_file is required")
+	errFingerprintsRequired = errors.New("fingerprints are required if the public key is downloaded with public_key_file")
+)
+
+type Verifier struct {
+	downloader download.ClientAPI
+}
+
+func New(downloader download.ClientAPI) *Verifier {
+	return &Verifier{
+		downloader: downloader,
+	}
+}
+
+type ParamVerify struct {
+	ArtifactPath string
+}
+
+// Verify verifies a file with an OpenPGP detached signature natively, so gpg isn't needed.
+// A public key downloaded with public_key_file must be pinned by fingerprints,
+// because anyone who can replace the signature can also replace the public key.
+func (v *Verifier) Verify(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, p *registry.PGP, art *template.Artifact, file *download.File, param *ParamVerify) error {
+	if p.PublicKey == "" && p.PublicKeyFile != nil && len(p.Fingerprints) == 0 {
+		return errFingerprintsRequired
+	}
+	kr, err := v.readKeyring(ctx, logger, rt, p, art, file)
+	if err != nil {
+		return err
+	}
+	if len(p.Fingerprints) != 0 {
+		kr, err = kr.Pin(p.Fingerprints)
+		if err != nil {
+			return err
+		}
+	}
+	sig, err := v.download(ctx, logger, rt, p.ToDownloadedFile(), art, file)
+	if err != nil {
+		return fmt.Errorf("download an OpenPGP signature: %w", err)
+	}
+	f, err := os.Open(param.ArtifactPath)
+	if err != nil {
+		return fmt.Errorf("open a verified file: %w", err)
+	}
+	defer f.Close()
+	fingerprint, err := kr.Verify(f, sig)
+	if err != nil {
+		return err
+	}
+	logger.Debug("verified a file with OpenPGP", "fingerprint", fingerprint)
+	return nil
+}
+
+func (v *Verifier) readKeyring(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, p *registry.PGP, art *template.Artifact, file *download.File) (*Keyring, error) {
+	if p.PublicKey != "" {
+		return ReadKeyring([]byte(p.PublicKey))
+	}
+	if p.PublicKeyFile == nil {
+		return nil, errPublicKeyRequired
+	}
+	b, err := v.download(ctx, logger, rt, p.PublicKeyFile, art, file)
+	if err != nil {
+		return nil, fmt.Errorf("download an OpenPGP public key: %w", err)
+	}
+	return ReadKeyring(b)
+}
+
+func (v *Verifier) download(ctx context.Context, logger *slog.Logger, rt *runtime.Runtime, df *registry.DownloadedFile, art *template.Artifact, file *download.File) ([]byte, error) {
+	f, err := download.ConvertDownloadedFileToFile(df, file, rt, art)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	rc, _, err := v.downloader.ReadCloser(ctx, logger, f)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	defer rc.Close()
+
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("read a downloaded file: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,28 @@
+package pgp_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/pgp"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/template"
+)
+
+func TestVerifier_Verify(t *testing.T) {
+	t.Parallel()
+	// A downloaded public key must be pinned, so the verification fails before downloading anything.
+	v := pgp.New(nil)
+	err := v.Verify(t.Context(), slog.New(slog.DiscardHandler), &runtime.Runtime{}, &registry.PGP{
+		Type:  "github_release",
+		Asset: new("checksums.txt.asc"),
+		PublicKeyFile: &registry.DownloadedFile{
+			Type:  "github_release",
+			Asset: new("KEYS"),
+		},
+	}, &template.Artifact{}, nil, &pgp.ParamVerify{})
+	if err == nil {
+		t.Fatal("public_key_file without fingerprints must be rejected")
+	}
+}
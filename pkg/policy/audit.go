@@ -0,0 +1,120 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+)
+
+// Outcomes of policy decisions.
+const (
+	OutcomeAllow = "allow"
+	OutcomeDeny  = "deny"
+)
+
+// Decision is a result of the evaluation of policies for a package.
+// Decisions are recorded in the audit log as newline-delimited JSON.
+type Decision struct {
+	Time          time.Time `json:"time"`
+	Command       string    `json:"command,omitempty"`
+	Package       string    `json:"package"`
+	Version       string    `json:"version"`
+	Registry      string    `json:"registry"`
+	PolicyFile    string    `json:"policy_file,omitempty"`
+	DefaultPolicy bool      `json:"default_policy,omitempty"`
+	Rule          string    `json:"rule,omitempty"`
+	Outcome       string    `json:"outcome"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// AuditLog records policy decisions.
+// A nil AuditLog discards decisions, so callers don't need to check if the audit log is enabled.
+type AuditLog struct {
+	path    string
+	command string
+	mutex   sync.Mutex
+	w       io.Writer
+	now     func() time.Time
+}
+
+// NewAuditLog returns an AuditLog appending decisions to the file path.
+// command is the aqua command evaluating policies such as "install" and "exec".
+// If path is empty, NewAuditLog returns nil.
+func NewAuditLog(path, command string) *AuditLog {
+	if path == "" {
+		return nil
+	}
+	return &AuditLog{
+		path:    path,
+		command: command,
+		now:     time.Now,
+	}
+}
+
+// NewAuditLogWriter returns an AuditLog writing decisions to w.
+func NewAuditLogWriter(w io.Writer, command string, now func() time.Time) *AuditLog {
+	if now == nil {
+		now = time.Now
+	}
+	return &AuditLog{
+		w:       w,
+		command: command,
+		now:     now,
+	}
+}
+
+// AuditLogPath returns the path of the audit log from the value of AQUA_POLICY_AUDIT_LOG.
+// If the value is "true", the audit log is written to policy-audit.jsonl under the root directory.
+func AuditLogPath(env, rootDir, cwd string) string {
+	switch env {
+	case "", "false":
+		return ""
+	case "true":
+		return filepath.Join(rootDir, "policy-audit.jsonl")
+	}
+	return osfile.Abs(cwd, env)
+}
+
+// Record writes a decision.
+// Time and Command are set by Record.
+// Errors are returned but callers can ignore them because the audit log must not change the decision.
+func (a *AuditLog) Record(d *Decision) error {
+	if a == nil {
+		return nil
+	}
+	d.Time = a.now()
+	d.Command = a.command
+	b, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("marshal a policy decision as JSON: %w", err)
+	}
+	b = append(b, '\n')
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.w != nil {
+		if _, err := a.w.Write(b); err != nil {
+			return fmt.Errorf("write a policy decision: %w", err)
+		}
+		return nil
+	}
+	if err := osfile.MkdirAll(filepath.Dir(a.path)); err != nil {
+		return fmt.Errorf("create a directory for the policy audit log: %w", err)
+	}
+	// The file is opened for each decision in append mode,
+	// so that aqua processes running in parallel can share the same file.
+	f, err := os.OpenFile(a.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, osfile.FilePermission)
+	if err != nil {
+		return fmt.Errorf("open the policy audit log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(b); err != nil {
+		return fmt.Errorf("write a policy decision: %w", err)
+	}
+	return nil
+}
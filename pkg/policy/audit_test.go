@@ -0,0 +1,285 @@
+package policy_test
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/policy"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestEvaluatePackage(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	localRegistry := &aqua.Registry{
+		Type: "local",
+		Name: "local",
+		Path: "/home/foo/registry.yaml",
+	}
+	localPolicy := &policy.Registry{
+		Type: "local",
+		Name: "local",
+		Path: "/home/foo/registry.yaml",
+	}
+	data := []struct {
+		name     string
+		pkg      *config.Package
+		policies []*policy.Config
+		exp      *policy.Decision
+		isErr    bool
+	}{
+		{
+			name: "default policy",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Name:     repoSuzukiTfcmt,
+					Version:  "v4.0.0",
+					Registry: registryTypeStandard,
+				},
+				PackageInfo: &registry.PackageInfo{},
+				Registry: &aqua.Registry{
+					Type:      pkgTypeGitHubContent,
+					Name:      registryTypeStandard,
+					RepoOwner: regOwnerAquaproj,
+					RepoName:  regNameAquaRegistry,
+					Path:      regFileRegistryYaml,
+					Ref:       "v4.0.0",
+				},
+			},
+			exp: &policy.Decision{
+				Package:       repoSuzukiTfcmt,
+				Version:       "v4.0.0",
+				Registry:      registryTypeStandard,
+				DefaultPolicy: true,
+				Rule:          "packages[0]",
+				Outcome:       policy.OutcomeAllow,
+			},
+		},
+		{
+			name: "allowed by the second rule",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Name:     repoSuzukiTfcmt,
+					Version:  "v4.0.0",
+					Registry: "local",
+				},
+				PackageInfo: &registry.PackageInfo{},
+				Registry:    localRegistry,
+			},
+			policies: []*policy.Config{
+				{
+					Path: pathHomeFooBarPolicy,
+					YAML: &policy.ConfigYAML{
+						Packages: []*policy.Package{
+							{
+								Name:     "cli/cli",
+								Registry: localPolicy,
+							},
+							{
+								Name:     repoSuzukiTfcmt,
+								Registry: localPolicy,
+							},
+						},
+					},
+				},
+			},
+			exp: &policy.Decision{
+				Package:    repoSuzukiTfcmt,
+				Version:    "v4.0.0",
+				Registry:   "local",
+				PolicyFile: pathHomeFooBarPolicy,
+				Rule:       "packages[1]",
+				Outcome:    policy.OutcomeAllow,
+			},
+		},
+		{
+			name: "denied",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Name:     repoSuzukiTfcmt,
+					Version:  "v4.0.0",
+					Registry: "local",
+				},
+				PackageInfo: &registry.PackageInfo{},
+				Registry:    localRegistry,
+			},
+			policies: []*policy.Config{
+				{
+					Path: pathHomeFooBarPolicy,
+					YAML: &policy.ConfigYAML{
+						Packages: []*policy.Package{
+							{
+								Name:     "cli/cli",
+								Registry: localPolicy,
+							},
+						},
+					},
+				},
+			},
+			isErr: true,
+			exp: &policy.Decision{
+				Package:  repoSuzukiTfcmt,
+				Version:  "v4.0.0",
+				Registry: "local",
+				Outcome:  policy.OutcomeDeny,
+				Error:    "this package isn't allowed",
+			},
+		},
+		{
+			name: "unmet requirement",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Name:     repoSuzukiTfcmt,
+					Version:  "v4.0.0",
+					Registry: "local",
+				},
+				PackageInfo: &registry.PackageInfo{},
+				Registry:    localRegistry,
+			},
+			policies: []*policy.Config{
+				{
+					Path: pathHomeFooBarPolicy,
+					YAML: &policy.ConfigYAML{
+						Packages: []*policy.Package{
+							{
+								Registry: localPolicy,
+								Require: &policy.Require{
+									AllOf: []string{"cosign"},
+								},
+							},
+						},
+					},
+				},
+			},
+			isErr: true,
+			exp: &policy.Decision{
+				Package:    repoSuzukiTfcmt,
+				Version:    "v4.0.0",
+				Registry:   "local",
+				PolicyFile: pathHomeFooBarPolicy,
+				Rule:       "packages[0]",
+				Outcome:    policy.OutcomeDeny,
+			},
+		},
+	}
+	logger := slog.New(slog.DiscardHandler)
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			decision, err := policy.EvaluatePackage(logger, d.pkg, d.policies, nil)
+			if err != nil {
+				if !d.isErr {
+					t.Fatal(err)
+				}
+			} else if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			opts := []cmp.Option{}
+			if d.exp.Error == "" {
+				opts = append(opts, cmpopts.IgnoreFields(policy.Decision{}, "Error"))
+			}
+			if diff := cmp.Diff(d.exp, decision, opts...); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestAuditLog_Record(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	buf := &bytes.Buffer{}
+	auditLog := policy.NewAuditLogWriter(buf, "install", func() time.Time { return now })
+	if err := auditLog.Record(&policy.Decision{
+		Package:    "cli/cli",
+		Version:    "v2.0.0",
+		Registry:   registryTypeStandard,
+		PolicyFile: pathHomeFooBarPolicy,
+		Rule:       "packages[0]",
+		Outcome:    policy.OutcomeAllow,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	exp := `{"time":"2026-01-02T03:04:05Z","command":"install","package":"cli/cli","version":"v2.0.0","registry":"standard","policy_file":"/home/foo/bar/aqua-policy.yaml","rule":"packages[0]","outcome":"allow"}
+`
+	if buf.String() != exp {
+		t.Fatalf("wanted %s, got %s", exp, buf.String())
+	}
+}
+
+func TestAuditLog_Record_file(t *testing.T) {
+	t.Parallel()
+	p := filepath.Join(t.TempDir(), "aqua", "policy-audit.jsonl")
+	auditLog := policy.NewAuditLog(p, "exec")
+	for range 2 {
+		if err := auditLog.Record(&policy.Decision{Outcome: policy.OutcomeDeny}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := bytes.Count(b, []byte("\n")); n != 2 {
+		t.Fatalf("wanted 2 lines, got %d: %s", n, string(b))
+	}
+}
+
+func TestAuditLog_Record_nil(t *testing.T) {
+	t.Parallel()
+	auditLog := policy.NewAuditLog("", "install")
+	if auditLog != nil {
+		t.Fatal("audit log must be nil")
+	}
+	if err := auditLog.Record(&policy.Decision{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuditLogPath(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name string
+		env  string
+		exp  string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "false",
+			env:  "false",
+		},
+		{
+			name: "true",
+			env:  "true",
+			exp:  "/home/foo/.local/share/aquaproj-aqua/policy-audit.jsonl",
+		},
+		{
+			name: "relative path",
+			env:  "audit.jsonl",
+			exp:  "/home/foo/workspace/audit.jsonl",
+		},
+		{
+			name: "absolute path",
+			env:  "/var/log/aqua/audit.jsonl",
+			exp:  "/var/log/aqua/audit.jsonl",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			p := policy.AuditLogPath(d.env, "/home/foo/.local/share/aquaproj-aqua", "/home/foo/workspace")
+			if p != d.exp {
+				t.Fatalf("wanted %s, got %s", d.exp, p)
+			}
+		})
+	}
+}
@@ -2,9 +2,13 @@ package policy
 
 import (
 	"errors"
+	"fmt"
 	"path/filepath"
 
+	"github.com/aquaproj/aqua/v2/pkg/expr"
 	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/expr-lang/expr/vm"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
 )
 
 const (
@@ -39,8 +43,12 @@ type Registry struct {
 type Package struct {
 	Name         string    `json:"name,omitempty"`
 	Version      string    `json:"version,omitempty"`
+	If           string    `json:"if,omitempty"`
 	RegistryName string    `yaml:"registry" json:"registry,omitempty"`
 	Registry     *Registry `yaml:"-" json:"-"`
+	Require      *Require  `json:"require,omitempty"`
+	// ifProg is If compiled by Config.Init.
+	ifProg *vm.Program
 }
 
 func (c *Config) Init() error {
@@ -65,7 +73,7 @@ func (c *Config) Init() error {
 		}
 		m[rgst.Name] = rgst
 	}
-	for _, pkg := range c.YAML.Packages {
+	for i, pkg := range c.YAML.Packages {
 		if pkg.RegistryName == "" {
 			pkg.RegistryName = registryTypeStandard
 		}
@@ -74,6 +82,20 @@ func (c *Config) Init() error {
 			return errUnknownRegistry
 		}
 		pkg.Registry = rgst
+		if err := pkg.Require.validate(); err != nil {
+			return err
+		}
+		if pkg.If != "" {
+			// A broken condition would otherwise make the rule silently never match.
+			prog, err := expr.CompilePolicyCondition(pkg.If)
+			if err != nil {
+				return fmt.Errorf("compile the if condition of the package policy: %w", slogerr.With(err,
+					"policy_file", c.Path,
+					"package_index", i,
+				))
+			}
+			pkg.ifProg = prog
+		}
 	}
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/aquaproj/aqua/v2/pkg/policy"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 const (
@@ -39,6 +40,7 @@ func TestConfig_Init(t *testing.T) { //nolint:funlen
 						{},
 						{
 							RegistryName: pkgFoo,
+							If:           `Type == "github_release" && semver(">= 1.0.0")`,
 						},
 					},
 				},
@@ -73,6 +75,7 @@ func TestConfig_Init(t *testing.T) { //nolint:funlen
 						},
 						{
 							RegistryName: pkgFoo,
+							If:           `Type == "github_release" && semver(">= 1.0.0")`,
 							Registry: &policy.Registry{
 								Type: registryTypeLocal,
 								Path: "/home/foo/registry.yaml",
@@ -83,6 +86,46 @@ func TestConfig_Init(t *testing.T) { //nolint:funlen
 				},
 			},
 		},
+		{
+			name: "unknown verification",
+			cfg: &policy.Config{
+				Path: "/home/foo/aqua-policy.yaml",
+				YAML: &policy.ConfigYAML{
+					Registries: []*policy.Registry{
+						{
+							Type: registryTypeStandard,
+						},
+					},
+					Packages: []*policy.Package{
+						{
+							Require: &policy.Require{
+								AnyOf: []string{"cosign", "gpg"},
+							},
+						},
+					},
+				},
+			},
+			isErr: true,
+		},
+		{
+			name: "invalid if condition",
+			cfg: &policy.Config{
+				Path: "/home/foo/aqua-policy.yaml",
+				YAML: &policy.ConfigYAML{
+					Registries: []*policy.Registry{
+						{
+							Type: registryTypeStandard,
+						},
+					},
+					Packages: []*policy.Package{
+						{
+							If: `Owner == "suzuki-shunsuke"`,
+						},
+					},
+				},
+			},
+			isErr: true,
+		},
 	}
 	for _, d := range data {
 		t.Run(d.name, func(t *testing.T) {
@@ -96,7 +139,7 @@ func TestConfig_Init(t *testing.T) { //nolint:funlen
 			if d.isErr {
 				t.Fatal("error must be returned")
 			}
-			if diff := cmp.Diff(d.exp, d.cfg); diff != "" {
+			if diff := cmp.Diff(d.exp, d.cfg, cmpopts.IgnoreUnexported(policy.Package{})); diff != "" {
 				t.Fatal(diff)
 			}
 		})
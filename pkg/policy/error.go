@@ -11,4 +11,5 @@ var (
 	errUnAllowedPackage   = slogerr.With(errors.New("this package isn't allowed"),
 		"doc", "https://aquaproj.github.io/docs/reference/codes/002",
 	)
+	errUnAllowedRegistry = errors.New("this registry isn't allowed")
 )
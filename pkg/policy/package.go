@@ -1,6 +1,7 @@
 package policy
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -12,45 +13,103 @@ import (
 )
 
 func ValidatePackage(logger *slog.Logger, pkg *config.Package, policies []*Config) error {
+	_, err := EvaluatePackage(logger, pkg, policies, nil)
+	return err
+}
+
+// EvaluatePackage validates the package with policies and returns the decision.
+// The decision is returned even if the package isn't allowed.
+// disabled is verifications disabled at runtime, which don't meet requirements of policies.
+func EvaluatePackage(logger *slog.Logger, pkg *config.Package, policies []*Config, disabled []string) (*Decision, error) {
+	decision := &Decision{
+		Package:  pkg.Package.Name,
+		Version:  pkg.Package.Version,
+		Registry: pkg.Package.Registry,
+		Outcome:  OutcomeDeny,
+	}
 	if len(policies) == 0 {
 		a, err := getDefaultPolicy()
 		if err != nil {
-			return err
+			decision.Error = err.Error()
+			return decision, err
 		}
 		policies = a
+		decision.DefaultPolicy = true
 	}
+	// If a policy matches with the package but the package doesn't meet its requirements,
+	// the unmet requirement is returned instead of errUnAllowedPackage.
+	var unmetErr error
 	for _, policyCfg := range policies {
-		if err := validatePackage(logger, &paramValidatePackage{
-			Pkg:          pkg,
-			PolicyConfig: policyCfg.YAML,
-		}); err == nil {
-			return nil
+		idx, err := validatePackage(logger, &paramValidatePackage{
+			Pkg:                   pkg,
+			PolicyConfig:          policyCfg.YAML,
+			DisabledVerifications: disabled,
+		})
+		if err == nil {
+			decision.Outcome = OutcomeAllow
+			decision.PolicyFile = policyCfg.Path
+			decision.Rule = ruleName(idx)
+			return decision, nil
 		}
+		if unmetErr == nil && errors.Is(err, errUnmetRequirement) {
+			unmetErr = slogerr.With(err, "policy_file", policyCfg.Path)
+			decision.PolicyFile = policyCfg.Path
+			decision.Rule = ruleName(idx)
+		}
+	}
+	if unmetErr == nil {
+		unmetErr = errUnAllowedPackage
 	}
-	return errUnAllowedPackage
+	decision.Error = unmetErr.Error()
+	return decision, unmetErr
+}
+
+func ruleName(idx int) string {
+	if idx < 0 {
+		return ""
+	}
+	return fmt.Sprintf("packages[%d]", idx)
 }
 
 type paramValidatePackage struct {
-	Pkg          *config.Package
-	PolicyConfig *ConfigYAML
+	Pkg                   *config.Package
+	PolicyConfig          *ConfigYAML
+	DisabledVerifications []string
 }
 
-func validatePackage(logger *slog.Logger, param *paramValidatePackage) error {
+// validatePackage returns the index of the package rule which allows the package.
+// If the package matches with a rule but doesn't meet its requirements, the index of the rule is returned with the error.
+func validatePackage(logger *slog.Logger, param *paramValidatePackage) (int, error) {
 	if param.PolicyConfig == nil {
-		return nil
+		return -1, nil
 	}
-	for _, policyPkg := range param.PolicyConfig.Packages {
+	var unmetErr error
+	unmetIdx := -1
+	for i, policyPkg := range param.PolicyConfig.Packages {
 		f, err := matchPkg(logger, param.Pkg, policyPkg)
 		if err != nil {
-			// If it fails to check if the policy matches with the package, output a debug log and treat as the policy doesn't match with the package.
-			slogerr.WithError(logger, err).Debug("check if the package matches with a policy")
+			// If it fails to check if the policy matches with the package, treat as the policy doesn't match with the package.
+			// The policy is likely to be wrong, so the error is output as a warning.
+			slogerr.WithError(logger, err).Warn("check if the package matches with a policy",
+				"package_index", i)
+			continue
+		}
+		if !f {
 			continue
 		}
-		if f {
-			return nil
+		if err := policyPkg.Require.check(param.Pkg.PackageInfo, param.DisabledVerifications); err != nil {
+			if unmetErr == nil {
+				unmetErr = err
+				unmetIdx = i
+			}
+			continue
 		}
+		return i, nil
+	}
+	if unmetErr != nil {
+		return unmetIdx, unmetErr
 	}
-	return errUnAllowedPackage
+	return -1, errUnAllowedPackage
 }
 
 func matchPkg(logger *slog.Logger, pkg *config.Package, policyPkg *Package) (bool, error) {
@@ -70,7 +129,39 @@ func matchPkg(logger *slog.Logger, pkg *config.Package, policyPkg *Package) (boo
 			return false, nil
 		}
 	}
-	return matchRegistry(logger, pkg.Registry, policyPkg.Registry)
+	matched, err := matchRegistry(logger, pkg.Registry, policyPkg.Registry)
+	if err != nil || !matched {
+		return matched, err
+	}
+	if policyPkg.If == "" {
+		return true, nil
+	}
+	if policyPkg.ifProg != nil {
+		matched, err = expr.EvaluatePolicyConditionProg(logger, policyPkg.ifProg, policyPackage(pkg))
+	} else {
+		matched, err = expr.EvaluatePolicyCondition(logger, policyPkg.If, policyPackage(pkg))
+	}
+	if err != nil {
+		return false, fmt.Errorf("evaluate the if condition of package: %w", err)
+	}
+	return matched, nil
+}
+
+func policyPackage(pkg *config.Package) *expr.PolicyPackage {
+	sv := pkg.Package.Version
+	if pkg.PackageInfo.VersionPrefix != "" {
+		sv = strings.TrimPrefix(pkg.Package.Version, pkg.PackageInfo.VersionPrefix)
+	}
+	return &expr.PolicyPackage{
+		Name:      pkg.Package.Name,
+		Version:   pkg.Package.Version,
+		SemVer:    sv,
+		RepoOwner: pkg.PackageInfo.RepoOwner,
+		RepoName:  pkg.PackageInfo.RepoName,
+		Type:      pkg.PackageInfo.Type,
+		Registry:  pkg.Registry.Name,
+		Tags:      pkg.Package.Tags,
+	}
 }
 
 func matchRegistry(logger *slog.Logger, rgst *aqua.Registry, rgstPolicy *Registry) (bool, error) {
@@ -77,6 +77,75 @@ func TestValidatePackage(t *testing.T) { //nolint:funlen
 				},
 			},
 		},
+		{
+			name: "if",
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Name:    repoSuzukiTfcmt,
+					Version: "v4.0.0",
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type:      "github_release",
+					RepoOwner: "suzuki-shunsuke",
+					RepoName:  "tfcmt",
+				},
+				Registry: &aqua.Registry{
+					Type: "local",
+					Name: "local",
+					Path: "registry.yaml",
+				},
+			},
+			policies: []*policy.Config{
+				{
+					YAML: &policy.ConfigYAML{
+						Packages: []*policy.Package{
+							{
+								If: `Type == "github_release" && RepoOwner == "suzuki-shunsuke"`,
+								Registry: &policy.Registry{
+									Type: "local",
+									Path: "registry.yaml",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "if doesn't match",
+			isErr: true,
+			pkg: &config.Package{
+				Package: &aqua.Package{
+					Name:    "golang.org/x/tools/gopls",
+					Version: "v0.16.0",
+				},
+				PackageInfo: &registry.PackageInfo{
+					Type:      "go_install",
+					RepoOwner: "golang",
+					RepoName:  "tools",
+				},
+				Registry: &aqua.Registry{
+					Type: "local",
+					Name: "local",
+					Path: "registry.yaml",
+				},
+			},
+			policies: []*policy.Config{
+				{
+					YAML: &policy.ConfigYAML{
+						Packages: []*policy.Package{
+							{
+								If: `Type != "go_install" || RepoOwner == "suzuki-shunsuke"`,
+								Registry: &policy.Registry{
+									Type: "local",
+									Path: "registry.yaml",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	logger := slog.New(slog.DiscardHandler)
 	for _, d := range data {
@@ -94,3 +163,74 @@ func TestValidatePackage(t *testing.T) { //nolint:funlen
 		})
 	}
 }
+
+func TestValidateRegistry(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name     string
+		isErr    bool
+		rgst     *aqua.Registry
+		policies []*policy.Config
+	}{
+		{
+			name: "standard registry is allowed by default",
+			rgst: &aqua.Registry{
+				Type:      pkgTypeGitHubContent,
+				Name:      registryTypeStandard,
+				RepoOwner: regOwnerAquaproj,
+				RepoName:  regNameAquaRegistry,
+				Path:      regFileRegistryYaml,
+				Ref:       "v4.0.0",
+			},
+		},
+		{
+			name:  "local registry isn't allowed by default",
+			isErr: true,
+			rgst: &aqua.Registry{
+				Type: "local",
+				Name: "local",
+				Path: "/home/foo/registry.yaml",
+			},
+		},
+		{
+			name: "local registry is allowed",
+			rgst: &aqua.Registry{
+				Type: "local",
+				Name: "local",
+				Path: "/home/foo/registry.yaml",
+			},
+			policies: []*policy.Config{
+				{
+					YAML: &policy.ConfigYAML{
+						Registries: []*policy.Registry{
+							{
+								Type: "local",
+								Name: "local",
+								Path: "/home/foo/registry.yaml",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "unknown registry",
+			isErr: true,
+		},
+	}
+	logger := slog.New(slog.DiscardHandler)
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if err := policy.ValidateRegistry(logger, d.rgst, d.policies); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package policy
+
+import (
+	"log/slog"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// ValidateRegistry returns an error if no policy allows the registry.
+// Packages of the registry aren't allowed unless the registry is allowed,
+// so this is useful to tell why packages are denied.
+func ValidateRegistry(logger *slog.Logger, rgst *aqua.Registry, policies []*Config) error {
+	if rgst == nil {
+		return errUnAllowedRegistry
+	}
+	if len(policies) == 0 {
+		a, err := getDefaultPolicy()
+		if err != nil {
+			return err
+		}
+		policies = a
+	}
+	for _, policyCfg := range policies {
+		if policyCfg.YAML == nil {
+			return nil
+		}
+		for _, rgstPolicy := range policyCfg.YAML.Registries {
+			f, err := matchRegistry(logger, rgst, rgstPolicy)
+			if err != nil {
+				slogerr.WithError(logger, err).Debug("check if the registry matches with a policy")
+				continue
+			}
+			if f {
+				return nil
+			}
+		}
+	}
+	return errUnAllowedRegistry
+}
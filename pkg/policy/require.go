@@ -0,0 +1,138 @@
+package policy
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// Verifications which can be required by policies.
+const (
+	VerificationChecksum                   = "checksum"
+	VerificationCosign                     = "cosign"
+	VerificationSLSAProvenance             = "slsa_provenance"
+	VerificationMinisign                   = "minisign"
+	VerificationGitHubArtifactAttestations = "github_artifact_attestations"
+	VerificationPGP                        = "pgp"
+)
+
+var (
+	errUnknownVerification = errors.New("unknown verification is required")
+	errUnmetRequirement    = errors.New("the package doesn't meet requirements of the policy")
+)
+
+const docRequire = "https://aquaproj.github.io/docs/reference/security/policy-as-code/require"
+
+// Require is requirements on verifications configured in the registry.
+// Signatures of checksum files are also treated as signatures of packages because checksum files verify packages.
+type Require struct {
+	// AllOf is verifications all of which must be configured.
+	AllOf []string `yaml:"all_of" json:"all_of,omitempty" jsonschema:"enum=checksum,enum=cosign,enum=slsa_provenance,enum=minisign,enum=github_artifact_attestations,enum=pgp"`
+	// AnyOf is verifications at least one of which must be configured.
+	AnyOf []string `yaml:"any_of" json:"any_of,omitempty" jsonschema:"enum=checksum,enum=cosign,enum=slsa_provenance,enum=minisign,enum=github_artifact_attestations,enum=pgp"`
+}
+
+func (r *Require) validate() error {
+	if r == nil {
+		return nil
+	}
+	for _, names := range [][]string{r.AllOf, r.AnyOf} {
+		for _, name := range names {
+			if _, ok := verificationConfigured(&registry.PackageInfo{}, name, false); !ok {
+				return slogerr.With(errUnknownVerification, "verification", name) //nolint:wrapcheck
+			}
+		}
+	}
+	return nil
+}
+
+// DisabledVerifications returns verifications disabled at runtime by command line options and environment variables
+// such as AQUA_DISABLE_COSIGN.
+// checksumEnabled is the result of config.Param.ChecksumEnabled for the configuration file.
+// If it's false, checksums aren't verified even if the registry configures them.
+func DisabledVerifications(param *config.Param, checksumEnabled bool) []string {
+	var disabled []string
+	if !checksumEnabled {
+		disabled = append(disabled, VerificationChecksum)
+	}
+	if param.CosignDisabled {
+		disabled = append(disabled, VerificationCosign)
+	}
+	if param.SLSADisabled {
+		disabled = append(disabled, VerificationSLSAProvenance)
+	}
+	if param.GitHubArtifactAttestationDisabled {
+		disabled = append(disabled, VerificationGitHubArtifactAttestations)
+	}
+	return disabled
+}
+
+// check returns an error pointing to the unmet requirement if the package doesn't meet requirements.
+// A verification disabled at runtime doesn't meet requirements even if it's configured, because it's skipped.
+// If the checksum verification is disabled, signatures of checksum files don't meet requirements either,
+// because checksum files aren't downloaded.
+func (r *Require) check(pkgInfo *registry.PackageInfo, disabled []string) error {
+	if r == nil {
+		return nil
+	}
+	checksumDisabled := slices.Contains(disabled, VerificationChecksum)
+	for _, name := range r.AllOf {
+		if slices.Contains(disabled, name) {
+			return slogerr.With(errUnmetRequirement, //nolint:wrapcheck
+				"doc", docRequire,
+				"required_verification", name,
+				"verification_disabled", true,
+			)
+		}
+		if f, _ := verificationConfigured(pkgInfo, name, checksumDisabled); !f {
+			return slogerr.With(errUnmetRequirement, //nolint:wrapcheck
+				"doc", docRequire,
+				"required_verification", name,
+			)
+		}
+	}
+	if len(r.AnyOf) == 0 {
+		return nil
+	}
+	for _, name := range r.AnyOf {
+		if slices.Contains(disabled, name) {
+			continue
+		}
+		if f, _ := verificationConfigured(pkgInfo, name, checksumDisabled); f {
+			return nil
+		}
+	}
+	return slogerr.With(errUnmetRequirement, //nolint:wrapcheck
+		"doc", docRequire,
+		"required_verification_any_of", strings.Join(r.AnyOf, ", "),
+	)
+}
+
+// verificationConfigured returns true if the verification is configured in the package.
+// The second returned value is false if the verification is unknown.
+// Signatures of checksum files are ignored if checksumDisabled is true.
+func verificationConfigured(pkgInfo *registry.PackageInfo, name string, checksumDisabled bool) (bool, bool) {
+	chk := pkgInfo.Checksum
+	if checksumDisabled {
+		chk = nil
+	}
+	switch name {
+	case VerificationChecksum:
+		return chk.GetEnabled(), true
+	case VerificationCosign:
+		return pkgInfo.Cosign.GetEnabled() || (chk.GetEnabled() && chk.GetCosign().GetEnabled()), true
+	case VerificationSLSAProvenance:
+		return pkgInfo.SLSAProvenance.GetEnabled(), true
+	case VerificationMinisign:
+		return pkgInfo.Minisign.GetEnabled() || (chk.GetEnabled() && chk.GetMinisign().GetEnabled()), true
+	case VerificationGitHubArtifactAttestations:
+		return pkgInfo.GitHubArtifactAttestations.GetEnabled() || (chk.GetEnabled() && chk.GetGitHubArtifactAttestations().GetEnabled()), true
+	case VerificationPGP:
+		return pkgInfo.PGP.GetEnabled() || (chk.GetEnabled() && chk.GetPGP().GetEnabled()), true
+	}
+	return false, false
+}
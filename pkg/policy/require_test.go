@@ -0,0 +1,199 @@
+package policy_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/policy"
+)
+
+func TestValidatePackage_require(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	boolFalse := false
+	standard := &policy.Registry{
+		Type:      pkgTypeGitHubContent,
+		Name:      registryTypeStandard,
+		RepoOwner: regOwnerAquaproj,
+		RepoName:  regNameAquaRegistry,
+		Path:      regFileRegistryYaml,
+	}
+	newPkg := func(pkgInfo *registry.PackageInfo) *config.Package {
+		return &config.Package{
+			Package: &aqua.Package{
+				Name:    repoSuzukiTfcmt,
+				Version: "v4.0.0",
+			},
+			PackageInfo: pkgInfo,
+			Registry: &aqua.Registry{
+				Type:      pkgTypeGitHubContent,
+				Name:      registryTypeStandard,
+				RepoOwner: regOwnerAquaproj,
+				RepoName:  regNameAquaRegistry,
+				Path:      regFileRegistryYaml,
+			},
+		}
+	}
+	newPolicies := func(require *policy.Require) []*policy.Config {
+		return []*policy.Config{
+			{
+				YAML: &policy.ConfigYAML{
+					Packages: []*policy.Package{
+						{
+							RegistryName: registryTypeStandard,
+							Registry:     standard,
+							Require:      require,
+						},
+					},
+				},
+			},
+		}
+	}
+	data := []struct {
+		name     string
+		isErr    bool
+		pkg      *config.Package
+		policies []*policy.Config
+		disabled []string
+	}{
+		{
+			name: "all_of is met",
+			pkg: newPkg(&registry.PackageInfo{
+				Checksum: &registry.Checksum{
+					Cosign: &registry.Cosign{
+						Opts: []string{"--key", "cosign.pub"},
+					},
+				},
+			}),
+			policies: newPolicies(&policy.Require{
+				AllOf: []string{"checksum", "cosign"},
+			}),
+		},
+		{
+			name: "checksum is disabled",
+			pkg: newPkg(&registry.PackageInfo{
+				Checksum: &registry.Checksum{
+					Enabled: &boolFalse,
+				},
+			}),
+			policies: newPolicies(&policy.Require{
+				AllOf: []string{"checksum"},
+			}),
+			isErr: true,
+		},
+		{
+			name: "any_of is met",
+			pkg: newPkg(&registry.PackageInfo{
+				SLSAProvenance: &registry.SLSAProvenance{
+					Type: "github_release",
+				},
+			}),
+			policies: newPolicies(&policy.Require{
+				AnyOf: []string{"cosign", "slsa_provenance"},
+			}),
+		},
+		{
+			name: "any_of isn't met",
+			pkg: newPkg(&registry.PackageInfo{
+				Minisign: &registry.Minisign{},
+			}),
+			policies: newPolicies(&policy.Require{
+				AnyOf: []string{"cosign", "slsa_provenance", "github_artifact_attestations"},
+			}),
+			isErr: true,
+		},
+		{
+			name: "required verification is disabled at runtime",
+			pkg: newPkg(&registry.PackageInfo{
+				Checksum: &registry.Checksum{
+					Cosign: &registry.Cosign{
+						Opts: []string{"--key", "cosign.pub"},
+					},
+				},
+			}),
+			policies: newPolicies(&policy.Require{
+				AllOf: []string{"checksum", "cosign"},
+			}),
+			disabled: []string{"cosign"},
+			isErr:    true,
+		},
+		{
+			name: "checksum is disabled in aqua.yaml",
+			pkg: newPkg(&registry.PackageInfo{
+				Checksum: &registry.Checksum{},
+			}),
+			policies: newPolicies(&policy.Require{
+				AllOf: []string{"checksum"},
+			}),
+			disabled: policy.DisabledVerifications(&config.Param{}, false),
+			isErr:    true,
+		},
+		{
+			name: "the signature of the checksum file isn't verified if checksum is disabled in aqua.yaml",
+			pkg: newPkg(&registry.PackageInfo{
+				Checksum: &registry.Checksum{
+					Cosign: &registry.Cosign{
+						Opts: []string{"--key", "cosign.pub"},
+					},
+				},
+			}),
+			policies: newPolicies(&policy.Require{
+				AnyOf: []string{"cosign"},
+			}),
+			disabled: policy.DisabledVerifications(&config.Param{}, false),
+			isErr:    true,
+		},
+		{
+			name: "any_of is met by a verification which isn't disabled",
+			pkg: newPkg(&registry.PackageInfo{
+				Cosign: &registry.Cosign{
+					Opts: []string{"--key", "cosign.pub"},
+				},
+				SLSAProvenance: &registry.SLSAProvenance{
+					Type: "github_release",
+				},
+			}),
+			policies: newPolicies(&policy.Require{
+				AnyOf: []string{"cosign", "slsa_provenance"},
+			}),
+			disabled: []string{"cosign"},
+		},
+		{
+			name: "any_of is met only by a disabled verification",
+			pkg: newPkg(&registry.PackageInfo{
+				SLSAProvenance: &registry.SLSAProvenance{
+					Type: "github_release",
+				},
+			}),
+			policies: newPolicies(&policy.Require{
+				AnyOf: []string{"cosign", "slsa_provenance"},
+			}),
+			disabled: []string{"slsa_provenance"},
+			isErr:    true,
+		},
+		{
+			name: "another policy allows the package",
+			pkg:  newPkg(&registry.PackageInfo{}),
+			policies: append(newPolicies(&policy.Require{
+				AllOf: []string{"pgp"},
+			}), newPolicies(nil)...),
+		},
+	}
+	logger := slog.New(slog.DiscardHandler)
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := policy.EvaluatePackage(logger, d.pkg, d.policies, d.disabled); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+		})
+	}
+}
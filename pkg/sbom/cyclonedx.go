@@ -0,0 +1,115 @@
+package sbom
+
+import (
+	"strings"
+	"time"
+)
+
+// https://cyclonedx.org/docs/1.5/json/
+type cycloneDX struct {
+	BOMFormat   string                `json:"bomFormat"`
+	SpecVersion string                `json:"specVersion"`
+	Version     int                   `json:"version"`
+	Metadata    *cycloneDXMetadata    `json:"metadata"`
+	Components  []*cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string          `json:"timestamp"`
+	Tools     *cycloneDXTools `json:"tools"`
+}
+
+type cycloneDXTools struct {
+	Components []*cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXComponent struct {
+	Type               string                        `json:"type"`
+	BOMRef             string                        `json:"bom-ref,omitempty"`
+	Name               string                        `json:"name"`
+	Version            string                        `json:"version,omitempty"`
+	PURL               string                        `json:"purl,omitempty"`
+	Hashes             []*cycloneDXHash              `json:"hashes,omitempty"`
+	ExternalReferences []*cycloneDXExternalReference `json:"externalReferences,omitempty"`
+	Properties         []*cycloneDXProperty          `json:"properties,omitempty"`
+}
+
+type cycloneDXHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cycloneDXExternalReference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+var cycloneDXHashAlgorithms = map[string]string{ //nolint:gochecknoglobals
+	"md5":    "MD5",
+	"sha1":   "SHA-1",
+	"sha256": "SHA-256",
+	"sha512": "SHA-512",
+}
+
+func newCycloneDX(pkgs []*Package, param *Param) *cycloneDX {
+	components := make([]*cycloneDXComponent, len(pkgs))
+	for i, pkg := range pkgs {
+		components[i] = newCycloneDXComponent(pkg)
+	}
+	return &cycloneDX{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+		Metadata: &cycloneDXMetadata{
+			Timestamp: param.Timestamp.UTC().Format(time.RFC3339),
+			Tools: &cycloneDXTools{
+				Components: []*cycloneDXComponent{
+					{
+						Type:    "application",
+						Name:    "aqua",
+						Version: param.Version,
+					},
+				},
+			},
+		},
+		Components: components,
+	}
+}
+
+func newCycloneDXComponent(pkg *Package) *cycloneDXComponent {
+	component := &cycloneDXComponent{
+		Type:    "application",
+		BOMRef:  pkg.PURL,
+		Name:    pkg.Name,
+		Version: pkg.Version,
+		PURL:    pkg.PURL,
+		Properties: []*cycloneDXProperty{
+			{Name: "aqua:registry", Value: pkg.Registry},
+			{Name: "aqua:package_type", Value: pkg.Type},
+		},
+	}
+	if pkg.URL != "" {
+		component.ExternalReferences = []*cycloneDXExternalReference{
+			{Type: "distribution", URL: pkg.URL},
+		}
+	}
+	if pkg.Checksum != nil {
+		if alg, ok := cycloneDXHashAlgorithms[pkg.Checksum.Algorithm]; ok {
+			component.Hashes = []*cycloneDXHash{
+				{Alg: alg, Content: strings.ToLower(pkg.Checksum.Checksum)},
+			}
+		}
+	}
+	for _, v := range pkg.Verifications {
+		component.Properties = append(component.Properties, &cycloneDXProperty{Name: "aqua:verification", Value: v})
+	}
+	for _, v := range pkg.ChecksumVerifications {
+		component.Properties = append(component.Properties, &cycloneDXProperty{Name: "aqua:checksum_verification", Value: v})
+	}
+	return component
+}
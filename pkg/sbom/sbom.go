@@ -0,0 +1,63 @@
+// Package sbom generates Software Bill of Materials (SBOM) of packages managed by aqua.
+// CycloneDX JSON and SPDX JSON are supported.
+package sbom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+const (
+	FormatCycloneDX = "cyclonedx"
+	FormatSPDX      = "spdx"
+)
+
+var errUnknownFormat = errors.New("unknown SBOM format")
+
+// Package is a component of the SBOM.
+type Package struct {
+	Name     string
+	Version  string
+	Registry string
+	Type     string
+	PURL     string
+	URL      string
+	// Checksum is a checksum in aqua-checksums.json.
+	Checksum *checksum.Checksum
+	// Verifications are names of signature and provenance verifications of the asset such as cosign and slsa.
+	Verifications []string
+	// ChecksumVerifications are names of verifications of the checksum file.
+	ChecksumVerifications []string
+}
+
+// Param is metadata of the SBOM.
+type Param struct {
+	// Version is the version of aqua.
+	Version   string
+	Timestamp time.Time
+}
+
+// Write outputs the SBOM of packages in the given format.
+func Write(w io.Writer, format string, pkgs []*Package, param *Param) error {
+	var doc any
+	switch format {
+	case FormatCycloneDX, "":
+		doc = newCycloneDX(pkgs, param)
+	case FormatSPDX:
+		doc = newSPDX(pkgs, param)
+	default:
+		return slogerr.With(errUnknownFormat, "format", format) //nolint:wrapcheck
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("encode the SBOM as JSON: %w", err)
+	}
+	return nil
+}
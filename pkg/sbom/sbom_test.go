@@ -0,0 +1,89 @@
+package sbom_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/sbom"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	pkgs := []*sbom.Package{
+		{
+			Name:     "cli/cli",
+			Version:  "v2.40.0",
+			Registry: "standard",
+			Type:     "github_release",
+			PURL:     "pkg:github/cli/cli@v2.40.0",
+			URL:      "https://github.com/cli/cli/releases/download/v2.40.0/gh_2.40.0_linux_amd64.tar.gz",
+			Checksum: &checksum.Checksum{
+				ID:        "github_release/github.com/cli/cli/v2.40.0/gh_2.40.0_linux_amd64.tar.gz",
+				Checksum:  "AB12",
+				Algorithm: "sha256",
+			},
+			Verifications:         []string{"github_artifact_attestations"},
+			ChecksumVerifications: []string{"cosign"},
+		},
+		{
+			Name:     "crates.io/ripgrep",
+			Version:  "14.1.0",
+			Registry: "standard",
+			Type:     "cargo",
+			PURL:     "pkg:cargo/ripgrep@14.1.0",
+		},
+	}
+	param := &sbom.Param{
+		Version:   "v2.50.0",
+		Timestamp: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	data := []struct {
+		name   string
+		format string
+		file   string
+		isErr  bool
+	}{
+		{
+			name:   "cyclonedx",
+			format: "cyclonedx",
+			file:   "cyclonedx.json",
+		},
+		{
+			name:   "spdx",
+			format: "spdx",
+			file:   "spdx.json",
+		},
+		{
+			name:   "unknown format",
+			format: "foo",
+			isErr:  true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			buf := &bytes.Buffer{}
+			if err := sbom.Write(buf, d.format, pkgs, param); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			exp, err := os.ReadFile(filepath.Join("testdata", d.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(exp), buf.String()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package sbom
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// https://spdx.github.io/spdx-spec/v2.3/
+type spdx struct {
+	SPDXVersion       string              `json:"spdxVersion"`
+	DataLicense       string              `json:"dataLicense"`
+	SPDXID            string              `json:"SPDXID"`
+	Name              string              `json:"name"`
+	DocumentNamespace string              `json:"documentNamespace"`
+	CreationInfo      *spdxCreationInfo   `json:"creationInfo"`
+	Packages          []*spdxPackage      `json:"packages"`
+	Relationships     []*spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID           string             `json:"SPDXID"`
+	Name             string             `json:"name"`
+	VersionInfo      string             `json:"versionInfo,omitempty"`
+	DownloadLocation string             `json:"downloadLocation"`
+	FilesAnalyzed    bool               `json:"filesAnalyzed"`
+	Checksums        []*spdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs     []*spdxExternalRef `json:"externalRefs,omitempty"`
+	Comment          string             `json:"comment,omitempty"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+const (
+	spdxDocumentID  = "SPDXRef-DOCUMENT"
+	spdxNoAssertion = "NOASSERTION"
+)
+
+// spdxVCSTools are VCS tools of VCS locators such as git+https://github.com/cli/cli@v2.40.0.
+var spdxVCSTools = map[string]struct{}{ //nolint:gochecknoglobals
+	"git": {},
+	"hg":  {},
+	"svn": {},
+	"bzr": {},
+}
+
+var spdxChecksumAlgorithms = map[string]string{ //nolint:gochecknoglobals
+	"md5":    "MD5",
+	"sha1":   "SHA1",
+	"sha256": "SHA256",
+	"sha512": "SHA512",
+}
+
+func newSPDX(pkgs []*Package, param *Param) *spdx {
+	doc := &spdx{
+		SPDXVersion: "SPDX-2.3",
+		DataLicense: "CC0-1.0",
+		SPDXID:      spdxDocumentID,
+		Name:        "aqua",
+		CreationInfo: &spdxCreationInfo{
+			Created:  param.Timestamp.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: aqua-" + param.Version},
+		},
+		Packages:      make([]*spdxPackage, len(pkgs)),
+		Relationships: make([]*spdxRelationship, len(pkgs)),
+	}
+	// The document namespace must be unique for each document,
+	// so it's generated from the creation time and packages.
+	h := sha256.New()
+	h.Write([]byte(doc.CreationInfo.Created))
+	for i, pkg := range pkgs {
+		h.Write([]byte("\n" + pkg.PURL + "\n" + pkg.URL))
+		p := newSPDXPackage(pkg)
+		p.SPDXID = "SPDXRef-Package-" + strconv.Itoa(i+1)
+		doc.Packages[i] = p
+		doc.Relationships[i] = &spdxRelationship{
+			SPDXElementID:      spdxDocumentID,
+			RelationshipType:   "DESCRIBES",
+			RelatedSPDXElement: p.SPDXID,
+		}
+	}
+	doc.DocumentNamespace = "https://aquaproj.github.io/spdx/aqua-" + hex.EncodeToString(h.Sum(nil))
+	return doc
+}
+
+func newSPDXPackage(pkg *Package) *spdxPackage {
+	p := &spdxPackage{
+		Name:             pkg.Name,
+		VersionInfo:      pkg.Version,
+		DownloadLocation: spdxNoAssertion,
+	}
+	if isSPDXDownloadLocation(pkg.URL) {
+		p.DownloadLocation = pkg.URL
+	}
+	if pkg.PURL != "" {
+		p.ExternalRefs = []*spdxExternalRef{
+			{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  pkg.PURL,
+			},
+		}
+	}
+	if pkg.Checksum != nil {
+		if alg, ok := spdxChecksumAlgorithms[pkg.Checksum.Algorithm]; ok {
+			p.Checksums = []*spdxChecksum{
+				{Algorithm: alg, ChecksumValue: strings.ToLower(pkg.Checksum.Checksum)},
+			}
+		}
+	}
+	comments := []string{"registry: " + pkg.Registry, "package type: " + pkg.Type}
+	if len(pkg.Verifications) != 0 {
+		comments = append(comments, "verifications: "+strings.Join(pkg.Verifications, ", "))
+	}
+	if len(pkg.ChecksumVerifications) != 0 {
+		comments = append(comments, "checksum file verifications: "+strings.Join(pkg.ChecksumVerifications, ", "))
+	}
+	p.Comment = strings.Join(comments, "\n")
+	return p
+}
+
+// isSPDXDownloadLocation returns true if u can be used as downloadLocation of SPDX.
+// Only HTTP(S) URLs and VCS locators are used. Others such as Go module paths
+// of go_install packages aren't URLs, so NOASSERTION is used instead.
+func isSPDXDownloadLocation(u string) bool {
+	scheme, rest, ok := strings.Cut(u, "://")
+	if !ok || rest == "" {
+		return false
+	}
+	switch scheme {
+	case "http", "https":
+		return true
+	}
+	tool, _, ok := strings.Cut(scheme, "+")
+	if !ok {
+		return false
+	}
+	_, ok = spdxVCSTools[tool]
+	return ok
+}
@@ -0,0 +1,50 @@
+package sbom
+
+import "testing"
+
+func Test_newSPDXPackage_downloadLocation(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name string
+		url  string
+		exp  string
+	}{
+		{
+			name: "https",
+			url:  "https://github.com/cli/cli/releases/download/v2.40.0/gh_2.40.0_linux_amd64.tar.gz",
+			exp:  "https://github.com/cli/cli/releases/download/v2.40.0/gh_2.40.0_linux_amd64.tar.gz",
+		},
+		{
+			name: "vcs",
+			url:  "git+https://github.com/cli/cli@v2.40.0",
+			exp:  "git+https://github.com/cli/cli@v2.40.0",
+		},
+		{
+			name: "go module path",
+			url:  "golang.org/x/tools/cmd/goimports@v0.20.0",
+			exp:  spdxNoAssertion,
+		},
+		{
+			name: "unsupported scheme",
+			url:  "file:///tmp/gh.tar.gz",
+			exp:  spdxNoAssertion,
+		},
+		{
+			name: "empty",
+			exp:  spdxNoAssertion,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			p := newSPDXPackage(&Package{
+				Name:    "cli/cli",
+				Version: "v2.40.0",
+				URL:     d.url,
+			})
+			if p.DownloadLocation != d.exp {
+				t.Fatalf("wanted %s, got %s", d.exp, p.DownloadLocation)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package vacuum
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/manifest"
 	"github.com/aquaproj/aqua/v2/pkg/osfile"
 	"github.com/suzuki-shunsuke/slog-error/slogerr"
 )
@@ -35,26 +37,37 @@ func ParseTime(s string) (time.Time, error) {
 
 type Client struct {
 	rootDir string
-	// updateDisabled disables only Update, which is called on every package
-	// execution and installation. Create and FindAll are called from the vacuum
+	// updateDisabled disables only Update and RecordUsage, which are called on
+	// every package execution (and installation in case of Update). Create and FindAll are called from the vacuum
 	// commands, which are rejected by the CLI if AQUA_DISABLE_TRACKING is set, and
 	// Remove is also called from the remove command to clean up a timestamp file
 	// of a removed package.
 	updateDisabled bool
+	// statsEnabled enables RecordUsage. Usage statistics are opt-in,
+	// because RecordUsage reads and rewrites a file on every package execution.
+	statsEnabled bool
 }
 
 func New(param *config.Param) *Client {
 	return &Client{
 		rootDir:        filepath.Join(param.RootDir, baseDir),
 		updateDisabled: param.DisableTracking,
+		statsEnabled:   param.EnableStats && !param.DisableTracking,
 	}
 }
 
+// Remove removes the timestamp file, the manifest, and usage statistics of a removed package.
 func (c *Client) Remove(pkgPath string) error {
 	file := c.file(pkgPath)
 	if err := os.Remove(file); err != nil {
 		return fmt.Errorf("reamove a package timestamp file: %w", err)
 	}
+	if err := os.Remove(filepath.Join(c.dir(pkgPath), manifest.FileName)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove a package manifest: %w", err)
+	}
+	if err := os.Remove(filepath.Join(c.dir(pkgPath), UsageFileName)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove package usage statistics: %w", err)
+	}
 	return nil
 }
 
@@ -26,6 +26,10 @@ func (m *Mock) Update(pkgPath string, timestamp time.Time) error {
 	return m.err
 }
 
+func (m *Mock) RecordUsage(pkgPath, pkgName, version, exeName string, timestamp time.Time) error {
+	return m.err
+}
+
 func (m *Mock) FindAll() (map[string]time.Time, error) {
 	return m.timestamps, m.err
 }
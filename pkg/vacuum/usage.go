@@ -0,0 +1,138 @@
+package vacuum
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// UsageFileName is the name of a usage statistics file in the package metadata directory.
+const UsageFileName = "usage.json"
+
+// Usage is usage statistics of a package recorded on every execution.
+type Usage struct {
+	Package  string                   `json:"package"`
+	Version  string                   `json:"version"`
+	Commands map[string]*CommandUsage `json:"commands"`
+}
+
+// CommandUsage is usage statistics of a command.
+type CommandUsage struct {
+	Count     int       `json:"count"`
+	FirstUsed time.Time `json:"first_used"`
+	LastUsed  time.Time `json:"last_used"`
+}
+
+// RecordUsage increments the invocation count of the command and updates the first and last used date times.
+// It does nothing unless AQUA_ENABLE_STATS is set, and like Update, it does nothing if AQUA_DISABLE_TRACKING is set.
+// Concurrent executions may lose some counts, but the file is never broken because it's replaced atomically.
+func (c *Client) RecordUsage(pkgPath, pkgName, version, exeName string, timestamp time.Time) error {
+	if !c.statsEnabled {
+		return nil
+	}
+	dir := c.dir(pkgPath)
+	file := filepath.Join(dir, UsageFileName)
+	usage, err := readUsage(file)
+	if err != nil {
+		// The file doesn't exist yet or is broken.
+		// Statistics are informative, so a broken file is recreated rather than making the command fail.
+		usage = &Usage{}
+	}
+	usage.Package = pkgName
+	usage.Version = version
+	if usage.Commands == nil {
+		usage.Commands = map[string]*CommandUsage{}
+	}
+	cmd, ok := usage.Commands[exeName]
+	if !ok {
+		cmd = &CommandUsage{
+			FirstUsed: timestamp,
+		}
+		usage.Commands[exeName] = cmd
+	}
+	cmd.Count++
+	cmd.LastUsed = timestamp
+	return writeUsage(file, dir, usage)
+}
+
+// FindAllUsages returns usage statistics of all packages.
+// The key is a package path relative to $AQUA_ROOT_DIR.
+// Broken files are skipped with a warning.
+func (c *Client) FindAllUsages(logger *slog.Logger) (map[string]*Usage, error) {
+	usages := map[string]*Usage{}
+	if err := filepath.WalkDir(filepath.Join(c.rootDir, "pkgs"), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return fmt.Errorf("walk directory to find usage files: %w", err)
+		}
+		if entry.Name() != UsageFileName {
+			return nil
+		}
+		usage, err := readUsage(path)
+		if err != nil {
+			slogerr.WithError(logger, err).Warn("a usage file is broken", "usage_file", path)
+			return nil
+		}
+		rel, err := filepath.Rel(c.rootDir, filepath.Dir(path))
+		if err != nil {
+			return fmt.Errorf("get a relative file path: %w", err)
+		}
+		usages[rel] = usage
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("find usage files: %w", err)
+	}
+	return usages, nil
+}
+
+func readUsage(file string) (*Usage, error) {
+	b, err := os.ReadFile(file) //nolint:gosec // the path is in aqua's own metadata directory
+	if err != nil {
+		return nil, fmt.Errorf("read a usage file: %w", err)
+	}
+	usage := &Usage{}
+	if err := json.Unmarshal(b, usage); err != nil {
+		return nil, fmt.Errorf("parse a usage file as JSON: %w", err)
+	}
+	return usage, nil
+}
+
+func writeUsage(file, dir string, usage *Usage) error {
+	b, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode a usage file as JSON: %w", err)
+	}
+	if err := osfile.MkdirAll(dir); err != nil {
+		return fmt.Errorf("create a package metadata directory: %w", err)
+	}
+	// Write a temporary file and rename it so that concurrent executions never read a partially written file.
+	f, err := os.CreateTemp(dir, "tmp-")
+	if err != nil {
+		return fmt.Errorf("create a temporary file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("write a usage file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close a usage file: %w", err)
+	}
+	if err := os.Chmod(f.Name(), filePermission); err != nil {
+		return fmt.Errorf("change the permission of a usage file: %w", err)
+	}
+	if err := os.Rename(f.Name(), file); err != nil {
+		return fmt.Errorf("rename a usage file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package vacuum_test
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/vacuum"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_RecordUsage(t *testing.T) {
+	t.Parallel()
+	first, err := vacuum.ParseTime("2025-01-10T00:15:00+09:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second := first.Add(time.Hour)
+	data := []struct {
+		name            string
+		files           map[string]string
+		disableTracking bool
+		disableStats    bool
+		exp             map[string]*vacuum.Usage
+	}{
+		{
+			name: "record",
+			exp: map[string]*vacuum.Usage{
+				pkgPath: {
+					Package: "cli/cli",
+					Version: "v2.65.0",
+					Commands: map[string]*vacuum.CommandUsage{
+						"gh": {
+							Count:     2,
+							FirstUsed: first,
+							LastUsed:  second,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "recreate a broken file",
+			files: map[string]string{
+				"metadata/" + pkgPath + "/usage.json": "{",
+			},
+			exp: map[string]*vacuum.Usage{
+				pkgPath: {
+					Package: "cli/cli",
+					Version: "v2.65.0",
+					Commands: map[string]*vacuum.CommandUsage{
+						"gh": {
+							Count:     2,
+							FirstUsed: first,
+							LastUsed:  second,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:            "tracking is disabled",
+			disableTracking: true,
+			exp:             map[string]*vacuum.Usage{},
+		},
+		{
+			name:         "stats aren't enabled",
+			disableStats: true,
+			exp:          map[string]*vacuum.Usage{},
+		},
+	}
+	logger := slog.New(slog.DiscardHandler)
+	for _, tt := range data {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rootDir := newRootDir(t, tt.files)
+			client := vacuum.New(&config.Param{
+				RootDir:         rootDir,
+				DisableTracking: tt.disableTracking,
+				EnableStats:     !tt.disableStats,
+			})
+			for _, ts := range []time.Time{first, second} {
+				if err := client.RecordUsage(pkgPath, "cli/cli", "v2.65.0", "gh", ts); err != nil {
+					t.Fatal(err)
+				}
+			}
+			usages, err := client.FindAllUsages(logger)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.exp, usages); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestClient_Remove_usage(t *testing.T) {
+	t.Parallel()
+	usageFile := "metadata/" + pkgPath + "/usage.json"
+	rootDir := newRootDir(t, map[string]string{
+		"metadata/" + pkgPath + "/timestamp.txt": "2025-01-01T00:15:00+09:00\n",
+		usageFile:                                "{}\n",
+	})
+	client := vacuum.New(&config.Param{
+		RootDir: rootDir,
+	})
+	if err := client.Remove(pkgPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(rootDir, filepath.FromSlash(usageFile))); !os.IsNotExist(err) {
+		t.Fatal("the usage file still exists")
+	}
+}
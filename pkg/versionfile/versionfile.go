@@ -0,0 +1,167 @@
+// Package versionfile reads and updates package versions in version files
+// such as go.mod, .node-version, rust-toolchain.toml, and .tool-versions.
+package versionfile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/expr"
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+var (
+	errUnknownType       = errors.New("unknown version file type")
+	errToolIsRequired    = errors.New("tool is required to read .tool-versions")
+	errNoCaptureGroup    = errors.New("pattern must have a capture group")
+	errVersionIsNotFound = errors.New("no version is found in the version file")
+	errUnsafeVersion     = errors.New("the version in the version file must be a semver x.y.z")
+	errInvalidVersion    = errors.New("the new version must not be empty or contain whitespaces")
+)
+
+var (
+	goModPattern = regexp.MustCompile(`(?m)^go (\d+\.\d+.\d+)$`)
+	// plainPattern matches the first line which isn't empty or a comment.
+	// A leading "v" isn't a part of the version, so both "v20.10.0" and "20.10.0" are read as "20.10.0".
+	plainPattern         = regexp.MustCompile(`\A(?:[ \t]*(?:#.*)?\r?\n)*[ \t]*v?(\d[^\s#]*)`)
+	rustToolchainPattern = regexp.MustCompile(`(?m)^[ \t]*channel[ \t]*=[ \t]*"(\d[^"]*)"`)
+)
+
+// File is a version file which was read.
+// It keeps the position of the version so that the version can be replaced without changing the rest of the file.
+type File struct {
+	path    string
+	content []byte
+	start   int
+	end     int
+	prefix  string
+	// leadingV is true if the version in the file has a leading "v".
+	// The "v" may be outside the capture group, like plain and tool_versions.
+	leadingV bool
+}
+
+// Read reads the version from the version file.
+// The path of the version file is relative to dir, which is the directory of the configuration file.
+func Read(dir string, vf *aqua.VersionFile) (*File, error) {
+	p := Path(dir, vf)
+	pattern, err := compile(vf)
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("read a version file: %w", slogerr.With(err,
+			"version_file", p,
+		))
+	}
+	idx := pattern.FindSubmatchIndex(b)
+	if len(idx) < 4 || idx[2] < 0 { //nolint:mnd
+		return nil, slogerr.With(errVersionIsNotFound, //nolint:wrapcheck
+			"version_file", p,
+			"version_file_type", vf.GetType(),
+		)
+	}
+	f := &File{
+		path:    p,
+		content: b,
+		start:   idx[2],
+		end:     idx[3],
+		prefix:  vf.Prefix,
+	}
+	f.leadingV = strings.HasPrefix(f.rawVersion(), "v") || (f.start > 0 && b[f.start-1] == 'v')
+	// Restrict the version to a semver for security reason like version_expr.
+	// Don't output the version to prevent leaking sensitive information.
+	if !expr.IsSafeVersion(f.rawVersion()) {
+		return nil, slogerr.With(errUnsafeVersion, //nolint:wrapcheck
+			"version_file", p,
+		)
+	}
+	return f, nil
+}
+
+// Version returns the package version, which is the prefix and the version in the file.
+func (f *File) Version() string {
+	return f.prefix + f.rawVersion()
+}
+
+func (f *File) rawVersion() string {
+	return string(f.content[f.start:f.end])
+}
+
+// Update replaces the version in the version file with the new package version.
+// The prefix is removed from the new version.
+// If the version in the file has a leading "v", it's kept and isn't duplicated.
+// It returns false if the version isn't changed.
+func (f *File) Update(version string) (bool, error) {
+	v := strings.TrimPrefix(version, f.prefix)
+	if f.leadingV {
+		v = strings.TrimPrefix(v, "v")
+		if strings.HasPrefix(f.rawVersion(), "v") {
+			v = "v" + v
+		}
+	}
+	if v == "" || v == "v" || strings.ContainsFunc(v, isSpace) {
+		return false, slogerr.With(errInvalidVersion, "new_version", version) //nolint:wrapcheck
+	}
+	if v == f.rawVersion() {
+		return false, nil
+	}
+	stat, err := os.Stat(f.path)
+	if err != nil {
+		return false, fmt.Errorf("get a version file stat: %w", err)
+	}
+	content := make([]byte, 0, len(f.content)-f.end+f.start+len(v))
+	content = append(content, f.content[:f.start]...)
+	content = append(content, v...)
+	content = append(content, f.content[f.end:]...)
+	if err := os.WriteFile(f.path, content, stat.Mode()); err != nil {
+		return false, fmt.Errorf("write a version file: %w", slogerr.With(err,
+			"version_file", f.path,
+		))
+	}
+	f.content = content
+	f.end = f.start + len(v)
+	return true, nil
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+func compile(vf *aqua.VersionFile) (*regexp.Regexp, error) {
+	switch typ := vf.GetType(); typ {
+	case aqua.VersionFileTypeGoMod:
+		return goModPattern, nil
+	case aqua.VersionFileTypePlain:
+		return plainPattern, nil
+	case aqua.VersionFileTypeRustToolchain:
+		return rustToolchainPattern, nil
+	case aqua.VersionFileTypeToolVersions:
+		if vf.Tool == "" {
+			return nil, errToolIsRequired
+		}
+		// A line has a tool name and versions. The first version is used.
+		return regexp.MustCompile(`(?m)^[ \t]*` + regexp.QuoteMeta(vf.Tool) + `[ \t]+v?(\d[^\s#]*)`), nil
+	case aqua.VersionFileTypeRegexp:
+		pattern, err := regexp.Compile(vf.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("compile a pattern of a version file: %w", err)
+		}
+		if pattern.NumSubexp() == 0 {
+			return nil, slogerr.With(errNoCaptureGroup, "pattern", vf.Pattern) //nolint:wrapcheck
+		}
+		return pattern, nil
+	default:
+		return nil, slogerr.With(errUnknownType, "version_file_type", typ) //nolint:wrapcheck
+	}
+}
+
+// Path returns the absolute path of the version file.
+func Path(dir string, vf *aqua.VersionFile) string {
+	return osfile.Abs(dir, vf.Path)
+}
@@ -0,0 +1,189 @@
+package versionfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/versionfile"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRead(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name       string
+		content    string
+		vf         *aqua.VersionFile
+		exp        string
+		newVersion string
+		expContent string
+		// expVersion is the version after the update. By default, it's newVersion.
+		expVersion string
+		isErr      bool
+	}{
+		{
+			name: "go.mod",
+			content: `module example.com/foo
+
+go 1.22.3
+
+require github.com/google/go-cmp v0.6.0
+`,
+			vf:         &aqua.VersionFile{Path: "go.mod"},
+			exp:        "1.22.3",
+			newVersion: "1.23.0",
+			expContent: `module example.com/foo
+
+go 1.23.0
+
+require github.com/google/go-cmp v0.6.0
+`,
+		},
+		{
+			name:       ".node-version",
+			content:    "20.10.0\n",
+			vf:         &aqua.VersionFile{Path: ".node-version", Prefix: "v"},
+			exp:        "v20.10.0",
+			newVersion: "v20.11.1",
+			expContent: "20.11.1\n",
+		},
+		{
+			name:       ".nvmrc with v",
+			content:    "# comment\n\nv20.10.0\n",
+			vf:         &aqua.VersionFile{Path: ".nvmrc", Prefix: "v"},
+			exp:        "v20.10.0",
+			newVersion: "v20.11.1",
+			expContent: "# comment\n\nv20.11.1\n",
+		},
+		{
+			name:       ".nvmrc with v without prefix",
+			content:    "v20.10.0\n",
+			vf:         &aqua.VersionFile{Path: ".nvmrc"},
+			exp:        "20.10.0",
+			newVersion: "v20.11.0",
+			expContent: "v20.11.0\n",
+			expVersion: "20.11.0",
+		},
+		{
+			name:       "regexp with v",
+			content:    "TERRAFORM_VERSION=v1.6.5\n",
+			vf:         &aqua.VersionFile{Path: "versions.env", Pattern: `TERRAFORM_VERSION=(\S+)`},
+			exp:        "v1.6.5",
+			newVersion: "1.7.0",
+			expContent: "TERRAFORM_VERSION=v1.7.0\n",
+			expVersion: "v1.7.0",
+		},
+		{
+			name:    ".nvmrc with an alias",
+			content: "lts/iron\n",
+			vf:      &aqua.VersionFile{Path: ".nvmrc"},
+			isErr:   true,
+		},
+		{
+			name:       ".python-version",
+			content:    "3.12.1\n3.11.7\n",
+			vf:         &aqua.VersionFile{Path: ".python-version"},
+			exp:        "3.12.1",
+			newVersion: "3.12.2",
+			expContent: "3.12.2\n3.11.7\n",
+		},
+		{
+			name: "rust-toolchain.toml",
+			content: `[toolchain]
+channel = "1.75.0"
+components = ["rustfmt"]
+`,
+			vf:         &aqua.VersionFile{Path: "rust-toolchain.toml"},
+			exp:        "1.75.0",
+			newVersion: "1.76.0",
+			expContent: `[toolchain]
+channel = "1.76.0"
+components = ["rustfmt"]
+`,
+		},
+		{
+			name: ".tool-versions",
+			content: `nodejs 20.10.0
+terraform 1.6.5 1.5.7
+`,
+			vf:         &aqua.VersionFile{Path: ".tool-versions", Tool: "terraform", Prefix: "v"},
+			exp:        "v1.6.5",
+			newVersion: "v1.7.0",
+			expContent: `nodejs 20.10.0
+terraform 1.7.0 1.5.7
+`,
+		},
+		{
+			name:    ".tool-versions without tool",
+			content: "terraform 1.6.5\n",
+			vf:      &aqua.VersionFile{Path: ".tool-versions"},
+			isErr:   true,
+		},
+		{
+			name:       "regexp",
+			content:    "TERRAFORM_VERSION=1.6.5\n",
+			vf:         &aqua.VersionFile{Path: "versions.env", Pattern: `TERRAFORM_VERSION=(\S+)`, Prefix: "v"},
+			exp:        "v1.6.5",
+			newVersion: "v1.7.0",
+			expContent: "TERRAFORM_VERSION=1.7.0\n",
+		},
+		{
+			name:    "regexp must not read secrets",
+			content: "TOKEN=secret\n",
+			vf:      &aqua.VersionFile{Path: "secret.env", Pattern: `TOKEN=(\S+)`},
+			isErr:   true,
+		},
+		{
+			name:    "pattern without a capture group",
+			content: "1.6.5\n",
+			vf:      &aqua.VersionFile{Path: "version.txt", Type: "regexp", Pattern: `\d+`},
+			isErr:   true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			p := filepath.Join(dir, d.vf.Path)
+			if err := os.WriteFile(p, []byte(d.content), 0o644); err != nil { //nolint:gosec
+				t.Fatal(err)
+			}
+			f, err := versionfile.Read(dir, d.vf)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if v := f.Version(); v != d.exp {
+				t.Fatalf("wanted %s, got %s", d.exp, v)
+			}
+			updated, err := f.Update(d.newVersion)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !updated {
+				t.Fatal("the version file must be updated")
+			}
+			b, err := os.ReadFile(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(d.expContent, string(b)); diff != "" {
+				t.Fatal(diff)
+			}
+			expVersion := d.expVersion
+			if expVersion == "" {
+				expVersion = d.newVersion
+			}
+			if v := f.Version(); v != expVersion {
+				t.Fatalf("wanted %s, got %s", expVersion, v)
+			}
+		})
+	}
+}